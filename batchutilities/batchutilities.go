@@ -0,0 +1,57 @@
+package batchutilities
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Run calls score on each of the indices 0 to n - 1 from the given number of
+// concurrent workers, or from one worker per CPU if workers is 0, and returns
+// the error from each call in order.  Once ctx is done no more calls are
+// started, the indices never scored get ctx's error, and ctx's error is also
+// returned.
+func Run(ctx context.Context, n, workers int, score func(i int) error) ([]error, error) {
+	if workers < 0 {
+		return nil, errors.New("number of workers cannot be negative")
+	}
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	errs := make([]error, n)
+	scored := make([]bool, n)
+	var next int64
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= n {
+					return
+				}
+
+				errs[i] = score(i)
+				scored[i] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	err := ctx.Err()
+	if err != nil {
+		for i := range errs {
+			if !scored[i] {
+				errs[i] = err
+			}
+		}
+	}
+
+	return errs, err
+}
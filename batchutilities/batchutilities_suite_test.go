@@ -0,0 +1,13 @@
+package batchutilities_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBatchutilities(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Batchutilities Suite")
+}
@@ -0,0 +1,69 @@
+package batchutilities_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/amitkgupta/goodlearn/batchutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Run", func() {
+	It("Scores every index once and returns the errors in order", func() {
+		results := make([]int, 100)
+		errs, err := batchutilities.Run(context.Background(), 100, 7, func(i int) error {
+			results[i] = results[i] + i*i
+			if i%10 == 3 {
+				return errors.New("bad row")
+			}
+			return nil
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(errs).Should(HaveLen(100))
+		for i := range results {
+			Ω(results[i]).Should(Equal(i * i))
+			if i%10 == 3 {
+				Ω(errs[i]).Should(HaveOccurred())
+			} else {
+				Ω(errs[i]).ShouldNot(HaveOccurred())
+			}
+		}
+	})
+
+	It("Uses one worker per CPU by default", func() {
+		errs, err := batchutilities.Run(context.Background(), 3, 0, func(int) error { return nil })
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(errs).Should(Equal([]error{nil, nil, nil}))
+	})
+
+	It("Rejects a negative number of workers", func() {
+		_, err := batchutilities.Run(context.Background(), 3, -1, func(int) error { return nil })
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("Stops scoring once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var calls int64
+		errs, err := batchutilities.Run(ctx, 1000, 1, func(i int) error {
+			if atomic.AddInt64(&calls, 1) == 10 {
+				cancel()
+			}
+			return nil
+		})
+		Ω(err).Should(Equal(context.Canceled))
+		Ω(calls).Should(Equal(int64(10)))
+
+		for i, rowErr := range errs {
+			if i < 10 {
+				Ω(rowErr).ShouldNot(HaveOccurred())
+			} else {
+				Ω(rowErr).Should(Equal(context.Canceled))
+			}
+		}
+	})
+})
@@ -0,0 +1,35 @@
+package classifier
+
+import (
+	"context"
+
+	"github.com/amitkgupta/goodlearn/batchutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// ClassifyBatch classifies every row of the test data from the given number
+// of concurrent workers, or from one worker per CPU if workers is 0, so the
+// classifier must be safe to call Classify on concurrently; with 1 worker any
+// trained classifier can be used.  The targets and per-row errors are
+// returned in the order of the rows, and a row which cannot be classified
+// does not stop the others.  Once ctx is done no more rows are classified,
+// those left get ctx's error, and ctx's error is also returned.
+func ClassifyBatch(ctx context.Context, classifier Classifier, testData dataset.Dataset, workers int) ([]slice.Slice, []error, error) {
+	targets := make([]slice.Slice, testData.NumRows())
+
+	errs, err := batchutilities.Run(ctx, len(targets), workers, func(i int) error {
+		testRow, err := testData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		targets[i], err = classifier.Classify(testRow)
+		return err
+	})
+	if errs == nil {
+		return nil, nil, err
+	}
+
+	return targets, errs, err
+}
@@ -0,0 +1,83 @@
+package classifier_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// signClassifier classifies rows by the sign of their first feature, and
+// refuses rows whose first feature is zero.
+type signClassifier struct{}
+
+func (signClassifier) Train(dataset.Dataset) error {
+	return nil
+}
+
+func (signClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	x := testRow.Features().(slice.FloatSlice).Values()[0]
+	switch {
+	case x > 0:
+		return slice.NewFloatSlice([]float64{1}), nil
+	case x < 0:
+		return slice.NewFloatSlice([]float64{-1}), nil
+	}
+	return nil, errors.New("cannot classify zero")
+}
+
+var _ = Describe("ClassifyBatch", func() {
+	var testData dataset.Dataset
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		testData = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for i := -50; i < 50; i++ {
+			Ω(testData.AddRowFromStrings([]string{strconv.Itoa(i), "0"})).Should(Succeed())
+		}
+	})
+
+	It("Classifies every row in order, returning per-row errors", func() {
+		targets, errs, err := classifier.ClassifyBatch(context.Background(), signClassifier{}, testData, 4)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets).Should(HaveLen(100))
+
+		for i := range targets {
+			switch {
+			case i < 50:
+				Ω(errs[i]).ShouldNot(HaveOccurred())
+				Ω(targets[i].Equals(slice.NewFloatSlice([]float64{-1}))).Should(BeTrue())
+			case i == 50:
+				Ω(errs[i]).Should(HaveOccurred())
+				Ω(targets[i]).Should(BeNil())
+			default:
+				Ω(errs[i]).ShouldNot(HaveOccurred())
+				Ω(targets[i].Equals(slice.NewFloatSlice([]float64{1}))).Should(BeTrue())
+			}
+		}
+	})
+
+	It("Returns the context's error when cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, errs, err := classifier.ClassifyBatch(ctx, signClassifier{}, testData, 2)
+		Ω(err).Should(Equal(context.Canceled))
+		Ω(errs[0]).Should(Equal(context.Canceled))
+	})
+
+	It("Rejects a negative number of workers", func() {
+		_, _, err := classifier.ClassifyBatch(context.Background(), signClassifier{}, testData, -2)
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,378 @@
+package calibration
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/calibrationerrors"
+)
+
+// Method is how scores are mapped to probabilities.
+type Method int
+
+const (
+	// Platt fits a sigmoid of the score; see FitPlatt.
+	Platt Method = iota
+	// Isotonic fits a non-decreasing function of the score; see FitIsotonic.
+	Isotonic
+)
+
+const defaultNumFolds = 5
+
+type Option func(*parameters)
+
+// Calibrating sets the calibration method; Platt by default.
+func Calibrating(method Method) Option {
+	return func(p *parameters) {
+		p.method = method
+	}
+}
+
+// HeldOut trains the classifier on a random part of the training data and
+// calibrates it on the given fraction held out from it.
+func HeldOut(fraction float64) Option {
+	return func(p *parameters) {
+		p.heldOutFraction = fraction
+		p.numFolds = 0
+	}
+}
+
+// CrossValidated calibrates on the out-of-fold scores of classifiers trained
+// on all but one of numFolds random folds, then trains the classifier on all
+// the training data.  This is the default, with 5 folds.
+func CrossValidated(numFolds int) Option {
+	return func(p *parameters) {
+		p.numFolds = numFolds
+		p.heldOutFraction = 0
+	}
+}
+
+// RandomSource sets the source used to split the training data.
+func RandomSource(source rand.Source) Option {
+	return func(p *parameters) {
+		p.source = source
+	}
+}
+
+type parameters struct {
+	method          Method
+	heldOutFraction float64
+	numFolds        int
+	source          rand.Source
+}
+
+// NewCalibratedClassifier returns a classifier whose class probabilities are
+// the calibrated scores of classifiers built by the factory, which must be
+// ProbabilisticClassifiers or ScoringClassifiers.  With two targets the first
+// target's score is calibrated and the other target gets the remaining
+// probability; with more, each target's score is calibrated against the rest
+// and the probabilities normalised to sum to 1.
+func NewCalibratedClassifier(factory classifier.Factory, options ...Option) (*calibratedClassifier, error) {
+	p := parameters{method: Platt, numFolds: defaultNumFolds}
+	for _, option := range options {
+		option(&p)
+	}
+
+	if p.method != Platt && p.method != Isotonic {
+		return nil, calibrationerrors.NewInvalidMethodError(int(p.method))
+	}
+
+	if p.numFolds == 0 && (p.heldOutFraction <= 0 || p.heldOutFraction >= 1) {
+		return nil, calibrationerrors.NewInvalidHeldOutFractionError(p.heldOutFraction)
+	}
+
+	if p.heldOutFraction == 0 && p.numFolds < 2 {
+		return nil, calibrationerrors.NewInvalidNumberOfFoldsError(p.numFolds)
+	}
+
+	if p.source == nil {
+		p.source = rand.NewSource(1)
+	}
+
+	return &calibratedClassifier{
+		factory:    factory,
+		parameters: p,
+		random:     rand.New(p.source),
+	}, nil
+}
+
+type calibratedClassifier struct {
+	factory    classifier.Factory
+	parameters parameters
+	random     *rand.Rand
+
+	targets     []slice.Slice
+	classifier  classifier.Classifier
+	calibrators []Calibrator
+}
+
+func (cc *calibratedClassifier) Train(trainingData dataset.Dataset) error {
+	numRows := trainingData.NumRows()
+	if numRows == 0 {
+		return calibrationerrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) < 2 {
+		return calibrationerrors.NewTooFewTargetsError(len(targets))
+	}
+
+	var trained classifier.Classifier
+	var scores [][]float64
+	var labels []int
+
+	if cc.parameters.numFolds > 0 {
+		trained, scores, labels, err = cc.crossValidatedScores(trainingData, targets)
+	} else {
+		trained, scores, labels, err = cc.heldOutScores(trainingData, targets)
+	}
+	if err != nil {
+		return err
+	}
+
+	calibrators, err := cc.fitCalibrators(scores, labels, len(targets))
+	if err != nil {
+		return err
+	}
+
+	cc.targets = targets
+	cc.classifier = trained
+	cc.calibrators = calibrators
+	return nil
+}
+
+func (cc *calibratedClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, probabilities, err := cc.ClassProbabilities(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	best := 0
+	for k, probability := range probabilities {
+		if probability > probabilities[best] {
+			best = k
+		}
+	}
+
+	return targets[best], nil
+}
+
+func (cc *calibratedClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if cc.classifier == nil {
+		return nil, nil, calibrationerrors.NewUntrainedClassifierError()
+	}
+
+	scores, err := targetScores(cc.classifier, testRow, cc.targets)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cc.targets, cc.calibrate(scores), nil
+}
+
+// Classifier returns the classifier whose scores are calibrated.
+func (cc *calibratedClassifier) Classifier() classifier.Classifier {
+	return cc.classifier
+}
+
+func (cc *calibratedClassifier) calibrate(scores []float64) []float64 {
+	probabilities := make([]float64, len(scores))
+
+	if len(scores) == 2 {
+		probabilities[0] = cc.calibrators[0].Probability(scores[0])
+		probabilities[1] = 1 - probabilities[0]
+		return probabilities
+	}
+
+	total := 0.0
+	for k, score := range scores {
+		probabilities[k] = cc.calibrators[k].Probability(score)
+		total = total + probabilities[k]
+	}
+
+	for k := range probabilities {
+		if total > 0 {
+			probabilities[k] = probabilities[k] / total
+		} else {
+			probabilities[k] = 1 / float64(len(probabilities))
+		}
+	}
+
+	return probabilities
+}
+
+func (cc *calibratedClassifier) fitCalibrators(scores [][]float64, labels []int, numTargets int) ([]Calibrator, error) {
+	fit := FitPlatt
+	if cc.parameters.method == Isotonic {
+		fit = FitIsotonic
+	}
+
+	numCalibrators := numTargets
+	if numTargets == 2 {
+		numCalibrators = 1
+	}
+
+	calibrators := make([]Calibrator, numCalibrators)
+	for k := range calibrators {
+		targetScores := make([]float64, len(scores))
+		outcomes := make([]bool, len(scores))
+		for i, rowScores := range scores {
+			targetScores[i], outcomes[i] = rowScores[k], labels[i] == k
+		}
+
+		calibrator, err := fit(targetScores, outcomes)
+		if err != nil {
+			return nil, err
+		}
+		calibrators[k] = calibrator
+	}
+
+	return calibrators, nil
+}
+
+func (cc *calibratedClassifier) crossValidatedScores(
+	trainingData dataset.Dataset,
+	targets []slice.Slice,
+) (classifier.Classifier, [][]float64, []int, error) {
+	numRows, numFolds := trainingData.NumRows(), cc.parameters.numFolds
+	if numRows < numFolds {
+		return nil, nil, nil, calibrationerrors.NewTooFewRowsError(numRows, numFolds)
+	}
+
+	folds := make([][]int, numFolds)
+	for i, rowIndex := range cc.random.Perm(numRows) {
+		folds[i%numFolds] = append(folds[i%numFolds], rowIndex)
+	}
+
+	scores, labels := [][]float64{}, []int{}
+	for f, heldOut := range folds {
+		trainingRowMap := []int{}
+		for g, fold := range folds {
+			if g != f {
+				trainingRowMap = append(trainingRowMap, fold...)
+			}
+		}
+
+		trained, err := cc.train(dataset.NewSubset(trainingData, trainingRowMap))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		foldScores, foldLabels, err := scoreRows(trained, trainingData, heldOut, targets)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		scores, labels = append(scores, foldScores...), append(labels, foldLabels...)
+	}
+
+	trained, err := cc.train(trainingData)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return trained, scores, labels, nil
+}
+
+func (cc *calibratedClassifier) heldOutScores(
+	trainingData dataset.Dataset,
+	targets []slice.Slice,
+) (classifier.Classifier, [][]float64, []int, error) {
+	numRows := trainingData.NumRows()
+	numHeldOut := int(math.Round(cc.parameters.heldOutFraction * float64(numRows)))
+	if numHeldOut < 1 || numHeldOut >= numRows {
+		return nil, nil, nil, calibrationerrors.NewTooFewRowsError(numRows, 2)
+	}
+
+	perm := cc.random.Perm(numRows)
+	trainingRowMap, heldOut := perm[numHeldOut:], perm[:numHeldOut]
+
+	trained, err := cc.train(dataset.NewSubset(trainingData, trainingRowMap))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	scores, labels, err := scoreRows(trained, trainingData, heldOut, targets)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return trained, scores, labels, nil
+}
+
+func (cc *calibratedClassifier) train(trainingData dataset.Dataset) (classifier.Classifier, error) {
+	c, err := cc.factory()
+	if err != nil {
+		return nil, calibrationerrors.NewClassifierConstructionError(err)
+	}
+
+	switch c.(type) {
+	case classifier.ProbabilisticClassifier, classifier.ScoringClassifier:
+	default:
+		return nil, calibrationerrors.NewNonScoringClassifierError()
+	}
+
+	err = c.Train(trainingData)
+	if err != nil {
+		return nil, calibrationerrors.NewClassifierTrainingError(err)
+	}
+
+	return c, nil
+}
+
+// scoreRows returns the scores of the given rows, and the index within
+// targets of each row's target.
+func scoreRows(c classifier.Classifier, ds dataset.Dataset, rowIndices []int, targets []slice.Slice) ([][]float64, []int, error) {
+	scores := make([][]float64, len(rowIndices))
+	labels := make([]int, len(rowIndices))
+
+	for i, rowIndex := range rowIndices {
+		r, err := ds.Row(rowIndex)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		scores[i], err = targetScores(c, r, targets)
+		if err != nil {
+			return nil, nil, err
+		}
+		labels[i] = classifierutilities.TargetIndex(targets, r.Target())
+	}
+
+	return scores, labels, nil
+}
+
+// targetScores returns the classifier's probability or score for each of the
+// targets, or 0 for targets it does not know about, such as those missing
+// from a fold.
+func targetScores(c classifier.Classifier, r row.Row, targets []slice.Slice) ([]float64, error) {
+	var classifierTargets []slice.Slice
+	var classifierScores []float64
+	var err error
+
+	if pc, ok := c.(classifier.ProbabilisticClassifier); ok {
+		classifierTargets, classifierScores, err = pc.ClassProbabilities(r)
+	} else {
+		classifierTargets, classifierScores, err = c.(classifier.ScoringClassifier).DecisionFunction(r)
+	}
+	if err != nil {
+		return nil, calibrationerrors.NewClassificationError(err)
+	}
+
+	scores := make([]float64, len(targets))
+	for j, target := range classifierTargets {
+		if k := classifierutilities.TargetIndex(targets, target); k >= 0 {
+			scores[k] = classifierScores[j]
+		}
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,13 @@
+package calibration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCalibration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Calibration Suite")
+}
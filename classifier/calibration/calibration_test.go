@@ -0,0 +1,197 @@
+package calibration_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/calibration"
+	"github.com/amitkgupta/goodlearn/classifier/knn"
+	"github.com/amitkgupta/goodlearn/classifier/svm"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/calibrationerrors"
+	"github.com/amitkgupta/goodlearn/evaluation/reliability"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// noisyDataset has one feature x, uniform over [-2, 2], and a target which
+// is "yes" with probability sigmoid(2x) and "no" otherwise.
+func noisyDataset(numRows int, seed int64) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(seed))
+	ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+	for i := 0; i < numRows; i++ {
+		x := 4*random.Float64() - 2
+		target := "no"
+		if random.Float64() < sigmoid(2*x) {
+			target = "yes"
+		}
+
+		err = ds.AddRowFromStrings([]string{target, fmt.Sprintf("%.6f", x)})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+	return ds
+}
+
+// yesProbabilities returns the probability the classifier gives "yes" for
+// each row of the dataset, and whether the row's target is "yes".
+func yesProbabilities(c classifier.ProbabilisticClassifier, ds dataset.Dataset) ([]float64, []bool) {
+	yes, err := slice.NewMixedSlice([]interface{}{"yes"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	probabilities := make([]float64, ds.NumRows())
+	outcomes := make([]bool, ds.NumRows())
+	for i := range probabilities {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		targets, targetProbabilities, err := c.ClassProbabilities(r)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		total := 0.0
+		for k, target := range targets {
+			total = total + targetProbabilities[k]
+			if target.Equals(yes) {
+				probabilities[i] = targetProbabilities[k]
+			}
+		}
+		Ω(total).Should(BeNumerically("~", 1))
+
+		outcomes[i] = r.Target().Equals(yes)
+	}
+	return probabilities, outcomes
+}
+
+// classifyOnly is a classifier with neither probabilities nor scores.
+type classifyOnly struct{}
+
+func (classifyOnly) Train(dataset.Dataset) error {
+	return nil
+}
+
+func (classifyOnly) Classify(row.Row) (slice.Slice, error) {
+	return slice.NewFloatSlice([]float64{0}), nil
+}
+
+var _ = Describe("Calibrated classifiers", func() {
+	factories := map[string]classifier.Factory{
+		"probabilistic": func() (classifier.Classifier, error) {
+			return knn.NewKNNClassifier(10)
+		},
+		"scoring": func() (classifier.Classifier, error) {
+			return svm.NewLinearSVM()
+		},
+	}
+
+	methods := map[string]calibration.Method{
+		"Platt scaling":       calibration.Platt,
+		"isotonic regression": calibration.Isotonic,
+	}
+
+	for factoryName, factory := range factories {
+		for methodName, method := range methods {
+			factoryName, factory, methodName, method := factoryName, factory, methodName, method
+
+			It(fmt.Sprintf("Calibrates %s classifiers with %s", factoryName, methodName), func() {
+				c, err := calibration.NewCalibratedClassifier(factory, calibration.Calibrating(method))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(c.Train(noisyDataset(600, 1))).Should(Succeed())
+
+				probabilities, outcomes := yesProbabilities(c, noisyDataset(2000, 2))
+				ece, err := reliability.ExpectedCalibrationError(probabilities, outcomes, 10)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(ece).Should(BeNumerically("<", 0.06))
+			})
+		}
+	}
+
+	It("Calibrates on a held out fraction of the training data", func() {
+		c, err := calibration.NewCalibratedClassifier(factories["scoring"], calibration.HeldOut(0.3))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(noisyDataset(600, 1))).Should(Succeed())
+
+		probabilities, outcomes := yesProbabilities(c, noisyDataset(2000, 2))
+		ece, err := reliability.ExpectedCalibrationError(probabilities, outcomes, 10)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(ece).Should(BeNumerically("<", 0.06))
+	})
+
+	It("Normalises the calibrated probabilities of more than two targets", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		random := rand.New(rand.NewSource(3))
+		ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		for i := 0; i < 300; i++ {
+			for k, colour := range []string{"red", "green", "blue"} {
+				x := fmt.Sprintf("%.6f", 3*float64(k)+random.NormFloat64())
+				Ω(ds.AddRowFromStrings([]string{colour, x})).Should(Succeed())
+			}
+		}
+
+		c, err := calibration.NewCalibratedClassifier(factories["probabilistic"])
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(ds)).Should(Succeed())
+
+		targets, probabilities, err := c.ClassProbabilities(row.NewRow(slice.NewFloatSlice([]float64{6}), nil, 1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets).Should(HaveLen(3))
+		Ω(probabilities[0] + probabilities[1] + probabilities[2]).Should(BeNumerically("~", 1))
+
+		target, err := c.Classify(row.NewRow(slice.NewFloatSlice([]float64{6}), nil, 1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(slice.Entries(target)).Should(Equal([]interface{}{"blue"}))
+	})
+
+	It("Rejects invalid options", func() {
+		_, err := calibration.NewCalibratedClassifier(factories["scoring"], calibration.Calibrating(calibration.Method(7)))
+		Ω(err).Should(BeAssignableToTypeOf(calibrationerrors.InvalidMethodError{}))
+
+		_, err = calibration.NewCalibratedClassifier(factories["scoring"], calibration.HeldOut(1))
+		Ω(err).Should(BeAssignableToTypeOf(calibrationerrors.InvalidHeldOutFractionError{}))
+
+		_, err = calibration.NewCalibratedClassifier(factories["scoring"], calibration.CrossValidated(1))
+		Ω(err).Should(BeAssignableToTypeOf(calibrationerrors.InvalidNumberOfFoldsError{}))
+	})
+
+	It("Refuses to train on unsuitable data or classifiers", func() {
+		c, err := calibration.NewCalibratedClassifier(factories["scoring"])
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(noisyDataset(0, 1))).Should(BeAssignableToTypeOf(calibrationerrors.EmptyTrainingDatasetError{}))
+		Ω(c.Train(noisyDataset(4, 1))).Should(BeAssignableToTypeOf(calibrationerrors.TooFewRowsError{}))
+
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+		ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		Ω(ds.AddRowFromStrings([]string{"yes", "1"})).Should(Succeed())
+		Ω(c.Train(ds)).Should(BeAssignableToTypeOf(calibrationerrors.TooFewTargetsError{}))
+
+		c, err = calibration.NewCalibratedClassifier(func() (classifier.Classifier, error) {
+			return classifyOnly{}, nil
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(noisyDataset(100, 1))).Should(BeAssignableToTypeOf(calibrationerrors.NonScoringClassifierError{}))
+
+		c, err = calibration.NewCalibratedClassifier(func() (classifier.Classifier, error) {
+			return nil, errors.New("no classifier")
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(noisyDataset(100, 1))).Should(BeAssignableToTypeOf(calibrationerrors.ClassifierConstructionError{}))
+	})
+
+	It("Refuses to classify before training", func() {
+		c, err := calibration.NewCalibratedClassifier(factories["scoring"])
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = c.Classify(row.NewRow(slice.NewFloatSlice([]float64{0}), nil, 1))
+		Ω(err).Should(BeAssignableToTypeOf(calibrationerrors.UntrainedClassifierError{}))
+	})
+})
@@ -0,0 +1,230 @@
+package calibration
+
+import (
+	"math"
+	"sort"
+
+	"github.com/amitkgupta/goodlearn/errors/classifier/calibrationerrors"
+)
+
+// Calibrator maps a classifier's score for a target to a probability that the
+// target is the right one.
+type Calibrator interface {
+	Probability(score float64) float64
+}
+
+// FitPlatt fits Platt scaling, a sigmoid of the score, to scores and whether
+// each belonged to the positive target.  It suits scores which are already
+// roughly sigmoid-shaped, such as SVM margins, and needs little data.  The
+// fit follows Lin, Lin and Weng's Newton's method, with Platt's smoothed
+// targets so as not to overfit when the classes separate perfectly.
+func FitPlatt(scores []float64, outcomes []bool) (Calibrator, error) {
+	err := validateScores(scores, outcomes)
+	if err != nil {
+		return nil, err
+	}
+
+	numPositive, numNegative := 0.0, 0.0
+	for _, positive := range outcomes {
+		if positive {
+			numPositive++
+		} else {
+			numNegative++
+		}
+	}
+
+	hiTarget, loTarget := (numPositive+1)/(numPositive+2), 1/(numNegative+2)
+	targets := make([]float64, len(outcomes))
+	for i, positive := range outcomes {
+		if positive {
+			targets[i] = hiTarget
+		} else {
+			targets[i] = loTarget
+		}
+	}
+
+	const (
+		maxIterations = 100
+		minStep       = 1e-10
+		sigma         = 1e-12
+		epsilon       = 1e-5
+	)
+
+	p := &platt{a: 0, b: math.Log((numNegative + 1) / (numPositive + 1))}
+	loss := p.loss(scores, targets)
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		h11, h22, h21, g1, g2 := sigma, sigma, 0.0, 0.0, 0.0
+		for i, s := range scores {
+			probability := p.Probability(s)
+			d2 := probability * (1 - probability)
+			h11 = h11 + s*s*d2
+			h22 = h22 + d2
+			h21 = h21 + s*d2
+			d1 := targets[i] - probability
+			g1 = g1 + s*d1
+			g2 = g2 + d1
+		}
+
+		if math.Abs(g1) < epsilon && math.Abs(g2) < epsilon {
+			break
+		}
+
+		det := h11*h22 - h21*h21
+		dA := -(h22*g1 - h21*g2) / det
+		dB := -(-h21*g1 + h11*g2) / det
+		gd := g1*dA + g2*dB
+
+		step := 1.0
+		for ; step >= minStep; step = step / 2 {
+			candidate := &platt{a: p.a + step*dA, b: p.b + step*dB}
+			if candidateLoss := candidate.loss(scores, targets); candidateLoss < loss+1e-4*step*gd {
+				p, loss = candidate, candidateLoss
+				break
+			}
+		}
+
+		if step < minStep {
+			break
+		}
+	}
+
+	return p, nil
+}
+
+type platt struct {
+	a, b float64
+}
+
+func (p *platt) Probability(score float64) float64 {
+	// written to avoid overflowing exp for scores far from the boundary
+	fApB := score*p.a + p.b
+	if fApB >= 0 {
+		return math.Exp(-fApB) / (1 + math.Exp(-fApB))
+	}
+	return 1 / (1 + math.Exp(fApB))
+}
+
+func (p *platt) loss(scores, targets []float64) float64 {
+	loss := 0.0
+	for i, s := range scores {
+		fApB := s*p.a + p.b
+		if fApB >= 0 {
+			loss = loss + targets[i]*fApB + math.Log1p(math.Exp(-fApB))
+		} else {
+			loss = loss + (targets[i]-1)*fApB + math.Log1p(math.Exp(fApB))
+		}
+	}
+	return loss
+}
+
+// FitIsotonic fits isotonic regression, the non-decreasing step function
+// closest to the outcomes, by pooling adjacent violators.  It makes no
+// assumption about the shape of the scores but needs more data than Platt
+// scaling to avoid overfitting.  Probabilities are interpolated linearly
+// between the fitted scores, and held constant beyond them.
+func FitIsotonic(scores []float64, outcomes []bool) (Calibrator, error) {
+	err := validateScores(scores, outcomes)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return scores[order[a]] < scores[order[b]]
+	})
+
+	// blocks of equal scores start pooled, as their order is arbitrary
+	type block struct {
+		score, total, weight float64
+		numScores            int
+	}
+	blocks := []block{}
+	for _, i := range order {
+		outcome := 0.0
+		if outcomes[i] {
+			outcome = 1
+		}
+
+		last := len(blocks) - 1
+		if last >= 0 && blocks[last].numScores == 1 && blocks[last].score == scores[i] {
+			blocks[last].total = blocks[last].total + outcome
+			blocks[last].weight = blocks[last].weight + 1
+		} else {
+			blocks = append(blocks, block{score: scores[i], total: outcome, weight: 1, numScores: 1})
+		}
+	}
+
+	isotonic := &isotonic{
+		scores:        make([]float64, len(blocks)),
+		probabilities: make([]float64, len(blocks)),
+	}
+	for j, b := range blocks {
+		isotonic.scores[j] = b.score
+	}
+
+	pooled := []block{}
+	for _, b := range blocks {
+		pooled = append(pooled, b)
+		for len(pooled) > 1 {
+			last, previous := pooled[len(pooled)-1], pooled[len(pooled)-2]
+			if previous.total/previous.weight < last.total/last.weight {
+				break
+			}
+			pooled = pooled[:len(pooled)-2]
+			pooled = append(pooled, block{
+				total:     previous.total + last.total,
+				weight:    previous.weight + last.weight,
+				numScores: previous.numScores + last.numScores,
+			})
+		}
+	}
+
+	j := 0
+	for _, b := range pooled {
+		for k := 0; k < b.numScores; k++ {
+			isotonic.probabilities[j] = b.total / b.weight
+			j++
+		}
+	}
+
+	return isotonic, nil
+}
+
+type isotonic struct {
+	scores        []float64
+	probabilities []float64
+}
+
+func (iso *isotonic) Probability(score float64) float64 {
+	last := len(iso.scores) - 1
+	if score <= iso.scores[0] {
+		return iso.probabilities[0]
+	}
+	if score >= iso.scores[last] {
+		return iso.probabilities[last]
+	}
+
+	j := sort.SearchFloat64s(iso.scores, score)
+	if iso.scores[j] == score {
+		return iso.probabilities[j]
+	}
+
+	fraction := (score - iso.scores[j-1]) / (iso.scores[j] - iso.scores[j-1])
+	return iso.probabilities[j-1] + fraction*(iso.probabilities[j]-iso.probabilities[j-1])
+}
+
+func validateScores(scores []float64, outcomes []bool) error {
+	if len(scores) != len(outcomes) {
+		return calibrationerrors.NewScoresLengthMismatchError(len(scores), len(outcomes))
+	}
+
+	if len(scores) == 0 {
+		return calibrationerrors.NewEmptyTrainingDatasetError()
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package calibration_test
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/calibration"
+	"github.com/amitkgupta/goodlearn/errors/classifier/calibrationerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+var _ = Describe("Calibrators", func() {
+	Describe("FitPlatt", func() {
+		It("recovers a sigmoid relationship between scores and outcomes", func() {
+			random := rand.New(rand.NewSource(1))
+			scores := make([]float64, 5000)
+			outcomes := make([]bool, 5000)
+			for i := range scores {
+				scores[i] = 2*random.Float64() - 1
+				outcomes[i] = random.Float64() < sigmoid(3*scores[i]-0.5)
+			}
+
+			platt, err := calibration.FitPlatt(scores, outcomes)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for _, s := range []float64{-1, 0, 0.5, 1} {
+				Ω(platt.Probability(s)).Should(BeNumerically("~", sigmoid(3*s-0.5), 0.05))
+			}
+		})
+
+		It("does not overfit perfectly separated outcomes", func() {
+			platt, err := calibration.FitPlatt([]float64{-1, -2, 1, 2}, []bool{false, false, true, true})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(platt.Probability(2)).Should(BeNumerically("<", 1))
+			Ω(platt.Probability(-2)).Should(BeNumerically(">", 0))
+			Ω(platt.Probability(1)).Should(BeNumerically(">", platt.Probability(-1)))
+		})
+	})
+
+	Describe("FitIsotonic", func() {
+		It("pools adjacent violators and interpolates between scores", func() {
+			isotonic, err := calibration.FitIsotonic([]float64{4, 1, 3, 2}, []bool{true, false, false, true})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(isotonic.Probability(1)).Should(BeNumerically("~", 0))
+			Ω(isotonic.Probability(1.5)).Should(BeNumerically("~", 0.25))
+			Ω(isotonic.Probability(2)).Should(BeNumerically("~", 0.5))
+			Ω(isotonic.Probability(3)).Should(BeNumerically("~", 0.5))
+			Ω(isotonic.Probability(4)).Should(BeNumerically("~", 1))
+		})
+
+		It("holds probabilities constant beyond the fitted scores", func() {
+			isotonic, err := calibration.FitIsotonic([]float64{1, 2}, []bool{false, true})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(isotonic.Probability(-10)).Should(BeNumerically("~", 0))
+			Ω(isotonic.Probability(10)).Should(BeNumerically("~", 1))
+		})
+
+		It("averages the outcomes of equal scores", func() {
+			isotonic, err := calibration.FitIsotonic([]float64{1, 1, 1, 2}, []bool{true, false, false, true})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(isotonic.Probability(1)).Should(BeNumerically("~", 1.0/3))
+		})
+	})
+
+	It("errors when there are no scores or as many outcomes as scores", func() {
+		for _, fit := range []func([]float64, []bool) (calibration.Calibrator, error){
+			calibration.FitPlatt,
+			calibration.FitIsotonic,
+		} {
+			_, err := fit([]float64{}, []bool{})
+			Ω(err).Should(BeAssignableToTypeOf(calibrationerrors.EmptyTrainingDatasetError{}))
+
+			_, err = fit([]float64{1}, []bool{true, false})
+			Ω(err).Should(BeAssignableToTypeOf(calibrationerrors.ScoresLengthMismatchError{}))
+		}
+	})
+})
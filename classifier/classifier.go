@@ -10,3 +10,33 @@ type Classifier interface {
 	Train(dataset.Dataset) error
 	Classify(row.Row) (slice.Slice, error)
 }
+
+// Factory returns a new, untrained classifier, for models made of many
+// classifiers.
+type Factory func() (Classifier, error)
+
+// ProbabilisticClassifier is a Classifier which can also report, for a given
+// row, the probability it assigns to each of the targets it knows about.  The
+// returned targets and probabilities are parallel slices.
+type ProbabilisticClassifier interface {
+	Classifier
+	ClassProbabilities(row.Row) ([]slice.Slice, []float64, error)
+}
+
+// ScoringClassifier is a Classifier which can also report, for a given row, a
+// score for each of the targets it knows about, greater for targets it
+// favours more, such as an SVM's signed margins.  The returned targets and
+// scores are parallel slices.
+type ScoringClassifier interface {
+	Classifier
+	DecisionFunction(row.Row) ([]slice.Slice, []float64, error)
+}
+
+// OnlineClassifier is a Classifier which can also learn incrementally:
+// PartialFit updates the classifier with a batch of rows without forgetting
+// those it has already seen, and may be called before Train.  Train starts
+// afresh.
+type OnlineClassifier interface {
+	Classifier
+	PartialFit(dataset.Dataset) error
+}
@@ -0,0 +1,13 @@
+package classifier_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestClassifier(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Classifier Suite")
+}
@@ -0,0 +1,13 @@
+package classifierutilities_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestClassifierutilities(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Classifierutilities Suite")
+}
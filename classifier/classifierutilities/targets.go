@@ -0,0 +1,37 @@
+package classifierutilities
+
+import (
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// DistinctTargets returns each distinct target in the dataset, in the order
+// in which they first appear.
+func DistinctTargets(ds dataset.Dataset) ([]slice.Slice, error) {
+	targets := []slice.Slice{}
+
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if TargetIndex(targets, r.Target()) < 0 {
+			targets = append(targets, r.Target())
+		}
+	}
+
+	return targets, nil
+}
+
+// TargetIndex returns the index of the given target within targets, or -1 if
+// it is not present.
+func TargetIndex(targets []slice.Slice, target slice.Slice) int {
+	for i, t := range targets {
+		if t.Equals(target) {
+			return i
+		}
+	}
+
+	return -1
+}
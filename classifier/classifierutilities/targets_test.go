@@ -0,0 +1,61 @@
+package classifierutilities_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Targets", func() {
+	var ds dataset.Dataset
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "1.0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds = dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		for _, line := range [][]string{{"b", "1"}, {"a", "2"}, {"b", "3"}, {"c", "4"}, {"a", "5"}} {
+			err = ds.AddRowFromStrings(line)
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	})
+
+	Describe("DistinctTargets", func() {
+		It("Returns each target once, in order of first appearance", func() {
+			targets, err := classifierutilities.DistinctTargets(ds)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(3))
+
+			for i, expectedRowIndex := range []int{0, 1, 3} {
+				r, err := ds.Row(expectedRowIndex)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(targets[i].Equals(r.Target())).Should(BeTrue())
+			}
+		})
+	})
+
+	Describe("TargetIndex", func() {
+		var targets []slice.Slice
+
+		BeforeEach(func() {
+			var err error
+			targets, err = classifierutilities.DistinctTargets(ds)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("Finds targets which are present", func() {
+			r, err := ds.Row(4)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(classifierutilities.TargetIndex(targets, r.Target())).Should(Equal(1))
+		})
+
+		It("Returns -1 for targets which are absent", func() {
+			Ω(classifierutilities.TargetIndex(targets, slice.NewFloatSlice([]float64{1}))).Should(Equal(-1))
+		})
+	})
+})
@@ -0,0 +1,64 @@
+package classifierutilities
+
+import (
+	"fmt"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// BalancedClassWeights returns each distinct target in the dataset, in the
+// order in which they first appear, and a weight for each inversely
+// proportional to its total row weight, so that weighting rows by their
+// target's weight gives every target the same total weight.  The weights
+// average 1 over the rows.
+func BalancedClassWeights(ds dataset.Dataset) ([]slice.Slice, []float64, error) {
+	targets, err := DistinctTargets(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	totals := make([]float64, len(targets))
+	total := 0.0
+	for i, w := range dataset.Weights(ds) {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		k := TargetIndex(targets, r.Target())
+		totals[k] = totals[k] + w
+		total = total + w
+	}
+
+	weights := make([]float64, len(targets))
+	for k, t := range totals {
+		if t > 0 {
+			weights[k] = total / (float64(len(targets)) * t)
+		}
+	}
+
+	return targets, weights, nil
+}
+
+// ClassWeightedDataset scales the weight of each row of the dataset by the
+// weight given for its target; targets not listed have weight 1.
+func ClassWeightedDataset(ds dataset.Dataset, targets []slice.Slice, weights []float64) (dataset.WeightedDataset, error) {
+	if len(targets) != len(weights) {
+		return nil, fmt.Errorf("Cannot weight %d targets with %d weights", len(targets), len(weights))
+	}
+
+	rowWeights := dataset.Weights(ds)
+	for i := range rowWeights {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if k := TargetIndex(targets, r.Target()); k >= 0 {
+			rowWeights[i] = rowWeights[i] * weights[k]
+		}
+	}
+
+	return dataset.NewWeightedDataset(ds, rowWeights)
+}
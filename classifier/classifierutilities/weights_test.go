@@ -0,0 +1,79 @@
+package classifierutilities_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Weights", func() {
+	var ds dataset.Dataset
+
+	mixed := func(value string) slice.Slice {
+		s, err := slice.NewMixedSlice([]interface{}{value})
+		Ω(err).ShouldNot(HaveOccurred())
+		return s
+	}
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "1.0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds = dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		for _, line := range [][]string{{"b", "1"}, {"a", "2"}, {"b", "3"}, {"b", "4"}} {
+			err = ds.AddRowFromStrings(line)
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	})
+
+	Describe("BalancedClassWeights", func() {
+		It("Weights targets inversely to their frequency", func() {
+			targets, weights, err := classifierutilities.BalancedClassWeights(ds)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(2))
+			Ω(targets[0].Equals(mixed("b"))).Should(BeTrue())
+			Ω(weights[0]).Should(BeNumerically("~", 4.0/6))
+			Ω(weights[1]).Should(BeNumerically("~", 2))
+		})
+
+		It("Counts the weights of weighted rows", func() {
+			weighted, err := dataset.NewWeightedDataset(ds, []float64{1, 3, 1, 1})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, weights, err := classifierutilities.BalancedClassWeights(weighted)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(weights).Should(Equal([]float64{1, 1}))
+		})
+	})
+
+	Describe("ClassWeightedDataset", func() {
+		It("Scales each row's weight by its target's weight", func() {
+			weighted, err := dataset.NewWeightedDataset(ds, []float64{1, 2, 3, 4})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			classWeighted, err := classifierutilities.ClassWeightedDataset(weighted, []slice.Slice{mixed("a")}, []float64{10})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(dataset.Weights(classWeighted)).Should(Equal([]float64{1, 20, 3, 4}))
+		})
+
+		It("Balances the total weight of each target with balanced weights", func() {
+			targets, weights, err := classifierutilities.BalancedClassWeights(ds)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			classWeighted, err := classifierutilities.ClassWeightedDataset(ds, targets, weights)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			rowWeights := dataset.Weights(classWeighted)
+			Ω(rowWeights[0] + rowWeights[2] + rowWeights[3]).Should(BeNumerically("~", rowWeights[1]))
+		})
+
+		It("Errors when targets and weights do not match", func() {
+			_, err := classifierutilities.ClassWeightedDataset(ds, []slice.Slice{mixed("a")}, []float64{1, 2})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,204 @@
+package discriminantanalysis
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/discriminantanalysiserrors"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+)
+
+type Option func(*parameters)
+
+// Shrinkage blends each estimated covariance matrix with a multiple of the
+// identity with the same trace, in proportion shrinkage, which must be
+// between 0 (the default) and 1.  It keeps covariances invertible when there
+// are few rows per feature or features are collinear.
+func Shrinkage(shrinkage float64) Option {
+	return func(p *parameters) {
+		p.shrinkage = shrinkage
+	}
+}
+
+// Components sets how many discriminant directions LDA keeps to transform
+// rows; by default as many as there can be, one fewer than the number of
+// targets or the number of features if that is less.
+func Components(numComponents int) Option {
+	return func(p *parameters) {
+		p.numComponents = numComponents
+	}
+}
+
+type parameters struct {
+	shrinkage     float64
+	numComponents int
+}
+
+func newParameters(options []Option) (parameters, error) {
+	p := parameters{}
+	for _, option := range options {
+		option(&p)
+	}
+
+	if p.shrinkage < 0 || p.shrinkage > 1 || math.IsNaN(p.shrinkage) {
+		return p, discriminantanalysiserrors.NewInvalidShrinkageError(p.shrinkage)
+	}
+
+	if p.numComponents < 0 {
+		return p, discriminantanalysiserrors.NewInvalidNumberOfComponentsError(p.numComponents)
+	}
+
+	return p, nil
+}
+
+// classRows holds the training rows grouped by target, and each target's
+// share of the rows.
+type classRows struct {
+	targets     []slice.Slice
+	priors      []float64
+	rows        [][][]float64
+	numRows     int
+	numFeatures int
+}
+
+func newClassRows(trainingData dataset.Dataset) (classRows, error) {
+	if !trainingData.AllFeaturesFloats() {
+		return classRows{}, discriminantanalysiserrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	numFeatures := trainingData.NumFeatures()
+	if numFeatures == 0 {
+		return classRows{}, discriminantanalysiserrors.NewNoFeaturesError()
+	}
+
+	numRows := trainingData.NumRows()
+	if numRows == 0 {
+		return classRows{}, discriminantanalysiserrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return classRows{}, err
+	}
+
+	if len(targets) < 2 {
+		return classRows{}, discriminantanalysiserrors.NewTooFewTargetsError(len(targets))
+	}
+
+	classes := classRows{
+		targets:     targets,
+		priors:      make([]float64, len(targets)),
+		rows:        make([][][]float64, len(targets)),
+		numRows:     numRows,
+		numFeatures: numFeatures,
+	}
+
+	for i := 0; i < numRows; i++ {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return classRows{}, err
+		}
+
+		k := classifierutilities.TargetIndex(targets, r.Target())
+		classes.rows[k] = append(classes.rows[k], r.Features().(slice.FloatSlice).Values())
+	}
+
+	for k, rows := range classes.rows {
+		classes.priors[k] = float64(len(rows)) / float64(numRows)
+	}
+
+	return classes, nil
+}
+
+// shrink blends the covariance with the identity scaled to the same trace.
+func shrink(covariance [][]float64, shrinkage float64) {
+	if shrinkage == 0 {
+		return
+	}
+
+	trace := 0.0
+	for i := range covariance {
+		trace = trace + covariance[i][i]
+	}
+	scale := trace / float64(len(covariance))
+
+	for i, r := range covariance {
+		for j := range r {
+			r[j] = (1 - shrinkage) * r[j]
+			if i == j {
+				r[j] = r[j] + shrinkage*scale
+			}
+		}
+	}
+}
+
+// factor returns the Cholesky factor of the covariance, treating it as
+// singular when some feature's variance, given the others, is negligible
+// beside the largest variance, as rounding can leave it slightly positive.
+func factor(covariance [][]float64) ([][]float64, error) {
+	l, err := matrixutilities.Cholesky(covariance)
+	if err != nil {
+		return nil, discriminantanalysiserrors.NewSingularCovarianceError()
+	}
+
+	largest := 0.0
+	for i := range covariance {
+		largest = math.Max(largest, covariance[i][i])
+	}
+
+	for i := range l {
+		if l[i][i]*l[i][i] <= 1e-12*largest {
+			return nil, discriminantanalysiserrors.NewSingularCovarianceError()
+		}
+	}
+
+	return l, nil
+}
+
+func testFeatureValues(testRow row.Row, numFeatures int, trained bool) ([]float64, error) {
+	if !trained {
+		return nil, discriminantanalysiserrors.NewUntrainedClassifierError()
+	}
+
+	if testRow.NumFeatures() != numFeatures {
+		return nil, discriminantanalysiserrors.NewRowLengthMismatchError(testRow.NumFeatures(), numFeatures)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, discriminantanalysiserrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return testFeatures.Values(), nil
+}
+
+// softmax turns discriminant scores, log probabilities up to a shared
+// constant, into probabilities.
+func softmax(scores []float64) []float64 {
+	largest := scores[argmax(scores)]
+
+	probabilities := make([]float64, len(scores))
+	total := 0.0
+	for k, s := range scores {
+		probabilities[k] = math.Exp(s - largest)
+		total = total + probabilities[k]
+	}
+	for k := range probabilities {
+		probabilities[k] = probabilities[k] / total
+	}
+
+	return probabilities
+}
+
+func argmax(values []float64) int {
+	best := 0
+	for k, v := range values {
+		if v > values[best] {
+			best = k
+		}
+	}
+	return best
+}
@@ -0,0 +1,13 @@
+package discriminantanalysis_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDiscriminantanalysis(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Discriminantanalysis Suite")
+}
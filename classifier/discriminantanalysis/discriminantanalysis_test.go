@@ -0,0 +1,172 @@
+package discriminantanalysis_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/discriminantanalysis"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/discriminantanalysiserrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// gaussianDataset has numRows rows for each target, with features drawn by
+// the target's function of a source of standard normal values.
+func gaussianDataset(numRows int, targets []string, draw func(k int, normal func() float64) []float64) dataset.Dataset {
+	random := rand.New(rand.NewSource(4))
+	numFeatures := len(draw(0, random.NormFloat64))
+
+	columnTypeStrings := []string{"a"}
+	featureColumns := []int{}
+	for j := 0; j < numFeatures; j++ {
+		columnTypeStrings = append(columnTypeStrings, "0")
+		featureColumns = append(featureColumns, j+1)
+	}
+	columnTypes, err := columntype.StringsToColumnTypes(columnTypeStrings)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ds := dataset.NewDataset(featureColumns, []int{0}, columnTypes)
+	for i := 0; i < numRows; i++ {
+		for k, target := range targets {
+			values := []string{target}
+			for _, v := range draw(k, random.NormFloat64) {
+				values = append(values, fmt.Sprintf("%.6f", v))
+			}
+			Ω(ds.AddRowFromStrings(values)).Should(Succeed())
+		}
+	}
+	return ds
+}
+
+func trainingAccuracy(c classifier.Classifier, ds dataset.Dataset) float64 {
+	correct := 0
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		target, err := c.Classify(r)
+		Ω(err).ShouldNot(HaveOccurred())
+		if target.Equals(r.Target()) {
+			correct++
+		}
+	}
+	return float64(correct) / float64(ds.NumRows())
+}
+
+func floatRow(values ...float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice(values), nil, len(values))
+}
+
+var _ = Describe("Discriminant analysis", func() {
+	models := map[string]func(...discriminantanalysis.Option) (classifier.ProbabilisticClassifier, error){
+		"LDA": func(options ...discriminantanalysis.Option) (classifier.ProbabilisticClassifier, error) {
+			return discriminantanalysis.NewLinearDiscriminantAnalysis(options...)
+		},
+		"QDA": func(options ...discriminantanalysis.Option) (classifier.ProbabilisticClassifier, error) {
+			return discriminantanalysis.NewQuadraticDiscriminantAnalysis(options...)
+		},
+	}
+
+	blobs := func() dataset.Dataset {
+		return gaussianDataset(60, []string{"red", "green", "blue"}, func(k int, normal func() float64) []float64 {
+			x, y := normal(), normal()
+			return []float64{3*float64(k) + x, x + 0.5*y}
+		})
+	}
+
+	for name, model := range models {
+		name, model := name, model
+
+		Describe(name, func() {
+			It("Separates Gaussian targets", func() {
+				c, err := model()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				ds := blobs()
+				Ω(c.Train(ds)).Should(Succeed())
+				Ω(trainingAccuracy(c, ds)).Should(BeNumerically(">", 0.9))
+
+				targets, probabilities, err := c.ClassProbabilities(floatRow(6, 0))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(targets).Should(HaveLen(3))
+				Ω(probabilities[0] + probabilities[1] + probabilities[2]).Should(BeNumerically("~", 1))
+				Ω(probabilities[2]).Should(BeNumerically(">", 0.9))
+			})
+
+			It("Is a scoring classifier", func() {
+				c, err := model()
+				Ω(err).ShouldNot(HaveOccurred())
+				_, ok := c.(classifier.ScoringClassifier)
+				Ω(ok).Should(BeTrue())
+			})
+
+			It("Needs shrinkage for features which never vary", func() {
+				constant := gaussianDataset(20, []string{"red", "blue"}, func(k int, normal func() float64) []float64 {
+					return []float64{float64(k) + normal(), 1}
+				})
+
+				c, err := model()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(c.Train(constant)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.SingularCovarianceError{}))
+
+				c, err = model(discriminantanalysis.Shrinkage(0.1))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(c.Train(constant)).Should(Succeed())
+			})
+
+			It("Rejects invalid options", func() {
+				_, err := model(discriminantanalysis.Shrinkage(1.5))
+				Ω(err).Should(BeAssignableToTypeOf(discriminantanalysiserrors.InvalidShrinkageError{}))
+
+				_, err = model(discriminantanalysis.Components(-1))
+				Ω(err).Should(BeAssignableToTypeOf(discriminantanalysiserrors.InvalidNumberOfComponentsError{}))
+			})
+
+			It("Refuses to train on unsuitable data", func() {
+				c, err := model()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+				Ω(err).ShouldNot(HaveOccurred())
+				ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+				Ω(c.Train(ds)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.EmptyTrainingDatasetError{}))
+
+				Ω(ds.AddRowFromStrings([]string{"red", "1"})).Should(Succeed())
+				Ω(ds.AddRowFromStrings([]string{"red", "2"})).Should(Succeed())
+				Ω(c.Train(ds)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.TooFewTargetsError{}))
+
+				ds = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+				Ω(ds.AddRowFromStrings([]string{"red", "1"})).Should(Succeed())
+				Ω(c.Train(ds)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.NonFloatFeaturesTrainingSetError{}))
+
+				ds = dataset.NewDataset([]int{}, []int{0}, columnTypes)
+				Ω(ds.AddRowFromStrings([]string{"red", "1"})).Should(Succeed())
+				Ω(c.Train(ds)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.NoFeaturesError{}))
+			})
+
+			It("Refuses to classify unsuitable rows", func() {
+				c, err := model()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, err = c.Classify(floatRow(1, 2))
+				Ω(err).Should(BeAssignableToTypeOf(discriminantanalysiserrors.UntrainedClassifierError{}))
+
+				Ω(c.Train(blobs())).Should(Succeed())
+
+				_, err = c.Classify(floatRow(1))
+				Ω(err).Should(BeAssignableToTypeOf(discriminantanalysiserrors.RowLengthMismatchError{}))
+
+				mixed, err := slice.NewMixedSlice([]interface{}{"a", 1.0})
+				Ω(err).ShouldNot(HaveOccurred())
+				_, _, err = c.ClassProbabilities(row.NewRow(mixed, nil, 2))
+				Ω(err).Should(BeAssignableToTypeOf(discriminantanalysiserrors.NonFloatFeaturesTestRowError{}))
+			})
+		})
+	}
+})
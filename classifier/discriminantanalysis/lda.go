@@ -0,0 +1,255 @@
+package discriminantanalysis
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/discriminantanalysiserrors"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+)
+
+// NewLinearDiscriminantAnalysis returns a classifier which models each target
+// as a Gaussian with its own mean and a covariance shared by all targets,
+// estimated by pooling the rows about their targets' means, and classifies
+// rows by Bayes' rule with the targets' shares of the training rows as
+// priors.  The resulting boundaries between targets are linear.
+//
+// Once trained it can also transform rows onto the directions which best
+// separate the targets' means relative to the shared covariance, as a
+// supervised dimensionality reduction.
+func NewLinearDiscriminantAnalysis(options ...Option) (*linearDiscriminantAnalysis, error) {
+	p, err := newParameters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &linearDiscriminantAnalysis{parameters: p}, nil
+}
+
+type linearDiscriminantAnalysis struct {
+	parameters parameters
+
+	targets     []slice.Slice
+	numFeatures int
+	weights     [][]float64
+	intercepts  []float64
+
+	mean                   []float64
+	directions             [][]float64
+	explainedVarianceRatio []float64
+}
+
+func (lda *linearDiscriminantAnalysis) Train(trainingData dataset.Dataset) error {
+	classes, err := newClassRows(trainingData)
+	if err != nil {
+		return err
+	}
+
+	numTargets, numFeatures := len(classes.targets), classes.numFeatures
+	if classes.numRows <= numTargets {
+		return discriminantanalysiserrors.NewTooFewRowsError(classes.numRows, numTargets)
+	}
+
+	maxComponents := numTargets - 1
+	if numFeatures < maxComponents {
+		maxComponents = numFeatures
+	}
+
+	numComponents := lda.parameters.numComponents
+	if numComponents == 0 {
+		numComponents = maxComponents
+	}
+	if numComponents > maxComponents {
+		return discriminantanalysiserrors.NewTooManyComponentsError(numComponents, maxComponents)
+	}
+
+	means := make([][]float64, numTargets)
+	pooled := matrixutilities.Zeros(numFeatures, numFeatures)
+	for k, rows := range classes.rows {
+		var covariance [][]float64
+		means[k], covariance = matrixutilities.Covariance(rows)
+
+		// Covariance divides by one fewer than the number of rows, except
+		// for a single row, whose covariance is 0 anyway
+		for i, r := range covariance {
+			for j, v := range r {
+				pooled[i][j] = pooled[i][j] + v*float64(len(rows)-1)/float64(classes.numRows-numTargets)
+			}
+		}
+	}
+	shrink(pooled, lda.parameters.shrinkage)
+
+	l, err := factor(pooled)
+	if err != nil {
+		return err
+	}
+
+	weights := make([][]float64, numTargets)
+	intercepts := make([]float64, numTargets)
+	for k, mean := range means {
+		weights[k] = matrixutilities.CholeskySolve(l, mean)
+		intercepts[k] = math.Log(classes.priors[k]) - dot(mean, weights[k])/2
+	}
+
+	mean, directions, explainedVarianceRatio := discriminantDirections(means, classes.priors, l, numComponents)
+
+	lda.targets = classes.targets
+	lda.numFeatures = numFeatures
+	lda.weights = weights
+	lda.intercepts = intercepts
+	lda.mean = mean
+	lda.directions = directions
+	lda.explainedVarianceRatio = explainedVarianceRatio
+	return nil
+}
+
+// discriminantDirections returns the prior-weighted mean of the targets'
+// means and the directions v maximising the between-target variance of v'x
+// relative to its within-target variance, scaled so that the latter is 1,
+// along with each direction's share of the between-target variance.  With
+// the shared covariance factored as L L', these come from the eigenvectors
+// u of the between-target covariance transformed by L's inverse, as v = L'⁻¹u.
+func discriminantDirections(means [][]float64, priors []float64, l [][]float64, numComponents int) ([]float64, [][]float64, []float64) {
+	numFeatures := len(l)
+
+	mean := make([]float64, numFeatures)
+	for k, m := range means {
+		for j, v := range m {
+			mean[j] = mean[j] + priors[k]*v
+		}
+	}
+
+	between := matrixutilities.Zeros(numFeatures, numFeatures)
+	for k, m := range means {
+		for i := range between {
+			for j := range between[i] {
+				between[i][j] = between[i][j] + priors[k]*(m[i]-mean[i])*(m[j]-mean[j])
+			}
+		}
+	}
+
+	// between is symmetric, so its rows are its columns; the columns of
+	// L⁻¹ between are then the rows of its transpose
+	halfTransformed := make([][]float64, numFeatures)
+	for j, column := range between {
+		halfTransformed[j] = matrixutilities.SolveLowerTriangular(l, column)
+	}
+	transformed := make([][]float64, numFeatures)
+	for j, column := range matrixutilities.Transpose(halfTransformed) {
+		transformed[j] = matrixutilities.SolveLowerTriangular(l, column)
+	}
+
+	values, vectors := matrixutilities.SymmetricEigen(transformed)
+
+	total := 0.0
+	for _, v := range values {
+		total = total + math.Max(v, 0)
+	}
+
+	lt := matrixutilities.Transpose(l)
+	directions := make([][]float64, numComponents)
+	ratios := make([]float64, numComponents)
+	for c := range directions {
+		directions[c] = matrixutilities.SolveUpperTriangular(lt, vectors[c])
+		if total > 0 {
+			ratios[c] = math.Max(values[c], 0) / total
+		}
+	}
+
+	return mean, directions, ratios
+}
+
+func (lda *linearDiscriminantAnalysis) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, scores, err := lda.DecisionFunction(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return targets[argmax(scores)], nil
+}
+
+func (lda *linearDiscriminantAnalysis) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	targets, scores, err := lda.DecisionFunction(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return targets, softmax(scores), nil
+}
+
+// DecisionFunction returns the targets and each one's log posterior
+// probability, up to a constant shared by the targets, which is linear in
+// the row's features.
+func (lda *linearDiscriminantAnalysis) DecisionFunction(testRow row.Row) ([]slice.Slice, []float64, error) {
+	x, err := testFeatureValues(testRow, lda.numFeatures, lda.weights != nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scores := make([]float64, len(lda.weights))
+	for k, w := range lda.weights {
+		scores[k] = dot(x, w) + lda.intercepts[k]
+	}
+
+	return lda.targets, scores, nil
+}
+
+// Transform projects the row onto the discriminant directions, after
+// centring it on the mean of the targets' means.
+func (lda *linearDiscriminantAnalysis) Transform(testRow row.Row) (slice.FloatSlice, error) {
+	x, err := testFeatureValues(testRow, lda.numFeatures, lda.weights != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	centred := make([]float64, len(x))
+	for j, v := range x {
+		centred[j] = v - lda.mean[j]
+	}
+
+	projected := make([]float64, len(lda.directions))
+	for c, direction := range lda.directions {
+		projected[c] = dot(centred, direction)
+	}
+
+	return slice.NewFloatSlice(projected), nil
+}
+
+// TransformDataset transforms the features of every row of the dataset,
+// keeping their targets.
+func (lda *linearDiscriminantAnalysis) TransformDataset(ds dataset.Dataset) (dataset.Dataset, error) {
+	numComponents := len(lda.directions)
+	rows := make([]row.Row, ds.NumRows())
+
+	for i := range rows {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, err
+		}
+
+		features, err := lda.Transform(r)
+		if err != nil {
+			return nil, err
+		}
+
+		rows[i] = row.NewRow(features, r.Target(), numComponents)
+	}
+
+	return dataset.NewDatasetFromRows(numComponents, ds.NumTargets(), rows), nil
+}
+
+// ExplainedVarianceRatio returns the share of the variance between the
+// targets' means along each discriminant direction kept for Transform.
+func (lda *linearDiscriminantAnalysis) ExplainedVarianceRatio() []float64 {
+	return lda.explainedVarianceRatio
+}
+
+func dot(x, y []float64) float64 {
+	sum := 0.0
+	for i, v := range x {
+		sum = sum + v*y[i]
+	}
+	return sum
+}
@@ -0,0 +1,119 @@
+package discriminantanalysis_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/discriminantanalysis"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/discriminantanalysiserrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LDA", func() {
+	// four features, of which only the first two separate the targets
+	fourFeatures := func() dataset.Dataset {
+		return gaussianDataset(80, []string{"a", "b", "c"}, func(k int, normal func() float64) []float64 {
+			centres := [][]float64{{0, 0}, {4, 0}, {0, 2}}
+			return []float64{centres[k][0] + normal(), centres[k][1] + normal(), normal(), 3 * normal()}
+		})
+	}
+
+	It("Gives equally likely targets equal probabilities halfway between them", func() {
+		ds := gaussianDataset(100, []string{"left", "right"}, func(k int, normal func() float64) []float64 {
+			return []float64{4*float64(k) + normal()}
+		})
+
+		lda, err := discriminantanalysis.NewLinearDiscriminantAnalysis()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(lda.Train(ds)).Should(Succeed())
+
+		_, probabilities, err := lda.ClassProbabilities(floatRow(2))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(probabilities[0]).Should(BeNumerically("~", 0.5, 0.1))
+
+		_, probabilities, err = lda.ClassProbabilities(floatRow(0))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(probabilities[0]).Should(BeNumerically(">", 0.99))
+	})
+
+	It("Transforms rows onto the directions separating the targets", func() {
+		ds := fourFeatures()
+		lda, err := discriminantanalysis.NewLinearDiscriminantAnalysis()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(lda.Train(ds)).Should(Succeed())
+
+		ratios := lda.ExplainedVarianceRatio()
+		Ω(ratios).Should(HaveLen(2))
+		Ω(ratios[0]).Should(BeNumerically(">=", ratios[1]))
+		Ω(ratios[0] + ratios[1]).Should(BeNumerically("~", 1, 1e-9))
+
+		transformed, err := lda.TransformDataset(ds)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(transformed.NumFeatures()).Should(Equal(2))
+		Ω(transformed.NumRows()).Should(Equal(ds.NumRows()))
+
+		// the targets stay as separable, and the directions are scaled to
+		// unit variance within targets
+		reclassifier, err := discriminantanalysis.NewLinearDiscriminantAnalysis()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reclassifier.Train(transformed)).Should(Succeed())
+		Ω(trainingAccuracy(reclassifier, transformed)).Should(BeNumerically("~", trainingAccuracy(lda, ds), 0.02))
+
+		sums, squares, counts := map[string][]float64{}, map[string][]float64{}, map[string]float64{}
+		for i := 0; i < transformed.NumRows(); i++ {
+			r, err := transformed.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Target().Equals(mustRow(ds, i).Target())).Should(BeTrue())
+
+			target := slice.Entries(r.Target())[0].(string)
+			if sums[target] == nil {
+				sums[target], squares[target] = make([]float64, 2), make([]float64, 2)
+			}
+			for c, v := range r.Features().(slice.FloatSlice).Values() {
+				sums[target][c] = sums[target][c] + v
+				squares[target][c] = squares[target][c] + v*v
+			}
+			counts[target]++
+		}
+
+		for c := 0; c < 2; c++ {
+			within := 0.0
+			for target, n := range counts {
+				mean := sums[target][c] / n
+				within = within + squares[target][c] - n*mean*mean
+			}
+			Ω(within / float64(transformed.NumRows()-3)).Should(BeNumerically("~", 1, 1e-6))
+		}
+	})
+
+	It("Keeps only the requested number of components", func() {
+		ds := fourFeatures()
+
+		lda, err := discriminantanalysis.NewLinearDiscriminantAnalysis(discriminantanalysis.Components(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(lda.Train(ds)).Should(Succeed())
+		Ω(lda.ExplainedVarianceRatio()).Should(HaveLen(1))
+
+		features, err := lda.Transform(floatRow(4, 0, 0, 0))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(features.Values()).Should(HaveLen(1))
+		Ω(math.Abs(features.Values()[0])).Should(BeNumerically(">", 1))
+
+		lda, err = discriminantanalysis.NewLinearDiscriminantAnalysis(discriminantanalysis.Components(3))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(lda.Train(ds)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.TooManyComponentsError{}))
+	})
+
+	It("Needs more rows than targets to pool covariances", func() {
+		ds := gaussianDataset(1, []string{"left", "right"}, func(k int, normal func() float64) []float64 {
+			return []float64{float64(k)}
+		})
+
+		lda, err := discriminantanalysis.NewLinearDiscriminantAnalysis()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(lda.Train(ds)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.TooFewRowsError{}))
+	})
+})
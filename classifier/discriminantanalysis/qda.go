@@ -0,0 +1,117 @@
+package discriminantanalysis
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/discriminantanalysiserrors"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+)
+
+// NewQuadraticDiscriminantAnalysis returns a classifier which models each
+// target as a Gaussian with its own mean and covariance, and classifies rows
+// by Bayes' rule with the targets' shares of the training rows as priors.
+// The resulting boundaries between targets are quadratic.  Every target needs
+// at least two training rows, and usually many more than there are features
+// unless covariances are shrunk.
+func NewQuadraticDiscriminantAnalysis(options ...Option) (*quadraticDiscriminantAnalysis, error) {
+	p, err := newParameters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &quadraticDiscriminantAnalysis{parameters: p}, nil
+}
+
+type quadraticDiscriminantAnalysis struct {
+	parameters parameters
+
+	targets     []slice.Slice
+	numFeatures int
+	means       [][]float64
+	factors     [][][]float64
+	constants   []float64
+}
+
+func (qda *quadraticDiscriminantAnalysis) Train(trainingData dataset.Dataset) error {
+	classes, err := newClassRows(trainingData)
+	if err != nil {
+		return err
+	}
+
+	numTargets := len(classes.targets)
+	means := make([][]float64, numTargets)
+	factors := make([][][]float64, numTargets)
+	constants := make([]float64, numTargets)
+
+	for k, rows := range classes.rows {
+		if len(rows) < 2 {
+			return discriminantanalysiserrors.NewTooFewRowsForTargetError(k, len(rows))
+		}
+
+		var covariance [][]float64
+		means[k], covariance = matrixutilities.Covariance(rows)
+		shrink(covariance, qda.parameters.shrinkage)
+
+		factors[k], err = factor(covariance)
+		if err != nil {
+			return err
+		}
+
+		// half the log determinant is the sum of the log diagonal of the factor
+		halfLogDeterminant := 0.0
+		for i := range factors[k] {
+			halfLogDeterminant = halfLogDeterminant + math.Log(factors[k][i][i])
+		}
+		constants[k] = math.Log(classes.priors[k]) - halfLogDeterminant
+	}
+
+	qda.targets = classes.targets
+	qda.numFeatures = classes.numFeatures
+	qda.means = means
+	qda.factors = factors
+	qda.constants = constants
+	return nil
+}
+
+func (qda *quadraticDiscriminantAnalysis) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, scores, err := qda.DecisionFunction(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return targets[argmax(scores)], nil
+}
+
+func (qda *quadraticDiscriminantAnalysis) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	targets, scores, err := qda.DecisionFunction(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return targets, softmax(scores), nil
+}
+
+// DecisionFunction returns the targets and each one's log posterior
+// probability, up to a constant shared by the targets.
+func (qda *quadraticDiscriminantAnalysis) DecisionFunction(testRow row.Row) ([]slice.Slice, []float64, error) {
+	x, err := testFeatureValues(testRow, qda.numFeatures, qda.factors != nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scores := make([]float64, len(qda.factors))
+	for k, l := range qda.factors {
+		deviation := make([]float64, len(x))
+		for j, v := range x {
+			deviation[j] = v - qda.means[k][j]
+		}
+
+		whitened := matrixutilities.SolveLowerTriangular(l, deviation)
+		scores[k] = qda.constants[k] - dot(whitened, whitened)/2
+	}
+
+	return qda.targets, scores, nil
+}
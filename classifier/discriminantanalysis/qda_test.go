@@ -0,0 +1,51 @@
+package discriminantanalysis_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/discriminantanalysis"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/errors/classifier/discriminantanalysiserrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func mustRow(ds dataset.Dataset, i int) row.Row {
+	r, err := ds.Row(i)
+	Ω(err).ShouldNot(HaveOccurred())
+	return r
+}
+
+var _ = Describe("QDA", func() {
+	It("Separates targets which differ only in their spread", func() {
+		ds := gaussianDataset(200, []string{"narrow", "wide"}, func(k int, normal func() float64) []float64 {
+			scale := 1 + 4*float64(k)
+			return []float64{scale * normal(), scale * normal()}
+		})
+
+		lda, err := discriminantanalysis.NewLinearDiscriminantAnalysis()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(lda.Train(ds)).Should(Succeed())
+
+		qda, err := discriminantanalysis.NewQuadraticDiscriminantAnalysis()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(qda.Train(ds)).Should(Succeed())
+
+		Ω(trainingAccuracy(lda, ds)).Should(BeNumerically("<", 0.7))
+		Ω(trainingAccuracy(qda, ds)).Should(BeNumerically(">", 0.85))
+	})
+
+	It("Needs two rows of every target", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+		ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		for _, values := range [][]string{{"red", "1"}, {"red", "2"}, {"blue", "5"}} {
+			Ω(ds.AddRowFromStrings(values)).Should(Succeed())
+		}
+
+		qda, err := discriminantanalysis.NewQuadraticDiscriminantAnalysis()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(qda.Train(ds)).Should(BeAssignableToTypeOf(discriminantanalysiserrors.TooFewRowsForTargetError{}))
+	})
+})
@@ -0,0 +1,103 @@
+package ensemble
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+)
+
+// NewBaggingClassifier trains numEstimators classifiers built by the factory,
+// each on a bootstrap sample (drawn with replacement, the same size as the
+// training set) of the training data, and classifies by majority vote.
+func NewBaggingClassifier(factory ClassifierFactory, numEstimators int, source rand.Source) (*baggingClassifier, error) {
+	if numEstimators < 1 {
+		return nil, ensembleerrors.NewInvalidNumberOfEstimatorsError(numEstimators)
+	}
+
+	return &baggingClassifier{
+		factory:       factory,
+		numEstimators: numEstimators,
+		random:        rand.New(source),
+	}, nil
+}
+
+type baggingClassifier struct {
+	factory       ClassifierFactory
+	numEstimators int
+	random        *rand.Rand
+	estimators    []classifier.Classifier
+}
+
+func (bc *baggingClassifier) Train(trainingData dataset.Dataset) error {
+	numRows := trainingData.NumRows()
+	if numRows == 0 {
+		return ensembleerrors.NewEmptyTrainingDatasetError()
+	}
+
+	estimators := make([]classifier.Classifier, bc.numEstimators)
+
+	for i := range estimators {
+		estimator, err := bc.factory()
+		if err != nil {
+			return ensembleerrors.NewBaseClassifierConstructionError(err)
+		}
+
+		rowMap := make([]int, numRows)
+		for j := range rowMap {
+			rowMap[j] = bc.random.Intn(numRows)
+		}
+
+		err = estimator.Train(dataset.NewSubset(trainingData, rowMap))
+		if err != nil {
+			return ensembleerrors.NewBaseClassifierTrainingError(i, err)
+		}
+
+		estimators[i] = estimator
+	}
+
+	bc.estimators = estimators
+	return nil
+}
+
+func (bc *baggingClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	tally, err := bc.tally(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return tally.winner(), nil
+}
+
+// ClassProbabilities reports the fraction of estimators voting for each target.
+func (bc *baggingClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	tally, err := bc.tally(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets, probabilities := tally.distribution()
+	return targets, probabilities, nil
+}
+
+func (bc *baggingClassifier) tally(testRow row.Row) (*targetTally, error) {
+	if bc.estimators == nil {
+		return nil, ensembleerrors.NewUntrainedClassifierError()
+	}
+
+	tally := &targetTally{}
+
+	for i, estimator := range bc.estimators {
+		target, err := estimator.Classify(testRow)
+		if err != nil {
+			return nil, ensembleerrors.NewBaseClassifierClassificationError(i, err)
+		}
+
+		tally.add(target, 1)
+	}
+
+	return tally, nil
+}
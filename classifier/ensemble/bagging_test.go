@@ -0,0 +1,75 @@
+package ensemble_test
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/ensemble"
+	"github.com/amitkgupta/goodlearn/classifier/knn"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bagging", func() {
+	var knnFactory classifier.Factory
+
+	BeforeEach(func() {
+		knnFactory = func() (classifier.Classifier, error) {
+			return knn.NewKNNClassifier(3)
+		}
+	})
+
+	Describe("NewBaggingClassifier", func() {
+		It("Requires a positive number of estimators", func() {
+			_, err := ensemble.NewBaggingClassifier(knnFactory, 0, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.InvalidNumberOfEstimatorsError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Returns an error for an empty dataset", func() {
+			bc, err := ensemble.NewBaggingClassifier(knnFactory, 5, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = bc.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.EmptyTrainingDatasetError{}))
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Returns an error before training", func() {
+			bc, err := ensemble.NewBaggingClassifier(knnFactory, 5, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = bc.Classify(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.UntrainedClassifierError{}))
+		})
+
+		It("Classifies by majority vote of the bootstrapped estimators", func() {
+			ds := twoClusterDataset()
+
+			bc, err := ensemble.NewBaggingClassifier(knnFactory, 7, rand.NewSource(42))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(bc.Train(ds)).Should(Succeed())
+
+			target, err := bc.Classify(testRowAt(0.2, 0.1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(targetOfRow(ds, 0))).Should(BeTrue())
+
+			target, err = bc.Classify(testRowAt(9.8, 10.1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(targetOfRow(ds, 1))).Should(BeTrue())
+
+			_, probabilities, err := bc.ClassProbabilities(testRowAt(9.8, 10.1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(probabilities).Should(Equal([]float64{1}))
+		})
+	})
+})
@@ -0,0 +1,369 @@
+package ensemble
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+)
+
+type BoostingAlgorithm int
+
+const (
+	// AdaBoost is discrete AdaBoost, for datasets with exactly two targets.
+	AdaBoost BoostingAlgorithm = iota
+	// SAMME is the multiclass generalisation of discrete AdaBoost.
+	SAMME
+	// SAMMER is SAMME.R, which boosts on the base classifiers' class
+	// probabilities rather than their predictions; the base classifiers must
+	// be ProbabilisticClassifiers.
+	SAMMER
+)
+
+const probabilityFloor = 1e-10
+
+// NewBoostingClassifier boosts up to maxRounds classifiers built by the
+// factory (decision stumps if the factory is nil).  With a positive patience,
+// training stops once the ensemble's training error has not improved for that
+// many rounds, and the ensemble is truncated to its best round.  The rows of a
+// dataset.WeightedDataset start with weights proportional to their own.
+func NewBoostingClassifier(
+	algorithm BoostingAlgorithm,
+	factory WeightedClassifierFactory,
+	maxRounds int,
+	patience int,
+) (*boostingClassifier, error) {
+	if algorithm != AdaBoost && algorithm != SAMME && algorithm != SAMMER {
+		return nil, ensembleerrors.NewInvalidBoostingAlgorithmError(int(algorithm))
+	}
+
+	if maxRounds < 1 {
+		return nil, ensembleerrors.NewInvalidNumberOfRoundsError(maxRounds)
+	}
+
+	if patience < 0 {
+		return nil, ensembleerrors.NewInvalidPatienceError(patience)
+	}
+
+	if factory == nil {
+		factory = DecisionStumpFactory
+	}
+
+	return &boostingClassifier{
+		algorithm: algorithm,
+		factory:   factory,
+		maxRounds: maxRounds,
+		patience:  patience,
+	}, nil
+}
+
+type boostingClassifier struct {
+	algorithm BoostingAlgorithm
+	factory   WeightedClassifierFactory
+	maxRounds int
+	patience  int
+
+	targets        []slice.Slice
+	estimators     []WeightedClassifier
+	alphas         []float64
+	roundErrors    []float64
+	trainingErrors []float64
+}
+
+// RoundErrors returns the weighted training error of each round's base
+// classifier.
+func (bc *boostingClassifier) RoundErrors() []float64 {
+	return bc.roundErrors
+}
+
+// TrainingErrors returns the fraction of training rows misclassified by the
+// ensemble after each round.
+func (bc *boostingClassifier) TrainingErrors() []float64 {
+	return bc.trainingErrors
+}
+
+func (bc *boostingClassifier) Train(trainingData dataset.Dataset) error {
+	numRows := trainingData.NumRows()
+	if numRows == 0 {
+		return ensembleerrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	numTargets := len(targets)
+	if numTargets < 2 {
+		return ensembleerrors.NewTooFewTargetsError(numTargets)
+	}
+
+	if bc.algorithm == AdaBoost && numTargets != 2 {
+		return ensembleerrors.NewNonBinaryTargetsError(numTargets)
+	}
+
+	rows := make([]row.Row, numRows)
+	labels := make([]int, numRows)
+	weights := make([]float64, numRows)
+	scores := make([][]float64, numRows)
+
+	for i := range rows {
+		rows[i], err = trainingData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		labels[i] = classifierutilities.TargetIndex(targets, rows[i].Target())
+		scores[i] = make([]float64, numTargets)
+	}
+
+	rowWeights := dataset.Weights(trainingData)
+	totalWeight := 0.0
+	for _, w := range rowWeights {
+		totalWeight = totalWeight + w
+	}
+	if totalWeight == 0 {
+		return ensembleerrors.NewEmptyTrainingDatasetError()
+	}
+	for i, w := range rowWeights {
+		weights[i] = w / totalWeight
+	}
+
+	bc.targets = targets
+	bc.estimators = []WeightedClassifier{}
+	bc.alphas = []float64{}
+	bc.roundErrors = []float64{}
+	bc.trainingErrors = []float64{}
+
+	bestRounds, bestTrainingError := 0, math.Inf(1)
+
+	for round := 0; round < bc.maxRounds; round++ {
+		estimator, err := bc.factory()
+		if err != nil {
+			return ensembleerrors.NewBaseClassifierConstructionError(err)
+		}
+
+		err = estimator.TrainWithWeights(trainingData, weights)
+		if err != nil {
+			return ensembleerrors.NewBaseClassifierTrainingError(round, err)
+		}
+
+		contributions := make([][]float64, numRows)
+		weightedError := 0.0
+		for i, r := range rows {
+			contributions[i], err = bc.contribution(estimator, r)
+			if err != nil {
+				return ensembleerrors.NewBaseClassifierClassificationError(round, err)
+			}
+
+			if argmax(contributions[i]) != labels[i] {
+				weightedError = weightedError + weights[i]
+			}
+		}
+
+		if bc.algorithm != SAMMER && weightedError >= 1-1/float64(numTargets) {
+			if round == 0 {
+				return ensembleerrors.NewBaseClassifierTooWeakError(weightedError, numTargets)
+			}
+			break
+		}
+
+		alpha := bc.alpha(weightedError, numTargets)
+		bc.updateWeights(weights, contributions, labels, alpha)
+
+		misclassifiedRows := 0
+		for i := range rows {
+			for k, c := range contributions[i] {
+				scores[i][k] = scores[i][k] + alpha*c
+			}
+
+			if argmax(scores[i]) != labels[i] {
+				misclassifiedRows++
+			}
+		}
+		trainingError := float64(misclassifiedRows) / float64(numRows)
+
+		bc.estimators = append(bc.estimators, estimator)
+		bc.alphas = append(bc.alphas, alpha)
+		bc.roundErrors = append(bc.roundErrors, weightedError)
+		bc.trainingErrors = append(bc.trainingErrors, trainingError)
+
+		if trainingError < bestTrainingError {
+			bestRounds, bestTrainingError = len(bc.estimators), trainingError
+		} else if bc.patience > 0 && len(bc.estimators)-bestRounds >= bc.patience {
+			break
+		}
+
+		if weightedError == 0 {
+			break
+		}
+	}
+
+	if bc.patience > 0 {
+		bc.estimators = bc.estimators[:bestRounds]
+		bc.alphas = bc.alphas[:bestRounds]
+	}
+
+	return nil
+}
+
+func (bc *boostingClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	scores, err := bc.scores(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return bc.targets[argmax(scores)], nil
+}
+
+// ClassProbabilities converts the ensemble's per-target scores into
+// probabilities with a softmax, scaled by the number of targets as in SAMME.
+func (bc *boostingClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	scores, err := bc.scores(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scale := float64(len(bc.targets) - 1)
+	if bc.algorithm == AdaBoost {
+		scale = 0.5
+	}
+
+	max := scores[argmax(scores)]
+	probabilities := make([]float64, len(scores))
+	sum := 0.0
+	for k, s := range scores {
+		probabilities[k] = math.Exp((s - max) / scale)
+		sum = sum + probabilities[k]
+	}
+	for k := range probabilities {
+		probabilities[k] = probabilities[k] / sum
+	}
+
+	return bc.targets, probabilities, nil
+}
+
+func (bc *boostingClassifier) scores(testRow row.Row) ([]float64, error) {
+	if bc.estimators == nil {
+		return nil, ensembleerrors.NewUntrainedClassifierError()
+	}
+
+	scores := make([]float64, len(bc.targets))
+	for m, estimator := range bc.estimators {
+		contribution, err := bc.contribution(estimator, testRow)
+		if err != nil {
+			return nil, ensembleerrors.NewBaseClassifierClassificationError(m, err)
+		}
+
+		for k, c := range contribution {
+			scores[k] = scores[k] + bc.alphas[m]*c
+		}
+	}
+
+	return scores, nil
+}
+
+// contribution returns the per-target vote of a single estimator: a one-hot
+// encoding of its prediction for AdaBoost and SAMME, and the symmetric
+// log-probabilities for SAMME.R.
+func (bc *boostingClassifier) contribution(estimator WeightedClassifier, r row.Row) ([]float64, error) {
+	numTargets := len(bc.targets)
+	contribution := make([]float64, numTargets)
+
+	if bc.algorithm != SAMMER {
+		target, err := estimator.Classify(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if k := classifierutilities.TargetIndex(bc.targets, target); k >= 0 {
+			contribution[k] = 1
+		}
+		return contribution, nil
+	}
+
+	pc, ok := estimator.(classifier.ProbabilisticClassifier)
+	if !ok {
+		return nil, ensembleerrors.NewNonProbabilisticBaseClassifierError()
+	}
+
+	targets, probabilities, err := pc.ClassProbabilities(r)
+	if err != nil {
+		return nil, err
+	}
+
+	logProbabilities := make([]float64, numTargets)
+	for k := range logProbabilities {
+		logProbabilities[k] = math.Log(probabilityFloor)
+	}
+	for j, target := range targets {
+		if k := classifierutilities.TargetIndex(bc.targets, target); k >= 0 {
+			logProbabilities[k] = math.Log(math.Max(probabilities[j], probabilityFloor))
+		}
+	}
+
+	meanLogProbability := 0.0
+	for _, lp := range logProbabilities {
+		meanLogProbability = meanLogProbability + lp/float64(numTargets)
+	}
+
+	for k, lp := range logProbabilities {
+		contribution[k] = float64(numTargets-1) * (lp - meanLogProbability)
+	}
+
+	return contribution, nil
+}
+
+func (bc *boostingClassifier) alpha(weightedError float64, numTargets int) float64 {
+	switch bc.algorithm {
+	case AdaBoost:
+		weightedError = math.Max(weightedError, probabilityFloor)
+		return 0.5 * math.Log((1-weightedError)/weightedError)
+	case SAMME:
+		weightedError = math.Max(weightedError, probabilityFloor)
+		return math.Log((1-weightedError)/weightedError) + math.Log(float64(numTargets-1))
+	default:
+		return 1
+	}
+}
+
+func (bc *boostingClassifier) updateWeights(weights []float64, contributions [][]float64, labels []int, alpha float64) {
+	numTargets := float64(len(bc.targets))
+	sum := 0.0
+
+	for i := range weights {
+		switch bc.algorithm {
+		case AdaBoost:
+			if argmax(contributions[i]) != labels[i] {
+				weights[i] = weights[i] * math.Exp(2*alpha)
+			}
+		case SAMME:
+			if argmax(contributions[i]) != labels[i] {
+				weights[i] = weights[i] * math.Exp(alpha)
+			}
+		case SAMMER:
+			// contributions are (K-1)(log p_k - mean log p); recover the
+			// SAMME.R exponent -((K-1)/K) y.log p with y coded as 1 for the
+			// true target and -1/(K-1) otherwise.
+			exponent := 0.0
+			for k, c := range contributions[i] {
+				y := -1 / (numTargets - 1)
+				if k == labels[i] {
+					y = 1
+				}
+				exponent = exponent + y*c/(numTargets-1)
+			}
+			weights[i] = weights[i] * math.Exp(-((numTargets-1)/numTargets)*exponent)
+		}
+
+		sum = sum + weights[i]
+	}
+
+	for i := range weights {
+		weights[i] = weights[i] / sum
+	}
+}
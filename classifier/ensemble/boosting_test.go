@@ -0,0 +1,178 @@
+package ensemble_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/ensemble"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Boosting", func() {
+	Describe("NewBoostingClassifier", func() {
+		It("Rejects unknown algorithms", func() {
+			_, err := ensemble.NewBoostingClassifier(ensemble.BoostingAlgorithm(7), nil, 10, 0)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.InvalidBoostingAlgorithmError{}))
+		})
+
+		It("Requires a positive number of rounds", func() {
+			_, err := ensemble.NewBoostingClassifier(ensemble.SAMME, nil, 0, 0)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.InvalidNumberOfRoundsError{}))
+		})
+
+		It("Requires a non-negative patience", func() {
+			_, err := ensemble.NewBoostingClassifier(ensemble.SAMME, nil, 10, -1)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.InvalidPatienceError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Requires exactly two targets for AdaBoost", func() {
+			bc, err := ensemble.NewBoostingClassifier(ensemble.AdaBoost, nil, 10, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = bc.Train(intervalDataset(3, "a", "b", "c"))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.NonBinaryTargetsError{}))
+		})
+
+		It("Requires at least two targets", func() {
+			for _, algorithm := range []ensemble.BoostingAlgorithm{ensemble.SAMME, ensemble.SAMMER} {
+				bc, err := ensemble.NewBoostingClassifier(algorithm, nil, 10, 0)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = bc.Train(intervalDataset(3, "a"))
+				Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.TooFewTargetsError{}))
+			}
+		})
+
+		It("Requires probabilistic base classifiers for SAMME.R", func() {
+			factory := func() (ensemble.WeightedClassifier, error) {
+				return &weightedConstantClassifier{}, nil
+			}
+
+			bc, err := ensemble.NewBoostingClassifier(ensemble.SAMMER, factory, 10, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = bc.Train(intervalDataset(3, "a", "b"))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.BaseClassifierClassificationError{}))
+		})
+
+		It("Rejects base classifiers no better than chance", func() {
+			factory := func() (ensemble.WeightedClassifier, error) {
+				return &weightedConstantClassifier{}, nil
+			}
+
+			bc, err := ensemble.NewBoostingClassifier(ensemble.SAMME, factory, 10, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = bc.Train(intervalDataset(3, "a", "b"))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.BaseClassifierTooWeakError{}))
+		})
+	})
+
+	for _, algorithm := range []ensemble.BoostingAlgorithm{ensemble.AdaBoost, ensemble.SAMME, ensemble.SAMMER} {
+		algorithm := algorithm
+
+		Describe("Classify", func() {
+			var ds dataset.Dataset
+			var bc classifier.ProbabilisticClassifier
+
+			BeforeEach(func() {
+				ds = intervalDataset(4, "a", "b", "a", "b")
+
+				boosted, err := ensemble.NewBoostingClassifier(algorithm, nil, 30, 0)
+				Ω(err).ShouldNot(HaveOccurred())
+				bc = boosted
+			})
+
+			It("Returns an error before training", func() {
+				_, err := bc.Classify(testRowAtX(0))
+				Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.UntrainedClassifierError{}))
+			})
+
+			It("Fits a target no single stump can represent", func() {
+				Ω(bc.Train(ds)).Should(Succeed())
+
+				for i := 0; i < ds.NumRows(); i++ {
+					r, err := ds.Row(i)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					target, err := bc.Classify(r)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(target.Equals(r.Target())).Should(BeTrue())
+				}
+
+				_, probabilities, err := bc.ClassProbabilities(testRowAtX(5))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(probabilities[0] + probabilities[1]).Should(BeNumerically("~", 1, 1e-9))
+				Ω(probabilities[1]).Should(BeNumerically(">", 0.5))
+			})
+		})
+	}
+
+	Describe("Multiclass boosting", func() {
+		It("Fits three targets with SAMME", func() {
+			ds := intervalDataset(3, "a", "b", "c", "b")
+
+			bc, err := ensemble.NewBoostingClassifier(ensemble.SAMME, nil, 30, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(bc.Train(ds)).Should(Succeed())
+
+			correct := 0
+			for i := 0; i < ds.NumRows(); i++ {
+				r, err := ds.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				target, err := bc.Classify(r)
+				Ω(err).ShouldNot(HaveOccurred())
+				if target.Equals(r.Target()) {
+					correct++
+				}
+			}
+			Ω(correct).Should(Equal(ds.NumRows()))
+		})
+	})
+
+	Describe("Round reporting and early stopping", func() {
+		It("Reports one weighted error and training error per round", func() {
+			bc, err := ensemble.NewBoostingClassifier(ensemble.SAMME, nil, 5, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(bc.Train(intervalDataset(4, "a", "b", "a", "b"))).Should(Succeed())
+
+			Ω(bc.RoundErrors()).Should(HaveLen(len(bc.TrainingErrors())))
+			Ω(len(bc.RoundErrors())).Should(BeNumerically(">", 1))
+			for _, e := range bc.RoundErrors() {
+				Ω(e).Should(BeNumerically("<", 0.5))
+			}
+		})
+
+		It("Stops once the training error stops improving", func() {
+			bc, err := ensemble.NewBoostingClassifier(ensemble.SAMME, nil, 200, 3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(bc.Train(intervalDataset(4, "a", "b", "a", "b"))).Should(Succeed())
+
+			Ω(len(bc.TrainingErrors())).Should(BeNumerically("<", 200))
+		})
+	})
+})
+
+type weightedConstantClassifier struct {
+	target slice.Slice
+}
+
+func (c *weightedConstantClassifier) Train(ds dataset.Dataset) error {
+	return c.TrainWithWeights(ds, nil)
+}
+
+func (c *weightedConstantClassifier) TrainWithWeights(ds dataset.Dataset, weights []float64) error {
+	c.target = targetOfRow(ds, 0)
+	return nil
+}
+
+func (c *weightedConstantClassifier) Classify(row.Row) (slice.Slice, error) {
+	return c.target, nil
+}
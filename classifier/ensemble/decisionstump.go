@@ -0,0 +1,219 @@
+package ensemble
+
+import (
+	"sort"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+)
+
+// WeightedClassifier is a Classifier which can be trained with a per-row
+// sample weight, as boosting requires.
+type WeightedClassifier interface {
+	classifier.Classifier
+	TrainWithWeights(dataset.Dataset, []float64) error
+}
+
+type WeightedClassifierFactory func() (WeightedClassifier, error)
+
+// NewDecisionStump returns a one-split decision tree over float features,
+// choosing the feature and threshold which minimise the weighted
+// misclassification error.
+func NewDecisionStump() *decisionStump {
+	return &decisionStump{}
+}
+
+func DecisionStumpFactory() (WeightedClassifier, error) {
+	return NewDecisionStump(), nil
+}
+
+type decisionStump struct {
+	targets     []slice.Slice
+	numFeatures int
+	feature     int
+	threshold   float64
+	left        []float64
+	right       []float64
+}
+
+func (ds *decisionStump) Train(trainingData dataset.Dataset) error {
+	return ds.TrainWithWeights(trainingData, dataset.Weights(trainingData))
+}
+
+func (ds *decisionStump) TrainWithWeights(trainingData dataset.Dataset, weights []float64) error {
+	if !trainingData.AllFeaturesFloats() {
+		return ensembleerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	numRows := trainingData.NumRows()
+	if numRows == 0 {
+		return ensembleerrors.NewEmptyTrainingDatasetError()
+	}
+
+	if len(weights) != numRows {
+		return ensembleerrors.NewSampleWeightsLengthMismatchError(len(weights), numRows)
+	}
+
+	for _, w := range weights {
+		if w < 0 {
+			return ensembleerrors.NewInvalidSampleWeightError(w)
+		}
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	numTargets := len(targets)
+	numFeatures := trainingData.NumFeatures()
+	features := make([][]float64, numRows)
+	labels := make([]int, numRows)
+	total := make([]float64, numTargets)
+
+	for i := 0; i < numRows; i++ {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		features[i] = r.Features().(slice.FloatSlice).Values()
+		labels[i] = classifierutilities.TargetIndex(targets, r.Target())
+		total[labels[i]] = total[labels[i]] + weights[i]
+	}
+
+	bestError := misclassified(total)
+	bestFeature := -1
+	bestThreshold := 0.0
+	bestLeft := total
+
+	order := make([]int, numRows)
+	for j := 0; j < numFeatures; j++ {
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool { return features[order[a]][j] < features[order[b]][j] })
+
+		left := make([]float64, numTargets)
+		right := make([]float64, numTargets)
+		copy(right, total)
+
+		for p := 0; p < numRows-1; p++ {
+			i := order[p]
+			left[labels[i]] = left[labels[i]] + weights[i]
+			right[labels[i]] = right[labels[i]] - weights[i]
+
+			x, nextX := features[i][j], features[order[p+1]][j]
+			if x == nextX {
+				continue
+			}
+
+			splitError := misclassified(left) + misclassified(right)
+			if splitError < bestError {
+				bestError = splitError
+				bestFeature = j
+				bestThreshold = (x + nextX) / 2
+				bestLeft = append([]float64{}, left...)
+			}
+		}
+	}
+
+	bestRight := make([]float64, numTargets)
+	for k := range bestRight {
+		bestRight[k] = total[k] - bestLeft[k]
+	}
+
+	ds.targets = targets
+	ds.numFeatures = numFeatures
+	ds.feature = bestFeature
+	ds.threshold = bestThreshold
+	ds.left = normalizedDistribution(bestLeft)
+	ds.right = normalizedDistribution(bestRight)
+	return nil
+}
+
+func (ds *decisionStump) Classify(testRow row.Row) (slice.Slice, error) {
+	distribution, err := ds.leafDistribution(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds.targets[argmax(distribution)], nil
+}
+
+func (ds *decisionStump) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	distribution, err := ds.leafDistribution(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ds.targets, append([]float64{}, distribution...), nil
+}
+
+func (ds *decisionStump) leafDistribution(testRow row.Row) ([]float64, error) {
+	if ds.targets == nil {
+		return nil, ensembleerrors.NewUntrainedClassifierError()
+	}
+
+	if testRow.NumFeatures() != ds.numFeatures {
+		return nil, ensembleerrors.NewRowLengthMismatchError(testRow.NumFeatures(), ds.numFeatures)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, ensembleerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	if ds.feature < 0 || testFeatures.Values()[ds.feature] <= ds.threshold {
+		return ds.left, nil
+	}
+
+	return ds.right, nil
+}
+
+func misclassified(classWeights []float64) float64 {
+	sum, max := 0.0, 0.0
+	for _, w := range classWeights {
+		sum = sum + w
+		if w > max {
+			max = w
+		}
+	}
+
+	return sum - max
+}
+
+func normalizedDistribution(classWeights []float64) []float64 {
+	sum := 0.0
+	for _, w := range classWeights {
+		sum = sum + w
+	}
+
+	distribution := make([]float64, len(classWeights))
+	for k, w := range classWeights {
+		if sum > 0 {
+			distribution[k] = w / sum
+		} else {
+			distribution[k] = 1 / float64(len(classWeights))
+		}
+	}
+
+	return distribution
+}
+
+// argmax returns the index of the largest entry, preferring the earliest
+// index amongst ties.
+func argmax(values []float64) int {
+	best := 0
+	for i, v := range values {
+		if v > values[best] {
+			best = i
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,93 @@
+package ensemble_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/ensemble"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DecisionStump", func() {
+	var ds dataset.Dataset
+
+	BeforeEach(func() {
+		ds = intervalDataset(3, "a", "b", "a")
+	})
+
+	Describe("TrainWithWeights", func() {
+		It("Requires float features", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "b"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			nonFloat := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			err = nonFloat.AddRowFromStrings([]string{"a", "b"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = ensemble.NewDecisionStump().Train(nonFloat)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.NonFloatFeaturesTrainingSetError{}))
+		})
+
+		It("Requires one weight per row", func() {
+			err := ensemble.NewDecisionStump().TrainWithWeights(ds, []float64{1, 2})
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.SampleWeightsLengthMismatchError{}))
+		})
+
+		It("Requires non-negative weights", func() {
+			weights := []float64{1, 1, 1, 1, 1, 1, 1, 1, -1}
+			err := ensemble.NewDecisionStump().TrainWithWeights(ds, weights)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.InvalidSampleWeightError{}))
+		})
+
+		It("Chooses the split which minimises weighted error", func() {
+			stump := ensemble.NewDecisionStump()
+
+			// Heavily weighting the rows at x = 6, 7, 8 makes the split
+			// between 5 and 6 the best one.
+			weights := []float64{1, 1, 1, 5, 5, 5, 10, 10, 10}
+			Ω(stump.TrainWithWeights(ds, weights)).Should(Succeed())
+
+			target, err := stump.Classify(testRowAtX(7))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(targetOfRow(ds, 0))).Should(BeTrue())
+
+			targets, probabilities, err := stump.ClassProbabilities(testRowAtX(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(2))
+			Ω(probabilities[0]).Should(BeNumerically("~", 3.0/18, 1e-9))
+			Ω(probabilities[1]).Should(BeNumerically("~", 15.0/18, 1e-9))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Weights the rows of a weighted dataset", func() {
+			weighted, err := dataset.NewWeightedDataset(ds, []float64{1, 1, 1, 5, 5, 5, 10, 10, 10})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			stump := ensemble.NewDecisionStump()
+			Ω(stump.Train(weighted)).Should(Succeed())
+
+			_, probabilities, err := stump.ClassProbabilities(testRowAtX(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(probabilities[0]).Should(BeNumerically("~", 3.0/18, 1e-9))
+			Ω(probabilities[1]).Should(BeNumerically("~", 15.0/18, 1e-9))
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Returns an error before training", func() {
+			_, err := ensemble.NewDecisionStump().Classify(testRowAtX(0))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.UntrainedClassifierError{}))
+		})
+
+		It("Returns an error for rows of the wrong length", func() {
+			stump := ensemble.NewDecisionStump()
+			Ω(stump.Train(ds)).Should(Succeed())
+
+			_, err := stump.Classify(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.RowLengthMismatchError{}))
+		})
+	})
+})
@@ -1 +1,59 @@
 package ensemble
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// ClassifierFactory builds a fresh, untrained classifier.  Ensembles which
+// need to train several independent copies of a base model take a factory
+// rather than a classifier.
+type ClassifierFactory = classifier.Factory
+
+type targetTally struct {
+	targets []slice.Slice
+	scores  []float64
+}
+
+func (tally *targetTally) add(target slice.Slice, score float64) {
+	i := classifierutilities.TargetIndex(tally.targets, target)
+	if i < 0 {
+		tally.targets = append(tally.targets, target)
+		tally.scores = append(tally.scores, score)
+		return
+	}
+
+	tally.scores[i] = tally.scores[i] + score
+}
+
+// winner returns the target with the highest score; ties go to whichever of
+// the tied targets was added first.
+func (tally *targetTally) winner() slice.Slice {
+	winningIndex := 0
+	for i, score := range tally.scores {
+		if score > tally.scores[winningIndex] {
+			winningIndex = i
+		}
+	}
+
+	return tally.targets[winningIndex]
+}
+
+func (tally *targetTally) distribution() ([]slice.Slice, []float64) {
+	total := 0.0
+	for _, score := range tally.scores {
+		total = total + score
+	}
+
+	probabilities := make([]float64, len(tally.scores))
+	for i, score := range tally.scores {
+		if total > 0 {
+			probabilities[i] = score / total
+		} else {
+			probabilities[i] = 1 / float64(len(tally.scores))
+		}
+	}
+
+	return tally.targets, probabilities
+}
@@ -1 +1,105 @@
 package ensemble_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/gomega"
+)
+
+// twoClusterDataset has label "a" near the origin and label "b" near (10, 10).
+func twoClusterDataset() dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+	for i := 0; i < 10; i++ {
+		offset := 0.1 * float64(i%4)
+
+		err = ds.AddRowFromStrings([]string{"a", fmt.Sprintf("%.2f", offset), fmt.Sprintf("%.2f", -offset)})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = ds.AddRowFromStrings([]string{"b", fmt.Sprintf("%.2f", 10+offset), fmt.Sprintf("%.2f", 10-offset)})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	return ds
+}
+
+func targetOfRow(ds dataset.Dataset, i int) slice.Slice {
+	r, err := ds.Row(i)
+	Ω(err).ShouldNot(HaveOccurred())
+	return r.Target()
+}
+
+func testRowAt(x, y float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice([]float64{x, y}), nil, 2)
+}
+
+type constantClassifier struct {
+	target     slice.Slice
+	trainError error
+	trained    bool
+}
+
+func (c *constantClassifier) Train(dataset.Dataset) error {
+	c.trained = true
+	return c.trainError
+}
+
+func (c *constantClassifier) Classify(row.Row) (slice.Slice, error) {
+	return c.target, nil
+}
+
+// weightRecordingClassifier is a constantClassifier which remembers the row
+// weights it was trained with.
+type weightRecordingClassifier struct {
+	constantClassifier
+	weights []float64
+}
+
+func (c *weightRecordingClassifier) Train(ds dataset.Dataset) error {
+	c.weights = dataset.Weights(ds)
+	return c.constantClassifier.Train(ds)
+}
+
+type fixedProbabilitiesClassifier struct {
+	targets       []slice.Slice
+	probabilities []float64
+}
+
+func (c *fixedProbabilitiesClassifier) Train(dataset.Dataset) error {
+	return nil
+}
+
+func (c *fixedProbabilitiesClassifier) Classify(row.Row) (slice.Slice, error) {
+	return nil, errors.New("should classify via ClassProbabilities")
+}
+
+func (c *fixedProbabilitiesClassifier) ClassProbabilities(row.Row) ([]slice.Slice, []float64, error) {
+	return c.targets, c.probabilities, nil
+}
+
+// intervalDataset has a single float feature x = 0, 1, ..., and target
+// labels[x/width].
+func intervalDataset(width int, labels ...string) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+	for x := 0; x < width*len(labels); x++ {
+		err = ds.AddRowFromStrings([]string{labels[x/width], fmt.Sprintf("%d", x)})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	return ds
+}
+
+func testRowAtX(x float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice([]float64{x}), nil, 1)
+}
@@ -0,0 +1,175 @@
+package ensemble
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/decider/gbdt"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+)
+
+// NewGradientBoostingClassifier boosts regression trees on the binomial
+// deviance when the training data has two targets, and on the multinomial
+// deviance otherwise.  If validationData is not nil its deviance is tracked
+// every round, and used for early stopping when params.Patience is positive.
+// Each row's deviance is weighted by its weight if the training or validation
+// data is a dataset.WeightedDataset.
+func NewGradientBoostingClassifier(
+	params gbdt.Parameters,
+	validationData dataset.Dataset,
+	source rand.Source,
+) (*gradientBoostingClassifier, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &gradientBoostingClassifier{
+		params:         params,
+		validationData: validationData,
+		source:         source,
+	}, nil
+}
+
+type gradientBoostingClassifier struct {
+	params         gbdt.Parameters
+	validationData dataset.Dataset
+	source         rand.Source
+	targets        []slice.Slice
+	model          *gbdt.Model
+}
+
+// Model returns the trained tree ensemble, which reports the per-round
+// training and validation deviance.
+func (gbc *gradientBoostingClassifier) Model() *gbdt.Model {
+	return gbc.model
+}
+
+func (gbc *gradientBoostingClassifier) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return ensembleerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if trainingData.NumRows() == 0 {
+		return ensembleerrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) < 2 {
+		return ensembleerrors.NewTooFewTargetsError(len(targets))
+	}
+
+	x, y, weights, err := encodedRows(trainingData, targets)
+	if err != nil {
+		return err
+	}
+
+	var validationX [][]float64
+	var validationY, validationWeights []float64
+	if gbc.validationData != nil {
+		if !gbc.validationData.AllFeaturesFloats() {
+			return ensembleerrors.NewNonFloatFeaturesTrainingSetError()
+		}
+
+		validationX, validationY, validationWeights, err = encodedRows(gbc.validationData, targets)
+		if err != nil {
+			return err
+		}
+	}
+
+	loss := gbdt.BinomialDeviance()
+	if len(targets) != 2 {
+		loss = gbdt.MultinomialDeviance(len(targets))
+	}
+
+	model, err := gbdt.TrainWeighted(
+		x,
+		y,
+		weights,
+		trainingData.NumFeatures(),
+		validationX,
+		validationY,
+		validationWeights,
+		loss,
+		gbc.params,
+		gbc.source,
+	)
+	if err != nil {
+		return ensembleerrors.NewTreeBoostingError(err)
+	}
+
+	gbc.targets = targets
+	gbc.model = model
+	return nil
+}
+
+func (gbc *gradientBoostingClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	_, probabilities, err := gbc.ClassProbabilities(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return gbc.targets[argmax(probabilities)], nil
+}
+
+func (gbc *gradientBoostingClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if gbc.model == nil {
+		return nil, nil, ensembleerrors.NewUntrainedClassifierError()
+	}
+
+	if testRow.NumFeatures() != gbc.model.NumFeatures() {
+		return nil, nil, ensembleerrors.NewRowLengthMismatchError(testRow.NumFeatures(), gbc.model.NumFeatures())
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, nil, ensembleerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	scores, err := gbc.model.Scores(testFeatures.Values())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(scores) == 1 {
+		p := gbdt.Sigmoid(scores[0])
+		return gbc.targets, []float64{1 - p, p}, nil
+	}
+
+	return gbc.targets, gbdt.Softmax(scores), nil
+}
+
+// encodedRows returns the float features of each row along with the index of
+// its target in targets and its weight.  Rows whose target is not in targets
+// are skipped.
+func encodedRows(ds dataset.Dataset, targets []slice.Slice) ([][]float64, []float64, []float64, error) {
+	x := [][]float64{}
+	y := []float64{}
+	w := []float64{}
+	weights := dataset.Weights(ds)
+
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		k := classifierutilities.TargetIndex(targets, r.Target())
+		if k < 0 {
+			continue
+		}
+
+		x = append(x, r.Features().(slice.FloatSlice).Values())
+		y = append(y, float64(k))
+		w = append(w, weights[i])
+	}
+
+	return x, y, w, nil
+}
@@ -0,0 +1,105 @@
+package ensemble_test
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/ensemble"
+	"github.com/amitkgupta/goodlearn/decider/gbdt"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GradientBoostingClassifier", func() {
+	var params gbdt.Parameters
+
+	BeforeEach(func() {
+		params = gbdt.DefaultParameters()
+		params.NumRounds = 30
+	})
+
+	It("Rejects invalid parameters", func() {
+		params.MaxDepth = 0
+		_, err := ensemble.NewGradientBoostingClassifier(params, nil, rand.NewSource(1))
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("Returns an error before training", func() {
+		gbc, err := ensemble.NewGradientBoostingClassifier(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = gbc.Classify(testRowAtX(0))
+		Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.UntrainedClassifierError{}))
+	})
+
+	It("Needs at least two targets", func() {
+		gbc, err := ensemble.NewGradientBoostingClassifier(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = gbc.Train(intervalDataset(3, "a"))
+		Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.TooFewTargetsError{}))
+	})
+
+	It("Fits two targets with the binomial deviance", func() {
+		ds := intervalDataset(4, "a", "b", "a", "b")
+
+		gbc, err := ensemble.NewGradientBoostingClassifier(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gbc.Train(ds)).Should(Succeed())
+
+		for i := 0; i < ds.NumRows(); i++ {
+			r, err := ds.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			target, err := gbc.Classify(r)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(r.Target())).Should(BeTrue())
+		}
+
+		targets, probabilities, err := gbc.ClassProbabilities(testRowAtX(5))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets).Should(HaveLen(2))
+		Ω(probabilities[1]).Should(BeNumerically(">", 0.9))
+	})
+
+	It("Fits several targets with the multinomial deviance", func() {
+		ds := intervalDataset(3, "a", "b", "c", "a")
+
+		gbc, err := ensemble.NewGradientBoostingClassifier(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gbc.Train(ds)).Should(Succeed())
+
+		for i := 0; i < ds.NumRows(); i++ {
+			r, err := ds.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			target, err := gbc.Classify(r)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(r.Target())).Should(BeTrue())
+		}
+
+		_, probabilities, err := gbc.ClassProbabilities(testRowAtX(4))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(probabilities[0] + probabilities[1] + probabilities[2]).Should(BeNumerically("~", 1, 1e-9))
+	})
+
+	It("Tracks the validation deviance", func() {
+		ds := intervalDataset(4, "a", "b")
+
+		gbc, err := ensemble.NewGradientBoostingClassifier(params, ds, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gbc.Train(ds)).Should(Succeed())
+
+		Ω(gbc.Model().ValidationLosses()).Should(HaveLen(params.NumRounds))
+	})
+	It("Skips validation rows whose target is not in the training data", func() {
+		validationData := intervalDataset(2, "a", "b", "c")
+
+		gbc, err := ensemble.NewGradientBoostingClassifier(params, validationData, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(gbc.Train(intervalDataset(4, "a", "b"))).Should(Succeed())
+
+		Ω(gbc.Model().ValidationLosses()).Should(HaveLen(params.NumRounds))
+	})
+})
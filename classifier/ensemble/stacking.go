@@ -0,0 +1,204 @@
+package ensemble
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+)
+
+// NewStackingClassifier trains a meta classifier on the out-of-fold
+// predictions of the base classifiers.  Each base classifier contributes one
+// meta feature per distinct training target: its class probabilities if it is
+// a ProbabilisticClassifier, and a one-hot encoding of its prediction
+// otherwise.  Once the meta classifier is trained, the base classifiers are
+// retrained on the full training set.
+func NewStackingClassifier(
+	baseFactories []ClassifierFactory,
+	metaFactory ClassifierFactory,
+	numFolds int,
+	source rand.Source,
+) (*stackingClassifier, error) {
+	if len(baseFactories) == 0 {
+		return nil, ensembleerrors.NewNoBaseClassifiersError()
+	}
+
+	if numFolds < 2 {
+		return nil, ensembleerrors.NewInvalidNumberOfFoldsError(numFolds)
+	}
+
+	return &stackingClassifier{
+		baseFactories: baseFactories,
+		metaFactory:   metaFactory,
+		numFolds:      numFolds,
+		random:        rand.New(source),
+	}, nil
+}
+
+type stackingClassifier struct {
+	baseFactories []ClassifierFactory
+	metaFactory   ClassifierFactory
+	numFolds      int
+	random        *rand.Rand
+
+	targets         []slice.Slice
+	baseClassifiers []classifier.Classifier
+	metaClassifier  classifier.Classifier
+}
+
+func (sc *stackingClassifier) Train(trainingData dataset.Dataset) error {
+	numRows := trainingData.NumRows()
+	if numRows == 0 {
+		return ensembleerrors.NewEmptyTrainingDatasetError()
+	}
+
+	if numRows < sc.numFolds {
+		return ensembleerrors.NewTooFewRowsForFoldsError(numRows, sc.numFolds)
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+	sc.targets = targets
+
+	folds := make([][]int, sc.numFolds)
+	for i, rowIndex := range sc.random.Perm(numRows) {
+		folds[i%sc.numFolds] = append(folds[i%sc.numFolds], rowIndex)
+	}
+
+	numMetaFeatures := len(sc.baseFactories) * len(targets)
+	metaRows := make([]row.Row, numRows)
+
+	for f, heldOut := range folds {
+		trainingRowMap := []int{}
+		for g, fold := range folds {
+			if g != f {
+				trainingRowMap = append(trainingRowMap, fold...)
+			}
+		}
+
+		baseClassifiers, err := sc.trainBaseClassifiers(dataset.NewSubset(trainingData, trainingRowMap))
+		if err != nil {
+			return err
+		}
+
+		for _, rowIndex := range heldOut {
+			r, err := trainingData.Row(rowIndex)
+			if err != nil {
+				return err
+			}
+
+			metaFeatures, err := sc.metaFeatures(baseClassifiers, r)
+			if err != nil {
+				return err
+			}
+
+			metaRows[rowIndex] = row.NewRow(slice.NewFloatSlice(metaFeatures), r.Target(), numMetaFeatures)
+		}
+	}
+
+	metaClassifier, err := sc.metaFactory()
+	if err != nil {
+		return ensembleerrors.NewMetaClassifierConstructionError(err)
+	}
+
+	metaData, err := dataset.NewWeightedDataset(
+		dataset.NewDatasetFromRows(numMetaFeatures, trainingData.NumTargets(), metaRows),
+		dataset.Weights(trainingData),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = metaClassifier.Train(metaData)
+	if err != nil {
+		return ensembleerrors.NewMetaClassifierTrainingError(err)
+	}
+
+	baseClassifiers, err := sc.trainBaseClassifiers(trainingData)
+	if err != nil {
+		return err
+	}
+
+	sc.baseClassifiers = baseClassifiers
+	sc.metaClassifier = metaClassifier
+	return nil
+}
+
+func (sc *stackingClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	if sc.metaClassifier == nil {
+		return nil, ensembleerrors.NewUntrainedClassifierError()
+	}
+
+	metaFeatures, err := sc.metaFeatures(sc.baseClassifiers, testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := sc.metaClassifier.Classify(
+		row.NewRow(slice.NewFloatSlice(metaFeatures), testRow.Target(), len(metaFeatures)),
+	)
+	if err != nil {
+		return nil, ensembleerrors.NewMetaClassifierClassificationError(err)
+	}
+
+	return target, nil
+}
+
+func (sc *stackingClassifier) trainBaseClassifiers(trainingData dataset.Dataset) ([]classifier.Classifier, error) {
+	baseClassifiers := make([]classifier.Classifier, len(sc.baseFactories))
+
+	for i, factory := range sc.baseFactories {
+		c, err := factory()
+		if err != nil {
+			return nil, ensembleerrors.NewBaseClassifierConstructionError(err)
+		}
+
+		err = c.Train(trainingData)
+		if err != nil {
+			return nil, ensembleerrors.NewBaseClassifierTrainingError(i, err)
+		}
+
+		baseClassifiers[i] = c
+	}
+
+	return baseClassifiers, nil
+}
+
+func (sc *stackingClassifier) metaFeatures(baseClassifiers []classifier.Classifier, r row.Row) ([]float64, error) {
+	numTargets := len(sc.targets)
+	metaFeatures := make([]float64, len(baseClassifiers)*numTargets)
+
+	for i, c := range baseClassifiers {
+		offset := i * numTargets
+
+		if pc, ok := c.(classifier.ProbabilisticClassifier); ok {
+			targets, probabilities, err := pc.ClassProbabilities(r)
+			if err != nil {
+				return nil, ensembleerrors.NewBaseClassifierClassificationError(i, err)
+			}
+
+			for j, target := range targets {
+				if k := classifierutilities.TargetIndex(sc.targets, target); k >= 0 {
+					metaFeatures[offset+k] = probabilities[j]
+				}
+			}
+		} else {
+			target, err := c.Classify(r)
+			if err != nil {
+				return nil, ensembleerrors.NewBaseClassifierClassificationError(i, err)
+			}
+
+			if k := classifierutilities.TargetIndex(sc.targets, target); k >= 0 {
+				metaFeatures[offset+k] = 1
+			}
+		}
+	}
+
+	return metaFeatures, nil
+}
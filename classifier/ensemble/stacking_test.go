@@ -0,0 +1,125 @@
+package ensemble_test
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/ensemble"
+	"github.com/amitkgupta/goodlearn/classifier/knn"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stacking", func() {
+	var baseFactories []ensemble.ClassifierFactory
+	var metaFactory ensemble.ClassifierFactory
+
+	BeforeEach(func() {
+		baseFactories = []ensemble.ClassifierFactory{
+			func() (classifier.Classifier, error) { return knn.NewKNNClassifier(1) },
+			func() (classifier.Classifier, error) { return knn.NewKNNClassifier(3) },
+		}
+		metaFactory = func() (classifier.Classifier, error) { return knn.NewKNNClassifier(1) }
+	})
+
+	Describe("NewStackingClassifier", func() {
+		It("Requires at least one base classifier", func() {
+			_, err := ensemble.NewStackingClassifier(nil, metaFactory, 3, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.NoBaseClassifiersError{}))
+		})
+
+		It("Requires at least two folds", func() {
+			_, err := ensemble.NewStackingClassifier(baseFactories, metaFactory, 1, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.InvalidNumberOfFoldsError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Requires at least as many rows as folds", func() {
+			sc, err := ensemble.NewStackingClassifier(baseFactories, metaFactory, 50, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = sc.Train(twoClusterDataset())
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.TooFewRowsForFoldsError{}))
+		})
+
+		It("Trains the meta classifier with the training rows' weights", func() {
+			meta := &weightRecordingClassifier{}
+			sc, err := ensemble.NewStackingClassifier(
+				baseFactories,
+				func() (classifier.Classifier, error) { return meta, nil },
+				3,
+				rand.NewSource(1),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			ds := twoClusterDataset()
+			rowWeights := make([]float64, ds.NumRows())
+			for i := range rowWeights {
+				rowWeights[i] = float64(i % 4)
+			}
+			weighted, err := dataset.NewWeightedDataset(ds, rowWeights)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(sc.Train(weighted)).Should(Succeed())
+			Ω(meta.weights).Should(Equal(rowWeights))
+		})
+
+		It("Reports base classifier construction errors", func() {
+			failingFactory := func() (classifier.Classifier, error) { return nil, errors.New("nope") }
+			sc, err := ensemble.NewStackingClassifier(
+				[]ensemble.ClassifierFactory{failingFactory},
+				metaFactory,
+				3,
+				rand.NewSource(1),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = sc.Train(twoClusterDataset())
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.BaseClassifierConstructionError{}))
+		})
+
+		It("Reports meta classifier construction errors", func() {
+			sc, err := ensemble.NewStackingClassifier(
+				baseFactories,
+				func() (classifier.Classifier, error) { return nil, errors.New("nope") },
+				3,
+				rand.NewSource(1),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = sc.Train(twoClusterDataset())
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.MetaClassifierConstructionError{}))
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Returns an error before training", func() {
+			sc, err := ensemble.NewStackingClassifier(baseFactories, metaFactory, 3, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = sc.Classify(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.UntrainedClassifierError{}))
+		})
+
+		It("Classifies using the meta classifier over base predictions", func() {
+			ds := twoClusterDataset()
+
+			sc, err := ensemble.NewStackingClassifier(baseFactories, metaFactory, 4, rand.NewSource(7))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(sc.Train(ds)).Should(Succeed())
+
+			target, err := sc.Classify(testRowAt(0.1, 0.3))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(targetOfRow(ds, 0))).Should(BeTrue())
+
+			target, err = sc.Classify(testRowAt(10.3, 9.9))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(targetOfRow(ds, 1))).Should(BeTrue())
+		})
+	})
+})
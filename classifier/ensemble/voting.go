@@ -0,0 +1,124 @@
+package ensemble
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+)
+
+// NewHardVotingClassifier combines the given classifiers by summing the weight
+// of each classifier behind its predicted target.  A nil weights slice gives
+// every classifier a weight of 1.
+func NewHardVotingClassifier(classifiers []classifier.Classifier, weights []float64) (*votingClassifier, error) {
+	return newVotingClassifier(classifiers, weights, false)
+}
+
+// NewSoftVotingClassifier combines the given classifiers by taking the
+// weighted average of their class probabilities.  Classifiers which are not
+// ProbabilisticClassifiers contribute probability 1 to their predicted target.
+func NewSoftVotingClassifier(classifiers []classifier.Classifier, weights []float64) (*votingClassifier, error) {
+	return newVotingClassifier(classifiers, weights, true)
+}
+
+func newVotingClassifier(classifiers []classifier.Classifier, weights []float64, soft bool) (*votingClassifier, error) {
+	if len(classifiers) == 0 {
+		return nil, ensembleerrors.NewNoBaseClassifiersError()
+	}
+
+	if weights == nil {
+		weights = make([]float64, len(classifiers))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	if len(weights) != len(classifiers) {
+		return nil, ensembleerrors.NewWeightsLengthMismatchError(len(weights), len(classifiers))
+	}
+
+	for _, w := range weights {
+		if w < 0 {
+			return nil, ensembleerrors.NewInvalidWeightError(w)
+		}
+	}
+
+	return &votingClassifier{
+		classifiers: classifiers,
+		weights:     weights,
+		soft:        soft,
+	}, nil
+}
+
+type votingClassifier struct {
+	classifiers []classifier.Classifier
+	weights     []float64
+	soft        bool
+	trained     bool
+}
+
+func (vc *votingClassifier) Train(trainingData dataset.Dataset) error {
+	if trainingData.NumRows() == 0 {
+		return ensembleerrors.NewEmptyTrainingDatasetError()
+	}
+
+	for i, c := range vc.classifiers {
+		err := c.Train(trainingData)
+		if err != nil {
+			return ensembleerrors.NewBaseClassifierTrainingError(i, err)
+		}
+	}
+
+	vc.trained = true
+	return nil
+}
+
+func (vc *votingClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	tally, err := vc.tally(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return tally.winner(), nil
+}
+
+func (vc *votingClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	tally, err := vc.tally(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets, probabilities := tally.distribution()
+	return targets, probabilities, nil
+}
+
+func (vc *votingClassifier) tally(testRow row.Row) (*targetTally, error) {
+	if !vc.trained {
+		return nil, ensembleerrors.NewUntrainedClassifierError()
+	}
+
+	tally := &targetTally{}
+
+	for i, c := range vc.classifiers {
+		if pc, ok := c.(classifier.ProbabilisticClassifier); ok && vc.soft {
+			targets, probabilities, err := pc.ClassProbabilities(testRow)
+			if err != nil {
+				return nil, ensembleerrors.NewBaseClassifierClassificationError(i, err)
+			}
+
+			for j, target := range targets {
+				tally.add(target, vc.weights[i]*probabilities[j])
+			}
+		} else {
+			target, err := c.Classify(testRow)
+			if err != nil {
+				return nil, ensembleerrors.NewBaseClassifierClassificationError(i, err)
+			}
+
+			tally.add(target, vc.weights[i])
+		}
+	}
+
+	return tally, nil
+}
@@ -0,0 +1,152 @@
+package ensemble_test
+
+import (
+	"errors"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/ensemble"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/ensembleerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Voting", func() {
+	var ds dataset.Dataset
+	var targetA, targetB slice.Slice
+
+	BeforeEach(func() {
+		ds = twoClusterDataset()
+		targetA = targetOfRow(ds, 0)
+		targetB = targetOfRow(ds, 1)
+	})
+
+	Describe("Construction", func() {
+		It("Requires at least one classifier", func() {
+			_, err := ensemble.NewHardVotingClassifier([]classifier.Classifier{}, nil)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.NoBaseClassifiersError{}))
+		})
+
+		It("Requires one weight per classifier", func() {
+			_, err := ensemble.NewSoftVotingClassifier(
+				[]classifier.Classifier{&constantClassifier{}},
+				[]float64{1, 2},
+			)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.WeightsLengthMismatchError{}))
+		})
+
+		It("Requires non-negative weights", func() {
+			_, err := ensemble.NewHardVotingClassifier(
+				[]classifier.Classifier{&constantClassifier{}},
+				[]float64{-1},
+			)
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.InvalidWeightError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Trains every base classifier", func() {
+			c1, c2 := &constantClassifier{target: targetA}, &constantClassifier{target: targetB}
+			vc, err := ensemble.NewHardVotingClassifier([]classifier.Classifier{c1, c2}, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(vc.Train(ds)).Should(Succeed())
+			Ω(c1.trained).Should(BeTrue())
+			Ω(c2.trained).Should(BeTrue())
+		})
+
+		It("Reports base classifier training errors", func() {
+			vc, err := ensemble.NewHardVotingClassifier(
+				[]classifier.Classifier{&constantClassifier{trainError: errors.New("nope")}},
+				nil,
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(vc.Train(ds)).Should(BeAssignableToTypeOf(ensembleerrors.BaseClassifierTrainingError{}))
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Returns an error before training", func() {
+			vc, err := ensemble.NewHardVotingClassifier([]classifier.Classifier{&constantClassifier{}}, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = vc.Classify(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(ensembleerrors.UntrainedClassifierError{}))
+		})
+
+		Context("With hard voting", func() {
+			It("Picks the target with the most weight behind it", func() {
+				vc, err := ensemble.NewHardVotingClassifier(
+					[]classifier.Classifier{
+						&constantClassifier{target: targetA},
+						&constantClassifier{target: targetB},
+						&constantClassifier{target: targetB},
+					},
+					[]float64{2.5, 1, 1},
+				)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(vc.Train(ds)).Should(Succeed())
+
+				target, err := vc.Classify(testRowAt(0, 0))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(target.Equals(targetA)).Should(BeTrue())
+
+				targets, probabilities, err := vc.ClassProbabilities(testRowAt(0, 0))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(targets).Should(HaveLen(2))
+				Ω(probabilities[0]).Should(BeNumerically("~", 2.5/4.5, 1e-9))
+				Ω(probabilities[1]).Should(BeNumerically("~", 2/4.5, 1e-9))
+			})
+
+			It("Breaks ties in favour of the earliest classifier's target", func() {
+				vc, err := ensemble.NewHardVotingClassifier(
+					[]classifier.Classifier{
+						&constantClassifier{target: targetB},
+						&constantClassifier{target: targetA},
+					},
+					nil,
+				)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(vc.Train(ds)).Should(Succeed())
+
+				target, err := vc.Classify(testRowAt(0, 0))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(target.Equals(targetB)).Should(BeTrue())
+			})
+		})
+
+		Context("With soft voting", func() {
+			It("Averages class probabilities, treating plain classifiers as certain", func() {
+				vc, err := ensemble.NewSoftVotingClassifier(
+					[]classifier.Classifier{
+						&fixedProbabilitiesClassifier{
+							targets:       []slice.Slice{targetA, targetB},
+							probabilities: []float64{0.4, 0.6},
+						},
+						&fixedProbabilitiesClassifier{
+							targets:       []slice.Slice{targetA, targetB},
+							probabilities: []float64{0.3, 0.7},
+						},
+						&constantClassifier{target: targetA},
+					},
+					nil,
+				)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(vc.Train(ds)).Should(Succeed())
+
+				targets, probabilities, err := vc.ClassProbabilities(testRowAt(0, 0))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(targets[0].Equals(targetA)).Should(BeTrue())
+				Ω(probabilities[0]).Should(BeNumerically("~", 1.7/3, 1e-9))
+				Ω(probabilities[1]).Should(BeNumerically("~", 1.3/3, 1e-9))
+
+				target, err := vc.Classify(testRowAt(0, 0))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(target.Equals(targetA)).Should(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package hoeffdingtree
+
+import (
+	"math"
+)
+
+// monitor adds whether n's subtree misclassifies the row to n's error
+// window, starts an alternate subtree at n when the error rises, and trains
+// the alternate on the row.  Once both n and its alternate have classified
+// at least a grace period's worth of rows, it returns the alternate if its
+// error is significantly lower, and discards it if it is significantly
+// higher; otherwise it returns nil.
+func (t *hoeffdingTree) monitor(n *node, x []float64, label int, weight float64) *node {
+	mistake := 0.0
+	if argmax(t.distribution(n, x)) != label {
+		mistake = 1
+	}
+
+	if n.errors.add(mistake) && n.alternate == nil {
+		n.alternate = t.newLeaf(n.depth, nil)
+	}
+
+	if n.alternate == nil {
+		return nil
+	}
+
+	n.alternate = t.learn(n.alternate, x, label, weight)
+
+	width, alternateWidth := n.errors.width(), n.alternate.errors.width()
+	if width < t.gracePeriod || alternateWidth < t.gracePeriod {
+		return nil
+	}
+
+	errorRate, alternateErrorRate := n.errors.mean(), n.alternate.errors.mean()
+	bound := math.Sqrt(2 * errorRate * (1 - errorRate) * math.Log(2/t.driftConfidence) *
+		(1/float64(width) + 1/float64(alternateWidth)))
+
+	switch {
+	case errorRate-alternateErrorRate > bound:
+		return n.alternate
+	case alternateErrorRate-errorRate > bound:
+		n.alternate = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package hoeffdingtree_test
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/hoeffdingtree"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// prequentialAccuracy classifies each batch of the stream before learning
+// from it, and returns the accuracy over the rows from start onwards.
+func prequentialAccuracy(c classifier.OnlineClassifier, stream dataset.Dataset, start int) float64 {
+	correct, total := 0, 0
+	for begin := 0; begin < stream.NumRows(); begin = begin + 20 {
+		batch := rowRange(stream, begin, begin+20)
+		if begin >= start {
+			correct = correct + int(accuracy(c, batch)*20+0.5)
+			total = total + 20
+		}
+		Ω(c.PartialFit(batch)).Should(Succeed())
+	}
+	return float64(correct) / float64(total)
+}
+
+var _ = Describe("HoeffdingAdaptiveTree", func() {
+	var stream dataset.Dataset
+
+	BeforeEach(func() {
+		// The concept reverses halfway through the stream.
+		numRows := 0
+		drifting := func(x0, x1 float64) string {
+			numRows++
+			if numRows > 3000 {
+				return reversedThreshold(x0, x1)
+			}
+			return threshold(x0, x1)
+		}
+
+		stream = conceptDataset(rand.New(rand.NewSource(7)), 6000, drifting)
+	})
+
+	It("Recovers from concept drift faster than a Hoeffding tree", func() {
+		adaptive, err := hoeffdingtree.NewHoeffdingAdaptiveTree()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		plain, err := hoeffdingtree.NewHoeffdingTree()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		adaptiveAccuracy := prequentialAccuracy(adaptive, stream, 4500)
+		plainAccuracy := prequentialAccuracy(plain, stream, 4500)
+
+		Ω(adaptiveAccuracy).Should(BeNumerically(">", 0.9))
+		Ω(adaptiveAccuracy).Should(BeNumerically(">", plainAccuracy+0.1))
+	})
+})
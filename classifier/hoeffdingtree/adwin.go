@@ -0,0 +1,109 @@
+package hoeffdingtree
+
+import (
+	"math"
+)
+
+const (
+	adwinMaxWindow     = 2000
+	adwinCheckInterval = 32
+	adwinMinSubwindow  = 5
+)
+
+// adwin is an ADWIN (adaptive windowing) change detector (Bifet and Gavaldà,
+// 2007): it keeps a window of the most recent values and drops its older
+// part whenever the older part's mean differs from the newer part's by more
+// than the window's size and variance allow at the given confidence.  To
+// bound memory and time, the window holds at most adwinMaxWindow values, and
+// is checked for a change every adwinCheckInterval values.
+type adwin struct {
+	delta         float64
+	values        []float64
+	total         float64
+	totalSquares  float64
+	numValuesSeen int
+}
+
+func newADWIN(delta float64) *adwin {
+	return &adwin{delta: delta}
+}
+
+// add adds a value to the window and reports whether the window's older part
+// was dropped because the newer part's mean is greater.
+func (a *adwin) add(value float64) bool {
+	a.values = append(a.values, value)
+	a.total = a.total + value
+	a.totalSquares = a.totalSquares + value*value
+	if len(a.values) > adwinMaxWindow {
+		a.drop(1)
+	}
+
+	a.numValuesSeen++
+	if a.numValuesSeen%adwinCheckInterval != 0 {
+		return false
+	}
+
+	increased := false
+	for {
+		cut, newerIsGreater := a.findCut()
+		if cut == 0 {
+			return increased
+		}
+
+		a.drop(cut)
+		increased = increased || newerIsGreater
+	}
+}
+
+// findCut returns the length of the oldest part of the window whose mean
+// differs significantly from that of the rest, and whether the rest's mean is
+// greater, or 0 if there is none.
+func (a *adwin) findCut() (int, bool) {
+	n := len(a.values)
+	if n < 2*adwinMinSubwindow {
+		return 0, false
+	}
+
+	mean := a.total / float64(n)
+	variance := math.Max(a.totalSquares/float64(n)-mean*mean, 0)
+	logTerm := math.Log(2 * float64(n) / a.delta)
+
+	older := 0.0
+	for cut := 1; cut <= n-adwinMinSubwindow; cut++ {
+		older = older + a.values[cut-1]
+		if cut < adwinMinSubwindow {
+			continue
+		}
+
+		n0, n1 := float64(cut), float64(n-cut)
+		olderMean, newerMean := older/n0, (a.total-older)/n1
+		m := 1 / (1/n0 + 1/n1)
+		epsilon := math.Sqrt(2/m*variance*logTerm) + 2/(3*m)*logTerm
+
+		if math.Abs(olderMean-newerMean) > epsilon {
+			return cut, newerMean > olderMean
+		}
+	}
+
+	return 0, false
+}
+
+// drop removes the oldest values from the window.
+func (a *adwin) drop(count int) {
+	for _, v := range a.values[:count] {
+		a.total = a.total - v
+		a.totalSquares = a.totalSquares - v*v
+	}
+	a.values = a.values[count:]
+}
+
+func (a *adwin) width() int {
+	return len(a.values)
+}
+
+func (a *adwin) mean() float64 {
+	if len(a.values) == 0 {
+		return 0
+	}
+	return a.total / float64(len(a.values))
+}
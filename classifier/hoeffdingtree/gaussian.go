@@ -0,0 +1,56 @@
+package hoeffdingtree
+
+import (
+	"math"
+)
+
+// varianceSmoothing is the fraction of the largest variance at a leaf which
+// is added to every variance when classifying with naive Bayes, as the
+// naivebayes package does by default.
+const varianceSmoothing = 1e-9
+
+// gaussian approximates the weighted values of a feature seen with a target
+// by a normal distribution, keeping their running mean and variance (West,
+// 1979), along with the smallest and largest.
+type gaussian struct {
+	weight            float64
+	mean              float64
+	squaredDeviations float64
+	min               float64
+	max               float64
+}
+
+func (g *gaussian) add(value, weight float64) {
+	if g.weight == 0 {
+		g.min, g.max = value, value
+	} else {
+		g.min = math.Min(g.min, value)
+		g.max = math.Max(g.max, value)
+	}
+
+	g.weight = g.weight + weight
+	delta := value - g.mean
+	g.mean = g.mean + delta*weight/g.weight
+	g.squaredDeviations = g.squaredDeviations + weight*delta*(value-g.mean)
+}
+
+func (g gaussian) variance() float64 {
+	if g.weight == 0 {
+		return 0
+	}
+	return g.squaredDeviations / g.weight
+}
+
+// weightAtMost estimates how much of the weight was of values at most x.
+func (g gaussian) weightAtMost(x float64) float64 {
+	if g.weight == 0 || x < g.min {
+		return 0
+	}
+
+	standardDeviation := math.Sqrt(g.variance())
+	if x >= g.max || standardDeviation == 0 {
+		return g.weight
+	}
+
+	return g.weight * 0.5 * math.Erfc(-(x-g.mean)/(standardDeviation*math.Sqrt2))
+}
@@ -0,0 +1,280 @@
+package hoeffdingtree
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/hoeffdingtreeerrors"
+)
+
+// LeafPrediction is how a leaf classifies the rows which reach it.
+type LeafPrediction int
+
+const (
+	// MajorityClass predicts the target of most of the leaf's rows.
+	MajorityClass LeafPrediction = iota
+	// NaiveBayes predicts with a Gaussian naive Bayes model of the leaf's
+	// rows.
+	NaiveBayes
+	// AdaptiveNaiveBayes predicts with whichever of MajorityClass and
+	// NaiveBayes has classified more of the leaf's rows correctly, each
+	// classified before the leaf learned from it.
+	AdaptiveNaiveBayes
+)
+
+const (
+	defaultGracePeriod     = 200
+	defaultSplitConfidence = 1e-7
+	defaultTieThreshold    = 0.05
+	defaultNumSplitPoints  = 10
+	defaultDriftConfidence = 0.002
+)
+
+type Option func(*hoeffdingTree)
+
+// GracePeriod sets how much weight of rows a leaf learns from between
+// attempts to split it; 200 by default.
+func GracePeriod(weight int) Option {
+	return func(t *hoeffdingTree) {
+		t.gracePeriod = weight
+	}
+}
+
+// SplitConfidence sets the probability, 1e-7 by default, that a leaf is split
+// on a different feature than it would be given infinitely many rows.
+func SplitConfidence(delta float64) Option {
+	return func(t *hoeffdingTree) {
+		t.splitConfidence = delta
+	}
+}
+
+// TieThreshold sets how small the Hoeffding bound must become before a leaf
+// is split on the best of several equally good features; 0.05 by default.
+func TieThreshold(tau float64) Option {
+	return func(t *hoeffdingTree) {
+		t.tieThreshold = tau
+	}
+}
+
+// NumSplitPoints sets how many evenly spaced thresholds between the smallest
+// and largest value of a feature are considered when splitting a leaf on it;
+// 10 by default.
+func NumSplitPoints(n int) Option {
+	return func(t *hoeffdingTree) {
+		t.numSplitPoints = n
+	}
+}
+
+// MaxDepth stops leaves at the given depth from splitting; by default the
+// depth is unlimited.
+func MaxDepth(depth int) Option {
+	return func(t *hoeffdingTree) {
+		t.maxDepth = depth
+	}
+}
+
+// Leaves sets how leaves classify rows; AdaptiveNaiveBayes by default.
+func Leaves(prediction LeafPrediction) Option {
+	return func(t *hoeffdingTree) {
+		t.leafPrediction = prediction
+	}
+}
+
+// DriftConfidence sets the confidence with which the adaptive tree detects
+// that a subtree's error has risen, and decides between a subtree and its
+// alternate; 0.002 by default.  It has no effect on a non-adaptive tree.
+func DriftConfidence(delta float64) Option {
+	return func(t *hoeffdingTree) {
+		t.driftConfidence = delta
+	}
+}
+
+// NewHoeffdingTree returns a Very Fast Decision Tree (Domingos and Hulten,
+// 2000), which learns from a stream of rows in bounded memory: each leaf
+// keeps only, for each feature and target, a Gaussian approximation of the
+// feature's values, and is split on the feature and threshold with the
+// greatest information gain once the Hoeffding bound shows, with the
+// configured confidence, that more rows would not change the choice.
+// Features must be floats.  Rows are weighted by their weight if the training
+// data is a dataset.WeightedDataset.
+//
+// Train and PartialFit both learn from the rows in order, once each, so
+// Train on a dataset and PartialFit on its consecutive batches build the same
+// tree.
+func NewHoeffdingTree(options ...Option) (*hoeffdingTree, error) {
+	return newHoeffdingTree(false, options)
+}
+
+// NewHoeffdingAdaptiveTree returns a Hoeffding Adaptive Tree (Bifet and
+// Gavaldà, 2009), a Hoeffding tree for streams whose concept drifts: every
+// node monitors the error of its subtree with an ADWIN change detector, and
+// when the error rises, grows an alternate subtree from the rows that follow,
+// replacing the subtree with it once the alternate is significantly more
+// accurate, or discarding the alternate once it is significantly less.
+func NewHoeffdingAdaptiveTree(options ...Option) (*hoeffdingTree, error) {
+	return newHoeffdingTree(true, options)
+}
+
+func newHoeffdingTree(adaptive bool, options []Option) (*hoeffdingTree, error) {
+	t := &hoeffdingTree{
+		gracePeriod:     defaultGracePeriod,
+		splitConfidence: defaultSplitConfidence,
+		tieThreshold:    defaultTieThreshold,
+		numSplitPoints:  defaultNumSplitPoints,
+		leafPrediction:  AdaptiveNaiveBayes,
+		adaptive:        adaptive,
+		driftConfidence: defaultDriftConfidence,
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	if t.gracePeriod < 1 {
+		return nil, hoeffdingtreeerrors.NewInvalidHyperparameterError("grace period", float64(t.gracePeriod))
+	}
+
+	if !(t.splitConfidence > 0 && t.splitConfidence < 1) {
+		return nil, hoeffdingtreeerrors.NewInvalidHyperparameterError("split confidence", t.splitConfidence)
+	}
+
+	if !(t.tieThreshold >= 0) {
+		return nil, hoeffdingtreeerrors.NewInvalidHyperparameterError("tie threshold", t.tieThreshold)
+	}
+
+	if t.numSplitPoints < 1 {
+		return nil, hoeffdingtreeerrors.NewInvalidHyperparameterError("number of split points", float64(t.numSplitPoints))
+	}
+
+	if t.maxDepth < 0 {
+		return nil, hoeffdingtreeerrors.NewInvalidHyperparameterError("max depth", float64(t.maxDepth))
+	}
+
+	if t.leafPrediction != MajorityClass && t.leafPrediction != NaiveBayes && t.leafPrediction != AdaptiveNaiveBayes {
+		return nil, hoeffdingtreeerrors.NewInvalidLeafPredictionError(int(t.leafPrediction))
+	}
+
+	if !(t.driftConfidence > 0 && t.driftConfidence < 1) {
+		return nil, hoeffdingtreeerrors.NewInvalidHyperparameterError("drift confidence", t.driftConfidence)
+	}
+
+	return t, nil
+}
+
+type hoeffdingTree struct {
+	gracePeriod     int
+	splitConfidence float64
+	tieThreshold    float64
+	numSplitPoints  int
+	maxDepth        int
+	leafPrediction  LeafPrediction
+	adaptive        bool
+	driftConfidence float64
+
+	numFeatures int
+	targets     []slice.Slice
+	root        *node
+}
+
+func (t *hoeffdingTree) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return hoeffdingtreeerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if trainingData.NumRows() == 0 {
+		return hoeffdingtreeerrors.NewEmptyTrainingDatasetError()
+	}
+
+	t.targets = nil
+	t.root = nil
+	return t.PartialFit(trainingData)
+}
+
+// PartialFit learns from each of the batch's rows in order; targets first
+// seen in a later batch are added to those already known.
+func (t *hoeffdingTree) PartialFit(batch dataset.Dataset) error {
+	if !batch.AllFeaturesFloats() {
+		return hoeffdingtreeerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if batch.NumRows() == 0 {
+		return nil
+	}
+
+	numFeatures := batch.NumFeatures()
+	if t.root == nil {
+		t.numFeatures = numFeatures
+		t.root = t.newLeaf(0, nil)
+	} else if numFeatures != t.numFeatures {
+		return hoeffdingtreeerrors.NewBatchLengthMismatchError(numFeatures, t.numFeatures)
+	}
+
+	rowWeights := dataset.Weights(batch)
+	for i, weight := range rowWeights {
+		if weight == 0 {
+			continue
+		}
+
+		r, err := batch.Row(i)
+		if err != nil {
+			return err
+		}
+
+		label := classifierutilities.TargetIndex(t.targets, r.Target())
+		if label < 0 {
+			label = len(t.targets)
+			t.targets = append(t.targets, r.Target())
+		}
+
+		t.root = t.learn(t.root, r.Features().(slice.FloatSlice).Values(), label, weight)
+	}
+
+	return nil
+}
+
+func (t *hoeffdingTree) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, probabilities, err := t.ClassProbabilities(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return targets[argmax(probabilities)], nil
+}
+
+// ClassProbabilities returns the distribution of targets predicted by the
+// leaf the row reaches.
+func (t *hoeffdingTree) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if len(t.targets) == 0 {
+		return nil, nil, hoeffdingtreeerrors.NewUntrainedClassifierError()
+	}
+
+	if testRow.NumFeatures() != t.numFeatures {
+		return nil, nil, hoeffdingtreeerrors.NewRowLengthMismatchError(testRow.NumFeatures(), t.numFeatures)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, nil, hoeffdingtreeerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return t.targets, t.distribution(t.root, testFeatures.Values()), nil
+}
+
+// NumLeaves returns the number of leaves in the tree, not counting those of
+// alternate subtrees.
+func (t *hoeffdingTree) NumLeaves() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.numLeaves()
+}
+
+// Depth returns the depth of the deepest leaf in the tree, the root being at
+// depth 0.
+func (t *hoeffdingTree) Depth() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.maxDepth()
+}
@@ -0,0 +1,13 @@
+package hoeffdingtree_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHoeffdingtree(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hoeffdingtree Suite")
+}
@@ -0,0 +1,260 @@
+package hoeffdingtree_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/hoeffdingtree"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/hoeffdingtreeerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type concept func(x0, x1 float64) string
+
+func threshold(x0, x1 float64) string {
+	if x0 <= 0.3 {
+		return "low"
+	}
+	return "high"
+}
+
+func reversedThreshold(x0, x1 float64) string {
+	if x0 <= 0.3 {
+		return "high"
+	}
+	return "low"
+}
+
+// conceptDataset has numRows rows of two features uniform on [0, 1],
+// labelled by the concept.
+func conceptDataset(random *rand.Rand, numRows int, c concept) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "a"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ds := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+	for i := 0; i < numRows; i++ {
+		x0, x1 := random.Float64(), random.Float64()
+		err = ds.AddRowFromStrings([]string{fmt.Sprintf("%.6f", x0), fmt.Sprintf("%.6f", x1), c(x0, x1)})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+	return ds
+}
+
+func rowRange(ds dataset.Dataset, start, end int) dataset.Dataset {
+	rowMap := []int{}
+	for i := start; i < end; i++ {
+		rowMap = append(rowMap, i)
+	}
+	return dataset.NewSubset(ds, rowMap)
+}
+
+func floatRow(values ...float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice(values), nil, len(values))
+}
+
+func accuracy(c classifier.Classifier, ds dataset.Dataset) float64 {
+	correct := 0
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		target, err := c.Classify(r)
+		Ω(err).ShouldNot(HaveOccurred())
+		if target.Equals(r.Target()) {
+			correct++
+		}
+	}
+	return float64(correct) / float64(ds.NumRows())
+}
+
+var _ = Describe("HoeffdingTree", func() {
+	var random *rand.Rand
+
+	BeforeEach(func() {
+		random = rand.New(rand.NewSource(5))
+	})
+
+	Describe("NewHoeffdingTree", func() {
+		It("Validates its hyperparameters", func() {
+			_, err := hoeffdingtree.NewHoeffdingTree(hoeffdingtree.GracePeriod(0))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.InvalidHyperparameterError{}))
+
+			_, err = hoeffdingtree.NewHoeffdingTree(hoeffdingtree.SplitConfidence(1))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.InvalidHyperparameterError{}))
+
+			_, err = hoeffdingtree.NewHoeffdingTree(hoeffdingtree.NumSplitPoints(0))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.InvalidHyperparameterError{}))
+
+			_, err = hoeffdingtree.NewHoeffdingAdaptiveTree(hoeffdingtree.DriftConfidence(0))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.InvalidHyperparameterError{}))
+
+			_, err = hoeffdingtree.NewHoeffdingTree(hoeffdingtree.Leaves(hoeffdingtree.LeafPrediction(7)))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.InvalidLeafPredictionError{}))
+		})
+
+		It("Is an online, probabilistic classifier", func() {
+			var c classifier.OnlineClassifier
+			c, err := hoeffdingtree.NewHoeffdingTree()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, ok := c.(classifier.ProbabilisticClassifier)
+			Ω(ok).Should(BeTrue())
+		})
+	})
+
+	Describe("Train", func() {
+		It("Rejects empty datasets and non-float features", func() {
+			t, err := hoeffdingtree.NewHoeffdingTree()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = t.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.EmptyTrainingDatasetError{}))
+
+			err = t.Train(dataset.NewDataset([]int{0}, []int{1}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.NonFloatFeaturesTrainingSetError{}))
+		})
+
+		It("Splits to learn a threshold", func() {
+			t, err := hoeffdingtree.NewHoeffdingTree()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(t.Train(conceptDataset(random, 3000, threshold))).Should(Succeed())
+
+			Ω(t.NumLeaves()).Should(BeNumerically(">", 1))
+			Ω(accuracy(t, conceptDataset(random, 500, threshold))).Should(BeNumerically(">", 0.95))
+		})
+
+		It("Does not split beyond the maximum depth", func() {
+			t, err := hoeffdingtree.NewHoeffdingTree(hoeffdingtree.MaxDepth(1), hoeffdingtree.GracePeriod(50))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(t.Train(conceptDataset(random, 3000, threshold))).Should(Succeed())
+
+			Ω(t.Depth()).Should(Equal(1))
+			Ω(t.NumLeaves()).Should(Equal(2))
+		})
+
+		It("Ignores rows of zero weight", func() {
+			ds := conceptDataset(random, 100, threshold)
+			weights := make([]float64, ds.NumRows())
+			for i := range weights {
+				r, err := ds.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+				if slice.Entries(r.Target())[0] == "low" {
+					weights[i] = 1
+				}
+			}
+
+			weighted, err := dataset.NewWeightedDataset(ds, weights)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			t, err := hoeffdingtree.NewHoeffdingTree()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(t.Train(weighted)).Should(Succeed())
+
+			targets, probabilities, err := t.ClassProbabilities(floatRow(0.9, 0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(1))
+			Ω(slice.Entries(targets[0])).Should(Equal([]interface{}{"low"}))
+			Ω(probabilities).Should(Equal([]float64{1}))
+		})
+	})
+
+	Describe("PartialFit", func() {
+		It("Builds the same tree as Train on the whole dataset", func() {
+			ds := conceptDataset(random, 2000, threshold)
+			test := conceptDataset(random, 200, threshold)
+
+			trained, err := hoeffdingtree.NewHoeffdingTree(hoeffdingtree.GracePeriod(50))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(trained.Train(ds)).Should(Succeed())
+
+			online, err := hoeffdingtree.NewHoeffdingTree(hoeffdingtree.GracePeriod(50))
+			Ω(err).ShouldNot(HaveOccurred())
+			for start := 0; start < ds.NumRows(); start = start + 300 {
+				end := start + 300
+				if end > ds.NumRows() {
+					end = ds.NumRows()
+				}
+				Ω(online.PartialFit(rowRange(ds, start, end))).Should(Succeed())
+			}
+
+			Ω(online.NumLeaves()).Should(Equal(trained.NumLeaves()))
+			for i := 0; i < test.NumRows(); i++ {
+				r, err := test.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, expected, err := trained.ClassProbabilities(r)
+				Ω(err).ShouldNot(HaveOccurred())
+				_, actual, err := online.ClassProbabilities(r)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(actual).Should(Equal(expected))
+			}
+		})
+
+		It("Rejects batches with a different number of features", func() {
+			t, err := hoeffdingtree.NewHoeffdingTree()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(t.PartialFit(conceptDataset(random, 10, threshold))).Should(Succeed())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "a"})
+			Ω(err).ShouldNot(HaveOccurred())
+			batch := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			Ω(batch.AddRowFromStrings([]string{"0.5", "low"})).Should(Succeed())
+
+			err = t.PartialFit(batch)
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.BatchLengthMismatchError{}))
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Refuses to classify before training, or rows of the wrong length", func() {
+			t, err := hoeffdingtree.NewHoeffdingTree()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = t.Classify(floatRow(0.5, 0.5))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.UntrainedClassifierError{}))
+
+			Ω(t.Train(conceptDataset(random, 10, threshold))).Should(Succeed())
+			_, err = t.Classify(floatRow(0.5))
+			Ω(err).Should(BeAssignableToTypeOf(hoeffdingtreeerrors.RowLengthMismatchError{}))
+		})
+
+		It("Classifies with naive Bayes leaves where majority class leaves cannot", func() {
+			ds := conceptDataset(random, 1000, threshold)
+			test := conceptDataset(random, 500, threshold)
+
+			// A grace period longer than the stream keeps the root a leaf.
+			majority, err := hoeffdingtree.NewHoeffdingTree(
+				hoeffdingtree.GracePeriod(5000),
+				hoeffdingtree.Leaves(hoeffdingtree.MajorityClass),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(majority.Train(ds)).Should(Succeed())
+			Ω(majority.NumLeaves()).Should(Equal(1))
+			Ω(accuracy(majority, test)).Should(BeNumerically("<", 0.8))
+
+			for _, leaves := range []hoeffdingtree.LeafPrediction{hoeffdingtree.NaiveBayes, hoeffdingtree.AdaptiveNaiveBayes} {
+				naiveBayes, err := hoeffdingtree.NewHoeffdingTree(
+					hoeffdingtree.GracePeriod(5000),
+					hoeffdingtree.Leaves(leaves),
+				)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(naiveBayes.Train(ds)).Should(Succeed())
+				Ω(accuracy(naiveBayes, test)).Should(BeNumerically(">", 0.9))
+
+				_, probabilities, err := naiveBayes.ClassProbabilities(floatRow(0.1, 0.5))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(probabilities[0] + probabilities[1]).Should(BeNumerically("~", 1, 1e-12))
+			}
+		})
+	})
+})
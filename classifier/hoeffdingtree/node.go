@@ -0,0 +1,352 @@
+package hoeffdingtree
+
+import (
+	"math"
+)
+
+// node is a leaf or, once split, an internal node which sends rows whose
+// feature is at most threshold to its left child and the rest to its right.
+type node struct {
+	depth     int
+	feature   int
+	threshold float64
+	left      *node
+	right     *node
+
+	// A leaf's prior is the weight of each target it was estimated to
+	// inherit when it was split off from its parent; the rest summarizes the
+	// rows the leaf has learned from since.
+	prior             []float64
+	counts            []float64
+	seen              float64
+	seenAtLastAttempt float64
+	observers         [][]gaussian
+	majorityCorrect   float64
+	naiveBayesCorrect float64
+
+	// Only the adaptive tree's nodes monitor their error and grow
+	// alternates.
+	errors    *adwin
+	alternate *node
+}
+
+func (t *hoeffdingTree) newLeaf(depth int, prior []float64) *node {
+	n := &node{
+		depth:     depth,
+		prior:     prior,
+		observers: make([][]gaussian, t.numFeatures),
+	}
+
+	if t.adaptive {
+		n.errors = newADWIN(t.driftConfidence)
+	}
+
+	return n
+}
+
+func (n *node) isLeaf() bool {
+	return n.left == nil
+}
+
+// sort returns the leaf of n's subtree which the row reaches.
+func (n *node) sort(x []float64) *node {
+	for !n.isLeaf() {
+		if x[n.feature] <= n.threshold {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return n
+}
+
+func (n *node) numLeaves() int {
+	if n.isLeaf() {
+		return 1
+	}
+	return n.left.numLeaves() + n.right.numLeaves()
+}
+
+func (n *node) maxDepth() int {
+	if n.isLeaf() {
+		return n.depth
+	}
+	left, right := n.left.maxDepth(), n.right.maxDepth()
+	if left > right {
+		return left
+	}
+	return right
+}
+
+// learn trains n's subtree on a row and returns the subtree which should
+// replace it, which is n itself unless an alternate has proven better.
+func (t *hoeffdingTree) learn(n *node, x []float64, label int, weight float64) *node {
+	if t.adaptive {
+		replacement := t.monitor(n, x, label, weight)
+		if replacement != nil {
+			return replacement
+		}
+	}
+
+	if !n.isLeaf() {
+		if x[n.feature] <= n.threshold {
+			n.left = t.learn(n.left, x, label, weight)
+		} else {
+			n.right = t.learn(n.right, x, label, weight)
+		}
+		return n
+	}
+
+	if t.leafPrediction == AdaptiveNaiveBayes && n.seen > 0 {
+		if argmax(t.majorityDistribution(n)) == label {
+			n.majorityCorrect = n.majorityCorrect + weight
+		}
+		if argmax(t.naiveBayesDistribution(n, x)) == label {
+			n.naiveBayesCorrect = n.naiveBayesCorrect + weight
+		}
+	}
+
+	n.counts = grow(n.counts, label+1)
+	n.counts[label] = n.counts[label] + weight
+	for j, v := range x {
+		for len(n.observers[j]) <= label {
+			n.observers[j] = append(n.observers[j], gaussian{})
+		}
+		n.observers[j][label].add(v, weight)
+	}
+	n.seen = n.seen + weight
+
+	if n.seen-n.seenAtLastAttempt >= float64(t.gracePeriod) && (t.maxDepth == 0 || n.depth < t.maxDepth) {
+		t.attemptSplit(n)
+		n.seenAtLastAttempt = n.seen
+	}
+
+	return n
+}
+
+// split is a candidate threshold on a feature, with its information gain
+// and the weight of each target estimated to fall on either side.
+type split struct {
+	feature   int
+	threshold float64
+	merit     float64
+	left      []float64
+	right     []float64
+}
+
+// attemptSplit splits the leaf on the split with the greatest information
+// gain if the Hoeffding bound shows that it beats both the next best
+// feature's split and not splitting at all, or that the bound has become so
+// small that the candidates are tied.
+func (t *hoeffdingTree) attemptSplit(leaf *node) {
+	numTargetsSeen := 0
+	for _, count := range leaf.counts {
+		if count > 0 {
+			numTargetsSeen++
+		}
+	}
+	if numTargetsSeen < 2 {
+		return
+	}
+
+	parentEntropy := entropy(leaf.counts)
+	best := split{feature: -1}
+	secondBest := split{feature: -1, merit: math.Inf(-1)}
+	for j := range leaf.observers {
+		candidate := t.bestSplit(leaf, j, parentEntropy)
+		if candidate.merit > best.merit {
+			secondBest = best
+			best = candidate
+		} else if candidate.merit > secondBest.merit {
+			secondBest = candidate
+		}
+	}
+
+	if best.feature < 0 {
+		return
+	}
+
+	bound := hoeffdingBound(math.Log2(float64(numTargetsSeen)), t.splitConfidence, leaf.seen)
+	if best.merit-secondBest.merit <= bound && bound >= t.tieThreshold {
+		return
+	}
+
+	leaf.feature = best.feature
+	leaf.threshold = best.threshold
+	leaf.left = t.newLeaf(leaf.depth+1, best.left)
+	leaf.right = t.newLeaf(leaf.depth+1, best.right)
+	leaf.prior = nil
+	leaf.counts = nil
+	leaf.observers = nil
+}
+
+// bestSplit returns the split on the given feature with the greatest
+// information gain, estimating from each target's Gaussian approximation how
+// much of its weight falls at or below each threshold.
+func (t *hoeffdingTree) bestSplit(leaf *node, feature int, parentEntropy float64) split {
+	best := split{feature: feature, merit: math.Inf(-1)}
+
+	observers := leaf.observers[feature]
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, g := range observers {
+		if g.weight > 0 {
+			low = math.Min(low, g.min)
+			high = math.Max(high, g.max)
+		}
+	}
+	if !(low < high) {
+		return best
+	}
+
+	for i := 1; i <= t.numSplitPoints; i++ {
+		threshold := low + (high-low)*float64(i)/float64(t.numSplitPoints+1)
+
+		left := make([]float64, len(observers))
+		right := make([]float64, len(observers))
+		for k, g := range observers {
+			left[k] = g.weightAtMost(threshold)
+			right[k] = g.weight - left[k]
+		}
+
+		leftWeight, rightWeight := sum(left), sum(right)
+		merit := parentEntropy - (leftWeight*entropy(left)+rightWeight*entropy(right))/(leftWeight+rightWeight)
+		if merit > best.merit {
+			best = split{feature, threshold, merit, left, right}
+		}
+	}
+
+	return best
+}
+
+// distribution returns the distribution of targets predicted for a row by
+// the leaf of n's subtree which it reaches.
+func (t *hoeffdingTree) distribution(n *node, x []float64) []float64 {
+	leaf := n.sort(x)
+
+	switch {
+	case t.leafPrediction == NaiveBayes && leaf.seen > 0,
+		t.leafPrediction == AdaptiveNaiveBayes && leaf.naiveBayesCorrect > leaf.majorityCorrect:
+		return t.naiveBayesDistribution(leaf, x)
+	}
+
+	return normalize(t.majorityDistribution(leaf))
+}
+
+// majorityDistribution returns the weight of each target at the leaf,
+// inherited or learned.
+func (t *hoeffdingTree) majorityDistribution(leaf *node) []float64 {
+	weights := make([]float64, len(t.targets))
+	for k, w := range leaf.prior {
+		weights[k] = weights[k] + w
+	}
+	for k, w := range leaf.counts {
+		weights[k] = weights[k] + w
+	}
+	return weights
+}
+
+// naiveBayesDistribution returns the Gaussian naive Bayes posterior of each
+// target learned at the leaf, with the leaf's weights of targets as priors.
+func (t *hoeffdingTree) naiveBayesDistribution(leaf *node, x []float64) []float64 {
+	priors := t.majorityDistribution(leaf)
+
+	largestVariance := 0.0
+	for _, observers := range leaf.observers {
+		for _, g := range observers {
+			largestVariance = math.Max(largestVariance, g.variance())
+		}
+	}
+	smoothing := varianceSmoothing
+	if largestVariance > 0 {
+		smoothing = smoothing * largestVariance
+	}
+
+	scores := make([]float64, len(t.targets))
+	for k := range scores {
+		if k >= len(leaf.counts) || leaf.counts[k] == 0 {
+			scores[k] = math.Inf(-1)
+			continue
+		}
+
+		scores[k] = math.Log(priors[k])
+		for j, v := range x {
+			g := leaf.observers[j][k]
+			variance := g.variance() + smoothing
+			deviation := v - g.mean
+			scores[k] = scores[k] - 0.5*(math.Log(2*math.Pi*variance)+deviation*deviation/variance)
+		}
+	}
+
+	largest := scores[argmax(scores)]
+	if math.IsInf(largest, -1) {
+		return normalize(priors)
+	}
+
+	probabilities := make([]float64, len(scores))
+	for k, s := range scores {
+		probabilities[k] = math.Exp(s - largest)
+	}
+	return normalize(probabilities)
+}
+
+// hoeffdingBound is the amount by which, with probability 1 - delta, the
+// mean of n observations of a variable with the given range lies within its
+// expected value.
+func hoeffdingBound(valueRange, delta, n float64) float64 {
+	return math.Sqrt(valueRange * valueRange * math.Log(1/delta) / (2 * n))
+}
+
+func entropy(weights []float64) float64 {
+	total := sum(weights)
+	if total == 0 {
+		return 0
+	}
+
+	h := 0.0
+	for _, w := range weights {
+		if w > 0 {
+			p := w / total
+			h = h - p*math.Log2(p)
+		}
+	}
+	return h
+}
+
+func normalize(weights []float64) []float64 {
+	total := sum(weights)
+
+	probabilities := make([]float64, len(weights))
+	for k, w := range weights {
+		if total > 0 {
+			probabilities[k] = w / total
+		} else {
+			probabilities[k] = 1 / float64(len(weights))
+		}
+	}
+	return probabilities
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total = total + v
+	}
+	return total
+}
+
+func argmax(values []float64) int {
+	best := 0
+	for k, v := range values {
+		if v > values[best] {
+			best = k
+		}
+	}
+	return best
+}
+
+func grow(values []float64, length int) []float64 {
+	for len(values) < length {
+		values = append(values, 0)
+	}
+	return values
+}
@@ -8,21 +8,84 @@ import (
 	"github.com/amitkgupta/goodlearn/errors/classifier/knnerrors"
 )
 
-func NewKNNClassifier(k int) (*kNNClassifier, error) {
+type Option func(*kNNClassifier)
+
+// Metric sets the distance between rows; knnutilities.EuclideanDistance by
+// default.  Metrics which are not restricted to floats, such as
+// knnutilities.GowerDistance, allow training on mixed features.
+func Metric(distance knnutilities.Distance) Option {
+	return func(classifier *kNNClassifier) {
+		classifier.distance = distance
+	}
+}
+
+// SearchIndex sets how the nearest training rows are found, e.g. with
+// knnutilities.KDTreeIndex or knnutilities.BallTreeIndex, which find the
+// same neighbours, or knnutilities.HNSWIndex, which only finds approximately
+// the nearest; by default each query is compared against every training row.
+func SearchIndex(builder knnutilities.IndexBuilder) Option {
+	return func(classifier *kNNClassifier) {
+		classifier.indexBuilder = builder
+	}
+}
+
+// Weighting sets how each neighbour's vote is weighted by its distance;
+// neighbours are weighted uniformly by default.
+func Weighting(weight knnutilities.WeightFunction) Option {
+	return func(classifier *kNNClassifier) {
+		classifier.weight = weight
+	}
+}
+
+// TieBreaking sets how ties between equally weighted targets are broken;
+// knnutilities.NearestFirst by default.
+func TieBreaking(tieBreak knnutilities.TieBreak) Option {
+	return func(classifier *kNNClassifier) {
+		classifier.tieBreak = tieBreak
+	}
+}
+
+func NewKNNClassifier(k int, options ...Option) (*kNNClassifier, error) {
 	if k < 1 {
 		return nil, knnerrors.NewInvalidNumberOfNeighboursError(k)
 	}
 
-	return &kNNClassifier{k: k}, nil
+	classifier := &kNNClassifier{
+		k:        k,
+		weight:   knnutilities.UniformWeights(),
+		tieBreak: knnutilities.NearestFirst,
+	}
+
+	for _, option := range options {
+		option(classifier)
+	}
+
+	if classifier.weight == nil {
+		classifier.weight = knnutilities.UniformWeights()
+	}
+
+	if classifier.tieBreak != knnutilities.NearestFirst && classifier.tieBreak != knnutilities.SmallestLabel {
+		return nil, knnerrors.NewInvalidTieBreakError(int(classifier.tieBreak))
+	}
+
+	return classifier, nil
 }
 
 type kNNClassifier struct {
 	k            int
+	distance     knnutilities.Distance
+	indexBuilder knnutilities.IndexBuilder
+	search       *knnutilities.NeighbourSearch
+	weight       knnutilities.WeightFunction
+	tieBreak     knnutilities.TieBreak
 	trainingData dataset.Dataset
+	rowWeights   []float64
 }
 
 func (classifier *kNNClassifier) Train(trainingData dataset.Dataset) error {
-	if !trainingData.AllFeaturesFloats() {
+	search := knnutilities.NewNeighbourSearch(classifier.k, classifier.distance, classifier.indexBuilder)
+
+	if search.FloatsOnly() && !trainingData.AllFeaturesFloats() {
 		return knnerrors.NewNonFloatFeaturesTrainingSetError()
 	}
 
@@ -30,11 +93,94 @@ func (classifier *kNNClassifier) Train(trainingData dataset.Dataset) error {
 		return knnerrors.NewEmptyTrainingDatasetError()
 	}
 
+	err := search.Train(trainingData)
+	if err != nil {
+		return err
+	}
+
+	classifier.search = search
 	classifier.trainingData = trainingData
+	classifier.rowWeights = dataset.Weights(trainingData)
+	return nil
+}
+
+// PartialFit appends the batch's rows to the training data, trains the
+// classifier on the batch if it hasn't been trained, and otherwise indexes
+// them without refitting the distance.  It must not be called concurrently
+// with classification.
+func (classifier *kNNClassifier) PartialFit(batch dataset.Dataset) error {
+	if classifier.trainingData == nil {
+		return classifier.Train(batch)
+	}
+
+	if batch.NumRows() == 0 {
+		return nil
+	}
+
+	if classifier.search.FloatsOnly() && !batch.AllFeaturesFloats() {
+		return knnerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	numBatchFeatures := batch.NumFeatures()
+	numTrainingDataFeatures := classifier.trainingData.NumFeatures()
+	if numBatchFeatures != numTrainingDataFeatures {
+		return knnerrors.NewBatchLengthMismatchError(numBatchFeatures, numTrainingDataFeatures)
+	}
+
+	err := classifier.search.Add(batch)
+	if err != nil {
+		return err
+	}
+
+	classifier.trainingData = classifier.search.TrainingData()
+	classifier.rowWeights = append(classifier.rowWeights, dataset.Weights(batch)...)
 	return nil
 }
 
+// Classify is safe to call concurrently once the classifier is trained, so
+// the classifier can be used with classifier.ClassifyBatch.
 func (classifier *kNNClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	neighbours, err := classifier.Neighbours(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return knnutilities.WeightedVote(neighbours, classifier.voteWeights(neighbours), classifier.tieBreak), nil
+}
+
+func (classifier *kNNClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	targets, distribution, _, err := classifier.ClassDistribution(testRow)
+	return targets, distribution, err
+}
+
+// voteWeights weights each neighbour's vote by its distance and, if the
+// training data is a dataset.WeightedDataset, by its row's weight.
+func (classifier *kNNClassifier) voteWeights(neighbours []knnutilities.Neighbour) []float64 {
+	return knnutilities.SampleWeighted(
+		neighbours,
+		knnutilities.Weights(neighbours, classifier.weight),
+		classifier.rowWeights,
+	)
+}
+
+// ClassDistribution returns the weighted distribution of targets amongst the
+// k nearest neighbours, ordered by each target's nearest neighbour, along
+// with the neighbours themselves.
+func (classifier *kNNClassifier) ClassDistribution(testRow row.Row) ([]slice.Slice, []float64, []knnutilities.Neighbour, error) {
+	neighbours, err := classifier.Neighbours(testRow)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	targets, distribution := knnutilities.WeightedDistribution(neighbours, classifier.voteWeights(neighbours))
+	return targets, distribution, neighbours, nil
+}
+
+// Neighbours returns the k nearest training rows, nearest first, with their
+// indices in the training data and distances from the test row.  The rows of
+// batches given to PartialFit follow those of the training data, in order.
+// Rows at equal distances are ordered by index.
+func (classifier *kNNClassifier) Neighbours(testRow row.Row) ([]knnutilities.Neighbour, error) {
 	trainingData := classifier.trainingData
 	if trainingData == nil {
 		return nil, knnerrors.NewUntrainedClassifierError()
@@ -46,24 +192,10 @@ func (classifier *kNNClassifier) Classify(testRow row.Row) (slice.Slice, error)
 		return nil, knnerrors.NewRowLengthMismatchError(numTestRowFeatures, numTrainingDataFeatures)
 	}
 
-	testFeatures, ok := testRow.Features().(slice.FloatSlice)
-	if !ok {
+	testFeatures := testRow.Features()
+	if _, ok := testFeatures.(slice.FloatSlice); classifier.search.FloatsOnly() && !ok {
 		return nil, knnerrors.NewNonFloatFeaturesTestRowError()
 	}
-	testFeatureValues := testFeatures.Values()
-
-	nearestNeighbours := knnutilities.NewKNNTargetCollection(classifier.k)
-
-	for i := 0; i < trainingData.NumRows(); i++ {
-		trainingRow, _ := trainingData.Row(i)
-		trainingFeatures, _ := trainingRow.Features().(slice.FloatSlice)
-		trainingFeatureValues := trainingFeatures.Values()
-
-		distance := knnutilities.Euclidean(testFeatureValues, trainingFeatureValues, nearestNeighbours.MaxDistance())
-		if distance < nearestNeighbours.MaxDistance() {
-			nearestNeighbours.Insert(trainingRow.Target(), distance)
-		}
-	}
 
-	return nearestNeighbours.Vote(), nil
+	return classifier.search.Nearest(testFeatures), nil
 }
@@ -1,8 +1,12 @@
 package knn_test
 
 import (
+	"context"
+	"strconv"
+
 	"github.com/amitkgupta/goodlearn/classifier"
 	"github.com/amitkgupta/goodlearn/classifier/knn"
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
 	"github.com/amitkgupta/goodlearn/data/columntype"
 	"github.com/amitkgupta/goodlearn/data/dataset"
 	"github.com/amitkgupta/goodlearn/data/row"
@@ -37,6 +41,13 @@ var _ = Describe("KNNClassifier", func() {
 				Ω(err).ShouldNot(HaveOccurred())
 			})
 		})
+
+		Context("When given an unknown tie-breaking rule", func() {
+			It("Returns an error", func() {
+				_, err := knn.NewKNNClassifier(5, knn.TieBreaking(knnutilities.TieBreak(9)))
+				Ω(err).Should(BeAssignableToTypeOf(knnerrors.InvalidTieBreakError{}))
+			})
+		})
 	})
 
 	Describe("Train", func() {
@@ -192,4 +203,365 @@ var _ = Describe("KNNClassifier", func() {
 			})
 		})
 	})
+	Describe("Weighting, tie-breaking and distributions", func() {
+		var trainingData dataset.Dataset
+
+		target := func(i int) slice.Slice {
+			r, err := trainingData.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+			return r.Target()
+		}
+
+		testRowAt := func(x float64) row.Row {
+			return row.NewRow(slice.NewFloatSlice([]float64{x}), nil, 1)
+		}
+
+		BeforeEach(func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingData = dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			for _, r := range [][]string{{"b", "1"}, {"a", "0"}, {"b", "2"}, {"b", "2.1"}} {
+				err = trainingData.AddRowFromStrings(r)
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+		})
+
+		It("Counts neighbours equally by default", func() {
+			c, err := knn.NewKNNClassifier(3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			classifiedTarget, err := c.Classify(testRowAt(-0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(target(0))).Should(BeTrue())
+		})
+
+		It("Weights neighbours by inverse distance", func() {
+			c, err := knn.NewKNNClassifier(3, knn.Weighting(knnutilities.InverseDistanceWeights()))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			classifiedTarget, err := c.Classify(testRowAt(-0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(target(1))).Should(BeTrue())
+
+			targets, distribution, err := c.ClassProbabilities(testRowAt(0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets[0].Equals(target(1))).Should(BeTrue())
+			Ω(distribution).Should(Equal([]float64{1, 0}))
+		})
+
+		It("Weights neighbours by a Gaussian kernel", func() {
+			gaussian, err := knnutilities.GaussianWeights(0.5)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			c, err := knn.NewKNNClassifier(3, knn.Weighting(gaussian))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			classifiedTarget, err := c.Classify(testRowAt(-0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(target(1))).Should(BeTrue())
+		})
+
+		It("Breaks ties in favour of the nearest neighbour, then the earliest row", func() {
+			c, err := knn.NewKNNClassifier(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			classifiedTarget, err := c.Classify(testRowAt(0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(target(0))).Should(BeTrue())
+		})
+
+		It("Breaks ties in favour of the smallest label when asked to", func() {
+			c, err := knn.NewKNNClassifier(2, knn.TieBreaking(knnutilities.SmallestLabel))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			classifiedTarget, err := c.Classify(testRowAt(0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(target(1))).Should(BeTrue())
+		})
+
+		It("Explains the distribution with the neighbours' indices and distances", func() {
+			c, err := knn.NewKNNClassifier(3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			targets, distribution, neighbours, err := c.ClassDistribution(testRowAt(1.5))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(targets).Should(HaveLen(1))
+			Ω(targets[0].Equals(target(0))).Should(BeTrue())
+			Ω(distribution).Should(Equal([]float64{1}))
+
+			Ω(neighbours).Should(HaveLen(3))
+			Ω(neighbours[0].Index).Should(Equal(0))
+			Ω(neighbours[1].Index).Should(Equal(2))
+			Ω(neighbours[2].Index).Should(Equal(3))
+			Ω(neighbours[0].Distance).Should(BeNumerically("~", 0.5, 1e-12))
+			Ω(neighbours[2].Distance).Should(BeNumerically("~", 0.6, 1e-12))
+		})
+
+		It("Weights neighbours by the weights of a weighted dataset's rows", func() {
+			weighted, err := dataset.NewWeightedDataset(trainingData, []float64{1, 3, 1, 1})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			c, err := knn.NewKNNClassifier(3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(weighted)).Should(Succeed())
+
+			classifiedTarget, err := c.Classify(testRowAt(-0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(target(1))).Should(BeTrue())
+
+			targets, distribution, err := c.ClassProbabilities(testRowAt(-0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets[0].Equals(target(1))).Should(BeTrue())
+			Ω(distribution).Should(Equal([]float64{0.6, 0.4}))
+		})
+	})
+
+	Describe("Metrics", func() {
+		var trainingData dataset.Dataset
+		var columnTypes []columntype.ColumnType
+
+		target := func(i int) slice.Slice {
+			r, err := trainingData.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+			return r.Target()
+		}
+
+		BeforeEach(func() {
+			var err error
+			columnTypes, err = columntype.StringsToColumnTypes([]string{"red", "0", "yes"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingData = dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			for _, r := range [][]string{{"red", "0", "yes"}, {"blue", "1", "no"}, {"red", "10", "no"}} {
+				err = trainingData.AddRowFromStrings(r)
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+		})
+
+		It("Refuses mixed features with a metric restricted to floats", func() {
+			c, err := knn.NewKNNClassifier(1, knn.Metric(knnutilities.ManhattanDistance()))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = c.Train(trainingData)
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.NonFloatFeaturesTrainingSetError{}))
+		})
+
+		It("Classifies mixed features with the Gower distance", func() {
+			c, err := knn.NewKNNClassifier(1, knn.Metric(knnutilities.GowerDistance()))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			blueRaw, err := columnTypes[0].PersistRawFromString("blue")
+			Ω(err).ShouldNot(HaveOccurred())
+			redRaw, err := columnTypes[0].PersistRawFromString("red")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			features, err := slice.SliceFromRawValues(false, []int{0, 1}, columnTypes, []float64{blueRaw, 2, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			classifiedTarget, err := c.Classify(row.NewRow(features, nil, 2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(target(1))).Should(BeTrue())
+
+			features, err = slice.SliceFromRawValues(false, []int{0, 1}, columnTypes, []float64{redRaw, 1, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			neighbours, err := c.Neighbours(row.NewRow(features, nil, 2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(neighbours[0].Index).Should(Equal(0))
+			Ω(neighbours[0].Distance).Should(BeNumerically("~", 0.05, 1e-12))
+		})
+
+		It("Propagates errors from fitting the metric", func() {
+			d, err := knnutilities.MahalanobisDistance(0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			c, err := knn.NewKNNClassifier(1, knn.Metric(d))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			floatColumnTypes, err := columntype.StringsToColumnTypes([]string{"red", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			collinear := dataset.NewDataset([]int{1, 2}, []int{0}, floatColumnTypes)
+			for _, r := range [][]string{{"red", "0", "0"}, {"red", "1", "1"}, {"blue", "2", "2"}} {
+				Ω(collinear.AddRowFromStrings(r)).Should(Succeed())
+			}
+			Ω(c.Train(collinear)).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("Search indexes", func() {
+		It("Finds the same neighbours with a KD-tree or ball tree as without", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingData := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			for i := 0; i < 200; i++ {
+				x, y := float64(i%17), float64((i*7)%23)
+				label := "a"
+				if x+y > 18 {
+					label = "b"
+				}
+				err = trainingData.AddRowFromStrings([]string{strconv.FormatFloat(x, 'f', -1, 64), strconv.FormatFloat(y, 'f', -1, 64), label})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			kdTree, err := knnutilities.KDTreeIndex(4)
+			Ω(err).ShouldNot(HaveOccurred())
+			ballTree, err := knnutilities.BallTreeIndex(4)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			bruteForce, err := knn.NewKNNClassifier(5)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(bruteForce.Train(trainingData)).Should(Succeed())
+
+			for _, builder := range []knnutilities.IndexBuilder{kdTree, ballTree} {
+				indexed, err := knn.NewKNNClassifier(5, knn.SearchIndex(builder))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(indexed.Train(trainingData)).Should(Succeed())
+
+				for _, query := range [][]float64{{0, 0}, {8.5, 11}, {16, 22}, {3, 19.5}} {
+					testRow := row.NewRow(slice.NewFloatSlice(query), nil, 2)
+
+					expected, err := bruteForce.Neighbours(testRow)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(indexed.Neighbours(testRow)).Should(Equal(expected))
+				}
+			}
+		})
+
+		It("Classifies with an approximate HNSW index", func() {
+			hnsw, err := knnutilities.HNSWIndex(knnutilities.DefaultHNSWParameters())
+			Ω(err).ShouldNot(HaveOccurred())
+
+			c, err := knn.NewKNNClassifier(3, knn.SearchIndex(hnsw))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+			trainingData := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			for _, r := range [][]string{{"0", "0", "a"}, {"0", "1", "a"}, {"1", "0", "a"}, {"9", "9", "b"}, {"9", "8", "b"}, {"8", "9", "b"}} {
+				Ω(trainingData.AddRowFromStrings(r)).Should(Succeed())
+			}
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			classifiedTarget, err := c.Classify(row.NewRow(slice.NewFloatSlice([]float64{8, 8}), nil, 2))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, err := trainingData.Row(3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(classifiedTarget.Equals(r.Target())).Should(BeTrue())
+		})
+
+		It("Refuses to train when the index cannot use the metric", func() {
+			kdTree, err := knnutilities.KDTreeIndex(4)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			c, err := knn.NewKNNClassifier(1, knn.Metric(knnutilities.CosineDistance()), knn.SearchIndex(kdTree))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+			trainingData := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			Ω(trainingData.AddRowFromStrings([]string{"1", "2", "a"})).Should(Succeed())
+
+			Ω(c.Train(trainingData)).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("PartialFit", func() {
+		var first, second dataset.Dataset
+
+		BeforeEach(func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			first = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			second = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for _, r := range [][]string{{"0", "a"}, {"1", "a"}} {
+				Ω(first.AddRowFromStrings(r)).Should(Succeed())
+			}
+			for _, r := range [][]string{{"10", "b"}, {"11", "b"}} {
+				Ω(second.AddRowFromStrings(r)).Should(Succeed())
+			}
+		})
+
+		It("Trains on the first batch and appends later ones", func() {
+			c, err := knn.NewKNNClassifier(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var online classifier.OnlineClassifier = c
+			Ω(online.PartialFit(first)).Should(Succeed())
+
+			firstRow, err := first.Row(0)
+			Ω(err).ShouldNot(HaveOccurred())
+			target, err := c.Classify(row.NewRow(slice.NewFloatSlice([]float64{10.5}), nil, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(firstRow.Target())).Should(BeTrue())
+
+			Ω(online.PartialFit(second)).Should(Succeed())
+
+			neighbours, err := c.Neighbours(row.NewRow(slice.NewFloatSlice([]float64{10.5}), nil, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(neighbours[0].Index).Should(Equal(2))
+
+			secondRow, err := second.Row(0)
+			Ω(err).ShouldNot(HaveOccurred())
+			target, err = c.Classify(row.NewRow(slice.NewFloatSlice([]float64{10.5}), nil, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(secondRow.Target())).Should(BeTrue())
+		})
+
+		It("Requires batches with as many features as the training data", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			wide := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			Ω(wide.AddRowFromStrings([]string{"1", "2", "a"})).Should(Succeed())
+
+			c, err := knn.NewKNNClassifier(1)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.PartialFit(first)).Should(Succeed())
+			Ω(c.PartialFit(wide)).Should(BeAssignableToTypeOf(knnerrors.BatchLengthMismatchError{}))
+		})
+	})
+
+	Describe("Batch classification", func() {
+		It("Classifies concurrently just as it does serially", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingData := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			testData := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			for i := 0; i < 300; i++ {
+				x, y := strconv.Itoa(i%19), strconv.Itoa((i*11)%29)
+				label := []string{"a", "b", "c"}[(i*7)%3]
+				Ω(trainingData.AddRowFromStrings([]string{x, y, label})).Should(Succeed())
+				Ω(testData.AddRowFromStrings([]string{y, x, "a"})).Should(Succeed())
+			}
+
+			c, err := knn.NewKNNClassifier(5)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(c.Train(trainingData)).Should(Succeed())
+
+			targets, errs, err := classifier.ClassifyBatch(context.Background(), c, testData, 8)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for i := 0; i < testData.NumRows(); i++ {
+				Ω(errs[i]).ShouldNot(HaveOccurred())
+
+				testRow, err := testData.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+				expected, err := c.Classify(testRow)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(targets[i].Equals(expected)).Should(BeTrue())
+			}
+		})
+	})
 })
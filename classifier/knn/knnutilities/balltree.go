@@ -0,0 +1,178 @@
+package knnutilities
+
+import (
+	"errors"
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// BallTreeIndex recursively splits the training rows between two far apart
+// rows until at most leafSize remain, covering each group with a ball, and
+// prunes a subtree when its ball is further than the k-th neighbour found so
+// far.  It needs a MetricDistance, but unlike a KD-tree also works with mixed
+// features and in more dimensions.
+func BallTreeIndex(leafSize int) (IndexBuilder, error) {
+	err := validateLeafSize(leafSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(trainingData dataset.Dataset, distance Distance) (Index, error) {
+		if _, ok := distance.(MetricDistance); !ok {
+			return nil, errors.New("ball trees need a distance satisfying the triangle inequality")
+		}
+
+		points, err := newIndexedPoints(trainingData, distance)
+		if err != nil {
+			return nil, err
+		}
+
+		index := &ballTree{indexedPoints: points}
+		index.indices = make([]int, len(points.features))
+		for i := range index.indices {
+			index.indices[i] = i
+		}
+
+		if len(index.indices) > 0 {
+			index.root = index.build(0, len(index.indices), leafSize)
+		}
+		return index, nil
+	}, nil
+}
+
+type ballTree struct {
+	indexedPoints
+	indices []int
+	root    *ballNode
+}
+
+type ballNode struct {
+	start, end  int
+	pivot       slice.Slice
+	radius      float64
+	left, right *ballNode
+}
+
+func (index *ballTree) between(i, j int) float64 {
+	return index.distance.Between(index.features[i], index.features[j], math.MaxFloat64)
+}
+
+// farthest returns the row amongst indices farthest from the i-th row.
+func (index *ballTree) farthest(i int, indices []int) int {
+	farthest, maxDistance := i, -1.0
+	for _, j := range indices {
+		if d := index.between(i, j); d > maxDistance {
+			farthest, maxDistance = j, d
+		}
+	}
+	return farthest
+}
+
+func (index *ballTree) build(start, end, leafSize int) *ballNode {
+	rows := index.indices[start:end]
+	node := &ballNode{start: start, end: end}
+
+	// the rows a and b are roughly the furthest apart; the pivot is the
+	// centroid of float rows, or else the row roughly central between a and b
+	a := index.farthest(rows[0], rows)
+	b := index.farthest(a, rows)
+
+	toA := make([]float64, len(rows))
+	toB := make([]float64, len(rows))
+	central, bestSpan := rows[0], math.MaxFloat64
+	for r, i := range rows {
+		toA[r], toB[r] = index.between(i, a), index.between(i, b)
+		if span := math.Max(toA[r], toB[r]); span < bestSpan {
+			central, bestSpan = i, span
+		}
+	}
+
+	node.pivot = index.centroid(rows)
+	if node.pivot == nil {
+		node.pivot = index.features[central]
+	}
+
+	for _, i := range rows {
+		node.radius = math.Max(node.radius, index.distance.Between(node.pivot, index.features[i], math.MaxFloat64))
+	}
+
+	if end-start <= leafSize || node.radius == 0 {
+		return node
+	}
+
+	// rows nearer to a go left and those nearer to b right, sharing out rows
+	// equidistant from both; as the radius is positive, a and b are apart
+	var left, right []int
+	for r, i := range rows {
+		if toA[r] < toB[r] || (toA[r] == toB[r] && len(left) <= len(right)) {
+			left = append(left, i)
+		} else {
+			right = append(right, i)
+		}
+	}
+	copy(rows, append(left, right...))
+	middle := len(left)
+
+	node.left = index.build(start, start+middle, leafSize)
+	node.right = index.build(start+middle, end, leafSize)
+	return node
+}
+
+// centroid returns the mean of the rows if their features are all floats
+// and the distance is defined between any float rows, and nil otherwise.
+func (index *ballTree) centroid(rows []int) slice.Slice {
+	if !index.distance.FloatsOnly() {
+		return nil
+	}
+
+	var mean []float64
+	for _, i := range rows {
+		features, ok := index.features[i].(slice.FloatSlice)
+		if !ok {
+			return nil
+		}
+
+		values := features.Values()
+		if mean == nil {
+			mean = make([]float64, len(values))
+		}
+		for j, v := range values {
+			mean[j] = mean[j] + v/float64(len(rows))
+		}
+	}
+	return slice.NewFloatSlice(mean)
+}
+
+func (index *ballTree) Nearest(query slice.Slice, k int) []Neighbour {
+	return index.search(query, k, func(h *neighbourHeap) {
+		index.visit(index.root, query, index.toPivot(index.root, query), h)
+	})
+}
+
+func (index *ballTree) toPivot(node *ballNode, query slice.Slice) float64 {
+	return index.distance.Between(query, node.pivot, math.MaxFloat64)
+}
+
+func (index *ballTree) visit(node *ballNode, query slice.Slice, toPivot float64, h *neighbourHeap) {
+	// allow for rounding so that rows exactly on the boundary are never lost
+	lowerBound := toPivot - node.radius - 1e-12*(toPivot+node.radius)
+	if h.prunes(lowerBound) {
+		return
+	}
+
+	if node.left == nil {
+		index.scan(query, index.indices[node.start:node.end], h)
+		return
+	}
+
+	toLeft, toRight := index.toPivot(node.left, query), index.toPivot(node.right, query)
+	if toLeft <= toRight {
+		index.visit(node.left, query, toLeft, h)
+		index.visit(node.right, query, toRight, h)
+	} else {
+		index.visit(node.right, query, toRight, h)
+		index.visit(node.left, query, toLeft, h)
+	}
+}
@@ -0,0 +1,532 @@
+package knnutilities
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// HNSWParameters tune a hierarchical navigable small world graph.  Larger
+// values of any of M, EfConstruction and EfSearch improve recall at the
+// expense of speed, and larger values of the first two also cost memory and
+// build time.
+type HNSWParameters struct {
+	// M is the number of links each row keeps on every layer of the graph
+	// but the bottom one, where it keeps 2M.
+	M int
+
+	// EfConstruction is the number of candidate neighbours considered when
+	// inserting a row.
+	EfConstruction int
+
+	// EfSearch is the number of candidate neighbours considered when
+	// searching; at least k are always considered.
+	EfSearch int
+
+	// Seed seeds the random choice of each row's highest layer.
+	Seed int64
+}
+
+func DefaultHNSWParameters() HNSWParameters {
+	return HNSWParameters{
+		M:              16,
+		EfConstruction: 200,
+		EfSearch:       50,
+		Seed:           1,
+	}
+}
+
+func (p HNSWParameters) Validate() error {
+	if p.M < 2 {
+		return errors.New("M must be at least 2")
+	}
+	if p.EfConstruction < 1 {
+		return errors.New("EfConstruction must be positive")
+	}
+	if p.EfSearch < 1 {
+		return errors.New("EfSearch must be positive")
+	}
+	return nil
+}
+
+// HNSW is an approximate nearest neighbour index which searches a layered
+// graph linking each row to rows near it, descending greedily from a sparse
+// top layer to the bottom layer, which contains every row.  Its search time
+// grows roughly logarithmically with the number of rows, whatever the
+// number of features.  Rows can be inserted after it is built, but not
+// concurrently with other insertions or searches.
+type HNSW struct {
+	parameters HNSWParameters
+	distance   Distance
+	source     *rand.Rand
+
+	features []slice.Slice
+	targets  []slice.Slice
+	levels   []int
+	links    [][][]int
+
+	entryPoint int
+	maxLevel   int
+}
+
+// NewHNSW returns an empty HNSW index using an already fitted distance.
+func NewHNSW(distance Distance, parameters HNSWParameters) (*HNSW, error) {
+	err := parameters.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &HNSW{
+		parameters: parameters,
+		distance:   distance,
+		source:     rand.New(rand.NewSource(parameters.Seed)),
+		entryPoint: -1,
+		maxLevel:   -1,
+	}, nil
+}
+
+// HNSWIndex builds an HNSW index by inserting the training rows in order.
+// It finds approximately, rather than exactly, the nearest neighbours.
+func HNSWIndex(parameters HNSWParameters) (IndexBuilder, error) {
+	err := parameters.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(trainingData dataset.Dataset, distance Distance) (Index, error) {
+		index, err := NewHNSW(distance, parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < trainingData.NumRows(); i++ {
+			r, err := trainingData.Row(i)
+			if err != nil {
+				return nil, err
+			}
+			index.Insert(r.Features(), r.Target())
+		}
+
+		return index, nil
+	}, nil
+}
+
+// Len returns the number of rows in the index.
+func (index *HNSW) Len() int {
+	return len(index.features)
+}
+
+// SetEfSearch changes the number of candidates considered when searching,
+// trading recall for speed.
+func (index *HNSW) SetEfSearch(efSearch int) error {
+	parameters := index.parameters
+	parameters.EfSearch = efSearch
+
+	err := parameters.Validate()
+	if err != nil {
+		return err
+	}
+
+	index.parameters = parameters
+	return nil
+}
+
+// Insert adds a row to the index and returns its index, which is the number
+// of rows inserted before it.
+func (index *HNSW) Insert(features, target slice.Slice) int {
+	i := len(index.features)
+	level := index.randomLevel()
+
+	index.features = append(index.features, features)
+	index.targets = append(index.targets, target)
+	index.levels = append(index.levels, level)
+	index.links = append(index.links, make([][]int, level+1))
+
+	if index.entryPoint < 0 {
+		index.entryPoint, index.maxLevel = i, level
+		return i
+	}
+
+	entryPoints := []Neighbour{index.neighbour(features, index.entryPoint)}
+	for l := index.maxLevel; l > level; l-- {
+		entryPoints = index.searchLayer(features, entryPoints, 1, l)
+	}
+
+	for l := int(math.Min(float64(level), float64(index.maxLevel))); l >= 0; l-- {
+		candidates := index.searchLayer(features, entryPoints, index.parameters.EfConstruction, l)
+
+		neighbours := index.selectNeighbours(candidates, index.parameters.M)
+		for _, n := range neighbours {
+			index.links[i][l] = append(index.links[i][l], n.Index)
+			index.link(n.Index, i, l)
+		}
+
+		entryPoints = candidates
+	}
+
+	if level > index.maxLevel {
+		index.entryPoint, index.maxLevel = i, level
+	}
+	return i
+}
+
+// randomLevel draws the highest layer of a new row, which is l with
+// probability proportional to M^-l.
+func (index *HNSW) randomLevel() int {
+	return int(-math.Log(1-index.source.Float64()) / math.Log(float64(index.parameters.M)))
+}
+
+func (index *HNSW) maxLinks(level int) int {
+	if level == 0 {
+		return 2 * index.parameters.M
+	}
+	return index.parameters.M
+}
+
+// link adds a link from the i-th row to the j-th on the given level,
+// pruning the i-th row's links if it has too many.
+func (index *HNSW) link(i, j, level int) {
+	links := append(index.links[i][level], j)
+
+	if len(links) > index.maxLinks(level) {
+		candidates := make([]Neighbour, len(links))
+		for c, l := range links {
+			candidates[c] = index.neighbour(index.features[i], l)
+		}
+		sortNeighbours(candidates)
+
+		links = links[:0]
+		for _, n := range index.selectNeighbours(candidates, index.maxLinks(level)) {
+			links = append(links, n.Index)
+		}
+	}
+
+	index.links[i][level] = links
+}
+
+func (index *HNSW) neighbour(query slice.Slice, i int) Neighbour {
+	return Neighbour{i, nil, index.distance.Between(query, index.features[i], math.MaxFloat64)}
+}
+
+// selectNeighbours picks up to m of the candidates, given nearest first,
+// preferring those nearer to the query than to any already picked so that
+// links reach out in different directions, and then the nearest of the rest.
+func (index *HNSW) selectNeighbours(candidates []Neighbour, m int) []Neighbour {
+	selected := make([]Neighbour, 0, m)
+	var skipped []Neighbour
+
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+
+		diverse := true
+		for _, s := range selected {
+			if index.distance.Between(index.features[c.Index], index.features[s.Index], c.Distance) < c.Distance {
+				diverse = false
+				break
+			}
+		}
+
+		if diverse {
+			selected = append(selected, c)
+		} else {
+			skipped = append(skipped, c)
+		}
+	}
+
+	for _, c := range skipped {
+		if len(selected) == m {
+			break
+		}
+		selected = append(selected, c)
+	}
+
+	return selected
+}
+
+// searchLayer returns the ef rows nearest to the query, nearest first, found
+// by a best-first search of the given layer from the entry points.
+func (index *HNSW) searchLayer(query slice.Slice, entryPoints []Neighbour, ef, level int) []Neighbour {
+	visited := make(map[int]bool, ef*index.maxLinks(level))
+	candidates := &candidateHeap{}
+	nearest := &neighbourHeap{k: ef, neighbours: make([]Neighbour, 0, ef)}
+
+	for _, e := range entryPoints {
+		visited[e.Index] = true
+		heap.Push(candidates, e)
+		nearest.offer(e)
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(Neighbour)
+		if nearest.prunes(c.Distance) {
+			break
+		}
+
+		for _, j := range index.links[c.Index][level] {
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+
+			distance := index.distance.Between(query, index.features[j], nearest.bailout())
+			if distance < nearest.bailout() {
+				n := Neighbour{j, nil, distance}
+				heap.Push(candidates, n)
+				nearest.offer(n)
+			}
+		}
+	}
+
+	return nearest.nearestFirst()
+}
+
+func (index *HNSW) Nearest(query slice.Slice, k int) []Neighbour {
+	if k < 1 || index.entryPoint < 0 {
+		return []Neighbour{}
+	}
+
+	entryPoints := []Neighbour{index.neighbour(query, index.entryPoint)}
+	for l := index.maxLevel; l > 0; l-- {
+		entryPoints = index.searchLayer(query, entryPoints, 1, l)
+	}
+
+	ef := int(math.Max(float64(index.parameters.EfSearch), float64(k)))
+	neighbours := index.searchLayer(query, entryPoints, ef, 0)
+	if len(neighbours) > k {
+		neighbours = neighbours[:k]
+	}
+
+	for n := range neighbours {
+		neighbours[n].Target = index.targets[neighbours[n].Index]
+	}
+	return neighbours
+}
+
+// candidateHeap holds the rows still to be explored in a layer search,
+// nearest first.
+type candidateHeap []Neighbour
+
+func (h candidateHeap) Len() int {
+	return len(h)
+}
+
+func (h candidateHeap) Less(i, j int) bool {
+	return closer(h[i], h[j])
+}
+
+func (h candidateHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *candidateHeap) Push(x interface{}) {
+	*h = append(*h, x.(Neighbour))
+}
+
+func (h *candidateHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+func sortNeighbours(neighbours []Neighbour) {
+	h := candidateHeap(append([]Neighbour{}, neighbours...))
+	heap.Init(&h)
+	for i := range neighbours {
+		neighbours[i] = heap.Pop(&h).(Neighbour)
+	}
+}
+
+// hnswSnapshot is the serialized form of an HNSW index.
+type hnswSnapshot struct {
+	Parameters HNSWParameters
+	Features   []serializedSlice
+	Targets    []serializedSlice
+	Levels     []int
+	Links      [][][]int
+	EntryPoint int
+	MaxLevel   int
+}
+
+type serializedSlice struct {
+	Nil    bool
+	Mixed  bool
+	Floats []float64
+	Values []interface{}
+}
+
+func serializeSlice(s slice.Slice) (serializedSlice, error) {
+	switch values := s.(type) {
+	case nil:
+		return serializedSlice{Nil: true}, nil
+	case slice.FloatSlice:
+		return serializedSlice{Floats: values.Values()}, nil
+	case slice.MixedSlice:
+		return serializedSlice{Mixed: true, Values: values.Values()}, nil
+	}
+	return serializedSlice{}, errors.New("cannot serialize slice which is neither float nor mixed")
+}
+
+func (s serializedSlice) slice() (slice.Slice, error) {
+	switch {
+	case s.Nil:
+		return nil, nil
+	case s.Mixed:
+		return slice.NewMixedSlice(s.Values)
+	}
+	return slice.NewFloatSlice(s.Floats), nil
+}
+
+// Save writes the index, including its rows, to w.  The distance is not
+// saved and must be given again to LoadHNSW.
+func (index *HNSW) Save(w io.Writer) error {
+	snapshot := hnswSnapshot{
+		Parameters: index.parameters,
+		Features:   make([]serializedSlice, len(index.features)),
+		Targets:    make([]serializedSlice, len(index.targets)),
+		Levels:     index.levels,
+		Links:      index.links,
+		EntryPoint: index.entryPoint,
+		MaxLevel:   index.maxLevel,
+	}
+
+	var err error
+	for i := range index.features {
+		snapshot.Features[i], err = serializeSlice(index.features[i])
+		if err != nil {
+			return err
+		}
+
+		snapshot.Targets[i], err = serializeSlice(index.targets[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return gob.NewEncoder(w).Encode(snapshot)
+}
+
+// LoadHNSW reads an index written by Save, which will compare rows with the
+// given distance.  Rows inserted after loading get different random layers
+// than they would have had before saving.
+func LoadHNSW(r io.Reader, distance Distance) (*HNSW, error) {
+	var snapshot hnswSnapshot
+	err := gob.NewDecoder(r).Decode(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := NewHNSW(distance, snapshot.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	err = snapshot.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	numRows := len(snapshot.Features)
+
+	index.features = make([]slice.Slice, numRows)
+	index.targets = make([]slice.Slice, numRows)
+	for i := 0; i < numRows; i++ {
+		index.features[i], err = snapshot.Features[i].slice()
+		if err != nil {
+			return nil, err
+		}
+
+		index.targets[i], err = snapshot.Targets[i].slice()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	index.levels = snapshot.Levels
+	index.links = snapshot.Links
+	index.entryPoint = snapshot.EntryPoint
+	index.maxLevel = snapshot.MaxLevel
+	index.source = rand.New(rand.NewSource(snapshot.Parameters.Seed + int64(numRows)))
+
+	return index, nil
+}
+
+// validate checks that every row and link of the snapshot refers to a row
+// and layer within the index, so that a corrupt index is rejected rather
+// than panicking when searched.
+func (snapshot hnswSnapshot) validate() error {
+	numRows := len(snapshot.Features)
+	if len(snapshot.Targets) != numRows || len(snapshot.Levels) != numRows || len(snapshot.Links) != numRows {
+		return errors.New("corrupt HNSW index: inconsistent number of rows")
+	}
+
+	if numRows == 0 {
+		if snapshot.EntryPoint != -1 || snapshot.MaxLevel != -1 {
+			return errors.New("corrupt HNSW index: entry point in empty index")
+		}
+		return nil
+	}
+
+	if snapshot.EntryPoint < 0 || snapshot.EntryPoint >= numRows {
+		return errors.New("corrupt HNSW index: entry point out of range")
+	}
+
+	if snapshot.Levels[snapshot.EntryPoint] != snapshot.MaxLevel {
+		return errors.New("corrupt HNSW index: entry point not on the top layer")
+	}
+
+	for i, level := range snapshot.Levels {
+		if level < 0 || level > snapshot.MaxLevel || len(snapshot.Links[i]) != level+1 {
+			return errors.New("corrupt HNSW index: row layers out of range")
+		}
+
+		for l, links := range snapshot.Links[i] {
+			for _, j := range links {
+				if j < 0 || j >= numRows || snapshot.Levels[j] < l {
+					return errors.New("corrupt HNSW index: link out of range")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Recall measures how well an approximate index finds the k nearest
+// training rows to each query, by Euclidean distance, as the fraction of
+// the true neighbours found by a brute force search which it returns.
+func Recall(index Index, trainingData dataset.Dataset, queries []slice.Slice, k int) (float64, error) {
+	distance := EuclideanDistance()
+	exact, err := BruteForceIndex()(trainingData, distance)
+	if err != nil {
+		return 0, err
+	}
+
+	found, total := 0, 0
+	for _, query := range queries {
+		approximate := make(map[int]bool)
+		for _, n := range index.Nearest(query, k) {
+			approximate[n.Index] = true
+		}
+
+		for _, n := range exact.Nearest(query, k) {
+			if approximate[n.Index] {
+				found++
+			}
+			total++
+		}
+	}
+
+	if total == 0 {
+		return 0, errors.New("no neighbours to recall")
+	}
+	return float64(found) / float64(total), nil
+}
@@ -0,0 +1,199 @@
+package knnutilities_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HNSW", func() {
+	var source *rand.Rand
+	var ds dataset.Dataset
+	var queries []slice.Slice
+
+	BeforeEach(func() {
+		source = rand.New(rand.NewSource(1))
+		ds = randomFloatDataset(source, 1000, 64, false)
+
+		queries = make([]slice.Slice, 50)
+		for i := range queries {
+			queries[i] = slice.NewFloatSlice(randomPoint(source, 64))
+		}
+	})
+
+	build := func(parameters knnutilities.HNSWParameters) *knnutilities.HNSW {
+		builder, err := knnutilities.HNSWIndex(parameters)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		index, err := builder(ds, knnutilities.EuclideanDistance())
+		Ω(err).ShouldNot(HaveOccurred())
+		return index.(*knnutilities.HNSW)
+	}
+
+	It("Recalls most of the true neighbours in many dimensions", func() {
+		index := build(knnutilities.DefaultHNSWParameters())
+		Ω(index.Len()).Should(Equal(1000))
+
+		recall, err := knnutilities.Recall(index, ds, queries, 10)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(recall).Should(BeNumerically(">", 0.9))
+	})
+
+	It("Trades recall for speed with EfSearch", func() {
+		parameters := knnutilities.DefaultHNSWParameters()
+		parameters.M = 4
+		parameters.EfConstruction = 20
+		index := build(parameters)
+
+		Ω(index.SetEfSearch(1)).Should(Succeed())
+		lowRecall, err := knnutilities.Recall(index, ds, queries, 10)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(index.SetEfSearch(400)).Should(Succeed())
+		highRecall, err := knnutilities.Recall(index, ds, queries, 10)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(highRecall).Should(BeNumerically(">", lowRecall))
+		Ω(index.SetEfSearch(0)).ShouldNot(Succeed())
+	})
+
+	It("Returns neighbours nearest first with their targets and exact distances", func() {
+		index := build(knnutilities.DefaultHNSWParameters())
+
+		r, err := ds.Row(7)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		neighbours := index.Nearest(r.Features(), 5)
+		Ω(neighbours).Should(HaveLen(5))
+		Ω(neighbours[0].Index).Should(Equal(7))
+		Ω(neighbours[0].Distance).Should(Equal(0.0))
+		Ω(neighbours[0].Target.Equals(r.Target())).Should(BeTrue())
+		for i := 1; i < len(neighbours); i++ {
+			Ω(neighbours[i].Distance).Should(BeNumerically(">=", neighbours[i-1].Distance))
+		}
+	})
+
+	It("Accepts rows inserted after it is built", func() {
+		index := build(knnutilities.DefaultHNSWParameters())
+
+		features := slice.NewFloatSlice(randomPoint(source, 64))
+		target := slice.NewFloatSlice([]float64{9})
+		Ω(index.Insert(features, target)).Should(Equal(1000))
+
+		neighbours := index.Nearest(features, 1)
+		Ω(neighbours[0].Index).Should(Equal(1000))
+		Ω(neighbours[0].Target.Equals(target)).Should(BeTrue())
+	})
+
+	It("Saves and loads the built index", func() {
+		index := build(knnutilities.DefaultHNSWParameters())
+
+		buffer := &bytes.Buffer{}
+		Ω(index.Save(buffer)).Should(Succeed())
+
+		loaded, err := knnutilities.LoadHNSW(buffer, knnutilities.EuclideanDistance())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(loaded.Len()).Should(Equal(index.Len()))
+
+		for _, query := range queries {
+			Ω(loaded.Nearest(query, 10)).Should(Equal(index.Nearest(query, 10)))
+		}
+
+		features := slice.NewFloatSlice(randomPoint(source, 64))
+		loaded.Insert(features, nil)
+		Ω(loaded.Nearest(features, 1)[0].Index).Should(Equal(1000))
+	})
+
+	It("Saves and loads mixed rows", func() {
+		mixed := mixedDataset(source, 50)
+		builder, err := knnutilities.HNSWIndex(knnutilities.DefaultHNSWParameters())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		distance := knnutilities.GowerDistance()
+		Ω(distance.Fit(mixed)).Should(Succeed())
+		index, err := builder(mixed, distance)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		buffer := &bytes.Buffer{}
+		Ω(index.(*knnutilities.HNSW).Save(buffer)).Should(Succeed())
+
+		loaded, err := knnutilities.LoadHNSW(buffer, distance)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		r, err := mixed.Row(3)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(loaded.Nearest(r.Features(), 5)).Should(Equal(index.Nearest(r.Features(), 5)))
+	})
+
+	It("Rejects a corrupt index rather than panicking", func() {
+		index := build(knnutilities.DefaultHNSWParameters())
+
+		buffer := &bytes.Buffer{}
+		Ω(index.Save(buffer)).Should(Succeed())
+		saved := buffer.Bytes()
+
+		// mirrors the saved index, whose fields gob matches by name
+		type serializedSlice struct {
+			Nil    bool
+			Floats []float64
+		}
+		type snapshot struct {
+			Parameters knnutilities.HNSWParameters
+			Features   []serializedSlice
+			Targets    []serializedSlice
+			Levels     []int
+			Links      [][][]int
+			EntryPoint int
+			MaxLevel   int
+		}
+
+		corruptions := []func(*snapshot){
+			func(s *snapshot) { s.EntryPoint = len(s.Levels) },
+			func(s *snapshot) { s.EntryPoint = -1 },
+			func(s *snapshot) { s.MaxLevel = s.MaxLevel + 1 },
+			func(s *snapshot) { s.Links[0][0][0] = len(s.Levels) },
+			func(s *snapshot) { s.Links[1][0][0] = -5 },
+			func(s *snapshot) { s.Links[2] = append(s.Links[2], []int{0}) },
+			func(s *snapshot) { s.Links = s.Links[1:] },
+		}
+
+		for _, corrupt := range corruptions {
+			var decoded snapshot
+			Ω(gob.NewDecoder(bytes.NewReader(saved)).Decode(&decoded)).Should(Succeed())
+			corrupt(&decoded)
+
+			corrupted := &bytes.Buffer{}
+			Ω(gob.NewEncoder(corrupted).Encode(decoded)).Should(Succeed())
+
+			_, err := knnutilities.LoadHNSW(corrupted, knnutilities.EuclideanDistance())
+			Ω(err).Should(HaveOccurred())
+		}
+	})
+
+	It("Validates its parameters", func() {
+		parameters := knnutilities.DefaultHNSWParameters()
+		Ω(parameters.Validate()).Should(Succeed())
+
+		parameters.M = 1
+		_, err := knnutilities.HNSWIndex(parameters)
+		Ω(err).Should(HaveOccurred())
+
+		parameters = knnutilities.DefaultHNSWParameters()
+		parameters.EfConstruction = 0
+		_, err = knnutilities.NewHNSW(knnutilities.EuclideanDistance(), parameters)
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("Finds nothing when empty", func() {
+		index, err := knnutilities.NewHNSW(knnutilities.EuclideanDistance(), knnutilities.DefaultHNSWParameters())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(index.Nearest(queries[0], 3)).Should(BeEmpty())
+	})
+})
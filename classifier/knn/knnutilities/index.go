@@ -0,0 +1,184 @@
+package knnutilities
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// Index finds the training rows nearest to a query.
+type Index interface {
+	// Nearest returns the k nearest rows, nearest first, with rows at equal
+	// distances ordered by index.
+	Nearest(query slice.Slice, k int) []Neighbour
+}
+
+// InsertableIndex is an Index to which rows can be added once it is built.
+type InsertableIndex interface {
+	Index
+	// Insert adds a row and returns its index, which is the number of rows
+	// inserted before it.
+	Insert(features, target slice.Slice) int
+}
+
+// IndexBuilder builds an Index over training data whose features are compared
+// by an already fitted distance.
+type IndexBuilder func(trainingData dataset.Dataset, distance Distance) (Index, error)
+
+// BruteForceIndex compares every query against every training row.
+func BruteForceIndex() IndexBuilder {
+	return func(trainingData dataset.Dataset, distance Distance) (Index, error) {
+		points, err := newIndexedPoints(trainingData, distance)
+		if err != nil {
+			return nil, err
+		}
+		return &bruteForceIndex{points}, nil
+	}
+}
+
+type indexedPoints struct {
+	distance Distance
+	features []slice.Slice
+	targets  []slice.Slice
+}
+
+func newIndexedPoints(trainingData dataset.Dataset, distance Distance) (indexedPoints, error) {
+	numRows := trainingData.NumRows()
+	points := indexedPoints{
+		distance: distance,
+		features: make([]slice.Slice, numRows),
+		targets:  make([]slice.Slice, numRows),
+	}
+
+	for i := 0; i < numRows; i++ {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return indexedPoints{}, err
+		}
+		points.features[i] = r.Features()
+		points.targets[i] = r.Target()
+	}
+
+	return points, nil
+}
+
+type bruteForceIndex struct {
+	indexedPoints
+}
+
+func (index *bruteForceIndex) Insert(features, target slice.Slice) int {
+	index.features = append(index.features, features)
+	index.targets = append(index.targets, target)
+	return len(index.features) - 1
+}
+
+func (index *bruteForceIndex) Nearest(query slice.Slice, k int) []Neighbour {
+	nearestNeighbours := NewKNNTargetCollection(k)
+
+	for i, features := range index.features {
+		distance := index.distance.Between(query, features, nearestNeighbours.MaxDistance())
+		if distance < nearestNeighbours.MaxDistance() {
+			nearestNeighbours.InsertNeighbour(i, index.targets[i], distance)
+		}
+	}
+
+	return nearestNeighbours.Neighbours()
+}
+
+func validateLeafSize(leafSize int) error {
+	if leafSize < 1 {
+		return errors.New("leaf size must be positive")
+	}
+	return nil
+}
+
+// neighbourHeap holds the k best candidates seen so far in a tree search,
+// worst first, ordering by distance and then by index so that trees find
+// exactly the neighbours a brute force search would.
+type neighbourHeap struct {
+	k          int
+	neighbours []Neighbour
+}
+
+func (h *neighbourHeap) Len() int {
+	return len(h.neighbours)
+}
+
+func (h *neighbourHeap) Less(i, j int) bool {
+	return closer(h.neighbours[j], h.neighbours[i])
+}
+
+func (h *neighbourHeap) Swap(i, j int) {
+	h.neighbours[i], h.neighbours[j] = h.neighbours[j], h.neighbours[i]
+}
+
+func (h *neighbourHeap) Push(x interface{}) {
+	h.neighbours = append(h.neighbours, x.(Neighbour))
+}
+
+func (h *neighbourHeap) Pop() interface{} {
+	last := h.neighbours[len(h.neighbours)-1]
+	h.neighbours = h.neighbours[:len(h.neighbours)-1]
+	return last
+}
+
+func closer(n1, n2 Neighbour) bool {
+	return n1.Distance < n2.Distance || (n1.Distance == n2.Distance && n1.Index < n2.Index)
+}
+
+func (h *neighbourHeap) full() bool {
+	return len(h.neighbours) == h.k
+}
+
+// bailout is the largest distance worth computing exactly: a candidate at
+// the same distance as the worst one may still displace it by index.
+func (h *neighbourHeap) bailout() float64 {
+	if !h.full() {
+		return math.MaxFloat64
+	}
+	return math.Nextafter(h.neighbours[0].Distance, math.Inf(1))
+}
+
+// prunes reports whether nothing at least lowerBound away can be a neighbour.
+func (h *neighbourHeap) prunes(lowerBound float64) bool {
+	return h.full() && lowerBound > h.neighbours[0].Distance
+}
+
+func (h *neighbourHeap) offer(candidate Neighbour) {
+	if !h.full() {
+		heap.Push(h, candidate)
+	} else if closer(candidate, h.neighbours[0]) {
+		h.neighbours[0] = candidate
+		heap.Fix(h, 0)
+	}
+}
+
+func (h *neighbourHeap) nearestFirst() []Neighbour {
+	neighbours := make([]Neighbour, len(h.neighbours))
+	for i := len(neighbours) - 1; i >= 0; i-- {
+		neighbours[i] = heap.Pop(h).(Neighbour)
+	}
+	return neighbours
+}
+
+func (points indexedPoints) search(query slice.Slice, k int, visit func(*neighbourHeap)) []Neighbour {
+	if k < 1 || len(points.features) == 0 {
+		return []Neighbour{}
+	}
+
+	h := &neighbourHeap{k: k, neighbours: make([]Neighbour, 0, k)}
+	visit(h)
+	return h.nearestFirst()
+}
+
+func (points indexedPoints) scan(query slice.Slice, indices []int, h *neighbourHeap) {
+	for _, i := range indices {
+		distance := points.distance.Between(query, points.features[i], h.bailout())
+		if distance < h.bailout() {
+			h.offer(Neighbour{i, points.targets[i], distance})
+		}
+	}
+}
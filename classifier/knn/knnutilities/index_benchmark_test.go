@@ -0,0 +1,66 @@
+package knnutilities_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// BenchmarkNearest compares the indexes answering 10-nearest-neighbour
+// queries over 20000 rows of normally distributed features; run with
+//
+//	go test -run XXX -bench Nearest ./classifier/knn/knnutilities/
+func BenchmarkNearest(b *testing.B) {
+	kdTree, _ := knnutilities.KDTreeIndex(16)
+	ballTree, _ := knnutilities.BallTreeIndex(16)
+	hnsw, _ := knnutilities.HNSWIndex(knnutilities.DefaultHNSWParameters())
+	builders := []struct {
+		name    string
+		builder knnutilities.IndexBuilder
+	}{
+		{"BruteForce", knnutilities.BruteForceIndex()},
+		{"KDTree", kdTree},
+		{"BallTree", ballTree},
+		{"HNSW", hnsw},
+	}
+
+	for _, numFeatures := range []int{2, 8, 32} {
+		source := rand.New(rand.NewSource(1))
+		rows := make([]row.Row, 20000)
+		for i := range rows {
+			rows[i] = row.NewRow(slice.NewFloatSlice(randomPoint(source, numFeatures)), slice.NewFloatSlice([]float64{0}), numFeatures)
+		}
+		ds := dataset.NewDatasetFromRows(numFeatures, 1, rows)
+
+		queries := make([]slice.Slice, 100)
+		for i := range queries {
+			queries[i] = slice.NewFloatSlice(randomPoint(source, numFeatures))
+		}
+
+		for _, indexBuilder := range builders {
+			index, err := indexBuilder.builder(ds, knnutilities.EuclideanDistance())
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(fmt.Sprintf("%s/%dFeatures", indexBuilder.name, numFeatures), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					index.Nearest(queries[i%len(queries)], 10)
+				}
+			})
+		}
+	}
+}
+
+func randomPoint(source *rand.Rand, numFeatures int) []float64 {
+	point := make([]float64, numFeatures)
+	for j := range point {
+		point[j] = source.NormFloat64()
+	}
+	return point
+}
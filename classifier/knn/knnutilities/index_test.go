@@ -0,0 +1,162 @@
+package knnutilities_test
+
+import (
+	"math/rand"
+	"strconv"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func randomFloatDataset(source *rand.Rand, numRows, numFeatures int, grid bool) dataset.Dataset {
+	rows := make([]row.Row, numRows)
+	for i := range rows {
+		features := make([]float64, numFeatures)
+		for j := range features {
+			if grid {
+				// a coarse grid makes for many duplicate rows and tied distances
+				features[j] = float64(source.Intn(4))
+			} else {
+				features[j] = source.NormFloat64()
+			}
+		}
+		rows[i] = row.NewRow(slice.NewFloatSlice(features), slice.NewFloatSlice([]float64{float64(i % 3)}), numFeatures)
+	}
+	return dataset.NewDatasetFromRows(numFeatures, 1, rows)
+}
+
+func mixedDataset(source *rand.Rand, numRows int) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "a", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ds := dataset.NewDataset([]int{0, 1, 2}, []int{3}, columnTypes)
+	labels := []string{"a", "b", "c"}
+	for i := 0; i < numRows; i++ {
+		err = ds.AddRowFromStrings([]string{
+			labels[source.Intn(3)],
+			strconv.Itoa(source.Intn(10)),
+			labels[source.Intn(2)],
+			strconv.Itoa(i % 2),
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+	return ds
+}
+
+func buildIndex(builder knnutilities.IndexBuilder, builderErr error, ds dataset.Dataset, distance knnutilities.Distance) knnutilities.Index {
+	Ω(builderErr).ShouldNot(HaveOccurred())
+	Ω(distance.Fit(ds)).Should(Succeed())
+
+	index, err := builder(ds, distance)
+	Ω(err).ShouldNot(HaveOccurred())
+	return index
+}
+
+var _ = Describe("Indexes", func() {
+	var source *rand.Rand
+
+	BeforeEach(func() {
+		source = rand.New(rand.NewSource(1))
+	})
+
+	expectSameNeighboursAsBruteForce := func(ds dataset.Dataset, queries dataset.Dataset, distance knnutilities.Distance, indexes ...knnutilities.Index) {
+		bruteForce := buildIndex(knnutilities.BruteForceIndex(), nil, ds, distance)
+
+		for _, k := range []int{1, 5, 17} {
+			for i := 0; i < queries.NumRows(); i++ {
+				r, err := queries.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				expected := bruteForce.Nearest(r.Features(), k)
+				Ω(expected).Should(HaveLen(k))
+				for _, index := range indexes {
+					Ω(index.Nearest(r.Features(), k)).Should(Equal(expected))
+				}
+			}
+		}
+	}
+
+	Context("With float features", func() {
+		for _, numFeatures := range []int{1, 3, 10} {
+			numFeatures := numFeatures
+
+			It("Finds the same neighbours as brute force for every metric", func() {
+				for _, grid := range []bool{false, true} {
+					ds := randomFloatDataset(source, 500, numFeatures, grid)
+					queries := randomFloatDataset(source, 20, numFeatures, grid)
+
+					minkowski, err := knnutilities.MinkowskiDistance(3)
+					Ω(err).ShouldNot(HaveOccurred())
+					mahalanobis, err := knnutilities.MahalanobisDistance(0.01)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					for _, distance := range []knnutilities.Distance{
+						knnutilities.EuclideanDistance(),
+						knnutilities.ManhattanDistance(),
+						knnutilities.ChebyshevDistance(),
+						minkowski,
+					} {
+						kdBuilder, kdErr := knnutilities.KDTreeIndex(8)
+						ballBuilder, ballErr := knnutilities.BallTreeIndex(8)
+						expectSameNeighboursAsBruteForce(ds, queries, distance,
+							buildIndex(kdBuilder, kdErr, ds, distance),
+							buildIndex(ballBuilder, ballErr, ds, distance),
+						)
+					}
+
+					ballBuilder, ballErr := knnutilities.BallTreeIndex(1)
+					expectSameNeighboursAsBruteForce(ds, queries, mahalanobis, buildIndex(ballBuilder, ballErr, ds, mahalanobis))
+				}
+			})
+		}
+	})
+
+	Context("With mixed features", func() {
+		It("Finds the same neighbours as brute force with a ball tree", func() {
+			ds := mixedDataset(source, 300)
+			queries := mixedDataset(source, 20)
+
+			for _, distance := range []knnutilities.Distance{knnutilities.GowerDistance(), knnutilities.HammingDistance()} {
+				ballBuilder, ballErr := knnutilities.BallTreeIndex(4)
+				expectSameNeighboursAsBruteForce(ds, queries, distance, buildIndex(ballBuilder, ballErr, ds, distance))
+			}
+		})
+	})
+
+	It("Returns every row when k exceeds the number of rows", func() {
+		ds := randomFloatDataset(source, 5, 2, false)
+		builder, err := knnutilities.KDTreeIndex(2)
+		index := buildIndex(builder, err, ds, knnutilities.EuclideanDistance())
+
+		r, err := ds.Row(0)
+		Ω(err).ShouldNot(HaveOccurred())
+		neighbours := index.Nearest(r.Features(), 10)
+		Ω(neighbours).Should(HaveLen(5))
+		Ω(neighbours[0].Index).Should(Equal(0))
+		Ω(neighbours[0].Distance).Should(Equal(0.0))
+	})
+
+	It("Rejects invalid leaf sizes and unsuitable distances", func() {
+		_, err := knnutilities.KDTreeIndex(0)
+		Ω(err).Should(HaveOccurred())
+		_, err = knnutilities.BallTreeIndex(-1)
+		Ω(err).Should(HaveOccurred())
+
+		ds := randomFloatDataset(source, 5, 2, false)
+		kdBuilder, err := knnutilities.KDTreeIndex(2)
+		Ω(err).ShouldNot(HaveOccurred())
+		_, err = kdBuilder(ds, knnutilities.GowerDistance())
+		Ω(err).Should(HaveOccurred())
+
+		ballBuilder, err := knnutilities.BallTreeIndex(2)
+		Ω(err).ShouldNot(HaveOccurred())
+		_, err = ballBuilder(ds, knnutilities.CosineDistance())
+		Ω(err).Should(HaveOccurred())
+	})
+})
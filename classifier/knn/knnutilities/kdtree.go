@@ -0,0 +1,157 @@
+package knnutilities
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// KDTreeIndex splits the training rows at the median of their most spread
+// out feature until at most leafSize rows remain, and prunes a subtree when
+// the nearest point of its bounding box is further than the k-th neighbour
+// found so far.  It needs float features and a CoordinatewiseDistance, and
+// works best in few dimensions.
+func KDTreeIndex(leafSize int) (IndexBuilder, error) {
+	err := validateLeafSize(leafSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(trainingData dataset.Dataset, distance Distance) (Index, error) {
+		if _, ok := distance.(CoordinatewiseDistance); !ok {
+			return nil, errors.New("KD-trees need a coordinatewise distance")
+		}
+
+		if !trainingData.AllFeaturesFloats() {
+			return nil, errors.New("KD-trees need float features")
+		}
+
+		points, err := newIndexedPoints(trainingData, distance)
+		if err != nil {
+			return nil, err
+		}
+
+		index := &kdTree{indexedPoints: points}
+		index.values = make([][]float64, len(points.features))
+		index.indices = make([]int, len(points.features))
+		for i, features := range points.features {
+			index.values[i] = features.(slice.FloatSlice).Values()
+			index.indices[i] = i
+		}
+
+		if len(index.indices) > 0 {
+			index.root = index.build(0, len(index.indices), leafSize)
+		}
+		return index, nil
+	}, nil
+}
+
+type kdTree struct {
+	indexedPoints
+	values  [][]float64
+	indices []int
+	root    *kdNode
+}
+
+type kdNode struct {
+	start, end   int
+	lower, upper []float64
+	splitFeature int
+	splitValue   float64
+	left, right  *kdNode
+}
+
+func (index *kdTree) build(start, end, leafSize int) *kdNode {
+	node := &kdNode{start: start, end: end}
+	node.lower, node.upper = index.boundingBox(start, end)
+
+	if end-start <= leafSize {
+		return node
+	}
+
+	widest, width := 0, -1.0
+	for j := range node.lower {
+		if node.upper[j]-node.lower[j] > width {
+			widest, width = j, node.upper[j]-node.lower[j]
+		}
+	}
+	if width <= 0 {
+		// every row is identical so no split can separate them
+		return node
+	}
+
+	rows := index.indices[start:end]
+	sort.Slice(rows, func(a, b int) bool {
+		return index.values[rows[a]][widest] < index.values[rows[b]][widest]
+	})
+
+	middle := start + (end-start)/2
+	node.splitFeature = widest
+	node.splitValue = index.values[index.indices[middle]][widest]
+	node.left = index.build(start, middle, leafSize)
+	node.right = index.build(middle, end, leafSize)
+	return node
+}
+
+func (index *kdTree) boundingBox(start, end int) ([]float64, []float64) {
+	first := index.values[index.indices[start]]
+	lower := append([]float64{}, first...)
+	upper := append([]float64{}, first...)
+
+	for _, i := range index.indices[start+1 : end] {
+		for j, v := range index.values[i] {
+			if v < lower[j] {
+				lower[j] = v
+			} else if v > upper[j] {
+				upper[j] = v
+			}
+		}
+	}
+
+	return lower, upper
+}
+
+func (index *kdTree) Nearest(query slice.Slice, k int) []Neighbour {
+	return index.search(query, k, func(h *neighbourHeap) {
+		index.visit(index.root, query, query.(slice.FloatSlice).Values(), h)
+	})
+}
+
+func (index *kdTree) visit(node *kdNode, query slice.Slice, queryValues []float64, h *neighbourHeap) {
+	if h.prunes(index.boxDistance(node, query, queryValues, h.bailout())) {
+		return
+	}
+
+	if node.left == nil {
+		index.scan(query, index.indices[node.start:node.end], h)
+		return
+	}
+
+	if queryValues[node.splitFeature] < node.splitValue {
+		index.visit(node.left, query, queryValues, h)
+		index.visit(node.right, query, queryValues, h)
+	} else {
+		index.visit(node.right, query, queryValues, h)
+		index.visit(node.left, query, queryValues, h)
+	}
+}
+
+// boxDistance is the distance from the query to the nearest point of the
+// node's bounding box.
+func (index *kdTree) boxDistance(node *kdNode, query slice.Slice, queryValues []float64, bailout float64) float64 {
+	nearest := make([]float64, len(queryValues))
+	for j, v := range queryValues {
+		switch {
+		case v < node.lower[j]:
+			nearest[j] = node.lower[j]
+		case v > node.upper[j]:
+			nearest[j] = node.upper[j]
+		default:
+			nearest[j] = v
+		}
+	}
+
+	return index.distance.Between(query, slice.NewFloatSlice(nearest), bailout)
+}
@@ -0,0 +1,364 @@
+package knnutilities
+
+import (
+	"errors"
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/knnerrors"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+)
+
+// Distance measures how far apart two feature slices are.
+type Distance interface {
+	// FloatsOnly reports whether the distance is only defined between
+	// slice.FloatSlices.
+	FloatsOnly() bool
+
+	// Fit prepares the distance for rows like those of the training data,
+	// e.g. by estimating their covariance; most distances need nothing.
+	Fit(trainingData dataset.Dataset) error
+
+	// Between returns the distance between x and y if it is less than
+	// bailout.  Otherwise it may give up early and return any value which is
+	// at least bailout.
+	Between(x, y slice.Slice, bailout float64) float64
+}
+
+// CoordinatewiseDistance is implemented by float distances which never
+// decrease as the absolute difference in any one feature grows, so that the
+// distance to the nearest point of a box bounds the distance to anything
+// inside it.  KD-trees require such a distance.
+type CoordinatewiseDistance interface {
+	Distance
+	Coordinatewise()
+}
+
+// MetricDistance is implemented by distances which satisfy the triangle
+// inequality.  Ball trees require such a distance.
+type MetricDistance interface {
+	Distance
+	TriangleInequality()
+}
+
+// bailoutSlack allows for rounding when comparing partial sums of powers
+// against a power of the bailout, so that distances just under the bailout
+// are still computed exactly.
+const bailoutSlack = 1e-9
+
+type floatDistance struct{}
+
+func (floatDistance) FloatsOnly() bool {
+	return true
+}
+
+func (floatDistance) Fit(dataset.Dataset) error {
+	return nil
+}
+
+func floatValues(x, y slice.Slice) ([]float64, []float64) {
+	return x.(slice.FloatSlice).Values(), y.(slice.FloatSlice).Values()
+}
+
+type minkowskiDistance struct {
+	floatDistance
+	p float64
+}
+
+// EuclideanDistance is the straight-line distance between feature vectors.
+func EuclideanDistance() Distance {
+	return minkowskiDistance{p: 2}
+}
+
+// ManhattanDistance is the sum of absolute differences in each feature.
+func ManhattanDistance() Distance {
+	return minkowskiDistance{p: 1}
+}
+
+// MinkowskiDistance is the p-norm of the difference, for p >= 1.
+func MinkowskiDistance(p float64) (Distance, error) {
+	if !(p >= 1) || math.IsInf(p, 1) {
+		return nil, errors.New("p must be at least 1 and finite; use ChebyshevDistance for the limit")
+	}
+	return minkowskiDistance{p: p}, nil
+}
+
+func (minkowskiDistance) Coordinatewise() {}
+
+func (minkowskiDistance) TriangleInequality() {}
+
+func (d minkowskiDistance) Between(x, y slice.Slice, bailout float64) float64 {
+	xs, ys := floatValues(x, y)
+
+	// compare sums of p-th powers against the p-th power of the bailout,
+	// leaving room for rounding so as to only give up when certain
+	powerBailout := math.Pow(bailout, d.p) * (1 + bailoutSlack)
+	sum := 0.0
+	for i := range xs {
+		diff := math.Abs(xs[i] - ys[i])
+		switch d.p {
+		case 1:
+			sum = sum + diff
+		case 2:
+			sum = sum + diff*diff
+		default:
+			sum = sum + math.Pow(diff, d.p)
+		}
+
+		if sum > powerBailout {
+			return bailout
+		}
+	}
+
+	var distance float64
+	switch d.p {
+	case 1:
+		distance = sum
+	case 2:
+		distance = math.Sqrt(sum)
+	default:
+		distance = math.Pow(sum, 1/d.p)
+	}
+	return math.Min(distance, bailout)
+}
+
+type chebyshevDistance struct {
+	floatDistance
+}
+
+// ChebyshevDistance is the largest absolute difference in any feature.
+func ChebyshevDistance() Distance {
+	return chebyshevDistance{}
+}
+
+func (chebyshevDistance) Coordinatewise() {}
+
+func (chebyshevDistance) TriangleInequality() {}
+
+func (chebyshevDistance) Between(x, y slice.Slice, bailout float64) float64 {
+	xs, ys := floatValues(x, y)
+
+	max := 0.0
+	for i := range xs {
+		max = math.Max(max, math.Abs(xs[i]-ys[i]))
+		if max >= bailout {
+			return bailout
+		}
+	}
+	return max
+}
+
+type cosineDistance struct {
+	floatDistance
+}
+
+// CosineDistance is one minus the cosine of the angle between the feature
+// vectors; it is 1 if either vector is zero.  It never bails out early.
+func CosineDistance() Distance {
+	return cosineDistance{}
+}
+
+func (cosineDistance) Between(x, y slice.Slice, bailout float64) float64 {
+	xs, ys := floatValues(x, y)
+
+	dot, xNorm, yNorm := 0.0, 0.0, 0.0
+	for i := range xs {
+		dot = dot + xs[i]*ys[i]
+		xNorm = xNorm + xs[i]*xs[i]
+		yNorm = yNorm + ys[i]*ys[i]
+	}
+
+	if xNorm == 0 || yNorm == 0 {
+		return 1
+	}
+	return 1 - dot/math.Sqrt(xNorm*yNorm)
+}
+
+type mahalanobisDistance struct {
+	floatDistance
+	ridge    float64
+	cholesky [][]float64
+}
+
+// MahalanobisDistance is the Euclidean distance after whitening by the
+// covariance of the training features, to whose diagonal ridge is added
+// before it is factored so that collinear features can be handled.
+func MahalanobisDistance(ridge float64) (Distance, error) {
+	if ridge < 0 {
+		return nil, errors.New("ridge cannot be negative")
+	}
+	return &mahalanobisDistance{ridge: ridge}, nil
+}
+
+func (d *mahalanobisDistance) Fit(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return knnerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	rows := make([][]float64, trainingData.NumRows())
+	for i := range rows {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return err
+		}
+		rows[i] = r.Features().(slice.FloatSlice).Values()
+	}
+
+	_, covariance := matrixutilities.Covariance(rows)
+	for i := range covariance {
+		covariance[i][i] = covariance[i][i] + d.ridge
+	}
+
+	cholesky, err := matrixutilities.Cholesky(covariance)
+	if err != nil {
+		return err
+	}
+
+	d.cholesky = cholesky
+	return nil
+}
+
+func (*mahalanobisDistance) TriangleInequality() {}
+
+// Between whitens the difference by forward substitution with the Cholesky
+// factor L of the covariance; the squared distance is the sum of squares of
+// the whitened entries, which only grows as they are computed.
+func (d *mahalanobisDistance) Between(x, y slice.Slice, bailout float64) float64 {
+	xs, ys := floatValues(x, y)
+	l := d.cholesky
+
+	squaredBailout := bailout * bailout * (1 + bailoutSlack)
+	z := make([]float64, len(xs))
+	sum := 0.0
+	for i := range xs {
+		v := xs[i] - ys[i]
+		for k := 0; k < i; k++ {
+			v = v - l[i][k]*z[k]
+		}
+		z[i] = v / l[i][i]
+
+		sum = sum + z[i]*z[i]
+		if sum > squaredBailout {
+			return bailout
+		}
+	}
+	return math.Min(math.Sqrt(sum), bailout)
+}
+
+type hammingDistance struct{}
+
+// HammingDistance is the fraction of features whose values differ.  It
+// accepts float and mixed features.
+func HammingDistance() Distance {
+	return hammingDistance{}
+}
+
+func (hammingDistance) FloatsOnly() bool {
+	return false
+}
+
+func (hammingDistance) Fit(dataset.Dataset) error {
+	return nil
+}
+
+func (hammingDistance) TriangleInequality() {}
+
+func (hammingDistance) Between(x, y slice.Slice, bailout float64) float64 {
+	xs, ys := slice.Entries(x), slice.Entries(y)
+	if len(xs) == 0 {
+		return 0
+	}
+
+	n := float64(len(xs))
+	differences := 0.0
+	for i := range xs {
+		if xs[i] != ys[i] {
+			differences++
+			if differences/n >= bailout {
+				return bailout
+			}
+		}
+	}
+	return differences / n
+}
+
+type gowerDistance struct {
+	ranges []float64
+}
+
+// GowerDistance averages, over the features, the absolute difference of
+// numeric values scaled by that feature's range in the training data, and 0
+// or 1 according to whether string values match.  It accepts float and mixed
+// features.
+func GowerDistance() Distance {
+	return &gowerDistance{}
+}
+
+func (d *gowerDistance) FloatsOnly() bool {
+	return false
+}
+
+func (d *gowerDistance) Fit(trainingData dataset.Dataset) error {
+	numFeatures := trainingData.NumFeatures()
+	min := make([]float64, numFeatures)
+	max := make([]float64, numFeatures)
+	for j := range min {
+		min[j], max[j] = math.Inf(1), math.Inf(-1)
+	}
+
+	for i := 0; i < trainingData.NumRows(); i++ {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		for j, entry := range slice.Entries(r.Features()) {
+			if v, ok := entry.(float64); ok {
+				min[j] = math.Min(min[j], v)
+				max[j] = math.Max(max[j], v)
+			}
+		}
+	}
+
+	d.ranges = make([]float64, numFeatures)
+	for j := range d.ranges {
+		if max[j] > min[j] {
+			d.ranges[j] = max[j] - min[j]
+		}
+	}
+
+	return nil
+}
+
+func (*gowerDistance) TriangleInequality() {}
+
+func (d *gowerDistance) Between(x, y slice.Slice, bailout float64) float64 {
+	xs, ys := slice.Entries(x), slice.Entries(y)
+	if len(xs) == 0 {
+		return 0
+	}
+
+	n := float64(len(xs))
+	sum := 0.0
+	for i := range xs {
+		xFloat, xIsFloat := xs[i].(float64)
+		yFloat, yIsFloat := ys[i].(float64)
+
+		switch {
+		case xIsFloat && yIsFloat:
+			if i < len(d.ranges) && d.ranges[i] > 0 {
+				sum = sum + math.Min(math.Abs(xFloat-yFloat)/d.ranges[i], 1)
+			} else if xFloat != yFloat {
+				sum = sum + 1
+			}
+		case xs[i] != ys[i]:
+			sum = sum + 1
+		}
+
+		if sum/n >= bailout {
+			return bailout
+		}
+	}
+	return sum / n
+}
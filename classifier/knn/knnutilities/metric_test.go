@@ -0,0 +1,148 @@
+package knnutilities_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/knnerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Distances", func() {
+	x := slice.NewFloatSlice([]float64{1, 2, -3})
+	y := slice.NewFloatSlice([]float64{-5, 6, 2})
+
+	floatDataset := func(rows [][]string) dataset.Dataset {
+		columnTypes, err := columntype.StringsToColumnTypes(rows[0])
+		Ω(err).ShouldNot(HaveOccurred())
+
+		numColumns := len(rows[0])
+		featureColumnIndices := make([]int, numColumns-1)
+		for i := range featureColumnIndices {
+			featureColumnIndices[i] = i
+		}
+
+		ds := dataset.NewDataset(featureColumnIndices, []int{numColumns - 1}, columnTypes)
+		for _, r := range rows {
+			Ω(ds.AddRowFromStrings(r)).Should(Succeed())
+		}
+		return ds
+	}
+
+	features := func(ds dataset.Dataset, i int) slice.Slice {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+		return r.Features()
+	}
+
+	Describe("Minkowski family", func() {
+		It("Computes Euclidean, Manhattan, Chebyshev and Minkowski distances", func() {
+			Ω(knnutilities.EuclideanDistance().Between(x, y, math.MaxFloat64)).Should(BeNumerically("~", math.Sqrt(77), 1e-12))
+			Ω(knnutilities.ManhattanDistance().Between(x, y, math.MaxFloat64)).Should(BeNumerically("~", 15, 1e-12))
+			Ω(knnutilities.ChebyshevDistance().Between(x, y, math.MaxFloat64)).Should(BeNumerically("~", 6, 1e-12))
+
+			minkowski, err := knnutilities.MinkowskiDistance(3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(minkowski.Between(x, y, math.MaxFloat64)).Should(BeNumerically("~", math.Cbrt(216+64+125), 1e-12))
+		})
+
+		It("Bails out once the distance reaches the bailout", func() {
+			Ω(knnutilities.EuclideanDistance().Between(x, y, 7)).Should(Equal(7.0))
+			Ω(knnutilities.ManhattanDistance().Between(x, y, 10)).Should(Equal(10.0))
+			Ω(knnutilities.ChebyshevDistance().Between(x, y, 5)).Should(Equal(5.0))
+		})
+
+		It("Only accepts finite p of at least 1", func() {
+			_, err := knnutilities.MinkowskiDistance(0.5)
+			Ω(err).Should(HaveOccurred())
+
+			_, err = knnutilities.MinkowskiDistance(math.Inf(1))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("CosineDistance", func() {
+		It("Is one minus the cosine similarity", func() {
+			d := knnutilities.CosineDistance()
+			Ω(d.Between(slice.NewFloatSlice([]float64{1, 0}), slice.NewFloatSlice([]float64{0, 3}), 0.1)).Should(BeNumerically("~", 1, 1e-12))
+			Ω(d.Between(slice.NewFloatSlice([]float64{1, 1}), slice.NewFloatSlice([]float64{2, 2}), 0.1)).Should(BeNumerically("~", 0, 1e-12))
+			Ω(d.Between(slice.NewFloatSlice([]float64{0, 0}), slice.NewFloatSlice([]float64{2, 2}), 0.1)).Should(Equal(1.0))
+		})
+	})
+
+	Describe("MahalanobisDistance", func() {
+		It("Whitens by the fitted covariance", func() {
+			ds := floatDataset([][]string{{"0", "0", "0"}, {"2", "0", "0"}, {"0", "4", "0"}, {"2", "4", "0"}})
+
+			d, err := knnutilities.MahalanobisDistance(0)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(d.Fit(ds)).Should(Succeed())
+
+			// the sample variances are 4/3 and 16/3
+			distance := d.Between(features(ds, 0), features(ds, 3), math.MaxFloat64)
+			Ω(distance).Should(BeNumerically("~", math.Sqrt(3+3), 1e-9))
+			Ω(d.Between(features(ds, 0), features(ds, 3), 1)).Should(Equal(1.0))
+		})
+
+		It("Needs a ridge to handle collinear features", func() {
+			ds := floatDataset([][]string{{"0", "0", "0"}, {"1", "1", "0"}, {"2", "2", "0"}})
+
+			d, err := knnutilities.MahalanobisDistance(0)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(d.Fit(ds)).ShouldNot(Succeed())
+
+			d, err = knnutilities.MahalanobisDistance(0.1)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(d.Fit(ds)).Should(Succeed())
+		})
+
+		It("Rejects a negative ridge and non-float features", func() {
+			_, err := knnutilities.MahalanobisDistance(-1)
+			Ω(err).Should(HaveOccurred())
+
+			d, err := knnutilities.MahalanobisDistance(0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			ds := floatDataset([][]string{{"a", "0", "0"}, {"b", "1", "0"}})
+			Ω(d.Fit(ds)).Should(BeAssignableToTypeOf(knnerrors.NonFloatFeaturesTrainingSetError{}))
+		})
+	})
+
+	Describe("Mixed-feature distances", func() {
+		var ds dataset.Dataset
+
+		BeforeEach(func() {
+			ds = floatDataset([][]string{
+				{"red", "0", "x", "0"},
+				{"blue", "10", "x", "0"},
+				{"red", "5", "y", "0"},
+			})
+		})
+
+		It("Computes the Hamming distance as the fraction of differing features", func() {
+			d := knnutilities.HammingDistance()
+			Ω(d.FloatsOnly()).Should(BeFalse())
+			Ω(d.Fit(ds)).Should(Succeed())
+
+			Ω(d.Between(features(ds, 0), features(ds, 1), math.MaxFloat64)).Should(BeNumerically("~", 2.0/3, 1e-12))
+			Ω(d.Between(features(ds, 0), features(ds, 0), math.MaxFloat64)).Should(Equal(0.0))
+			Ω(d.Between(x, y, math.MaxFloat64)).Should(Equal(1.0))
+		})
+
+		It("Computes the Gower distance with numeric features scaled by their ranges", func() {
+			d := knnutilities.GowerDistance()
+			Ω(d.FloatsOnly()).Should(BeFalse())
+			Ω(d.Fit(ds)).Should(Succeed())
+
+			Ω(d.Between(features(ds, 0), features(ds, 1), math.MaxFloat64)).Should(BeNumerically("~", 2.0/3, 1e-12))
+			Ω(d.Between(features(ds, 0), features(ds, 2), math.MaxFloat64)).Should(BeNumerically("~", 1.5/3, 1e-12))
+			Ω(d.Between(features(ds, 1), features(ds, 2), math.MaxFloat64)).Should(BeNumerically("~", 2.5/3, 1e-12))
+			Ω(d.Between(features(ds, 1), features(ds, 2), 0.5)).Should(Equal(0.5))
+		})
+	})
+})
@@ -0,0 +1,132 @@
+package knnutilities
+
+import (
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// NeighbourSearch finds the k training rows nearest to a query, with a
+// distance fitted to the training data and an index built over it, for kNN
+// classifiers and regressors alike.  Models validate rows themselves, so
+// that they can report their own errors.
+type NeighbourSearch struct {
+	k            int
+	distance     Distance
+	indexBuilder IndexBuilder
+	index        Index
+	trainingData dataset.Dataset
+}
+
+// NewNeighbourSearch returns an untrained search, which compares rows with
+// EuclideanDistance if distance is nil and scans every row if indexBuilder is
+// nil.
+func NewNeighbourSearch(k int, distance Distance, indexBuilder IndexBuilder) *NeighbourSearch {
+	if distance == nil {
+		distance = EuclideanDistance()
+	}
+
+	if indexBuilder == nil {
+		indexBuilder = BruteForceIndex()
+	}
+
+	return &NeighbourSearch{k: k, distance: distance, indexBuilder: indexBuilder}
+}
+
+// FloatsOnly reports whether the search's distance needs float features.
+func (search *NeighbourSearch) FloatsOnly() bool {
+	return search.distance.FloatsOnly()
+}
+
+// Train fits the distance to the training data and indexes its rows.
+func (search *NeighbourSearch) Train(trainingData dataset.Dataset) error {
+	err := search.distance.Fit(trainingData)
+	if err != nil {
+		return err
+	}
+
+	index, err := search.indexBuilder(trainingData, search.distance)
+	if err != nil {
+		return err
+	}
+
+	search.index = index
+	search.trainingData = trainingData
+	return nil
+}
+
+// Add indexes more training rows once the search is trained, numbering them
+// after the rows already indexed, without refitting the distance, and appends
+// them to the search's training data.  An InsertableIndex is extended; any
+// other index is rebuilt over every row.  Add must not be called concurrently
+// with Nearest.
+func (search *NeighbourSearch) Add(rows dataset.Dataset) error {
+	trainingData, err := appendRows(search.trainingData, rows)
+	if err != nil {
+		return err
+	}
+
+	if index, ok := search.index.(InsertableIndex); ok {
+		for i := 0; i < rows.NumRows(); i++ {
+			r, err := rows.Row(i)
+			if err != nil {
+				return err
+			}
+			index.Insert(r.Features(), r.Target())
+		}
+
+		search.trainingData = trainingData
+		return nil
+	}
+
+	index, err := search.indexBuilder(trainingData, search.distance)
+	if err != nil {
+		return err
+	}
+
+	search.index = index
+	search.trainingData = trainingData
+	return nil
+}
+
+// appendRows returns a dataset of the rows of ds followed by those of rows,
+// keeping the rows' weights if either is a dataset.WeightedDataset.
+func appendRows(ds, rows dataset.Dataset) (dataset.Dataset, error) {
+	allRows := make([]row.Row, 0, ds.NumRows()+rows.NumRows())
+	for _, d := range []dataset.Dataset{ds, rows} {
+		for i := 0; i < d.NumRows(); i++ {
+			r, err := d.Row(i)
+			if err != nil {
+				return nil, err
+			}
+			allRows = append(allRows, r)
+		}
+	}
+
+	combined := dataset.NewDatasetFromRows(ds.NumFeatures(), ds.NumTargets(), allRows)
+
+	_, weighted := ds.(dataset.WeightedDataset)
+	_, rowsWeighted := rows.(dataset.WeightedDataset)
+	if !weighted && !rowsWeighted {
+		return combined, nil
+	}
+
+	return dataset.NewWeightedDataset(combined, append(dataset.Weights(ds), dataset.Weights(rows)...))
+}
+
+// TrainingData returns every row indexed so far, those of Train followed by
+// those of each Add in turn, in the order of the indices Nearest returns.
+func (search *NeighbourSearch) TrainingData() dataset.Dataset {
+	return search.trainingData
+}
+
+func (search *NeighbourSearch) Trained() bool {
+	return search.index != nil
+}
+
+// Nearest returns the k nearest training rows to the query, nearest first,
+// with rows at equal distances ordered by index.  It is safe to call
+// concurrently once the search is trained.
+func (search *NeighbourSearch) Nearest(query slice.Slice) []Neighbour {
+	return search.index.Nearest(query, search.k)
+}
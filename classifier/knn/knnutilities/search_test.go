@@ -0,0 +1,119 @@
+package knnutilities_test
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NeighbourSearch", func() {
+	It("Defaults to a brute force Euclidean search", func() {
+		source := rand.New(rand.NewSource(1))
+		ds := randomFloatDataset(source, 100, 3, false)
+
+		search := knnutilities.NewNeighbourSearch(4, nil, nil)
+		Ω(search.FloatsOnly()).Should(BeTrue())
+		Ω(search.Trained()).Should(BeFalse())
+		Ω(search.Train(ds)).Should(Succeed())
+		Ω(search.Trained()).Should(BeTrue())
+
+		bruteForce := buildIndex(knnutilities.BruteForceIndex(), nil, ds, knnutilities.EuclideanDistance())
+		for i := 0; i < 10; i++ {
+			r, err := ds.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(search.Nearest(r.Features())).Should(Equal(bruteForce.Nearest(r.Features(), 4)))
+		}
+	})
+
+	It("Fits its distance and builds its index when trained", func() {
+		source := rand.New(rand.NewSource(1))
+		ds := mixedDataset(source, 30)
+
+		ballTree, err := knnutilities.BallTreeIndex(2)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		search := knnutilities.NewNeighbourSearch(1, knnutilities.GowerDistance(), ballTree)
+		Ω(search.FloatsOnly()).Should(BeFalse())
+		Ω(search.Train(ds)).Should(Succeed())
+
+		r, err := ds.Row(5)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(search.Nearest(r.Features())[0].Distance).Should(Equal(0.0))
+
+		kdTree, err := knnutilities.KDTreeIndex(2)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(knnutilities.NewNeighbourSearch(1, knnutilities.GowerDistance(), kdTree).Train(ds)).ShouldNot(Succeed())
+	})
+
+	It("Adds rows after training, extending or rebuilding its index", func() {
+		source := rand.New(rand.NewSource(1))
+		ds := randomFloatDataset(source, 100, 3, false)
+		first := dataset.NewSubset(ds, intRange(0, 60))
+		rest := dataset.NewSubset(ds, intRange(60, 100))
+
+		kdTree, err := knnutilities.KDTreeIndex(4)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		bruteForce := buildIndex(knnutilities.BruteForceIndex(), nil, ds, knnutilities.EuclideanDistance())
+		for _, builder := range []knnutilities.IndexBuilder{nil, kdTree} {
+			search := knnutilities.NewNeighbourSearch(4, nil, builder)
+			Ω(search.Train(first)).Should(Succeed())
+			Ω(search.Add(rest)).Should(Succeed())
+
+			for i := 50; i < 70; i++ {
+				r, err := ds.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(search.Nearest(r.Features())).Should(Equal(bruteForce.Nearest(r.Features(), 4)))
+			}
+		}
+	})
+
+	It("Keeps its training data in step with the indices of added rows", func() {
+		source := rand.New(rand.NewSource(1))
+		ds := randomFloatDataset(source, 100, 3, false)
+
+		weights := make([]float64, 100)
+		for i := range weights {
+			weights[i] = float64(i % 4)
+		}
+		weighted, err := dataset.NewWeightedDataset(ds, weights)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		hnsw, err := knnutilities.HNSWIndex(knnutilities.DefaultHNSWParameters())
+		Ω(err).ShouldNot(HaveOccurred())
+
+		for _, builder := range []knnutilities.IndexBuilder{nil, hnsw} {
+			search := knnutilities.NewNeighbourSearch(1, nil, builder)
+			Ω(search.Train(dataset.NewSubset(weighted, intRange(0, 60)))).Should(Succeed())
+			Ω(search.Add(dataset.NewSubset(weighted, intRange(60, 100)))).Should(Succeed())
+
+			trainingData := search.TrainingData()
+			Ω(trainingData.NumRows()).Should(Equal(100))
+			Ω(dataset.Weights(trainingData)).Should(Equal(weights))
+
+			for i := 55; i < 65; i++ {
+				r, err := ds.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				nearest := search.Nearest(r.Features())[0]
+				Ω(nearest.Distance).Should(Equal(0.0))
+
+				indexed, err := trainingData.Row(nearest.Index)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(indexed.Features().Equals(r.Features())).Should(BeTrue())
+			}
+		}
+	})
+})
+
+func intRange(start, end int) []int {
+	values := []int{}
+	for i := start; i < end; i++ {
+		values = append(values, i)
+	}
+	return values
+}
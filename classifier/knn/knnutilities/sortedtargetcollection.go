@@ -8,8 +8,10 @@ import (
 
 type SortedTargetCollection interface {
 	Insert(slice.Slice, float64)
+	InsertNeighbour(int, slice.Slice, float64)
 	MaxDistance() float64
 	Vote() slice.Slice
+	Neighbours() []Neighbour
 }
 
 type kNNTargetCollection struct {
@@ -18,6 +20,7 @@ type kNNTargetCollection struct {
 }
 
 type targetWithDistance struct {
+	index    int
 	target   slice.Slice
 	distance float64
 }
@@ -27,7 +30,13 @@ func NewKNNTargetCollection(k int) *kNNTargetCollection {
 }
 
 func (stc *kNNTargetCollection) Insert(target slice.Slice, distance float64) {
-	newTargetWithDistance := targetWithDistance{target, distance}
+	stc.InsertNeighbour(-1, target, distance)
+}
+
+// InsertNeighbour inserts the target of the index-th training row.  Targets
+// at equal distances are kept in the order they were inserted.
+func (stc *kNNTargetCollection) InsertNeighbour(index int, target slice.Slice, distance float64) {
+	newTargetWithDistance := targetWithDistance{index, target, distance}
 
 	for i, twd := range stc.targetCollection {
 		if distance < twd.distance {
@@ -52,24 +61,18 @@ func (stc *kNNTargetCollection) MaxDistance() float64 {
 	return stc.targetCollection[stc.k-1].distance
 }
 
+// Vote returns the most common target, breaking ties in favour of the target
+// of the nearest neighbour.
 func (stc *kNNTargetCollection) Vote() slice.Slice {
-	winner := stc.targetCollection[0].target
-	votesForWinner := 0
-
-	for i, candidate := range stc.targetCollection {
-		votesForCurrent := 0
-
-		for _, other := range stc.targetCollection[i:] {
-			if candidate.target.Equals(other.target) {
-				votesForCurrent++
-			}
-		}
+	return Vote(stc.Neighbours(), UniformWeights(), NearestFirst)
+}
 
-		if votesForCurrent > votesForWinner {
-			winner = candidate.target
-			votesForWinner = votesForCurrent
-		}
+// Neighbours returns the collected targets nearest first; Index is -1 for
+// targets inserted without one.
+func (stc *kNNTargetCollection) Neighbours() []Neighbour {
+	neighbours := make([]Neighbour, len(stc.targetCollection))
+	for i, twd := range stc.targetCollection {
+		neighbours[i] = Neighbour{twd.index, twd.target, twd.distance}
 	}
-
-	return winner
+	return neighbours
 }
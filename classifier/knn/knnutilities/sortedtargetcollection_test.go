@@ -147,4 +147,22 @@ var _ = Describe("SortedTargetCollection", func() {
 			})
 		})
 	})
+	Describe("Neighbours", func() {
+		It("Returns the collected targets nearest first, keeping insertion order for equal distances", func() {
+			stc := knnutilities.NewKNNTargetCollection(3)
+			target := slice.NewFloatSlice([]float64{1})
+
+			stc.InsertNeighbour(7, target, 2.0)
+			stc.InsertNeighbour(3, target, 1.0)
+			stc.InsertNeighbour(5, target, 2.0)
+			stc.Insert(target, 0.5)
+
+			neighbours := stc.Neighbours()
+			Ω(neighbours).Should(HaveLen(3))
+			Ω(neighbours[0].Index).Should(Equal(-1))
+			Ω(neighbours[1].Index).Should(Equal(3))
+			Ω(neighbours[2].Index).Should(Equal(7))
+			Ω(neighbours[2].Distance).Should(Equal(2.0))
+		})
+	})
 })
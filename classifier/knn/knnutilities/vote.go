@@ -0,0 +1,212 @@
+package knnutilities
+
+import (
+	"errors"
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+type Neighbour struct {
+	Index    int
+	Target   slice.Slice
+	Distance float64
+}
+
+// WeightFunction gives the weight of a neighbour's vote from its distance.
+type WeightFunction func(distance float64) float64
+
+func UniformWeights() WeightFunction {
+	return func(float64) float64 {
+		return 1
+	}
+}
+
+// InverseDistanceWeights weights each neighbour by 1 / distance.  If any
+// neighbours are at distance 0, only they vote, with equal weight.
+func InverseDistanceWeights() WeightFunction {
+	return func(distance float64) float64 {
+		return 1 / distance
+	}
+}
+
+// GaussianWeights weights each neighbour by exp(-distance^2 / (2 bandwidth^2)).
+func GaussianWeights(bandwidth float64) (WeightFunction, error) {
+	if bandwidth <= 0 || math.IsInf(bandwidth, 0) || math.IsNaN(bandwidth) {
+		return nil, errors.New("bandwidth must be positive and finite")
+	}
+
+	return func(distance float64) float64 {
+		return math.Exp(-distance * distance / (2 * bandwidth * bandwidth))
+	}, nil
+}
+
+type TieBreak int
+
+const (
+	// NearestFirst breaks ties in favour of the target whose nearest
+	// neighbour is nearest; neighbours at equal distances are ordered by
+	// insertion.
+	NearestFirst TieBreak = iota
+	// SmallestLabel breaks ties in favour of the smallest target, comparing
+	// entries in turn, numbers before strings.
+	SmallestLabel
+)
+
+// Weights returns the weight of each neighbour.  If any weights are
+// infinite, as with InverseDistanceWeights at distance 0, those neighbours
+// get weight 1 and the others 0; if every weight is 0, as may happen with
+// GaussianWeights far from the training data, every neighbour gets weight 1.
+func Weights(neighbours []Neighbour, weight WeightFunction) []float64 {
+	weights := make([]float64, len(neighbours))
+	total, exactMatches := 0.0, false
+	for i, n := range neighbours {
+		weights[i] = weight(n.Distance)
+		if math.IsInf(weights[i], 1) {
+			exactMatches = true
+		}
+		total = total + weights[i]
+	}
+
+	for i := range weights {
+		if exactMatches {
+			weights[i] = 0
+			if math.IsInf(weight(neighbours[i].Distance), 1) {
+				weights[i] = 1
+			}
+		} else if total == 0 {
+			weights[i] = 1
+		}
+	}
+
+	return weights
+}
+
+// SampleWeighted scales each neighbour's weight by the weight of its training
+// row, as given by dataset.Weights for the training data; rows beyond
+// sampleWeights have weight 1.  If every scaled weight is 0, the neighbours
+// keep their weights, so that there is still a vote.
+func SampleWeighted(neighbours []Neighbour, weights, sampleWeights []float64) []float64 {
+	scaled := make([]float64, len(weights))
+	total := 0.0
+	for i, n := range neighbours {
+		scaled[i] = weights[i]
+		if n.Index < len(sampleWeights) {
+			scaled[i] = scaled[i] * sampleWeights[n.Index]
+		}
+		total = total + scaled[i]
+	}
+
+	if total == 0 {
+		return weights
+	}
+	return scaled
+}
+
+// Distribution returns the distinct targets amongst the neighbours, ordered
+// by their nearest neighbour, and the normalized total weight of each, as
+// given by Weights.
+func Distribution(neighbours []Neighbour, weight WeightFunction) ([]slice.Slice, []float64) {
+	return WeightedDistribution(neighbours, Weights(neighbours, weight))
+}
+
+// WeightedDistribution is Distribution with the given weight for each
+// neighbour.
+func WeightedDistribution(neighbours []Neighbour, weights []float64) ([]slice.Slice, []float64) {
+	targets := []slice.Slice{}
+	distribution := []float64{}
+	total := 0.0
+	for i, n := range neighbours {
+		k := 0
+		for k < len(targets) && !targets[k].Equals(n.Target) {
+			k++
+		}
+		if k == len(targets) {
+			targets = append(targets, n.Target)
+			distribution = append(distribution, 0)
+		}
+
+		distribution[k] = distribution[k] + weights[i]
+		total = total + weights[i]
+	}
+
+	for k := range distribution {
+		distribution[k] = distribution[k] / total
+	}
+
+	return targets, distribution
+}
+
+// Vote returns the target with the greatest total weight amongst the
+// neighbours, or nil if there are none.
+func Vote(neighbours []Neighbour, weight WeightFunction, tieBreak TieBreak) slice.Slice {
+	return WeightedVote(neighbours, Weights(neighbours, weight), tieBreak)
+}
+
+// WeightedVote is Vote with the given weight for each neighbour.
+func WeightedVote(neighbours []Neighbour, weights []float64, tieBreak TieBreak) slice.Slice {
+	targets, distribution := WeightedDistribution(neighbours, weights)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	winner := 0
+	for k := 1; k < len(targets); k++ {
+		if distribution[k] > distribution[winner] {
+			winner = k
+		} else if distribution[k] == distribution[winner] &&
+			tieBreak == SmallestLabel &&
+			CompareTargets(targets[k], targets[winner]) < 0 {
+			winner = k
+		}
+	}
+
+	return targets[winner]
+}
+
+// CompareTargets orders targets entry by entry, numbers before strings,
+// returning -1, 0 or 1.
+func CompareTargets(a, b slice.Slice) int {
+	x, y := slice.Entries(a), slice.Entries(b)
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if c := compareEntries(x[i], y[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(x) < len(y):
+		return -1
+	case len(x) > len(y):
+		return 1
+	}
+	return 0
+}
+
+func compareEntries(a, b interface{}) int {
+	aFloat, aIsFloat := a.(float64)
+	bFloat, bIsFloat := b.(float64)
+
+	switch {
+	case aIsFloat && bIsFloat:
+		if aFloat < bFloat {
+			return -1
+		} else if aFloat > bFloat {
+			return 1
+		}
+		return 0
+	case aIsFloat:
+		return -1
+	case bIsFloat:
+		return 1
+	}
+
+	aString, _ := a.(string)
+	bString, _ := b.(string)
+	if aString < bString {
+		return -1
+	} else if aString > bString {
+		return 1
+	}
+	return 0
+}
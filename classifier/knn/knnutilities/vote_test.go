@@ -0,0 +1,150 @@
+package knnutilities_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Vote", func() {
+	var red, blue slice.Slice
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"x"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		redRaw, err := columnTypes[0].PersistRawFromString("red")
+		Ω(err).ShouldNot(HaveOccurred())
+		blueRaw, err := columnTypes[0].PersistRawFromString("blue")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		red, err = slice.SliceFromRawValues(false, []int{0}, columnTypes, []float64{redRaw})
+		Ω(err).ShouldNot(HaveOccurred())
+		blue, err = slice.SliceFromRawValues(false, []int{0}, columnTypes, []float64{blueRaw})
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Describe("Weight functions", func() {
+		It("Weights uniformly, by inverse distance, or by a Gaussian kernel", func() {
+			Ω(knnutilities.UniformWeights()(3)).Should(Equal(1.0))
+			Ω(knnutilities.InverseDistanceWeights()(4)).Should(Equal(0.25))
+
+			gaussian, err := knnutilities.GaussianWeights(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(gaussian(2)).Should(BeNumerically("~", math.Exp(-0.5), 1e-12))
+		})
+
+		It("Rejects a non-positive bandwidth", func() {
+			_, err := knnutilities.GaussianWeights(0)
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Weights", func() {
+		It("Weights each neighbour by its distance", func() {
+			neighbours := []knnutilities.Neighbour{{0, red, 1}, {1, blue, 2}, {2, blue, 4}}
+			Ω(knnutilities.Weights(neighbours, knnutilities.InverseDistanceWeights())).Should(Equal([]float64{1, 0.5, 0.25}))
+		})
+
+		It("Weights only exact matches when there are any", func() {
+			neighbours := []knnutilities.Neighbour{{0, red, 1}, {1, blue, 0}, {2, blue, 4}}
+			Ω(knnutilities.Weights(neighbours, knnutilities.InverseDistanceWeights())).Should(Equal([]float64{0, 1, 0}))
+		})
+	})
+
+	Describe("Distribution", func() {
+		It("Normalizes the total weight of each target, ordered by nearest neighbour", func() {
+			neighbours := []knnutilities.Neighbour{{0, red, 1}, {1, blue, 2}, {2, blue, 4}}
+
+			targets, distribution := knnutilities.Distribution(neighbours, knnutilities.InverseDistanceWeights())
+			Ω(targets).Should(HaveLen(2))
+			Ω(targets[0].Equals(red)).Should(BeTrue())
+			Ω(distribution[0]).Should(BeNumerically("~", 4.0/7, 1e-12))
+			Ω(distribution[1]).Should(BeNumerically("~", 3.0/7, 1e-12))
+		})
+
+		It("Gives exact matches all the weight under inverse distance weighting", func() {
+			neighbours := []knnutilities.Neighbour{{0, red, 0}, {1, blue, 0}, {2, blue, 1}}
+
+			_, distribution := knnutilities.Distribution(neighbours, knnutilities.InverseDistanceWeights())
+			Ω(distribution).Should(Equal([]float64{0.5, 0.5}))
+		})
+
+		It("Falls back to uniform weights when every weight is zero", func() {
+			gaussian, err := knnutilities.GaussianWeights(0.01)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			neighbours := []knnutilities.Neighbour{{0, red, 100}, {1, blue, 200}, {2, blue, 300}}
+			_, distribution := knnutilities.Distribution(neighbours, gaussian)
+			Ω(distribution[1]).Should(BeNumerically("~", 2.0/3, 1e-12))
+		})
+	})
+
+	Describe("Vote", func() {
+		var tied []knnutilities.Neighbour
+
+		BeforeEach(func() {
+			tied = []knnutilities.Neighbour{{0, red, 1}, {1, blue, 2}}
+		})
+
+		It("Returns nil without neighbours", func() {
+			Ω(knnutilities.Vote(nil, knnutilities.UniformWeights(), knnutilities.NearestFirst)).Should(BeNil())
+		})
+
+		It("Breaks ties in favour of the nearest neighbour", func() {
+			winner := knnutilities.Vote(tied, knnutilities.UniformWeights(), knnutilities.NearestFirst)
+			Ω(winner.Equals(red)).Should(BeTrue())
+		})
+
+		It("Breaks ties in favour of the smallest label", func() {
+			winner := knnutilities.Vote(tied, knnutilities.UniformWeights(), knnutilities.SmallestLabel)
+			Ω(winner.Equals(blue)).Should(BeTrue())
+		})
+
+		It("Lets nearer neighbours outvote more numerous ones", func() {
+			neighbours := []knnutilities.Neighbour{{0, red, 0.1}, {1, blue, 2}, {2, blue, 2}}
+			winner := knnutilities.Vote(neighbours, knnutilities.InverseDistanceWeights(), knnutilities.NearestFirst)
+			Ω(winner.Equals(red)).Should(BeTrue())
+		})
+	})
+
+	Describe("SampleWeighted", func() {
+		var neighbours []knnutilities.Neighbour
+
+		BeforeEach(func() {
+			neighbours = []knnutilities.Neighbour{{2, red, 1}, {0, blue, 2}, {5, blue, 3}}
+		})
+
+		It("Scales each neighbour's weight by its row's weight", func() {
+			weights := knnutilities.SampleWeighted(neighbours, []float64{1, 0.5, 1}, []float64{4, 0, 3, 0, 0, 1})
+			Ω(weights).Should(Equal([]float64{3, 2, 1}))
+		})
+
+		It("Keeps the weights when every scaled weight is zero", func() {
+			weights := knnutilities.SampleWeighted(neighbours, []float64{1, 0.5, 1}, []float64{0, 0, 0, 0, 0, 0})
+			Ω(weights).Should(Equal([]float64{1, 0.5, 1}))
+		})
+
+		It("Lets heavier rows outvote more numerous ones", func() {
+			weights := knnutilities.SampleWeighted(neighbours, []float64{1, 1, 1}, []float64{1, 0, 3, 0, 0, 1})
+			winner := knnutilities.WeightedVote(neighbours, weights, knnutilities.NearestFirst)
+			Ω(winner.Equals(red)).Should(BeTrue())
+		})
+	})
+
+	Describe("CompareTargets", func() {
+		It("Orders numbers numerically, strings lexically, and numbers first", func() {
+			Ω(knnutilities.CompareTargets(blue, red)).Should(Equal(-1))
+			Ω(knnutilities.CompareTargets(red, red)).Should(Equal(0))
+
+			one, two := slice.NewFloatSlice([]float64{1}), slice.NewFloatSlice([]float64{2})
+			Ω(knnutilities.CompareTargets(two, one)).Should(Equal(1))
+			Ω(knnutilities.CompareTargets(two, blue)).Should(Equal(-1))
+		})
+	})
+})
@@ -0,0 +1,154 @@
+package mlp
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/mlperrors"
+	"github.com/amitkgupta/goodlearn/neuralnetwork"
+)
+
+// NewMLPClassifier trains a feed-forward network with a softmax output unit
+// per target on the cross-entropy.  If validationData is not nil its loss is
+// tracked every epoch, and used for early stopping when params.Patience is
+// positive.
+func NewMLPClassifier(
+	params neuralnetwork.Parameters,
+	validationData dataset.Dataset,
+	source rand.Source,
+) (*mlpClassifier, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mlpClassifier{
+		params:         params,
+		validationData: validationData,
+		source:         source,
+	}, nil
+}
+
+type mlpClassifier struct {
+	params         neuralnetwork.Parameters
+	validationData dataset.Dataset
+	source         rand.Source
+	targets        []slice.Slice
+	network        *neuralnetwork.Network
+}
+
+// Network returns the trained network, which reports the per-epoch training
+// and validation losses.
+func (c *mlpClassifier) Network() *neuralnetwork.Network {
+	return c.network
+}
+
+func (c *mlpClassifier) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return mlperrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if trainingData.NumRows() == 0 {
+		return mlperrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	x, y, err := oneHotRows(trainingData, targets)
+	if err != nil {
+		return err
+	}
+
+	var validationX, validationY [][]float64
+	if c.validationData != nil {
+		if !c.validationData.AllFeaturesFloats() {
+			return mlperrors.NewNonFloatFeaturesTrainingSetError()
+		}
+
+		validationX, validationY, err = oneHotRows(c.validationData, targets)
+		if err != nil {
+			return err
+		}
+	}
+
+	network, err := neuralnetwork.Train(x, y, validationX, validationY, neuralnetwork.SoftmaxOutput, c.params, c.source)
+	if err != nil {
+		return mlperrors.NewNetworkTrainingError(err)
+	}
+
+	c.targets = targets
+	c.network = network
+	return nil
+}
+
+func (c *mlpClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	_, probabilities, err := c.ClassProbabilities(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	best := 0
+	for k, p := range probabilities {
+		if p > probabilities[best] {
+			best = k
+		}
+	}
+
+	return c.targets[best], nil
+}
+
+func (c *mlpClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if c.network == nil {
+		return nil, nil, mlperrors.NewUntrainedClassifierError()
+	}
+
+	if testRow.NumFeatures() != c.network.NumInputs() {
+		return nil, nil, mlperrors.NewRowLengthMismatchError(testRow.NumFeatures(), c.network.NumInputs())
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, nil, mlperrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	probabilities, err := c.network.Outputs(testFeatures.Values())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.targets, probabilities, nil
+}
+
+// oneHotRows returns the float features of each row along with the one-hot
+// encoding of its target amongst targets.  Rows whose target is not in
+// targets are skipped.
+func oneHotRows(ds dataset.Dataset, targets []slice.Slice) ([][]float64, [][]float64, error) {
+	x := [][]float64{}
+	y := [][]float64{}
+
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		k := classifierutilities.TargetIndex(targets, r.Target())
+		if k < 0 {
+			continue
+		}
+
+		oneHot := make([]float64, len(targets))
+		oneHot[k] = 1
+
+		x = append(x, r.Features().(slice.FloatSlice).Values())
+		y = append(y, oneHot)
+	}
+
+	return x, y, nil
+}
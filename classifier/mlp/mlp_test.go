@@ -0,0 +1,120 @@
+package mlp_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/mlp"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/mlperrors"
+	"github.com/amitkgupta/goodlearn/errors/neuralnetworkerrors"
+	"github.com/amitkgupta/goodlearn/neuralnetwork"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MLPClassifier", func() {
+	var trainingData, validationData dataset.Dataset
+	var params neuralnetwork.Parameters
+
+	// quadrantDataset labels points by quadrant: "a" and "c" in opposite
+	// quadrants, "b" in the remaining two.
+	quadrantDataset := func(n int, seed int64) dataset.Dataset {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		random := rand.New(rand.NewSource(seed))
+		ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+		for i := 0; i < n; i++ {
+			x, y := 2*random.Float64()-1, 2*random.Float64()-1
+			label := "b"
+			if x > 0 && y > 0 {
+				label = "a"
+			} else if x < 0 && y < 0 {
+				label = "c"
+			}
+
+			err = ds.AddRowFromStrings([]string{label, fmt.Sprintf("%.6f", x), fmt.Sprintf("%.6f", y)})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		return ds
+	}
+
+	testRowAt := func(x, y float64) row.Row {
+		return row.NewRow(slice.NewFloatSlice([]float64{x, y}), nil, 2)
+	}
+
+	BeforeEach(func() {
+		trainingData = quadrantDataset(400, 1)
+		validationData = quadrantDataset(100, 2)
+
+		params = neuralnetwork.DefaultParameters()
+		params.HiddenLayers = []int{16}
+		params.LearningRate = 0.05
+		params.MaxEpochs = 100
+	})
+
+	It("Rejects invalid parameters", func() {
+		params.BatchSize = 0
+		_, err := mlp.NewMLPClassifier(params, nil, rand.NewSource(1))
+		Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.InvalidParameterError{}))
+	})
+
+	It("Returns an error before training", func() {
+		c, err := mlp.NewMLPClassifier(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = c.Classify(testRowAt(0, 0))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.UntrainedClassifierError{}))
+	})
+
+	It("Requires float features", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "x"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		c, err := mlp.NewMLPClassifier(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = c.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.NonFloatFeaturesTrainingSetError{}))
+	})
+
+	It("Classifies amongst several targets and reports probabilities", func() {
+		c, err := mlp.NewMLPClassifier(params, validationData, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(trainingData)).Should(Succeed())
+
+		correct := 0
+		for i := 0; i < validationData.NumRows(); i++ {
+			r, err := validationData.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			target, err := c.Classify(r)
+			Ω(err).ShouldNot(HaveOccurred())
+			if target.Equals(r.Target()) {
+				correct++
+			}
+		}
+		Ω(correct).Should(BeNumerically(">=", 90))
+
+		targets, probabilities, err := c.ClassProbabilities(testRowAt(0.5, 0.5))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets).Should(HaveLen(3))
+		Ω(probabilities).Should(HaveLen(3))
+
+		Ω(c.Network().ValidationLosses()).Should(HaveLen(params.MaxEpochs))
+	})
+
+	It("Returns an error for rows of the wrong length", func() {
+		c, err := mlp.NewMLPClassifier(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(trainingData)).Should(Succeed())
+
+		_, err = c.Classify(row.NewRow(slice.NewFloatSlice([]float64{0}), nil, 1))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.RowLengthMismatchError{}))
+	})
+})
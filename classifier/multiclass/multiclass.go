@@ -0,0 +1,183 @@
+package multiclass
+
+import (
+	"context"
+
+	"github.com/amitkgupta/goodlearn/batchutilities"
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multiclasserrors"
+)
+
+// Factory returns a new, untrained binary classifier.  The binary classifiers
+// are trained on rows whose targets are the float 1 for the positive class
+// and 0 for the negative one.
+type Factory = classifier.Factory
+
+type Option func(*parameters)
+
+// Workers sets how many binary classifiers are trained at once, or one per
+// CPU if workers is 0, which is the default.
+func Workers(workers int) Option {
+	return func(p *parameters) {
+		p.workers = workers
+	}
+}
+
+type parameters struct {
+	workers int
+}
+
+func newParameters(options []Option) (parameters, error) {
+	p := parameters{}
+	for _, option := range options {
+		option(&p)
+	}
+
+	if p.workers < 0 {
+		return parameters{}, multiclasserrors.NewInvalidNumberOfWorkersError(p.workers)
+	}
+
+	return p, nil
+}
+
+var (
+	positive = slice.NewFloatSlice([]float64{1})
+	negative = slice.NewFloatSlice([]float64{0})
+)
+
+// distinctTargets returns the distinct targets of the training data, in the
+// order they first appear, and the index of each row's target amongst them.
+func distinctTargets(trainingData dataset.Dataset) ([]slice.Slice, []int, error) {
+	if trainingData.NumRows() == 0 {
+		return nil, nil, multiclasserrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(targets) < 2 {
+		return nil, nil, multiclasserrors.NewTooFewTargetsError(len(targets))
+	}
+
+	labels := make([]int, trainingData.NumRows())
+	for i := range labels {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		labels[i] = classifierutilities.TargetIndex(targets, r.Target())
+	}
+
+	return targets, labels, nil
+}
+
+// binaryDataset returns the rows of the training data whose label is
+// accepted, with targets relabelled as positive or negative, keeping their
+// weights.
+func binaryDataset(trainingData dataset.Dataset, labels []int, isPositive func(int) bool, accept func(int) bool) (dataset.Dataset, error) {
+	trainingWeights := dataset.Weights(trainingData)
+	rows := []row.Row{}
+	weights := []float64{}
+	for i, label := range labels {
+		if !accept(label) {
+			continue
+		}
+
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return nil, err
+		}
+
+		target := negative
+		if isPositive(label) {
+			target = positive
+		}
+		rows = append(rows, row.NewRow(r.Features(), target, r.NumFeatures()))
+		weights = append(weights, trainingWeights[i])
+	}
+
+	return dataset.NewWeightedDataset(dataset.NewDatasetFromRows(trainingData.NumFeatures(), 1, rows), weights)
+}
+
+// trainAll builds and trains a binary classifier on each dataset, from the
+// given number of concurrent workers.
+func trainAll(factory Factory, datasets []dataset.Dataset, workers int) ([]classifier.Classifier, error) {
+	classifiers := make([]classifier.Classifier, len(datasets))
+
+	errs, err := batchutilities.Run(context.Background(), len(datasets), workers, func(i int) error {
+		c, err := factory()
+		if err != nil {
+			return multiclasserrors.NewBinaryClassifierConstructionError(err)
+		}
+
+		err = c.Train(datasets[i])
+		if err != nil {
+			return multiclasserrors.NewBinaryClassifierTrainingError(err)
+		}
+
+		classifiers[i] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return classifiers, nil
+}
+
+// binaryScore is how strongly a binary classifier favours the positive class
+// for a row: the probability it assigns if it is a
+// classifier.ProbabilisticClassifier, else the score it gives if it is a
+// classifier.ScoringClassifier, else 1 or 0 according to its classification.
+type binaryScore struct {
+	score           float64
+	probability     bool
+	favoursPositive bool
+}
+
+func positiveScore(c classifier.Classifier, testRow row.Row) (binaryScore, error) {
+	var targets []slice.Slice
+	var scores []float64
+	var err error
+	threshold := 0.0
+	probability := false
+
+	switch scorer := c.(type) {
+	case classifier.ProbabilisticClassifier:
+		targets, scores, err = scorer.ClassProbabilities(testRow)
+		threshold, probability = 0.5, true
+	case classifier.ScoringClassifier:
+		targets, scores, err = scorer.DecisionFunction(testRow)
+	default:
+		var target slice.Slice
+		target, err = c.Classify(testRow)
+		if err == nil {
+			targets, scores, threshold = []slice.Slice{target}, []float64{1}, 0.5
+			if !target.Equals(positive) {
+				scores[0] = 0
+			}
+		}
+	}
+	if err != nil {
+		return binaryScore{}, multiclasserrors.NewBinaryClassificationError(err)
+	}
+
+	score := 0.0
+	if k := classifierutilities.TargetIndex(targets, positive); k >= 0 {
+		score = scores[k]
+	}
+
+	return binaryScore{score, probability, score > threshold}, nil
+}
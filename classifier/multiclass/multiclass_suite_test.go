@@ -0,0 +1,13 @@
+package multiclass_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMulticlass(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Multiclass Suite")
+}
@@ -0,0 +1,249 @@
+package multiclass_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/multiclass"
+	"github.com/amitkgupta/goodlearn/classifier/svm"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multiclasserrors"
+	"github.com/amitkgupta/goodlearn/regressor/logistic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// blobsDataset has 30 rows about each of the given centres, labelled by the
+// corresponding label.
+func blobsDataset(labels []string, centres [][2]float64) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(3))
+	ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+	for i := 0; i < 30; i++ {
+		for b, label := range labels {
+			err = ds.AddRowFromStrings([]string{
+				label,
+				fmt.Sprintf("%.6f", centres[b][0]+0.5*random.NormFloat64()),
+				fmt.Sprintf("%.6f", centres[b][1]+0.5*random.NormFloat64()),
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	}
+	return ds
+}
+
+func threeBlobs() dataset.Dataset {
+	return blobsDataset([]string{"red", "green", "blue"}, [][2]float64{{0, 0}, {5, 0}, {0, 5}})
+}
+
+func trainingAccuracy(c classifier.Classifier, ds dataset.Dataset) float64 {
+	correct := 0
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		target, err := c.Classify(r)
+		Ω(err).ShouldNot(HaveOccurred())
+		if target.Equals(r.Target()) {
+			correct++
+		}
+	}
+	return float64(correct) / float64(ds.NumRows())
+}
+
+// nearestMeanClassifier is a binary classifier which only classifies, as
+// the target whose training rows have the nearest mean.
+type nearestMeanClassifier struct {
+	targets []slice.Slice
+	means   [][]float64
+}
+
+func (c *nearestMeanClassifier) Train(ds dataset.Dataset) error {
+	counts := []float64{}
+	for i := 0; i < ds.NumRows(); i++ {
+		r, _ := ds.Row(i)
+		k := 0
+		for k < len(c.targets) && !c.targets[k].Equals(r.Target()) {
+			k++
+		}
+		if k == len(c.targets) {
+			c.targets = append(c.targets, r.Target())
+			c.means = append(c.means, make([]float64, ds.NumFeatures()))
+			counts = append(counts, 0)
+		}
+
+		counts[k]++
+		for j, v := range r.Features().(slice.FloatSlice).Values() {
+			c.means[k][j] = c.means[k][j] + (v-c.means[k][j])/counts[k]
+		}
+	}
+	return nil
+}
+
+func (c *nearestMeanClassifier) Classify(r row.Row) (slice.Slice, error) {
+	best, bestDistance := 0, -1.0
+	for k, mean := range c.means {
+		distance := 0.0
+		for j, v := range r.Features().(slice.FloatSlice).Values() {
+			distance = distance + (v-mean[j])*(v-mean[j])
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = k, distance
+		}
+	}
+	return c.targets[best], nil
+}
+
+// weightRecordingClassifier records the row weights of the datasets its
+// classifiers are trained on.
+type weightRecordingClassifier struct {
+	nearestMeanClassifier
+	mutex   *sync.Mutex
+	weights *[][]float64
+}
+
+func (c *weightRecordingClassifier) Train(ds dataset.Dataset) error {
+	c.mutex.Lock()
+	*c.weights = append(*c.weights, dataset.Weights(ds))
+	c.mutex.Unlock()
+
+	return c.nearestMeanClassifier.Train(ds)
+}
+
+var factories = map[string]multiclass.Factory{
+	"probabilistic": func() (classifier.Classifier, error) {
+		return logistic.NewLogisticRegression(logistic.Precision(1e-4))
+	},
+	"scoring": func() (classifier.Classifier, error) {
+		return svm.NewLinearSVM()
+	},
+	"hard": func() (classifier.Classifier, error) {
+		return &nearestMeanClassifier{}, nil
+	},
+}
+
+var _ = Describe("Multiclass wrappers", func() {
+	wrappers := map[string]func(multiclass.Factory, ...multiclass.Option) (classifier.Classifier, error){
+		"one-vs-rest": func(f multiclass.Factory, options ...multiclass.Option) (classifier.Classifier, error) {
+			return multiclass.NewOneVsRestClassifier(f, options...)
+		},
+		"one-vs-one": func(f multiclass.Factory, options ...multiclass.Option) (classifier.Classifier, error) {
+			return multiclass.NewOneVsOneClassifier(f, options...)
+		},
+	}
+
+	for wrapperName, wrapper := range wrappers {
+		wrapperName, wrapper := wrapperName, wrapper
+
+		Describe(wrapperName, func() {
+			for factoryName, factory := range factories {
+				factoryName, factory := factoryName, factory
+
+				It(fmt.Sprintf("Separates three classes with %s binary classifiers", factoryName), func() {
+					c, err := wrapper(factory)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					ds := threeBlobs()
+					Ω(c.Train(ds)).Should(Succeed())
+					Ω(trainingAccuracy(c, ds)).Should(BeNumerically(">", 0.95))
+				})
+			}
+
+			It("Trains the same with one worker as with many", func() {
+				ds := threeBlobs()
+
+				serial, err := wrapper(factories["scoring"], multiclass.Workers(1))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(serial.Train(ds)).Should(Succeed())
+
+				concurrent, err := wrapper(factories["scoring"], multiclass.Workers(4))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(concurrent.Train(ds)).Should(Succeed())
+
+				for i := 0; i < ds.NumRows(); i++ {
+					r, err := ds.Row(i)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					expected, err := serial.Classify(r)
+					Ω(err).ShouldNot(HaveOccurred())
+					actual, err := concurrent.Classify(r)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(actual.Equals(expected)).Should(BeTrue())
+				}
+			})
+
+			It("Keeps the training rows' weights for the binary classifiers", func() {
+				mutex, weights := &sync.Mutex{}, [][]float64{}
+				c, err := wrapper(func() (classifier.Classifier, error) {
+					return &weightRecordingClassifier{mutex: mutex, weights: &weights}, nil
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				ds := threeBlobs()
+				rowWeights := make([]float64, ds.NumRows())
+				for i := range rowWeights {
+					rowWeights[i] = 2.5
+				}
+				weighted, err := dataset.NewWeightedDataset(ds, rowWeights)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(c.Train(weighted)).Should(Succeed())
+				Ω(weights).Should(HaveLen(3))
+				for _, binaryWeights := range weights {
+					Ω(binaryWeights).ShouldNot(BeEmpty())
+					for _, w := range binaryWeights {
+						Ω(w).Should(Equal(2.5))
+					}
+				}
+			})
+
+			It("Rejects a negative number of workers", func() {
+				_, err := wrapper(factories["hard"], multiclass.Workers(-1))
+				Ω(err).Should(BeAssignableToTypeOf(multiclasserrors.InvalidNumberOfWorkersError{}))
+			})
+
+			It("Needs at least two targets", func() {
+				c, err := wrapper(factories["hard"])
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = c.Train(blobsDataset([]string{"red"}, [][2]float64{{0, 0}}))
+				Ω(err).Should(BeAssignableToTypeOf(multiclasserrors.TooFewTargetsError{}))
+			})
+
+			It("Refuses to classify before training", func() {
+				c, err := wrapper(factories["hard"])
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, err = c.Classify(row.NewRow(slice.NewFloatSlice([]float64{0, 0}), nil, 2))
+				Ω(err).Should(BeAssignableToTypeOf(multiclasserrors.UntrainedClassifierError{}))
+			})
+
+			It("Reports binary classifiers which cannot be built or trained", func() {
+				c, err := wrapper(func() (classifier.Classifier, error) {
+					return nil, errors.New("no classifier")
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(c.Train(threeBlobs())).Should(BeAssignableToTypeOf(multiclasserrors.BinaryClassifierConstructionError{}))
+
+				c, err = wrapper(factories["scoring"])
+				Ω(err).ShouldNot(HaveOccurred())
+
+				columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "a"})
+				Ω(err).ShouldNot(HaveOccurred())
+				ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+				Ω(ds.AddRowFromStrings([]string{"x", "y"})).Should(Succeed())
+				Ω(ds.AddRowFromStrings([]string{"y", "x"})).Should(Succeed())
+				Ω(c.Train(ds)).Should(BeAssignableToTypeOf(multiclasserrors.BinaryClassifierTrainingError{}))
+			})
+		})
+	}
+})
@@ -0,0 +1,118 @@
+package multiclass
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multiclasserrors"
+)
+
+// NewOneVsOneClassifier returns a classifier which trains one binary
+// classifier for each pair of targets, on the rows with either target, and
+// classifies a row as the target winning the most pairwise votes.  Ties are
+// broken in favour of the target with the greatest total probability over
+// its pairs, for probabilistic binary classifiers, and then in favour of the
+// target appearing first in the training data.
+func NewOneVsOneClassifier(factory Factory, options ...Option) (*oneVsOneClassifier, error) {
+	p, err := newParameters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oneVsOneClassifier{factory: factory, parameters: p}, nil
+}
+
+type oneVsOneClassifier struct {
+	factory     Factory
+	parameters  parameters
+	targets     []slice.Slice
+	pairs       [][2]int
+	classifiers []classifier.Classifier
+}
+
+func (ovo *oneVsOneClassifier) Train(trainingData dataset.Dataset) error {
+	targets, labels, err := distinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	pairs := [][2]int{}
+	datasets := []dataset.Dataset{}
+	for a := range targets {
+		for b := a + 1; b < len(targets); b++ {
+			a, b := a, b
+			ds, err := binaryDataset(
+				trainingData,
+				labels,
+				func(label int) bool { return label == a },
+				func(label int) bool { return label == a || label == b },
+			)
+			if err != nil {
+				return err
+			}
+
+			pairs = append(pairs, [2]int{a, b})
+			datasets = append(datasets, ds)
+		}
+	}
+
+	classifiers, err := trainAll(ovo.factory, datasets, ovo.parameters.workers)
+	if err != nil {
+		return err
+	}
+
+	ovo.targets = targets
+	ovo.pairs = pairs
+	ovo.classifiers = classifiers
+	return nil
+}
+
+func (ovo *oneVsOneClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, votes, confidences, err := ovo.Votes(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	best := 0
+	for k := range targets {
+		if votes[k] > votes[best] || (votes[k] == votes[best] && confidences[k] > confidences[best]) {
+			best = k
+		}
+	}
+
+	return targets[best], nil
+}
+
+// Votes returns each target with the number of pairwise votes it wins and
+// its total probability over its pairs, which is 0 unless the binary
+// classifiers are probabilistic.
+func (ovo *oneVsOneClassifier) Votes(testRow row.Row) ([]slice.Slice, []int, []float64, error) {
+	if ovo.classifiers == nil {
+		return nil, nil, nil, multiclasserrors.NewUntrainedClassifierError()
+	}
+
+	votes := make([]int, len(ovo.targets))
+	confidences := make([]float64, len(ovo.targets))
+	for p, c := range ovo.classifiers {
+		a, b := ovo.pairs[p][0], ovo.pairs[p][1]
+
+		binary, err := positiveScore(c, testRow)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if binary.probability {
+			confidences[a] = confidences[a] + binary.score
+			confidences[b] = confidences[b] + 1 - binary.score
+		}
+
+		if binary.favoursPositive {
+			votes[a]++
+		} else {
+			votes[b]++
+		}
+	}
+
+	return ovo.targets, votes, confidences, nil
+}
@@ -0,0 +1,38 @@
+package multiclass_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/multiclass"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OneVsOneClassifier", func() {
+	It("Votes with one binary classifier per pair of targets", func() {
+		c, err := multiclass.NewOneVsOneClassifier(factories["hard"])
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := blobsDataset([]string{"a", "b", "c", "d"}, [][2]float64{{0, 0}, {5, 0}, {0, 5}, {5, 5}})
+		Ω(c.Train(ds)).Should(Succeed())
+
+		targets, votes, confidences, err := c.Votes(row.NewRow(slice.NewFloatSlice([]float64{5, 5}), nil, 2))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets).Should(HaveLen(4))
+		Ω(votes).Should(Equal([]int{0, 1, 2, 3}))
+		Ω(confidences).Should(Equal([]float64{0, 0, 0, 0}))
+	})
+
+	It("Totals the probabilities of probabilistic binary classifiers", func() {
+		c, err := multiclass.NewOneVsOneClassifier(factories["probabilistic"])
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(c.Train(threeBlobs())).Should(Succeed())
+
+		_, votes, confidences, err := c.Votes(row.NewRow(slice.NewFloatSlice([]float64{0, 0}), nil, 2))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(votes[0]).Should(Equal(2))
+		Ω(confidences[0]).Should(BeNumerically(">", 1.8))
+		Ω(confidences[0] + confidences[1] + confidences[2]).Should(BeNumerically("~", 3, 1e-9))
+	})
+})
@@ -0,0 +1,101 @@
+package multiclass
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multiclasserrors"
+)
+
+// NewOneVsRestClassifier returns a classifier which trains one binary
+// classifier per target, separating it from all the others, and classifies a
+// row as the target whose binary classifier favours it most strongly.
+func NewOneVsRestClassifier(factory Factory, options ...Option) (*oneVsRestClassifier, error) {
+	p, err := newParameters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oneVsRestClassifier{factory: factory, parameters: p}, nil
+}
+
+type oneVsRestClassifier struct {
+	factory     Factory
+	parameters  parameters
+	targets     []slice.Slice
+	classifiers []classifier.Classifier
+}
+
+func (ovr *oneVsRestClassifier) Train(trainingData dataset.Dataset) error {
+	targets, labels, err := distinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	datasets := make([]dataset.Dataset, len(targets))
+	for k := range targets {
+		k := k
+		datasets[k], err = binaryDataset(
+			trainingData,
+			labels,
+			func(label int) bool { return label == k },
+			func(int) bool { return true },
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	classifiers, err := trainAll(ovr.factory, datasets, ovr.parameters.workers)
+	if err != nil {
+		return err
+	}
+
+	ovr.targets = targets
+	ovr.classifiers = classifiers
+	return nil
+}
+
+func (ovr *oneVsRestClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, scores, err := ovr.DecisionFunction(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	best := 0
+	for k, score := range scores {
+		if score > scores[best] {
+			best = k
+		}
+	}
+
+	return targets[best], nil
+}
+
+// DecisionFunction returns, for each target, how strongly its binary
+// classifier favours it: the probability it assigns to the target if it is
+// a classifier.ProbabilisticClassifier, else the score it gives if it is a
+// classifier.ScoringClassifier, else 1 or 0 according to its classification.
+func (ovr *oneVsRestClassifier) DecisionFunction(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if ovr.classifiers == nil {
+		return nil, nil, multiclasserrors.NewUntrainedClassifierError()
+	}
+
+	scores := make([]float64, len(ovr.classifiers))
+	for k, c := range ovr.classifiers {
+		binary, err := positiveScore(c, testRow)
+		if err != nil {
+			return nil, nil, err
+		}
+		scores[k] = binary.score
+	}
+
+	return ovr.targets, scores, nil
+}
+
+// BinaryClassifiers returns the binary classifier for each target, in the
+// same order as the targets returned by DecisionFunction.
+func (ovr *oneVsRestClassifier) BinaryClassifiers() []classifier.Classifier {
+	return ovr.classifiers
+}
@@ -0,0 +1,59 @@
+package multiclass_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/multiclass"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OneVsRestClassifier", func() {
+	It("Scores each target by its binary classifier's probability", func() {
+		c, err := multiclass.NewOneVsRestClassifier(factories["probabilistic"])
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := threeBlobs()
+		Ω(c.Train(ds)).Should(Succeed())
+		Ω(c.BinaryClassifiers()).Should(HaveLen(3))
+
+		targets, scores, err := c.DecisionFunction(row.NewRow(slice.NewFloatSlice([]float64{5, 0}), nil, 2))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets).Should(HaveLen(3))
+
+		r, err := ds.Row(1)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets[1].Equals(r.Target())).Should(BeTrue())
+
+		Ω(scores[1]).Should(BeNumerically(">", 0.9))
+		Ω(scores[0]).Should(BeNumerically("<", 0.1))
+		Ω(scores[2]).Should(BeNumerically("<", 0.1))
+	})
+
+	It("Arbitrates between binary classifiers which all decline a row by their scores", func() {
+		c, err := multiclass.NewOneVsRestClassifier(factories["scoring"])
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := threeBlobs()
+		Ω(c.Train(ds)).Should(Succeed())
+
+		// between the green and blue blobs but far from all three
+		testRow := row.NewRow(slice.NewFloatSlice([]float64{6, 5.5}), nil, 2)
+		_, scores, err := c.DecisionFunction(testRow)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		target, err := c.Classify(testRow)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		best := 0
+		for k := range scores {
+			if scores[k] > scores[best] {
+				best = k
+			}
+		}
+		r, err := ds.Row(best)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(target.Equals(r.Target())).Should(BeTrue())
+	})
+})
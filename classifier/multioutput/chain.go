@@ -0,0 +1,190 @@
+package multioutput
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multioutputerrors"
+)
+
+// NewClassifierChain returns a classifier which trains one classifier for
+// each target column, taking the target columns in the given order (or in
+// their own order if order is nil), with each classifier also given the
+// earlier targets in the chain as features: the true targets in training
+// and the predicted ones when classifying.  This lets it learn how the
+// targets depend on one another.  Numeric targets are given as features
+// unchanged and other targets one-hot encoded over the values seen in
+// training.
+func NewClassifierChain(factory classifier.Factory, order []int, options ...Option) (*classifierChain, error) {
+	p, err := newParameters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &classifierChain{factory: factory, order: order, parameters: p}, nil
+}
+
+type classifierChain struct {
+	factory      classifier.Factory
+	order        []int
+	parameters   parameters
+	trainedOrder []int
+	encoders     []targetEncoder
+	classifiers  []classifier.Classifier
+}
+
+func (chain *classifierChain) Train(trainingData dataset.Dataset) error {
+	if trainingData.NumRows() == 0 {
+		return multioutputerrors.NewEmptyTrainingDatasetError()
+	}
+
+	numTargets := trainingData.NumTargets()
+	order, err := chainOrder(chain.order, numTargets)
+	if err != nil {
+		return err
+	}
+
+	encoders, err := newTargetEncoders(trainingData)
+	if err != nil {
+		return err
+	}
+
+	datasets := make([]dataset.Dataset, numTargets)
+	for p, j := range order {
+		earlier := order[:p]
+		datasets[p], err = singleTargetDataset(trainingData, j, func(r row.Row) []float64 {
+			return encodeTargets(encoders, earlier, slice.Entries(r.Target()))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	classifiers, err := trainAll(chain.factory, datasets, order, chain.parameters.workers)
+	if err != nil {
+		return err
+	}
+
+	chain.trainedOrder = order
+	chain.encoders = encoders
+	chain.classifiers = classifiers
+	return nil
+}
+
+func (chain *classifierChain) Classify(testRow row.Row) (slice.Slice, error) {
+	if chain.classifiers == nil {
+		return nil, multioutputerrors.NewUntrainedClassifierError()
+	}
+
+	predictions := make([]interface{}, len(chain.trainedOrder))
+	columns := make([]slice.Slice, len(chain.trainedOrder))
+	for p, j := range chain.trainedOrder {
+		earlier := chain.trainedOrder[:p]
+		extra := encodeTargets(chain.encoders, earlier, predictions)
+		features := extendFeatures(testRow.Features(), extra)
+
+		column, err := chain.classifiers[p].Classify(row.NewRow(features, nil, testRow.NumFeatures()+len(extra)))
+		if err != nil {
+			return nil, multioutputerrors.NewClassificationError(j, err)
+		}
+
+		columns[j] = column
+		predictions[j] = slice.Entries(column)[0]
+	}
+
+	return joinColumns(columns), nil
+}
+
+// Classifiers returns the classifier for each target column, in the order of
+// the chain.
+func (chain *classifierChain) Classifiers() []classifier.Classifier {
+	return chain.classifiers
+}
+
+func chainOrder(order []int, numTargets int) ([]int, error) {
+	if order == nil {
+		order = make([]int, numTargets)
+		for j := range order {
+			order[j] = j
+		}
+		return order, nil
+	}
+
+	if len(order) != numTargets {
+		return nil, multioutputerrors.NewInvalidChainOrderError(order, numTargets)
+	}
+
+	seen := make([]bool, numTargets)
+	for _, j := range order {
+		if j < 0 || j >= numTargets || seen[j] {
+			return nil, multioutputerrors.NewInvalidChainOrderError(order, numTargets)
+		}
+		seen[j] = true
+	}
+
+	return order, nil
+}
+
+// targetEncoder turns values of a target column into features: numeric
+// columns give their values unchanged, others one-hot encode their values.
+type targetEncoder struct {
+	numeric bool
+	values  []interface{}
+}
+
+func newTargetEncoders(trainingData dataset.Dataset) ([]targetEncoder, error) {
+	encoders := make([]targetEncoder, trainingData.NumTargets())
+	for j := range encoders {
+		encoders[j].numeric = true
+	}
+
+	for i := 0; i < trainingData.NumRows(); i++ {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, v := range slice.Entries(r.Target()) {
+			if _, ok := v.(float64); !ok {
+				encoders[j].numeric = false
+			}
+			if encoders[j].index(v) < 0 {
+				encoders[j].values = append(encoders[j].values, v)
+			}
+		}
+	}
+
+	return encoders, nil
+}
+
+func (e targetEncoder) index(v interface{}) int {
+	for k, value := range e.values {
+		if value == v {
+			return k
+		}
+	}
+	return -1
+}
+
+func (e targetEncoder) encode(v interface{}) []float64 {
+	if e.numeric {
+		f, _ := v.(float64)
+		return []float64{f}
+	}
+
+	oneHot := make([]float64, len(e.values))
+	if k := e.index(v); k >= 0 {
+		oneHot[k] = 1
+	}
+	return oneHot
+}
+
+// encodeTargets returns the encoded values of the given target columns.
+func encodeTargets(encoders []targetEncoder, columns []int, values []interface{}) []float64 {
+	encoded := []float64{}
+	for _, j := range columns {
+		encoded = append(encoded, encoders[j].encode(values[j])...)
+	}
+	return encoded
+}
@@ -0,0 +1,44 @@
+package multioutput_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/multioutput"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multioutputerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Classifier chains", func() {
+	numFeatures := func(order []int) []int {
+		chain, err := multioutput.NewClassifierChain(recordingFactory, order)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(chain.Train(quadrantsDataset())).Should(Succeed())
+
+		_, err = chain.Classify(row.NewRow(slice.NewFloatSlice([]float64{0, 0}), nil, 2))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		counts := []int{}
+		for _, c := range chain.Classifiers() {
+			counts = append(counts, c.(*recordingClassifier).numFeatures)
+		}
+		return counts
+	}
+
+	It("Gives each classifier the earlier targets as features", func() {
+		// the colour target is one-hot encoded over red and blue
+		Ω(numFeatures(nil)).Should(Equal([]int{2, 4}))
+		Ω(numFeatures([]int{1, 0})).Should(Equal([]int{2, 3}))
+	})
+
+	It("Rejects orders which are not an ordering of the targets", func() {
+		for _, order := range [][]int{{}, {0}, {0, 0}, {0, 2}, {-1, 0}, {0, 1, 2}} {
+			chain, err := multioutput.NewClassifierChain(recordingFactory, order)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = chain.Train(quadrantsDataset())
+			Ω(err).Should(BeAssignableToTypeOf(multioutputerrors.InvalidChainOrderError{}))
+		}
+	})
+})
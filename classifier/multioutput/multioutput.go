@@ -0,0 +1,213 @@
+package multioutput
+
+import (
+	"context"
+
+	"github.com/amitkgupta/goodlearn/batchutilities"
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multioutputerrors"
+)
+
+type Option func(*parameters)
+
+// Workers sets how many of the per-target classifiers are trained at once,
+// or one per CPU if workers is 0, which is the default.
+func Workers(workers int) Option {
+	return func(p *parameters) {
+		p.workers = workers
+	}
+}
+
+type parameters struct {
+	workers int
+}
+
+func newParameters(options []Option) (parameters, error) {
+	p := parameters{}
+	for _, option := range options {
+		option(&p)
+	}
+
+	if p.workers < 0 {
+		return parameters{}, multioutputerrors.NewInvalidNumberOfWorkersError(p.workers)
+	}
+
+	return p, nil
+}
+
+// NewMultiOutputClassifier returns a classifier which trains one classifier
+// for each target column, independently of the others, and classifies a row
+// by classifying each of its targets in turn.  Unlike classifiers which
+// treat the targets as a single joint label, it only needs to have seen
+// each value of each target, rather than each combination of values.
+func NewMultiOutputClassifier(factory classifier.Factory, options ...Option) (*multiOutputClassifier, error) {
+	p, err := newParameters(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &multiOutputClassifier{factory: factory, parameters: p}, nil
+}
+
+type multiOutputClassifier struct {
+	factory     classifier.Factory
+	parameters  parameters
+	classifiers []classifier.Classifier
+}
+
+func (moc *multiOutputClassifier) Train(trainingData dataset.Dataset) error {
+	if trainingData.NumRows() == 0 {
+		return multioutputerrors.NewEmptyTrainingDatasetError()
+	}
+
+	numTargets := trainingData.NumTargets()
+	datasets := make([]dataset.Dataset, numTargets)
+	targetIndices := make([]int, numTargets)
+	for j := range datasets {
+		ds, err := singleTargetDataset(trainingData, j, nil)
+		if err != nil {
+			return err
+		}
+		datasets[j], targetIndices[j] = ds, j
+	}
+
+	classifiers, err := trainAll(moc.factory, datasets, targetIndices, moc.parameters.workers)
+	if err != nil {
+		return err
+	}
+
+	moc.classifiers = classifiers
+	return nil
+}
+
+func (moc *multiOutputClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	if moc.classifiers == nil {
+		return nil, multioutputerrors.NewUntrainedClassifierError()
+	}
+
+	columns := make([]slice.Slice, len(moc.classifiers))
+	for j, c := range moc.classifiers {
+		column, err := c.Classify(testRow)
+		if err != nil {
+			return nil, multioutputerrors.NewClassificationError(j, err)
+		}
+		columns[j] = column
+	}
+
+	return joinColumns(columns), nil
+}
+
+// Classifiers returns the classifier for each target column.
+func (moc *multiOutputClassifier) Classifiers() []classifier.Classifier {
+	return moc.classifiers
+}
+
+// targetColumn returns the j-th entry of the target as a slice of its own.
+func targetColumn(target slice.Slice, j int) slice.Slice {
+	return entrySlice([]interface{}{slice.Entries(target)[j]})
+}
+
+// joinColumns returns the single-entry slices predicted for each target
+// column as one target.
+func joinColumns(columns []slice.Slice) slice.Slice {
+	entries := make([]interface{}, len(columns))
+	for j, column := range columns {
+		entries[j] = slice.Entries(column)[0]
+	}
+	return entrySlice(entries)
+}
+
+// entrySlice returns a float slice if every entry is a float, and a mixed
+// slice otherwise.
+func entrySlice(entries []interface{}) slice.Slice {
+	values := make([]float64, len(entries))
+	for i, entry := range entries {
+		v, ok := entry.(float64)
+		if !ok {
+			mixed, _ := slice.NewMixedSlice(entries)
+			return mixed
+		}
+		values[i] = v
+	}
+	return slice.NewFloatSlice(values)
+}
+
+// singleTargetDataset returns the training data with only the j-th target
+// column, and with the features of each row extended by extraFeatures, if
+// it is not nil, keeping the rows' weights.
+func singleTargetDataset(
+	trainingData dataset.Dataset,
+	j int,
+	extraFeatures func(r row.Row) []float64,
+) (dataset.Dataset, error) {
+	numFeatures := trainingData.NumFeatures()
+	rows := make([]row.Row, trainingData.NumRows())
+
+	for i := range rows {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return nil, err
+		}
+
+		features := r.Features()
+		if extraFeatures != nil {
+			extra := extraFeatures(r)
+			features = extendFeatures(features, extra)
+			numFeatures = trainingData.NumFeatures() + len(extra)
+		}
+
+		rows[i] = row.NewRow(features, targetColumn(r.Target(), j), numFeatures)
+	}
+
+	return dataset.NewWeightedDataset(dataset.NewDatasetFromRows(numFeatures, 1, rows), dataset.Weights(trainingData))
+}
+
+func extendFeatures(features slice.Slice, extra []float64) slice.Slice {
+	if floatFeatures, ok := features.(slice.FloatSlice); ok {
+		values := append([]float64{}, floatFeatures.Values()...)
+		return slice.NewFloatSlice(append(values, extra...))
+	}
+
+	entries := slice.Entries(features)
+	for _, v := range extra {
+		entries = append(entries, v)
+	}
+	mixed, _ := slice.NewMixedSlice(entries)
+	return mixed
+}
+
+// trainAll builds and trains a classifier for each dataset, from the given
+// number of concurrent workers; targetIndices give the target column each
+// dataset is for, to report errors.
+func trainAll(factory classifier.Factory, datasets []dataset.Dataset, targetIndices []int, workers int) ([]classifier.Classifier, error) {
+	classifiers := make([]classifier.Classifier, len(datasets))
+
+	errs, err := batchutilities.Run(context.Background(), len(datasets), workers, func(i int) error {
+		c, err := factory()
+		if err != nil {
+			return multioutputerrors.NewClassifierConstructionError(targetIndices[i], err)
+		}
+
+		err = c.Train(datasets[i])
+		if err != nil {
+			return multioutputerrors.NewClassifierTrainingError(targetIndices[i], err)
+		}
+
+		classifiers[i] = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return classifiers, nil
+}
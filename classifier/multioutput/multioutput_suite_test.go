@@ -0,0 +1,13 @@
+package multioutput_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMultioutput(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Multioutput Suite")
+}
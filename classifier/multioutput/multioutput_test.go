@@ -0,0 +1,204 @@
+package multioutput_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/knn"
+	"github.com/amitkgupta/goodlearn/classifier/multioutput"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/multioutputerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// quadrantsDataset has a colour target, red left of the y-axis and blue
+// right of it, and a numeric target, 1 above the x-axis and 0 below it.
+func quadrantsDataset() dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(5))
+	ds := dataset.NewDataset([]int{2, 3}, []int{0, 1}, columnTypes)
+	for i := 0; i < 120; i++ {
+		x, y := 2*random.Float64()-1, 2*random.Float64()-1
+		colour, above := "red", "0"
+		if x > 0 {
+			colour = "blue"
+		}
+		if y > 0 {
+			above = "1"
+		}
+
+		err = ds.AddRowFromStrings([]string{colour, above, fmt.Sprintf("%.6f", x), fmt.Sprintf("%.6f", y)})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+	return ds
+}
+
+func knnFactory() (classifier.Classifier, error) {
+	return knn.NewKNNClassifier(3)
+}
+
+// labelAccuracy is the fraction of training rows whose j-th target is
+// classified correctly, for each j.
+func labelAccuracy(c classifier.Classifier, ds dataset.Dataset) []float64 {
+	accuracy := make([]float64, ds.NumTargets())
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		target, err := c.Classify(r)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		predicted, actual := slice.Entries(target), slice.Entries(r.Target())
+		Ω(predicted).Should(HaveLen(len(actual)))
+		for j := range actual {
+			if predicted[j] == actual[j] {
+				accuracy[j] = accuracy[j] + 1/float64(ds.NumRows())
+			}
+		}
+	}
+	return accuracy
+}
+
+// recordingClassifier remembers how many features and what row weights it
+// was trained with, and classifies every row as the first target it saw.
+type recordingClassifier struct {
+	numFeatures int
+	weights     []float64
+	target      slice.Slice
+}
+
+func (c *recordingClassifier) Train(ds dataset.Dataset) error {
+	c.numFeatures = ds.NumFeatures()
+	c.weights = dataset.Weights(ds)
+	r, err := ds.Row(0)
+	if err != nil {
+		return err
+	}
+	c.target = r.Target()
+	return nil
+}
+
+func (c *recordingClassifier) Classify(r row.Row) (slice.Slice, error) {
+	if r.NumFeatures() != c.numFeatures {
+		return nil, errors.New("wrong number of features")
+	}
+	return c.target, nil
+}
+
+func recordingFactory() (classifier.Classifier, error) {
+	return &recordingClassifier{}, nil
+}
+
+var _ = Describe("Multi-output classifiers", func() {
+	wrappers := map[string]func(classifier.Factory, ...multioutput.Option) (classifier.Classifier, error){
+		"independent": func(f classifier.Factory, options ...multioutput.Option) (classifier.Classifier, error) {
+			return multioutput.NewMultiOutputClassifier(f, options...)
+		},
+		"chained": func(f classifier.Factory, options ...multioutput.Option) (classifier.Classifier, error) {
+			return multioutput.NewClassifierChain(f, nil, options...)
+		},
+	}
+
+	for wrapperName, wrapper := range wrappers {
+		wrapperName, wrapper := wrapperName, wrapper
+
+		Describe(wrapperName, func() {
+			It("Classifies each target", func() {
+				c, err := wrapper(knnFactory)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				ds := quadrantsDataset()
+				Ω(c.Train(ds)).Should(Succeed())
+				for _, accuracy := range labelAccuracy(c, ds) {
+					Ω(accuracy).Should(BeNumerically(">", 0.9))
+				}
+			})
+
+			It("Predicts float slices for float targets", func() {
+				c, err := wrapper(recordingFactory)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+				Ω(err).ShouldNot(HaveOccurred())
+				ds := dataset.NewDataset([]int{2}, []int{0, 1}, columnTypes)
+				Ω(ds.AddRowFromStrings([]string{"1", "2", "3"})).Should(Succeed())
+				Ω(c.Train(ds)).Should(Succeed())
+
+				target, err := c.Classify(row.NewRow(slice.NewFloatSlice([]float64{0}), nil, 1))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(target.Equals(slice.NewFloatSlice([]float64{1, 2}))).Should(BeTrue())
+			})
+
+			It("Keeps the training rows' weights for each target's classifier", func() {
+				classifiers := []*recordingClassifier{}
+				c, err := wrapper(func() (classifier.Classifier, error) {
+					c := &recordingClassifier{}
+					classifiers = append(classifiers, c)
+					return c, nil
+				}, multioutput.Workers(1))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				ds := quadrantsDataset()
+				rowWeights := make([]float64, ds.NumRows())
+				for i := range rowWeights {
+					rowWeights[i] = float64(i % 3)
+				}
+				weighted, err := dataset.NewWeightedDataset(ds, rowWeights)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(c.Train(weighted)).Should(Succeed())
+				Ω(classifiers).Should(HaveLen(2))
+				for _, c := range classifiers {
+					Ω(c.weights).Should(Equal(rowWeights))
+				}
+			})
+
+			It("Rejects a negative number of workers", func() {
+				_, err := wrapper(recordingFactory, multioutput.Workers(-1))
+				Ω(err).Should(BeAssignableToTypeOf(multioutputerrors.InvalidNumberOfWorkersError{}))
+			})
+
+			It("Refuses to train on an empty dataset", func() {
+				c, err := wrapper(recordingFactory)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+				Ω(err).ShouldNot(HaveOccurred())
+				err = c.Train(dataset.NewDataset([]int{2}, []int{0, 1}, columnTypes))
+				Ω(err).Should(BeAssignableToTypeOf(multioutputerrors.EmptyTrainingDatasetError{}))
+			})
+
+			It("Refuses to classify before training", func() {
+				c, err := wrapper(recordingFactory)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, err = c.Classify(row.NewRow(slice.NewFloatSlice([]float64{0, 0}), nil, 2))
+				Ω(err).Should(BeAssignableToTypeOf(multioutputerrors.UntrainedClassifierError{}))
+			})
+
+			It("Reports classifiers which cannot be built, trained or classify", func() {
+				c, err := wrapper(func() (classifier.Classifier, error) {
+					return nil, errors.New("no classifier")
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(c.Train(quadrantsDataset())).Should(BeAssignableToTypeOf(multioutputerrors.ClassifierConstructionError{}))
+
+				c, err = wrapper(knnFactory)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(c.Train(quadrantsDataset())).Should(Succeed())
+
+				_, err = c.Classify(row.NewRow(slice.NewFloatSlice([]float64{0, 0, 0}), nil, 3))
+				Ω(err).Should(BeAssignableToTypeOf(multioutputerrors.ClassificationError{}))
+			})
+		})
+	}
+})
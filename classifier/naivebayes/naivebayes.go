@@ -1 +1,264 @@
 package naivebayes
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/naivebayeserrors"
+)
+
+const defaultVarianceSmoothing = 1e-9
+
+type Option func(*gaussianNaiveBayes)
+
+// VarianceSmoothing adds epsilon times the largest of the per-target feature
+// variances to every variance, so that features which are constant within a
+// target don't make its likelihood infinite; 1e-9 by default.
+func VarianceSmoothing(epsilon float64) Option {
+	return func(nb *gaussianNaiveBayes) {
+		nb.varianceSmoothing = epsilon
+	}
+}
+
+// NewGaussianNaiveBayes returns a classifier which models each feature,
+// independently given the target, as normally distributed, with the targets'
+// prior probabilities, means and variances estimated from the training rows,
+// each weighted by its weight if the training data is a
+// dataset.WeightedDataset.
+//
+// The estimates are pooled across batches, so PartialFit learns from a batch
+// of rows exactly as if they had been part of every earlier batch; targets
+// first seen in a later batch are added to those already known.
+func NewGaussianNaiveBayes(options ...Option) (*gaussianNaiveBayes, error) {
+	nb := &gaussianNaiveBayes{varianceSmoothing: defaultVarianceSmoothing}
+	for _, option := range options {
+		option(nb)
+	}
+
+	if !(nb.varianceSmoothing > 0) {
+		return nil, naivebayeserrors.NewInvalidVarianceSmoothingError(nb.varianceSmoothing)
+	}
+
+	return nb, nil
+}
+
+type gaussianNaiveBayes struct {
+	varianceSmoothing float64
+
+	numFeatures int
+	targets     []slice.Slice
+	counts      []float64
+	means       [][]float64
+	variances   [][]float64
+}
+
+func (nb *gaussianNaiveBayes) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return naivebayeserrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if trainingData.NumRows() == 0 {
+		return naivebayeserrors.NewEmptyTrainingDatasetError()
+	}
+
+	nb.targets = nil
+	nb.counts = nil
+	nb.means = nil
+	nb.variances = nil
+	return nb.PartialFit(trainingData)
+}
+
+// PartialFit merges the batch's per-target means and variances into those of
+// the rows already seen.
+func (nb *gaussianNaiveBayes) PartialFit(batch dataset.Dataset) error {
+	if !batch.AllFeaturesFloats() {
+		return naivebayeserrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	numFeatures := batch.NumFeatures()
+	if len(nb.targets) > 0 && numFeatures != nb.numFeatures {
+		return naivebayeserrors.NewBatchLengthMismatchError(numFeatures, nb.numFeatures)
+	}
+
+	targets, err := classifierutilities.DistinctTargets(batch)
+	if err != nil {
+		return err
+	}
+
+	numRows := batch.NumRows()
+	features := make([][]float64, numRows)
+	labels := make([]int, numRows)
+	rowWeights := dataset.Weights(batch)
+
+	counts := make([]float64, len(targets))
+	means := make([][]float64, len(targets))
+	variances := make([][]float64, len(targets))
+	for k := range targets {
+		means[k] = make([]float64, numFeatures)
+		variances[k] = make([]float64, numFeatures)
+	}
+
+	for i := range features {
+		r, err := batch.Row(i)
+		if err != nil {
+			return err
+		}
+
+		features[i] = r.Features().(slice.FloatSlice).Values()
+		labels[i] = classifierutilities.TargetIndex(targets, r.Target())
+
+		k := labels[i]
+		counts[k] = counts[k] + rowWeights[i]
+		for j, x := range features[i] {
+			means[k][j] = means[k][j] + rowWeights[i]*x
+		}
+	}
+
+	for k := range targets {
+		for j := range means[k] {
+			if counts[k] > 0 {
+				means[k][j] = means[k][j] / counts[k]
+			}
+		}
+	}
+
+	for i, x := range features {
+		k := labels[i]
+		for j, v := range x {
+			deviation := v - means[k][j]
+			variances[k][j] = variances[k][j] + rowWeights[i]*deviation*deviation
+		}
+	}
+
+	for k, target := range targets {
+		if counts[k] == 0 {
+			continue
+		}
+
+		for j := range variances[k] {
+			variances[k][j] = variances[k][j] / counts[k]
+		}
+
+		nb.merge(target, counts[k], means[k], variances[k])
+	}
+
+	nb.numFeatures = numFeatures
+	return nil
+}
+
+// merge pools the count, means and variances of a target's new rows with
+// those of its rows already seen.
+func (nb *gaussianNaiveBayes) merge(target slice.Slice, count float64, means, variances []float64) {
+	k := classifierutilities.TargetIndex(nb.targets, target)
+	if k < 0 {
+		nb.targets = append(nb.targets, target)
+		nb.counts = append(nb.counts, count)
+		nb.means = append(nb.means, means)
+		nb.variances = append(nb.variances, variances)
+		return
+	}
+
+	oldCount := nb.counts[k]
+	total := oldCount + count
+	for j := range means {
+		delta := means[j] - nb.means[k][j]
+		squaredDeviations := nb.variances[k][j]*oldCount + variances[j]*count + delta*delta*oldCount*count/total
+
+		nb.means[k][j] = nb.means[k][j] + delta*count/total
+		nb.variances[k][j] = squaredDeviations / total
+	}
+	nb.counts[k] = total
+}
+
+func (nb *gaussianNaiveBayes) Classify(testRow row.Row) (slice.Slice, error) {
+	scores, err := nb.jointLogLikelihoods(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return nb.targets[argmax(scores)], nil
+}
+
+func (nb *gaussianNaiveBayes) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	scores, err := nb.jointLogLikelihoods(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nb.targets, softmax(scores), nil
+}
+
+// jointLogLikelihoods returns, for each target, the log of its prior
+// probability times the likelihood of the test row's features.
+func (nb *gaussianNaiveBayes) jointLogLikelihoods(testRow row.Row) ([]float64, error) {
+	if len(nb.targets) == 0 {
+		return nil, naivebayeserrors.NewUntrainedClassifierError()
+	}
+
+	if testRow.NumFeatures() != nb.numFeatures {
+		return nil, naivebayeserrors.NewRowLengthMismatchError(testRow.NumFeatures(), nb.numFeatures)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, naivebayeserrors.NewNonFloatFeaturesTestRowError()
+	}
+	x := testFeatures.Values()
+
+	total, largestVariance := 0.0, 0.0
+	for k, count := range nb.counts {
+		total = total + count
+		for _, v := range nb.variances[k] {
+			largestVariance = math.Max(largestVariance, v)
+		}
+	}
+
+	smoothing := nb.varianceSmoothing
+	if largestVariance > 0 {
+		smoothing = smoothing * largestVariance
+	}
+
+	scores := make([]float64, len(nb.targets))
+	for k := range nb.targets {
+		scores[k] = math.Log(nb.counts[k] / total)
+		for j, v := range x {
+			variance := nb.variances[k][j] + smoothing
+			deviation := v - nb.means[k][j]
+			scores[k] = scores[k] - 0.5*(math.Log(2*math.Pi*variance)+deviation*deviation/variance)
+		}
+	}
+
+	return scores, nil
+}
+
+// softmax turns log probabilities, up to a shared constant, into
+// probabilities.
+func softmax(scores []float64) []float64 {
+	largest := scores[argmax(scores)]
+
+	probabilities := make([]float64, len(scores))
+	total := 0.0
+	for k, s := range scores {
+		probabilities[k] = math.Exp(s - largest)
+		total = total + probabilities[k]
+	}
+	for k := range probabilities {
+		probabilities[k] = probabilities[k] / total
+	}
+
+	return probabilities
+}
+
+func argmax(values []float64) int {
+	best := 0
+	for k, v := range values {
+		if v > values[best] {
+			best = k
+		}
+	}
+
+	return best
+}
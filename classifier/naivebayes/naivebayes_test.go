@@ -1 +1,214 @@
 package naivebayes_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/naivebayes"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/naivebayeserrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// blobsDataset has 50 rows about each of three centres, separated by the
+// first feature only; the second feature is noise.
+func blobsDataset() dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(3))
+	ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+	for i := 0; i < 50; i++ {
+		for k, colour := range []string{"red", "green", "blue"} {
+			err = ds.AddRowFromStrings([]string{
+				colour,
+				fmt.Sprintf("%.6f", 4*float64(k)+random.NormFloat64()),
+				fmt.Sprintf("%.6f", 3*random.NormFloat64()),
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	}
+	return ds
+}
+
+func rowRange(ds dataset.Dataset, start, end int) dataset.Dataset {
+	rowMap := []int{}
+	for i := start; i < end; i++ {
+		rowMap = append(rowMap, i)
+	}
+	return dataset.NewSubset(ds, rowMap)
+}
+
+func floatRow(values ...float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice(values), nil, len(values))
+}
+
+var _ = Describe("GaussianNaiveBayes", func() {
+	var ds dataset.Dataset
+
+	BeforeEach(func() {
+		ds = blobsDataset()
+	})
+
+	Describe("NewGaussianNaiveBayes", func() {
+		It("Requires positive variance smoothing", func() {
+			_, err := naivebayes.NewGaussianNaiveBayes(naivebayes.VarianceSmoothing(0))
+			Ω(err).Should(BeAssignableToTypeOf(naivebayeserrors.InvalidVarianceSmoothingError{}))
+		})
+
+		It("Is an online, probabilistic classifier", func() {
+			nb, err := naivebayes.NewGaussianNaiveBayes()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var c classifier.OnlineClassifier = nb
+			_, ok := c.(classifier.ProbabilisticClassifier)
+			Ω(ok).Should(BeTrue())
+		})
+	})
+
+	Describe("Train", func() {
+		It("Requires float features", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "b"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			nonFloat := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			Ω(nonFloat.AddRowFromStrings([]string{"a", "b"})).Should(Succeed())
+
+			nb, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(nb.Train(nonFloat)).Should(BeAssignableToTypeOf(naivebayeserrors.NonFloatFeaturesTrainingSetError{}))
+			Ω(nb.PartialFit(nonFloat)).Should(BeAssignableToTypeOf(naivebayeserrors.NonFloatFeaturesTrainingSetError{}))
+		})
+
+		It("Requires a non-empty dataset", func() {
+			nb, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(nb.Train(rowRange(ds, 0, 0))).Should(BeAssignableToTypeOf(naivebayeserrors.EmptyTrainingDatasetError{}))
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Returns an error before training", func() {
+			nb, _ := naivebayes.NewGaussianNaiveBayes()
+			_, err := nb.Classify(floatRow(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(naivebayeserrors.UntrainedClassifierError{}))
+		})
+
+		It("Returns an error for rows of the wrong length", func() {
+			nb, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(nb.Train(ds)).Should(Succeed())
+
+			_, err := nb.Classify(floatRow(0))
+			Ω(err).Should(BeAssignableToTypeOf(naivebayeserrors.RowLengthMismatchError{}))
+		})
+
+		It("Classifies by the most likely target", func() {
+			nb, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(nb.Train(ds)).Should(Succeed())
+
+			for k, x := range []float64{0, 4, 8} {
+				target, err := nb.Classify(floatRow(x, 5))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				r, _ := ds.Row(k)
+				Ω(target.Equals(r.Target())).Should(BeTrue())
+			}
+
+			targets, probabilities, err := nb.ClassProbabilities(floatRow(2, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(3))
+			Ω(probabilities[0] + probabilities[1] + probabilities[2]).Should(BeNumerically("~", 1, 1e-12))
+			Ω(probabilities[2]).Should(BeNumerically("<", 0.01))
+		})
+	})
+
+	Describe("PartialFit", func() {
+		It("Learns from batches as from all of their rows at once", func() {
+			whole, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(whole.Train(ds)).Should(Succeed())
+
+			batched, _ := naivebayes.NewGaussianNaiveBayes()
+			for start := 0; start < ds.NumRows(); start = start + 40 {
+				end := start + 40
+				if end > ds.NumRows() {
+					end = ds.NumRows()
+				}
+				Ω(batched.PartialFit(rowRange(ds, start, end))).Should(Succeed())
+			}
+
+			_, wholeProbabilities, err := whole.ClassProbabilities(floatRow(2.5, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			_, batchedProbabilities, err := batched.ClassProbabilities(floatRow(2.5, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for k := range wholeProbabilities {
+				Ω(batchedProbabilities[k]).Should(BeNumerically("~", wholeProbabilities[k], 1e-9))
+			}
+		})
+
+		It("Adds targets first seen in later batches", func() {
+			nb, _ := naivebayes.NewGaussianNaiveBayes()
+
+			// Rows cycle through red, green and blue, so the first two
+			// rows have no blue.
+			Ω(nb.PartialFit(rowRange(ds, 0, 2))).Should(Succeed())
+			targets, _, err := nb.ClassProbabilities(floatRow(8, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(2))
+
+			Ω(nb.PartialFit(rowRange(ds, 2, ds.NumRows()))).Should(Succeed())
+			target, err := nb.Classify(floatRow(8, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			blue, _ := ds.Row(2)
+			Ω(target.Equals(blue.Target())).Should(BeTrue())
+		})
+
+		It("Requires batches with as many features as earlier ones", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			narrow := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			Ω(narrow.AddRowFromStrings([]string{"red", "1"})).Should(Succeed())
+
+			nb, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(nb.PartialFit(ds)).Should(Succeed())
+			Ω(nb.PartialFit(narrow)).Should(BeAssignableToTypeOf(naivebayeserrors.BatchLengthMismatchError{}))
+		})
+
+		It("Weights the rows of a weighted dataset as if they were repeated", func() {
+			weights := make([]float64, ds.NumRows())
+			rowMap := []int{}
+			for i := range weights {
+				weights[i] = float64(i % 3)
+				for j := 0; j < i%3; j++ {
+					rowMap = append(rowMap, i)
+				}
+			}
+
+			weighted, err := dataset.NewWeightedDataset(ds, weights)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			fromWeights, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(fromWeights.Train(weighted)).Should(Succeed())
+
+			fromRepeats, _ := naivebayes.NewGaussianNaiveBayes()
+			Ω(fromRepeats.Train(dataset.NewSubset(ds, rowMap))).Should(Succeed())
+
+			targets, weightedProbabilities, err := fromWeights.ClassProbabilities(floatRow(5, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			_, repeatedProbabilities, err := fromRepeats.ClassProbabilities(floatRow(5, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// Red rows all have weight 0, so red is never learned.
+			Ω(targets).Should(HaveLen(2))
+			for k := range weightedProbabilities {
+				Ω(weightedProbabilities[k]).Should(BeNumerically("~", repeatedProbabilities[k], 1e-9))
+			}
+		})
+	})
+})
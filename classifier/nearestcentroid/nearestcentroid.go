@@ -0,0 +1,246 @@
+package nearestcentroid
+
+import (
+	"math"
+	"sort"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/nearestcentroiderrors"
+)
+
+type Option func(*nearestCentroid)
+
+// ShrinkThreshold shrinks each target's centroid towards the overall
+// centroid, as in Tibshirani et al.'s nearest shrunken centroids: each
+// feature's difference from the overall mean, standardised by the pooled
+// within-target spread, is soft thresholded by threshold, so that features
+// which barely separate the targets stop counting at all.  0, the default,
+// leaves the centroids as the targets' means.
+func ShrinkThreshold(threshold float64) Option {
+	return func(nc *nearestCentroid) {
+		nc.shrinkThreshold = threshold
+	}
+}
+
+// NewNearestCentroid returns a classifier which classifies a row as the
+// target whose centroid, the mean of its training rows, is nearest by
+// Euclidean distance.  Class probabilities treat each target as an equally
+// likely spherical Gaussian about its centroid, with the variance pooled
+// within targets and averaged over features.
+func NewNearestCentroid(options ...Option) (*nearestCentroid, error) {
+	nc := &nearestCentroid{}
+	for _, option := range options {
+		option(nc)
+	}
+
+	if nc.shrinkThreshold < 0 || math.IsNaN(nc.shrinkThreshold) {
+		return nil, nearestcentroiderrors.NewInvalidShrinkThresholdError(nc.shrinkThreshold)
+	}
+
+	return nc, nil
+}
+
+type nearestCentroid struct {
+	shrinkThreshold float64
+
+	targets   []slice.Slice
+	centroids [][]float64
+	variance  float64
+}
+
+func (nc *nearestCentroid) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return nearestcentroiderrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	numRows := trainingData.NumRows()
+	if numRows == 0 {
+		return nearestcentroiderrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	numTargets := len(targets)
+	if numTargets < 2 {
+		return nearestcentroiderrors.NewTooFewTargetsError(numTargets)
+	}
+
+	if numRows <= numTargets {
+		return nearestcentroiderrors.NewTooFewRowsError(numRows, numTargets)
+	}
+
+	numFeatures := trainingData.NumFeatures()
+	values := make([][]float64, numRows)
+	labels := make([]int, numRows)
+	counts := make([]float64, numTargets)
+	centroids := make([][]float64, numTargets)
+	for k := range centroids {
+		centroids[k] = make([]float64, numFeatures)
+	}
+	overall := make([]float64, numFeatures)
+
+	for i := range values {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		values[i] = r.Features().(slice.FloatSlice).Values()
+		labels[i] = classifierutilities.TargetIndex(targets, r.Target())
+		counts[labels[i]]++
+		for j, v := range values[i] {
+			centroids[labels[i]][j] = centroids[labels[i]][j] + v
+			overall[j] = overall[j] + v/float64(numRows)
+		}
+	}
+
+	for k, centroid := range centroids {
+		for j := range centroid {
+			centroid[j] = centroid[j] / counts[k]
+		}
+	}
+
+	// the within-target variance of each feature, pooled over targets
+	variances := make([]float64, numFeatures)
+	for i, v := range values {
+		for j, x := range v {
+			deviation := x - centroids[labels[i]][j]
+			variances[j] = variances[j] + deviation*deviation/float64(numRows-numTargets)
+		}
+	}
+
+	if nc.shrinkThreshold > 0 && numFeatures > 0 {
+		shrink(centroids, overall, variances, counts, nc.shrinkThreshold)
+	}
+
+	variance := 0.0
+	for _, v := range variances {
+		variance = variance + v/float64(numFeatures)
+	}
+
+	nc.targets = targets
+	nc.centroids = centroids
+	nc.variance = variance
+	return nil
+}
+
+// shrink soft thresholds the standardised differences of the centroids from
+// the overall centroid.
+func shrink(centroids [][]float64, overall, variances, counts []float64, threshold float64) {
+	numRows := 0.0
+	for _, c := range counts {
+		numRows = numRows + c
+	}
+
+	deviations := make([]float64, len(variances))
+	for j, v := range variances {
+		deviations[j] = math.Sqrt(v)
+	}
+
+	// the median deviation guards against features with tiny spreads
+	sorted := append([]float64{}, deviations...)
+	sort.Float64s(sorted)
+	offset := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		offset = (sorted[len(sorted)/2-1] + offset) / 2
+	}
+
+	for k, centroid := range centroids {
+		m := math.Sqrt(1/counts[k] - 1/numRows)
+		for j := range centroid {
+			scale := m * (deviations[j] + offset)
+			if scale == 0 {
+				continue
+			}
+
+			d := (centroid[j] - overall[j]) / scale
+			shrunk := math.Max(math.Abs(d)-threshold, 0)
+			if d < 0 {
+				shrunk = -shrunk
+			}
+			centroid[j] = overall[j] + scale*shrunk
+		}
+	}
+}
+
+func (nc *nearestCentroid) Classify(testRow row.Row) (slice.Slice, error) {
+	distances, err := nc.squaredDistances(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return nc.targets[nearest(distances)], nil
+}
+
+func (nc *nearestCentroid) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	distances, err := nc.squaredDistances(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	probabilities := make([]float64, len(distances))
+	if nc.variance == 0 {
+		probabilities[nearest(distances)] = 1
+		return nc.targets, probabilities, nil
+	}
+
+	// subtracting the nearest distance keeps the largest exponent at 0
+	closest := distances[nearest(distances)]
+	total := 0.0
+	for k, d := range distances {
+		probabilities[k] = math.Exp(-(d - closest) / (2 * nc.variance))
+		total = total + probabilities[k]
+	}
+	for k := range probabilities {
+		probabilities[k] = probabilities[k] / total
+	}
+
+	return nc.targets, probabilities, nil
+}
+
+// Centroids returns each training target and its, possibly shrunken,
+// centroid.
+func (nc *nearestCentroid) Centroids() ([]slice.Slice, [][]float64) {
+	return nc.targets, nc.centroids
+}
+
+func (nc *nearestCentroid) squaredDistances(testRow row.Row) ([]float64, error) {
+	if nc.centroids == nil {
+		return nil, nearestcentroiderrors.NewUntrainedClassifierError()
+	}
+
+	numFeatures := len(nc.centroids[0])
+	if testRow.NumFeatures() != numFeatures {
+		return nil, nearestcentroiderrors.NewRowLengthMismatchError(testRow.NumFeatures(), numFeatures)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, nearestcentroiderrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	distances := make([]float64, len(nc.centroids))
+	for k, centroid := range nc.centroids {
+		for j, v := range testFeatures.Values() {
+			distances[k] = distances[k] + (v-centroid[j])*(v-centroid[j])
+		}
+	}
+	return distances, nil
+}
+
+// nearest returns the index of the smallest distance, the first if tied.
+func nearest(distances []float64) int {
+	best := 0
+	for k, d := range distances {
+		if d < distances[best] {
+			best = k
+		}
+	}
+	return best
+}
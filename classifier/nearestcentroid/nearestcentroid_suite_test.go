@@ -0,0 +1,13 @@
+package nearestcentroid_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestNearestcentroid(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Nearestcentroid Suite")
+}
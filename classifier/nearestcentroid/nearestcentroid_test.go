@@ -0,0 +1,131 @@
+package nearestcentroid_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/nearestcentroid"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/nearestcentroiderrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// blobsDataset has 50 rows about each of three centres, separated by the
+// first feature only; the second feature is noise.
+func blobsDataset() dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(2))
+	ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+	for i := 0; i < 50; i++ {
+		for k, colour := range []string{"red", "green", "blue"} {
+			err = ds.AddRowFromStrings([]string{
+				colour,
+				fmt.Sprintf("%.6f", 4*float64(k)+random.NormFloat64()),
+				fmt.Sprintf("%.6f", random.NormFloat64()),
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	}
+	return ds
+}
+
+func floatRow(values ...float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice(values), nil, len(values))
+}
+
+var _ = Describe("NearestCentroid", func() {
+	It("Classifies rows as the target with the nearest mean", func() {
+		nc, err := nearestcentroid.NewNearestCentroid()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(nc.Train(blobsDataset())).Should(Succeed())
+
+		for k, colour := range []string{"red", "green", "blue"} {
+			target, err := nc.Classify(floatRow(4*float64(k)+0.5, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(slice.Entries(target)).Should(Equal([]interface{}{colour}))
+		}
+	})
+
+	It("Gives probabilities favouring nearer centroids", func() {
+		nc, err := nearestcentroid.NewNearestCentroid()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(nc.Train(blobsDataset())).Should(Succeed())
+
+		targets, probabilities, err := nc.ClassProbabilities(floatRow(2, 0))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(targets).Should(HaveLen(3))
+		Ω(probabilities[0] + probabilities[1] + probabilities[2]).Should(BeNumerically("~", 1))
+		Ω(probabilities[0]).Should(BeNumerically("~", probabilities[1], 0.2))
+		Ω(probabilities[2]).Should(BeNumerically("<", 0.05))
+	})
+
+	It("Shrinks centroids towards the overall mean", func() {
+		unshrunk, err := nearestcentroid.NewNearestCentroid()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(unshrunk.Train(blobsDataset())).Should(Succeed())
+		_, centroids := unshrunk.Centroids()
+		Ω(centroids[0][1]).ShouldNot(Equal(centroids[1][1]))
+
+		shrunk, err := nearestcentroid.NewNearestCentroid(nearestcentroid.ShrinkThreshold(3))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(shrunk.Train(blobsDataset())).Should(Succeed())
+		_, shrunkCentroids := shrunk.Centroids()
+
+		// the noise feature no longer separates the targets, the other still does
+		Ω(shrunkCentroids[0][1]).Should(Equal(shrunkCentroids[1][1]))
+		Ω(shrunkCentroids[1][1]).Should(Equal(shrunkCentroids[2][1]))
+		Ω(shrunkCentroids[0][0]).Should(BeNumerically("<", shrunkCentroids[1][0]))
+		Ω(shrunkCentroids[1][0]).Should(BeNumerically("<", shrunkCentroids[2][0]))
+		Ω(shrunkCentroids[2][0] - shrunkCentroids[0][0]).Should(BeNumerically("<", centroids[2][0]-centroids[0][0]))
+	})
+
+	It("Rejects a negative shrink threshold", func() {
+		_, err := nearestcentroid.NewNearestCentroid(nearestcentroid.ShrinkThreshold(-1))
+		Ω(err).Should(BeAssignableToTypeOf(nearestcentroiderrors.InvalidShrinkThresholdError{}))
+	})
+
+	It("Refuses to train on unsuitable data", func() {
+		nc, err := nearestcentroid.NewNearestCentroid()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+		ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		Ω(nc.Train(ds)).Should(BeAssignableToTypeOf(nearestcentroiderrors.EmptyTrainingDatasetError{}))
+
+		Ω(ds.AddRowFromStrings([]string{"red", "1"})).Should(Succeed())
+		Ω(nc.Train(ds)).Should(BeAssignableToTypeOf(nearestcentroiderrors.TooFewTargetsError{}))
+
+		Ω(ds.AddRowFromStrings([]string{"blue", "2"})).Should(Succeed())
+		Ω(nc.Train(ds)).Should(BeAssignableToTypeOf(nearestcentroiderrors.TooFewRowsError{}))
+
+		ds = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		Ω(ds.AddRowFromStrings([]string{"red", "1"})).Should(Succeed())
+		Ω(nc.Train(ds)).Should(BeAssignableToTypeOf(nearestcentroiderrors.NonFloatFeaturesTrainingSetError{}))
+	})
+
+	It("Refuses to classify unsuitable rows", func() {
+		nc, err := nearestcentroid.NewNearestCentroid()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = nc.Classify(floatRow(1, 2))
+		Ω(err).Should(BeAssignableToTypeOf(nearestcentroiderrors.UntrainedClassifierError{}))
+
+		Ω(nc.Train(blobsDataset())).Should(Succeed())
+
+		_, err = nc.Classify(floatRow(1))
+		Ω(err).Should(BeAssignableToTypeOf(nearestcentroiderrors.RowLengthMismatchError{}))
+
+		mixed, err := slice.NewMixedSlice([]interface{}{"a", 1.0})
+		Ω(err).ShouldNot(HaveOccurred())
+		_, err = nc.Classify(row.NewRow(mixed, nil, 2))
+		Ω(err).Should(BeAssignableToTypeOf(nearestcentroiderrors.NonFloatFeaturesTestRowError{}))
+	})
+})
@@ -0,0 +1,308 @@
+package sgd
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/sgderrors"
+	"github.com/amitkgupta/goodlearn/optimizer/stochasticgradientdescent"
+)
+
+const (
+	defaultL2Penalty           = 1e-4
+	defaultInitialLearningRate = 0.1
+	defaultPower               = 0.5
+	defaultEpochs              = 5
+)
+
+type Option func(*sgdClassifier)
+
+// L2Penalty adds penalty * w * w / 2 to each example's loss for each
+// non-intercept coefficient w; 1e-4 by default.
+func L2Penalty(penalty float64) Option {
+	return func(c *sgdClassifier) {
+		c.l2Penalty = penalty
+	}
+}
+
+// LearningRate sets the step size of each update; by default it decays from
+// 0.1 as the inverse square root of the number of updates.
+func LearningRate(schedule stochasticgradientdescent.LearningRateSchedule) Option {
+	return func(c *sgdClassifier) {
+		c.schedule = schedule
+	}
+}
+
+// Epochs sets how many passes Train makes over the training rows; 5 by
+// default.  PartialFit always makes one pass over its batch.
+func Epochs(epochs int) Option {
+	return func(c *sgdClassifier) {
+		c.epochs = epochs
+	}
+}
+
+// RandomSource sets the source used to shuffle the order in which Train
+// visits the training rows.
+func RandomSource(source rand.Source) Option {
+	return func(c *sgdClassifier) {
+		c.source = source
+	}
+}
+
+// NewSGDClassifier returns a one-vs-rest linear SVM fit by stochastic
+// gradient descent on the hinge loss, stepping once per row, each row's step
+// scaled by its weight if the training data is a dataset.WeightedDataset.
+// Features should be on similar scales.
+//
+// PartialFit continues descent from the current coefficients, and the
+// learning rate continues to decay across batches; targets first seen in a
+// later batch start with zero coefficients.
+func NewSGDClassifier(options ...Option) (*sgdClassifier, error) {
+	return newSGDClassifier(false, options)
+}
+
+// NewLogLossSGDClassifier returns a classifier like NewSGDClassifier's which
+// fits one-vs-rest logistic regressions on the log loss rather than linear
+// SVMs, so that it can also report class probabilities.
+func NewLogLossSGDClassifier(options ...Option) (*logLossSGDClassifier, error) {
+	c, err := newSGDClassifier(true, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logLossSGDClassifier{c}, nil
+}
+
+func newSGDClassifier(logLoss bool, options []Option) (*sgdClassifier, error) {
+	c := &sgdClassifier{
+		logLoss:   logLoss,
+		l2Penalty: defaultL2Penalty,
+		epochs:    defaultEpochs,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	if c.l2Penalty < 0 || math.IsNaN(c.l2Penalty) {
+		return nil, sgderrors.NewInvalidHyperparameterError("L2 penalty", c.l2Penalty)
+	}
+
+	if c.epochs < 1 {
+		return nil, sgderrors.NewInvalidHyperparameterError("epochs", float64(c.epochs))
+	}
+
+	if c.schedule == nil {
+		c.schedule, _ = stochasticgradientdescent.InverseScalingLearningRate(defaultInitialLearningRate, defaultPower)
+	}
+
+	if c.source == nil {
+		c.source = rand.NewSource(1)
+	}
+	c.random = rand.New(c.source)
+
+	return c, nil
+}
+
+type sgdClassifier struct {
+	logLoss   bool
+	l2Penalty float64
+	schedule  stochasticgradientdescent.LearningRateSchedule
+	epochs    int
+	source    rand.Source
+	random    *rand.Rand
+
+	numFeatures  int
+	targets      []slice.Slice
+	coefficients [][]float64
+	intercepts   []float64
+	updates      int
+}
+
+func (c *sgdClassifier) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return sgderrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if trainingData.NumRows() == 0 {
+		return sgderrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	c.targets = targets
+	c.coefficients = make([][]float64, len(targets))
+	c.intercepts = make([]float64, len(targets))
+	for k := range targets {
+		c.coefficients[k] = make([]float64, trainingData.NumFeatures())
+	}
+	c.numFeatures = trainingData.NumFeatures()
+	c.updates = 0
+
+	for epoch := 0; epoch < c.epochs; epoch++ {
+		err := c.fit(trainingData, c.random.Perm(trainingData.NumRows()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PartialFit makes one pass over the batch's rows in order.
+func (c *sgdClassifier) PartialFit(batch dataset.Dataset) error {
+	if !batch.AllFeaturesFloats() {
+		return sgderrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	order := make([]int, batch.NumRows())
+	for i := range order {
+		order[i] = i
+	}
+
+	return c.fit(batch, order)
+}
+
+// fit steps once for each of the given rows of the batch, in order.
+func (c *sgdClassifier) fit(batch dataset.Dataset, order []int) error {
+	numFeatures := batch.NumFeatures()
+	if len(c.targets) > 0 && numFeatures != c.numFeatures {
+		return sgderrors.NewBatchLengthMismatchError(numFeatures, c.numFeatures)
+	}
+	c.numFeatures = numFeatures
+
+	rowWeights := dataset.Weights(batch)
+	for _, i := range order {
+		r, err := batch.Row(i)
+		if err != nil {
+			return err
+		}
+
+		label := classifierutilities.TargetIndex(c.targets, r.Target())
+		if label < 0 {
+			label = len(c.targets)
+			c.targets = append(c.targets, r.Target())
+			c.coefficients = append(c.coefficients, make([]float64, numFeatures))
+			c.intercepts = append(c.intercepts, 0)
+		}
+
+		c.step(r.Features().(slice.FloatSlice).Values(), label, rowWeights[i])
+	}
+
+	return nil
+}
+
+// step moves each target's coefficients against the gradient of its binary
+// loss, with the row's target as the positive class, on one row.
+func (c *sgdClassifier) step(x []float64, label int, weight float64) {
+	learningRate := c.schedule(c.updates)
+	c.updates++
+
+	for k := range c.targets {
+		y := -1.0
+		if k == label {
+			y = 1
+		}
+
+		g := weight * c.lossDerivative(y, c.score(k, x))
+		for j, v := range x {
+			c.coefficients[k][j] = c.coefficients[k][j] - learningRate*(g*v+c.l2Penalty*c.coefficients[k][j])
+		}
+		c.intercepts[k] = c.intercepts[k] - learningRate*g
+	}
+}
+
+// lossDerivative is the derivative of the hinge or log loss of the score s
+// of a row with label y, which is 1 or -1, with respect to s.
+func (c *sgdClassifier) lossDerivative(y, s float64) float64 {
+	if c.logLoss {
+		return -y / (1 + math.Exp(y*s))
+	}
+
+	if y*s < 1 {
+		return -y
+	}
+	return 0
+}
+
+func (c *sgdClassifier) score(k int, x []float64) float64 {
+	s := c.intercepts[k]
+	for j, v := range x {
+		s = s + c.coefficients[k][j]*v
+	}
+	return s
+}
+
+func (c *sgdClassifier) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, scores, err := c.DecisionFunction(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	best := 0
+	for k, s := range scores {
+		if s > scores[best] {
+			best = k
+		}
+	}
+
+	return targets[best], nil
+}
+
+// DecisionFunction returns each target's one-vs-rest score.
+func (c *sgdClassifier) DecisionFunction(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if len(c.targets) == 0 {
+		return nil, nil, sgderrors.NewUntrainedClassifierError()
+	}
+
+	if testRow.NumFeatures() != c.numFeatures {
+		return nil, nil, sgderrors.NewRowLengthMismatchError(testRow.NumFeatures(), c.numFeatures)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, nil, sgderrors.NewNonFloatFeaturesTestRowError()
+	}
+	x := testFeatures.Values()
+
+	scores := make([]float64, len(c.targets))
+	for k := range scores {
+		scores[k] = c.score(k, x)
+	}
+
+	return c.targets, scores, nil
+}
+
+// logLossSGDClassifier is an sgdClassifier fit on the log loss, whose scores
+// are log-odds and so give class probabilities.
+type logLossSGDClassifier struct {
+	*sgdClassifier
+}
+
+// ClassProbabilities normalizes the one-vs-rest logistic probabilities of
+// each target.
+func (c *logLossSGDClassifier) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	targets, scores, err := c.DecisionFunction(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	probabilities := make([]float64, len(scores))
+	total := 0.0
+	for k, s := range scores {
+		probabilities[k] = 1 / (1 + math.Exp(-s))
+		total = total + probabilities[k]
+	}
+	for k := range probabilities {
+		probabilities[k] = probabilities[k] / total
+	}
+
+	return targets, probabilities, nil
+}
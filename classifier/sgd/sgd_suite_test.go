@@ -0,0 +1,13 @@
+package sgd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSgd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sgd Suite")
+}
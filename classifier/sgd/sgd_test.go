@@ -0,0 +1,210 @@
+package sgd_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/multiclass"
+	"github.com/amitkgupta/goodlearn/classifier/sgd"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/sgderrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// blobsDataset has numRows rows cycling through three targets, normally
+// distributed about the corners of a triangle, so that each target can be
+// separated from the rest by a line.
+func blobsDataset(numRows int) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(5))
+	ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+	for i := 0; i < numRows; i++ {
+		k := i % 3
+		err = ds.AddRowFromStrings([]string{
+			[]string{"red", "green", "blue"}[k],
+			fmt.Sprintf("%.6f", []float64{-3, 0, 3}[k]+0.5*random.NormFloat64()),
+			fmt.Sprintf("%.6f", []float64{0, 3, 0}[k]+0.5*random.NormFloat64()),
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+	return ds
+}
+
+func rowRange(ds dataset.Dataset, start, end int) dataset.Dataset {
+	rowMap := []int{}
+	for i := start; i < end; i++ {
+		rowMap = append(rowMap, i)
+	}
+	return dataset.NewSubset(ds, rowMap)
+}
+
+func testRowAt(x ...float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice(x), nil, len(x))
+}
+
+func accuracy(c classifier.Classifier, ds dataset.Dataset) float64 {
+	correct := 0
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		target, err := c.Classify(r)
+		Ω(err).ShouldNot(HaveOccurred())
+		if target.Equals(r.Target()) {
+			correct++
+		}
+	}
+	return float64(correct) / float64(ds.NumRows())
+}
+
+var _ = Describe("SGDClassifier", func() {
+	var ds dataset.Dataset
+
+	BeforeEach(func() {
+		ds = blobsDataset(300)
+	})
+
+	Describe("NewSGDClassifier", func() {
+		It("Rejects invalid hyperparameters", func() {
+			_, err := sgd.NewSGDClassifier(sgd.L2Penalty(-1))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.InvalidHyperparameterError{}))
+
+			_, err = sgd.NewSGDClassifier(sgd.Epochs(0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.InvalidHyperparameterError{}))
+
+			_, err = sgd.NewLogLossSGDClassifier(sgd.Epochs(0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.InvalidHyperparameterError{}))
+		})
+
+		It("Is an online, scoring classifier", func() {
+			c, err := sgd.NewSGDClassifier()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var online classifier.OnlineClassifier = c
+			_, ok := online.(classifier.ScoringClassifier)
+			Ω(ok).Should(BeTrue())
+		})
+	})
+
+	Describe("Train", func() {
+		It("Requires float features", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "b"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			nonFloat := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			Ω(nonFloat.AddRowFromStrings([]string{"a", "b"})).Should(Succeed())
+
+			c, _ := sgd.NewSGDClassifier()
+			Ω(c.Train(nonFloat)).Should(BeAssignableToTypeOf(sgderrors.NonFloatFeaturesTrainingSetError{}))
+			Ω(c.PartialFit(nonFloat)).Should(BeAssignableToTypeOf(sgderrors.NonFloatFeaturesTrainingSetError{}))
+		})
+
+		It("Requires a non-empty dataset", func() {
+			c, _ := sgd.NewSGDClassifier()
+			Ω(c.Train(rowRange(ds, 0, 0))).Should(BeAssignableToTypeOf(sgderrors.EmptyTrainingDatasetError{}))
+		})
+
+		It("Separates the targets with the hinge loss, without class probabilities", func() {
+			c, _ := sgd.NewSGDClassifier()
+			Ω(c.Train(ds)).Should(Succeed())
+			Ω(accuracy(c, ds)).Should(BeNumerically(">", 0.95))
+
+			var trained classifier.Classifier = c
+			_, ok := trained.(classifier.ProbabilisticClassifier)
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("Separates the targets with the log loss, with class probabilities", func() {
+			c, err := sgd.NewLogLossSGDClassifier()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var _ classifier.ProbabilisticClassifier = c
+			var _ classifier.OnlineClassifier = c
+
+			Ω(c.Train(ds)).Should(Succeed())
+			Ω(accuracy(c, ds)).Should(BeNumerically(">", 0.95))
+
+			targets, probabilities, err := c.ClassProbabilities(testRowAt(3, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(3))
+			Ω(probabilities[0] + probabilities[1] + probabilities[2]).Should(BeNumerically("~", 1, 1e-12))
+			Ω(probabilities[2]).Should(BeNumerically(">", 0.5))
+		})
+	})
+
+	Describe("One-vs-rest", func() {
+		It("Separates the targets with binary SGD classifiers scored by their decision function", func() {
+			for _, factory := range []multiclass.Factory{
+				func() (classifier.Classifier, error) { return sgd.NewSGDClassifier() },
+				func() (classifier.Classifier, error) { return sgd.NewLogLossSGDClassifier() },
+			} {
+				c, err := multiclass.NewOneVsRestClassifier(factory)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(c.Train(ds)).Should(Succeed())
+				Ω(accuracy(c, ds)).Should(BeNumerically(">", 0.9))
+			}
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Returns an error before training", func() {
+			c, _ := sgd.NewSGDClassifier()
+			_, err := c.Classify(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.UntrainedClassifierError{}))
+		})
+
+		It("Returns an error for rows of the wrong length", func() {
+			c, _ := sgd.NewSGDClassifier()
+			Ω(c.Train(ds)).Should(Succeed())
+
+			_, err := c.Classify(testRowAt(0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.RowLengthMismatchError{}))
+		})
+	})
+
+	Describe("PartialFit", func() {
+		It("Learns from a stream of small batches", func() {
+			c, _ := sgd.NewSGDClassifier()
+			for start := 0; start < ds.NumRows(); start = start + 10 {
+				Ω(c.PartialFit(rowRange(ds, start, start+10))).Should(Succeed())
+			}
+
+			Ω(accuracy(c, blobsDataset(90))).Should(BeNumerically(">", 0.9))
+		})
+
+		It("Adds targets first seen in later batches", func() {
+			c, _ := sgd.NewSGDClassifier()
+			Ω(c.PartialFit(rowRange(ds, 0, 2))).Should(Succeed())
+
+			targets, _, err := c.DecisionFunction(testRowAt(0, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(2))
+
+			Ω(c.PartialFit(rowRange(ds, 2, ds.NumRows()))).Should(Succeed())
+			targets, _, err = c.DecisionFunction(testRowAt(0, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets).Should(HaveLen(3))
+		})
+
+		It("Requires batches with as many features as earlier ones", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			narrow := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			Ω(narrow.AddRowFromStrings([]string{"red", "1"})).Should(Succeed())
+
+			c, _ := sgd.NewSGDClassifier()
+			Ω(c.PartialFit(ds)).Should(Succeed())
+			Ω(c.PartialFit(narrow)).Should(BeAssignableToTypeOf(sgderrors.BatchLengthMismatchError{}))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package svm
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/svmerrors"
+)
+
+const defaultKernelTolerance = 1e-3
+
+// NewKernelSVM returns a support vector machine trained by sequential minimal
+// optimization in the feature space of the given kernel.  A nil kernel means
+// an RBF kernel with gamma of one over the number of features.  Datasets with
+// more than two targets are handled one-vs-rest.
+func NewKernelSVM(kernel svmutilities.Kernel, options ...Option) (*kernelSVM, error) {
+	p, err := newParameters(options, defaultKernelTolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kernelSVM{parameters: p, kernel: kernel}, nil
+}
+
+type kernelSVM struct {
+	parameters
+	kernel svmutilities.Kernel
+
+	targets     []slice.Slice
+	numFeatures int
+	models      []*svmutilities.Model
+}
+
+// Models returns the support vectors and dual coefficients of each binary
+// problem.
+func (svm *kernelSVM) Models() []*svmutilities.Model {
+	return svm.models
+}
+
+func (svm *kernelSVM) Train(trainingData dataset.Dataset) error {
+	features, targets, labels, err := trainingFeatures(trainingData)
+	if err != nil {
+		return err
+	}
+
+	numFeatures := trainingData.NumFeatures()
+	kernel := svm.kernel
+	if kernel == nil {
+		kernel = svmutilities.RBFKernel(1 / float64(numFeatures))
+	}
+
+	vectors := make([][]float64, len(features))
+	index := make([]int, len(features))
+	p := make([]float64, len(features))
+	for i, f := range features {
+		vectors[i] = f.Values()
+		index[i] = i
+		p[i] = -1
+	}
+
+	cache := svmutilities.NewKernelCache(kernel, vectors, svm.cacheRows)
+	costs := svm.rowCosts(targets, labels, dataset.Weights(trainingData))
+
+	positives := oneVsRestPositives(len(targets))
+	models := make([]*svmutilities.Model, len(positives))
+	for j, positive := range positives {
+		y := binaryLabels(labels, positive)
+		solution := svmutilities.Solve(
+			svmutilities.Problem{Y: y, P: p, C: costs, Index: index},
+			cache,
+			svm.tolerance,
+			svm.maxIterations,
+			svm.shrinking,
+		)
+
+		coefficients := make([]float64, len(vectors))
+		for i, alpha := range solution.Alpha {
+			coefficients[i] = y[i] * alpha
+		}
+		models[j] = svmutilities.NewModel(kernel, vectors, coefficients, solution.Rho)
+	}
+
+	svm.targets = targets
+	svm.numFeatures = numFeatures
+	svm.models = models
+	return nil
+}
+
+func (svm *kernelSVM) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, scores, err := svm.DecisionFunction(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return targets[argmax(scores)], nil
+}
+
+// DecisionFunction returns a signed margin for each target; the row is
+// classified as the target with the greatest margin.
+func (svm *kernelSVM) DecisionFunction(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if svm.models == nil {
+		return nil, nil, svmerrors.NewUntrainedClassifierError()
+	}
+
+	features, err := testFeatures(testRow, svm.numFeatures)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	x := features.Values()
+	decisionValues := make([]float64, len(svm.models))
+	for j, model := range svm.models {
+		decisionValues[j] = model.Decision(x)
+	}
+
+	return svm.targets, oneVsRestScores(decisionValues, len(svm.targets)), nil
+}
@@ -0,0 +1,141 @@
+package svm_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/svm"
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/classifier/svmerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KernelSVM", func() {
+	var ringData dataset.Dataset
+
+	BeforeEach(func() {
+		// "inner" within radius 1 of the origin, "outer" between radii 2 and 3
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		random := rand.New(rand.NewSource(5))
+		ringData = dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+		for i := 0; i < 120; i++ {
+			label, radius := "inner", random.Float64()
+			if i%2 == 1 {
+				label, radius = "outer", 2+random.Float64()
+			}
+			angle := 2 * math.Pi * random.Float64()
+
+			err = ringData.AddRowFromStrings([]string{
+				label,
+				fmt.Sprintf("%.6f", radius*math.Cos(angle)),
+				fmt.Sprintf("%.6f", radius*math.Sin(angle)),
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	})
+
+	It("Returns an error before training", func() {
+		kernelSVM, err := svm.NewKernelSVM(nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = kernelSVM.Classify(testRowAt(0, 0))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.UntrainedClassifierError{}))
+	})
+
+	It("Rejects invalid solver settings", func() {
+		_, err := svm.NewKernelSVM(nil, svm.MaxIterations(0))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.InvalidHyperparameterError{}))
+
+		_, err = svm.NewKernelSVM(nil, svm.CacheSize(0))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.InvalidHyperparameterError{}))
+	})
+
+	It("Separates concentric rings with the default RBF kernel", func() {
+		kernelSVM, err := svm.NewKernelSVM(nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(kernelSVM.Train(ringData)).Should(Succeed())
+
+		Ω(trainingAccuracy(kernelSVM, ringData)).Should(Equal(1.0))
+
+		target, err := kernelSVM.Classify(testRowAt(0.1, -0.2))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(target.Equals(targetOfRow(ringData, 0))).Should(BeTrue())
+
+		target, err = kernelSVM.Classify(testRowAt(-2.5, 0))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(target.Equals(targetOfRow(ringData, 1))).Should(BeTrue())
+	})
+
+	It("Separates concentric rings with a quadratic polynomial kernel", func() {
+		kernelSVM, err := svm.NewKernelSVM(svmutilities.PolynomialKernel(2, 1, 1), svm.Cost(10))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(kernelSVM.Train(ringData)).Should(Succeed())
+
+		Ω(trainingAccuracy(kernelSVM, ringData)).Should(Equal(1.0))
+	})
+
+	It("Accepts user-supplied kernels", func() {
+		squaredNormKernel := func(x, y []float64) float64 {
+			return (x[0]*x[0] + x[1]*x[1]) * (y[0]*y[0] + y[1]*y[1])
+		}
+
+		kernelSVM, err := svm.NewKernelSVM(squaredNormKernel, svm.Cost(10))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(kernelSVM.Train(ringData)).Should(Succeed())
+
+		Ω(trainingAccuracy(kernelSVM, ringData)).Should(Equal(1.0))
+	})
+
+	It("Trains with the sigmoid kernel", func() {
+		ds := blobsDataset(0.5, blob{"a", -2, -2, 30}, blob{"b", 2, 2, 30})
+
+		kernelSVM, err := svm.NewKernelSVM(svmutilities.SigmoidKernel(0.1, 0))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(kernelSVM.Train(ds)).Should(Succeed())
+
+		Ω(trainingAccuracy(kernelSVM, ds)).Should(BeNumerically(">=", 0.95))
+	})
+
+	It("Exposes support vectors and dual coefficients", func() {
+		kernelSVM, err := svm.NewKernelSVM(nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(kernelSVM.Train(ringData)).Should(Succeed())
+
+		models := kernelSVM.Models()
+		Ω(models).Should(HaveLen(1))
+		Ω(len(models[0].SupportVectors)).Should(BeNumerically(">", 0))
+		Ω(len(models[0].SupportVectors)).Should(BeNumerically("<", ringData.NumRows()))
+		Ω(models[0].DualCoefficients).Should(HaveLen(len(models[0].SupportVectors)))
+
+		sum := 0.0
+		for _, coefficient := range models[0].DualCoefficients {
+			Ω(math.Abs(coefficient)).Should(BeNumerically("<=", 1+1e-9))
+			sum = sum + coefficient
+		}
+		Ω(sum).Should(BeNumerically("~", 0, 1e-9))
+
+		for j, i := range models[0].SupportIndices {
+			r, err := ringData.Row(i)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(testRowAt(models[0].SupportVectors[j]...).Features().Equals(r.Features())).Should(BeTrue())
+		}
+	})
+
+	It("Classifies amongst several targets one-vs-rest", func() {
+		ds := blobsDataset(0.5, blob{"a", 0, 0, 20}, blob{"b", 4, 0, 20}, blob{"c", 0, 4, 20})
+
+		kernelSVM, err := svm.NewKernelSVM(svmutilities.RBFKernel(0.5), svm.NoShrinking())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(kernelSVM.Train(ds)).Should(Succeed())
+
+		Ω(kernelSVM.Models()).Should(HaveLen(3))
+		Ω(trainingAccuracy(kernelSVM, ds)).Should(BeNumerically(">=", 0.95))
+	})
+})
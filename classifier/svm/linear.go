@@ -0,0 +1,166 @@
+package svm
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/svmerrors"
+)
+
+const defaultLinearTolerance = 0.1
+
+// NewLinearSVM returns a linear support vector machine trained by dual
+// coordinate descent.  Features may be dense or slice.SparseFloatSlices; the
+// solver only touches non-zero entries.  Datasets with more than two targets
+// are handled one-vs-rest.
+func NewLinearSVM(options ...Option) (*linearSVM, error) {
+	p, err := newParameters(options, defaultLinearTolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &linearSVM{parameters: p}, nil
+}
+
+type linearSVM struct {
+	parameters
+
+	targets     []slice.Slice
+	numFeatures int
+	weights     [][]float64
+}
+
+// Weights returns, for each binary problem, the coefficient of each feature
+// followed by the intercept.
+func (svm *linearSVM) Weights() [][]float64 {
+	return svm.weights
+}
+
+func (svm *linearSVM) Train(trainingData dataset.Dataset) error {
+	features, targets, labels, err := trainingFeatures(trainingData)
+	if err != nil {
+		return err
+	}
+
+	vectors := make([]sparseVector, len(features))
+	for i, f := range features {
+		vectors[i] = newSparseVector(f)
+	}
+
+	numFeatures := trainingData.NumFeatures()
+	costs := svm.rowCosts(targets, labels, dataset.Weights(trainingData))
+	random := rand.New(svm.source)
+
+	positives := oneVsRestPositives(len(targets))
+	weights := make([][]float64, len(positives))
+	for j, positive := range positives {
+		weights[j] = svm.solve(vectors, binaryLabels(labels, positive), costs, numFeatures, random)
+	}
+
+	svm.targets = targets
+	svm.numFeatures = numFeatures
+	svm.weights = weights
+	return nil
+}
+
+func (svm *linearSVM) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, scores, err := svm.DecisionFunction(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	return targets[argmax(scores)], nil
+}
+
+// DecisionFunction returns a signed margin for each target; the row is
+// classified as the target with the greatest margin.
+func (svm *linearSVM) DecisionFunction(testRow row.Row) ([]slice.Slice, []float64, error) {
+	if svm.weights == nil {
+		return nil, nil, svmerrors.NewUntrainedClassifierError()
+	}
+
+	features, err := testFeatures(testRow, svm.numFeatures)
+	if err != nil {
+		return nil, nil, err
+	}
+	x := newSparseVector(features)
+
+	decisionValues := make([]float64, len(svm.weights))
+	for j, w := range svm.weights {
+		decisionValues[j] = x.dot(w) + w[svm.numFeatures]
+	}
+
+	return svm.targets, oneVsRestScores(decisionValues, len(svm.targets)), nil
+}
+
+// solve minimizes the dual of the L2-regularized hinge (or squared hinge)
+// loss problem one coordinate at a time, as in Hsieh et al. (2008).  The
+// intercept is learnt as the weight of an extra feature which is always 1.
+func (svm *linearSVM) solve(
+	vectors []sparseVector,
+	y []float64,
+	costs []float64,
+	numFeatures int,
+	random *rand.Rand,
+) []float64 {
+	bias := 0.0
+	if svm.fitIntercept {
+		bias = 1
+	}
+
+	n := len(vectors)
+	w := make([]float64, numFeatures+1)
+	alpha := make([]float64, n)
+	upper := make([]float64, n)
+	diagonal := make([]float64, n)
+	qii := make([]float64, n)
+
+	for i, v := range vectors {
+		upper[i] = costs[i]
+		if svm.squaredHinge && costs[i] > 0 {
+			upper[i] = math.Inf(1)
+			diagonal[i] = 1 / (2 * costs[i])
+		}
+		qii[i] = v.squaredNorm() + bias*bias + diagonal[i]
+	}
+
+	for epoch := 0; epoch < svm.maxEpochs; epoch++ {
+		maxProjectedGradient := math.Inf(-1)
+		minProjectedGradient := math.Inf(1)
+
+		for _, i := range random.Perm(n) {
+			if costs[i] == 0 || qii[i] == 0 {
+				continue
+			}
+
+			g := y[i]*(vectors[i].dot(w)+bias*w[numFeatures]) - 1 + diagonal[i]*alpha[i]
+
+			projectedGradient := g
+			if alpha[i] == 0 {
+				projectedGradient = math.Min(g, 0)
+			} else if alpha[i] == upper[i] {
+				projectedGradient = math.Max(g, 0)
+			}
+
+			maxProjectedGradient = math.Max(maxProjectedGradient, projectedGradient)
+			minProjectedGradient = math.Min(minProjectedGradient, projectedGradient)
+
+			if projectedGradient != 0 {
+				old := alpha[i]
+				alpha[i] = math.Min(math.Max(alpha[i]-g/qii[i], 0), upper[i])
+				step := (alpha[i] - old) * y[i]
+				vectors[i].addScaledTo(w, step)
+				w[numFeatures] = w[numFeatures] + step*bias
+			}
+		}
+
+		if maxProjectedGradient-minProjectedGradient <= svm.tolerance {
+			break
+		}
+	}
+
+	return w
+}
@@ -0,0 +1,198 @@
+package svm_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/svm"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/svmerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LinearSVM", func() {
+	var binaryData dataset.Dataset
+
+	BeforeEach(func() {
+		binaryData = blobsDataset(0.5, blob{"a", 0, 0, 40}, blob{"b", 3, 3, 40})
+	})
+
+	Describe("Train", func() {
+		It("Requires float features", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = linearSVM.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(svmerrors.NonFloatFeaturesTrainingSetError{}))
+		})
+
+		It("Requires a non-empty dataset", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = linearSVM.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(svmerrors.EmptyTrainingDatasetError{}))
+		})
+
+		It("Requires at least two targets", func() {
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = linearSVM.Train(blobsDataset(1, blob{"a", 0, 0, 5}))
+			Ω(err).Should(BeAssignableToTypeOf(svmerrors.TooFewTargetsError{}))
+		})
+	})
+
+	Describe("Classify", func() {
+		It("Returns an error before training", func() {
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = linearSVM.Classify(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(svmerrors.UntrainedClassifierError{}))
+		})
+
+		It("Returns an error for rows of the wrong length", func() {
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(linearSVM.Train(binaryData)).Should(Succeed())
+
+			_, err = linearSVM.Classify(testRowAt(0))
+			Ω(err).Should(BeAssignableToTypeOf(svmerrors.RowLengthMismatchError{}))
+		})
+
+		It("Separates two classes with the hinge loss", func() {
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(linearSVM.Train(binaryData)).Should(Succeed())
+
+			Ω(trainingAccuracy(linearSVM, binaryData)).Should(BeNumerically(">=", 0.97))
+
+			targets, scores, err := linearSVM.DecisionFunction(testRowAt(3, 3))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets[1].Equals(targetOfRow(binaryData, 40))).Should(BeTrue())
+			Ω(scores[1]).Should(BeNumerically(">", 1))
+		})
+
+		It("Separates two classes with the squared hinge loss", func() {
+			linearSVM, err := svm.NewLinearSVM(svm.SquaredHingeLoss())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(linearSVM.Train(binaryData)).Should(Succeed())
+
+			Ω(trainingAccuracy(linearSVM, binaryData)).Should(BeNumerically(">=", 0.97))
+		})
+
+		It("Fits no intercept when asked not to", func() {
+			linearSVM, err := svm.NewLinearSVM(svm.NoIntercept())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(linearSVM.Train(binaryData)).Should(Succeed())
+
+			Ω(linearSVM.Weights()[0][2]).Should(BeZero())
+		})
+
+		It("Classifies amongst several targets one-vs-rest", func() {
+			ds := blobsDataset(0.5, blob{"a", 0, 0, 30}, blob{"b", 4, 0, 30}, blob{"c", 0, 4, 30})
+
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(linearSVM.Train(ds)).Should(Succeed())
+
+			Ω(linearSVM.Weights()).Should(HaveLen(3))
+			Ω(trainingAccuracy(linearSVM, ds)).Should(BeNumerically(">=", 0.95))
+		})
+
+		It("Moves the boundary away from up-weighted classes", func() {
+			imbalanced := blobsDataset(1, blob{"a", 0, 0, 100}, blob{"b", 2, 2, 10})
+			minority := targetOfRow(imbalanced, 100)
+
+			unweighted, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(unweighted.Train(imbalanced)).Should(Succeed())
+
+			weighted, err := svm.NewLinearSVM(svm.BalancedClassWeights())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(weighted.Train(imbalanced)).Should(Succeed())
+
+			_, unweightedScores, err := unweighted.DecisionFunction(testRowAt(1, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			_, weightedScores, err := weighted.DecisionFunction(testRowAt(1, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(weightedScores[1]).Should(BeNumerically(">", unweightedScores[1]))
+
+			target, err := weighted.Classify(testRowAt(1.5, 1.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(minority)).Should(BeTrue())
+		})
+
+		It("Moves the boundary away from up-weighted rows", func() {
+			imbalanced := blobsDataset(1, blob{"a", 0, 0, 100}, blob{"b", 2, 2, 10})
+			minority := targetOfRow(imbalanced, 100)
+
+			rowWeights := make([]float64, imbalanced.NumRows())
+			for i := range rowWeights {
+				rowWeights[i] = 1
+				if i >= 100 {
+					rowWeights[i] = 10
+				}
+			}
+			weightedData, err := dataset.NewWeightedDataset(imbalanced, rowWeights)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			unweighted, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(unweighted.Train(imbalanced)).Should(Succeed())
+
+			weighted, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(weighted.Train(weightedData)).Should(Succeed())
+
+			_, unweightedScores, err := unweighted.DecisionFunction(testRowAt(1, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			_, weightedScores, err := weighted.DecisionFunction(testRowAt(1, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(weightedScores[1]).Should(BeNumerically(">", unweightedScores[1]))
+
+			target, err := weighted.Classify(testRowAt(1.5, 1.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(minority)).Should(BeTrue())
+		})
+
+		It("Trains and classifies on sparse features", func() {
+			rows := []row.Row{}
+			for i := 0; i < 20; i++ {
+				label, index := "spam", i%3
+				if i%2 == 0 {
+					label, index = "ham", 500+i%3
+				}
+
+				features, err := slice.NewSparseFloatSlice(1000, []int{index, 999}, []float64{1, 0.5})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				target := targetOfRow(blobsDataset(0, blob{label, 0, 0, 1}), 0)
+				rows = append(rows, row.NewRow(features, target, 1000))
+			}
+			ds := dataset.NewDatasetFromRows(1000, 1, rows)
+
+			linearSVM, err := svm.NewLinearSVM()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(linearSVM.Train(ds)).Should(Succeed())
+			Ω(trainingAccuracy(linearSVM, ds)).Should(Equal(1.0))
+
+			features, err := slice.NewSparseFloatSlice(1000, []int{501}, []float64{1})
+			Ω(err).ShouldNot(HaveOccurred())
+			target, err := linearSVM.Classify(row.NewRow(features, nil, 1000))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(targetOfRow(ds, 0))).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,346 @@
+package svm
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/svmerrors"
+)
+
+const (
+	defaultCost          = 1.0
+	defaultMaxEpochs     = 1000
+	defaultMaxIterations = 10000000
+	defaultCacheRows     = 1000
+)
+
+type Option func(*parameters)
+
+// Cost sets C, the penalty on margin violations; smaller values regularize
+// more strongly.
+func Cost(cost float64) Option {
+	return func(p *parameters) {
+		p.cost = cost
+	}
+}
+
+// SquaredHingeLoss penalizes margin violations quadratically rather than
+// linearly (linear SVM only).
+func SquaredHingeLoss() Option {
+	return func(p *parameters) {
+		p.squaredHinge = true
+	}
+}
+
+// NoIntercept fits a separating hyperplane through the origin (linear SVM
+// only).
+func NoIntercept() Option {
+	return func(p *parameters) {
+		p.fitIntercept = false
+	}
+}
+
+// ClassWeights scales C for rows of each given target; targets not listed have
+// weight 1.
+func ClassWeights(targets []slice.Slice, weights []float64) Option {
+	return func(p *parameters) {
+		p.classWeightTargets = targets
+		p.classWeights = weights
+	}
+}
+
+// BalancedClassWeights scales C for each target inversely to its frequency in
+// the training data.
+func BalancedClassWeights() Option {
+	return func(p *parameters) {
+		p.balancedClassWeights = true
+	}
+}
+
+// Tolerance sets the stopping tolerance on the solver's projected gradient.
+func Tolerance(tolerance float64) Option {
+	return func(p *parameters) {
+		p.tolerance = tolerance
+	}
+}
+
+// MaxEpochs bounds the number of passes the solver makes over the training
+// rows (linear SVM only).
+func MaxEpochs(maxEpochs int) Option {
+	return func(p *parameters) {
+		p.maxEpochs = maxEpochs
+	}
+}
+
+// MaxIterations bounds the number of pairs of dual variables the SMO solver
+// optimizes (kernel SVM only).
+func MaxIterations(maxIterations int) Option {
+	return func(p *parameters) {
+		p.maxIterations = maxIterations
+	}
+}
+
+// CacheSize sets how many rows of the kernel matrix are kept in memory
+// (kernel SVM only).
+func CacheSize(rows int) Option {
+	return func(p *parameters) {
+		p.cacheRows = rows
+	}
+}
+
+// NoShrinking stops the SMO solver from setting aside variables which appear
+// settled at a bound (kernel SVM only).
+func NoShrinking() Option {
+	return func(p *parameters) {
+		p.shrinking = false
+	}
+}
+
+// RandomSource sets the source used to shuffle the order in which the solver
+// visits training rows.
+func RandomSource(source rand.Source) Option {
+	return func(p *parameters) {
+		p.source = source
+	}
+}
+
+type parameters struct {
+	cost                 float64
+	squaredHinge         bool
+	fitIntercept         bool
+	classWeightTargets   []slice.Slice
+	classWeights         []float64
+	balancedClassWeights bool
+	tolerance            float64
+	maxEpochs            int
+	maxIterations        int
+	cacheRows            int
+	shrinking            bool
+	source               rand.Source
+}
+
+func newParameters(options []Option, defaultTolerance float64) (parameters, error) {
+	p := parameters{
+		cost:          defaultCost,
+		fitIntercept:  true,
+		tolerance:     defaultTolerance,
+		maxEpochs:     defaultMaxEpochs,
+		maxIterations: defaultMaxIterations,
+		cacheRows:     defaultCacheRows,
+		shrinking:     true,
+	}
+
+	for _, option := range options {
+		option(&p)
+	}
+
+	if p.cost <= 0 {
+		return p, svmerrors.NewInvalidHyperparameterError("cost", p.cost)
+	}
+
+	if p.tolerance <= 0 {
+		return p, svmerrors.NewInvalidHyperparameterError("tolerance", p.tolerance)
+	}
+
+	if p.maxEpochs < 1 {
+		return p, svmerrors.NewInvalidHyperparameterError("max epochs", float64(p.maxEpochs))
+	}
+
+	if p.maxIterations < 1 {
+		return p, svmerrors.NewInvalidHyperparameterError("max iterations", float64(p.maxIterations))
+	}
+
+	if p.cacheRows < 1 {
+		return p, svmerrors.NewInvalidHyperparameterError("cache size", float64(p.cacheRows))
+	}
+
+	if len(p.classWeightTargets) != len(p.classWeights) {
+		return p, svmerrors.NewClassWeightsLengthMismatchError(len(p.classWeightTargets), len(p.classWeights))
+	}
+
+	for _, w := range p.classWeights {
+		if w < 0 {
+			return p, svmerrors.NewInvalidHyperparameterError("class weight", w)
+		}
+	}
+
+	if p.source == nil {
+		p.source = rand.NewSource(1)
+	}
+
+	return p, nil
+}
+
+// rowCosts returns C scaled by the class weight of each row's target and by
+// the row's weight.
+func (p parameters) rowCosts(targets []slice.Slice, labels []int, rowWeights []float64) []float64 {
+	counts := make([]float64, len(targets))
+	total := 0.0
+	for i, k := range labels {
+		counts[k] = counts[k] + rowWeights[i]
+		total = total + rowWeights[i]
+	}
+
+	targetCosts := make([]float64, len(targets))
+	for k, target := range targets {
+		targetCosts[k] = p.cost
+		if p.balancedClassWeights {
+			targetCosts[k] = targetCosts[k] * total / (float64(len(targets)) * counts[k])
+		}
+
+		if j := classifierutilities.TargetIndex(p.classWeightTargets, target); j >= 0 {
+			targetCosts[k] = targetCosts[k] * p.classWeights[j]
+		}
+	}
+
+	costs := make([]float64, len(labels))
+	for i, k := range labels {
+		costs[i] = targetCosts[k] * rowWeights[i]
+	}
+	return costs
+}
+
+type sparseVector struct {
+	indices []int
+	values  []float64
+}
+
+func newSparseVector(features slice.FloatSlice) sparseVector {
+	if sparseFeatures, ok := features.(slice.SparseFloatSlice); ok {
+		indices, values := sparseFeatures.NonZero()
+		return sparseVector{indices, values}
+	}
+
+	var v sparseVector
+	for i, value := range features.Values() {
+		if value != 0 {
+			v.indices = append(v.indices, i)
+			v.values = append(v.values, value)
+		}
+	}
+	return v
+}
+
+func (v sparseVector) dot(w []float64) float64 {
+	result := 0.0
+	for j, i := range v.indices {
+		result = result + v.values[j]*w[i]
+	}
+	return result
+}
+
+func (v sparseVector) addScaledTo(w []float64, scale float64) {
+	for j, i := range v.indices {
+		w[i] = w[i] + scale*v.values[j]
+	}
+}
+
+func (v sparseVector) squaredNorm() float64 {
+	result := 0.0
+	for _, value := range v.values {
+		result = result + value*value
+	}
+	return result
+}
+
+// trainingFeatures returns the features of each row of the dataset, along
+// with the distinct targets and the index of each row's target amongst them.
+func trainingFeatures(trainingData dataset.Dataset) ([]slice.FloatSlice, []slice.Slice, []int, error) {
+	if !trainingData.AllFeaturesFloats() {
+		return nil, nil, nil, svmerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if trainingData.NumRows() == 0 {
+		return nil, nil, nil, svmerrors.NewEmptyTrainingDatasetError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(targets) < 2 {
+		return nil, nil, nil, svmerrors.NewTooFewTargetsError(len(targets))
+	}
+
+	features := make([]slice.FloatSlice, trainingData.NumRows())
+	labels := make([]int, trainingData.NumRows())
+	for i := range features {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		var ok bool
+		features[i], ok = r.Features().(slice.FloatSlice)
+		if !ok {
+			return nil, nil, nil, svmerrors.NewNonFloatFeaturesTrainingSetError()
+		}
+
+		labels[i] = classifierutilities.TargetIndex(targets, r.Target())
+	}
+
+	return features, targets, labels, nil
+}
+
+func testFeatures(testRow row.Row, numFeatures int) (slice.FloatSlice, error) {
+	if testRow.NumFeatures() != numFeatures {
+		return nil, svmerrors.NewRowLengthMismatchError(testRow.NumFeatures(), numFeatures)
+	}
+
+	features, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, svmerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return features, nil
+}
+
+// oneVsRestPositives returns the index of the positive target of each binary
+// problem: a single problem separating the second target from the first when
+// there are two targets, otherwise one problem per target.
+func oneVsRestPositives(numTargets int) []int {
+	if numTargets == 2 {
+		return []int{1}
+	}
+
+	positives := make([]int, numTargets)
+	for k := range positives {
+		positives[k] = k
+	}
+	return positives
+}
+
+// binaryLabels returns +1 for rows whose target is positive and -1 otherwise.
+func binaryLabels(labels []int, positive int) []float64 {
+	y := make([]float64, len(labels))
+	for i, k := range labels {
+		y[i] = -1
+		if k == positive {
+			y[i] = 1
+		}
+	}
+	return y
+}
+
+// oneVsRestScores returns a score per target from the decision values of the
+// binary problems given by oneVsRestPositives.
+func oneVsRestScores(decisionValues []float64, numTargets int) []float64 {
+	if numTargets == 2 {
+		return []float64{-decisionValues[0], decisionValues[0]}
+	}
+	return decisionValues
+}
+
+func argmax(values []float64) int {
+	best := 0
+	for i, v := range values {
+		if v > values[best] {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,13 @@
+package svm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSvm(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Svm Suite")
+}
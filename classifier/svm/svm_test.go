@@ -0,0 +1,95 @@
+package svm_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/classifier/svm"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/classifier/svmerrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type blob struct {
+	label string
+	x, y  float64
+	size  int
+}
+
+// blobsDataset has, for each blob, size rows labelled with the blob's label
+// and normally distributed about its centre.
+func blobsDataset(spread float64, blobs ...blob) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(7))
+	ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+	for _, b := range blobs {
+		for i := 0; i < b.size; i++ {
+			err = ds.AddRowFromStrings([]string{
+				b.label,
+				fmt.Sprintf("%.6f", b.x+spread*random.NormFloat64()),
+				fmt.Sprintf("%.6f", b.y+spread*random.NormFloat64()),
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	}
+
+	return ds
+}
+
+func testRowAt(x ...float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice(x), nil, len(x))
+}
+
+func targetOfRow(ds dataset.Dataset, i int) slice.Slice {
+	r, err := ds.Row(i)
+	Ω(err).ShouldNot(HaveOccurred())
+	return r.Target()
+}
+
+func trainingAccuracy(c classifier.Classifier, ds dataset.Dataset) float64 {
+	correct := 0
+	for i := 0; i < ds.NumRows(); i++ {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		target, err := c.Classify(r)
+		Ω(err).ShouldNot(HaveOccurred())
+		if target.Equals(r.Target()) {
+			correct++
+		}
+	}
+	return float64(correct) / float64(ds.NumRows())
+}
+
+var _ = Describe("Options", func() {
+	It("Rejects a non-positive cost", func() {
+		_, err := svm.NewLinearSVM(svm.Cost(0))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.InvalidHyperparameterError{}))
+	})
+
+	It("Rejects a non-positive tolerance", func() {
+		_, err := svm.NewLinearSVM(svm.Tolerance(-1))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.InvalidHyperparameterError{}))
+	})
+
+	It("Rejects a non-positive number of epochs", func() {
+		_, err := svm.NewLinearSVM(svm.MaxEpochs(0))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.InvalidHyperparameterError{}))
+	})
+
+	It("Rejects mismatched or negative class weights", func() {
+		_, err := svm.NewLinearSVM(svm.ClassWeights([]slice.Slice{nil, nil}, []float64{1}))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.ClassWeightsLengthMismatchError{}))
+
+		_, err = svm.NewLinearSVM(svm.ClassWeights([]slice.Slice{nil}, []float64{-1}))
+		Ω(err).Should(BeAssignableToTypeOf(svmerrors.InvalidHyperparameterError{}))
+	})
+})
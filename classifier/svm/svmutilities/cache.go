@@ -0,0 +1,85 @@
+package svmutilities
+
+import (
+	"container/list"
+)
+
+// KernelCache evaluates the kernel between pairs of training vectors,
+// keeping the most recently used rows of the kernel matrix in memory.
+type KernelCache struct {
+	kernel   Kernel
+	vectors  [][]float64
+	diagonal []float64
+	maxRows  int
+
+	rows    map[int]*list.Element
+	recency *list.List
+}
+
+type cachedRow struct {
+	index  int
+	values []float64
+}
+
+// NewKernelCache returns a cache holding at most maxRows rows of the kernel
+// matrix of the given vectors; maxRows is raised to 2 if smaller, since the
+// solver works on two rows at a time.
+func NewKernelCache(kernel Kernel, vectors [][]float64, maxRows int) *KernelCache {
+	if maxRows < 2 {
+		maxRows = 2
+	}
+
+	diagonal := make([]float64, len(vectors))
+	for i, v := range vectors {
+		diagonal[i] = kernel(v, v)
+	}
+
+	return &KernelCache{
+		kernel:   kernel,
+		vectors:  vectors,
+		diagonal: diagonal,
+		maxRows:  maxRows,
+		rows:     map[int]*list.Element{},
+		recency:  list.New(),
+	}
+}
+
+func (kc *KernelCache) NumVectors() int {
+	return len(kc.vectors)
+}
+
+func (kc *KernelCache) Diagonal(i int) float64 {
+	return kc.diagonal[i]
+}
+
+// Row returns the kernel between the i-th vector and every vector.  The
+// returned slice must not be modified; it remains valid across one further
+// call to Row, but may be reused after that.
+func (kc *KernelCache) Row(i int) []float64 {
+	if element, ok := kc.rows[i]; ok {
+		kc.recency.MoveToFront(element)
+		return element.Value.(*cachedRow).values
+	}
+
+	var values []float64
+	if kc.recency.Len() >= kc.maxRows {
+		oldest := kc.recency.Back()
+		kc.recency.Remove(oldest)
+		evicted := oldest.Value.(*cachedRow)
+		delete(kc.rows, evicted.index)
+		values = evicted.values
+	} else {
+		values = make([]float64, len(kc.vectors))
+	}
+
+	for j, v := range kc.vectors {
+		values[j] = kc.kernel(kc.vectors[i], v)
+	}
+
+	kc.rows[i] = kc.recency.PushFront(&cachedRow{i, values})
+	return values
+}
+
+func (kc *KernelCache) NumCachedRows() int {
+	return kc.recency.Len()
+}
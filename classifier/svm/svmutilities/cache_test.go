@@ -0,0 +1,48 @@
+package svmutilities_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KernelCache", func() {
+	var evaluations int
+	var cache *svmutilities.KernelCache
+
+	BeforeEach(func() {
+		evaluations = 0
+		countingKernel := func(x, y []float64) float64 {
+			evaluations++
+			return x[0] * y[0]
+		}
+
+		cache = svmutilities.NewKernelCache(countingKernel, [][]float64{{1}, {2}, {3}}, 2)
+	})
+
+	It("Precomputes the diagonal", func() {
+		Ω(evaluations).Should(Equal(3))
+		Ω(cache.Diagonal(2)).Should(Equal(9.0))
+		Ω(cache.NumVectors()).Should(Equal(3))
+	})
+
+	It("Computes rows of the kernel matrix", func() {
+		Ω(cache.Row(1)).Should(Equal([]float64{2, 4, 6}))
+	})
+
+	It("Only computes a row again once it has been evicted", func() {
+		cache.Row(0)
+		cache.Row(1)
+		cache.Row(0)
+		Ω(evaluations).Should(Equal(9))
+		Ω(cache.NumCachedRows()).Should(Equal(2))
+
+		cache.Row(2)
+		cache.Row(0)
+		Ω(evaluations).Should(Equal(12))
+
+		cache.Row(1)
+		Ω(evaluations).Should(Equal(15))
+	})
+})
@@ -0,0 +1,50 @@
+package svmutilities
+
+import (
+	"math"
+)
+
+// Kernel computes an inner product between two feature vectors in some
+// (possibly implicit) feature space.  Any func(x, y []float64) float64 which
+// is symmetric and positive semi-definite may be used.
+type Kernel func(x, y []float64) float64
+
+func LinearKernel() Kernel {
+	return dot
+}
+
+// RBFKernel is exp(-gamma * |x - y|^2).
+func RBFKernel(gamma float64) Kernel {
+	return func(x, y []float64) float64 {
+		squaredDistance := 0.0
+		for i := range x {
+			diff := x[i] - y[i]
+			squaredDistance = squaredDistance + diff*diff
+		}
+		return math.Exp(-gamma * squaredDistance)
+	}
+}
+
+// PolynomialKernel is (gamma * x.y + coef0)^degree.
+func PolynomialKernel(degree int, gamma, coef0 float64) Kernel {
+	return func(x, y []float64) float64 {
+		return math.Pow(gamma*dot(x, y)+coef0, float64(degree))
+	}
+}
+
+// SigmoidKernel is tanh(gamma * x.y + coef0).  It is not positive
+// semi-definite for all parameters, in which case the solver still
+// terminates but the result need not be optimal.
+func SigmoidKernel(gamma, coef0 float64) Kernel {
+	return func(x, y []float64) float64 {
+		return math.Tanh(gamma*dot(x, y) + coef0)
+	}
+}
+
+func dot(x, y []float64) float64 {
+	result := 0.0
+	for i := range x {
+		result = result + x[i]*y[i]
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package svmutilities_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Kernels", func() {
+	x := []float64{1, 2}
+	y := []float64{3, -1}
+
+	It("LinearKernel is the dot product", func() {
+		Ω(svmutilities.LinearKernel()(x, y)).Should(Equal(1.0))
+	})
+
+	It("RBFKernel decays with squared distance", func() {
+		Ω(svmutilities.RBFKernel(0.1)(x, y)).Should(BeNumerically("~", math.Exp(-1.3), 1e-12))
+		Ω(svmutilities.RBFKernel(0.1)(x, x)).Should(Equal(1.0))
+	})
+
+	It("PolynomialKernel raises the shifted, scaled dot product to a power", func() {
+		Ω(svmutilities.PolynomialKernel(3, 2, 1)(x, y)).Should(BeNumerically("~", 27, 1e-12))
+	})
+
+	It("SigmoidKernel is the tanh of the shifted, scaled dot product", func() {
+		Ω(svmutilities.SigmoidKernel(0.5, -1)(x, y)).Should(BeNumerically("~", math.Tanh(-0.5), 1e-12))
+	})
+})
@@ -0,0 +1,36 @@
+package svmutilities
+
+// Model is the kernel expansion
+//
+//	f(x) = sum_i DualCoefficients[i] * K(SupportVectors[i], x) - Rho
+//
+// where SupportVectors[i] is the SupportIndices[i]-th training vector.
+type Model struct {
+	Kernel           Kernel
+	SupportVectors   [][]float64
+	SupportIndices   []int
+	DualCoefficients []float64
+	Rho              float64
+}
+
+// NewModel keeps the vectors whose coefficients are non-zero as support
+// vectors.
+func NewModel(kernel Kernel, vectors [][]float64, coefficients []float64, rho float64) *Model {
+	model := &Model{Kernel: kernel, Rho: rho}
+	for i, coefficient := range coefficients {
+		if coefficient != 0 {
+			model.SupportVectors = append(model.SupportVectors, vectors[i])
+			model.SupportIndices = append(model.SupportIndices, i)
+			model.DualCoefficients = append(model.DualCoefficients, coefficient)
+		}
+	}
+	return model
+}
+
+func (m *Model) Decision(x []float64) float64 {
+	result := -m.Rho
+	for i, sv := range m.SupportVectors {
+		result = result + m.DualCoefficients[i]*m.Kernel(sv, x)
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+package svmutilities_test
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Model", func() {
+	It("Keeps only vectors with non-zero coefficients", func() {
+		vectors := [][]float64{{0}, {1}, {2}}
+		model := svmutilities.NewModel(svmutilities.LinearKernel(), vectors, []float64{0.5, 0, -0.5}, -1)
+
+		Ω(model.SupportIndices).Should(Equal([]int{0, 2}))
+		Ω(model.SupportVectors).Should(Equal([][]float64{{0}, {2}}))
+		Ω(model.DualCoefficients).Should(Equal([]float64{0.5, -0.5}))
+	})
+
+	It("Evaluates the kernel expansion less rho", func() {
+		vectors := [][]float64{{0}, {2}}
+		model := svmutilities.NewModel(svmutilities.LinearKernel(), vectors, []float64{0.5, -0.5}, -1)
+
+		Ω(model.Decision([]float64{0})).Should(Equal(1.0))
+		Ω(model.Decision([]float64{2})).Should(Equal(-1.0))
+	})
+})
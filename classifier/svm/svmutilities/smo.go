@@ -0,0 +1,399 @@
+package svmutilities
+
+import (
+	"math"
+)
+
+const tau = 1e-12
+
+// Problem is the dual quadratic program
+//
+//	minimize    1/2 a'Qa + P'a
+//	subject to  Y'a = 0 and 0 <= a[t] <= C[t]
+//
+// where Q[s][t] = Y[s] * Y[t] * K(Index[s], Index[t]) and K is the kernel
+// matrix of a KernelCache.  Each variable t has label Y[t] of +1 or -1 and
+// refers to training vector Index[t]; several variables may refer to the same
+// vector, as in epsilon-SVR.
+type Problem struct {
+	Y     []float64
+	P     []float64
+	C     []float64
+	Index []int
+}
+
+type Solution struct {
+	Alpha      []float64
+	Rho        float64
+	Iterations int
+	Converged  bool
+}
+
+// Solve runs sequential minimal optimization with second order working set
+// selection (Fan, Chen and Lin, 2005) until the maximal violating pair
+// violates the optimality conditions by less than tolerance, or until
+// maxIterations.  With shrinking, variables which appear to be settled at a
+// bound are periodically left out of the working set selection; the
+// gradients of all variables are reconstructed before optimality is
+// confirmed.
+func Solve(problem Problem, cache *KernelCache, tolerance float64, maxIterations int, shrinking bool) Solution {
+	s := newSolver(problem, cache)
+
+	shrinkInterval := len(s.Y)
+	if shrinkInterval > 1000 {
+		shrinkInterval = 1000
+	}
+	countdown := shrinkInterval
+	unshrunk := false
+
+	solution := Solution{}
+	for solution.Iterations < maxIterations {
+		if shrinking {
+			countdown--
+			if countdown == 0 {
+				countdown = shrinkInterval
+				unshrunk = s.shrink(tolerance, unshrunk)
+			}
+		}
+
+		i, j, optimal := s.selectWorkingSet(tolerance)
+		if optimal {
+			if len(s.active) == len(s.Y) {
+				solution.Converged = true
+				break
+			}
+
+			s.unshrink()
+			countdown = shrinkInterval
+			continue
+		}
+
+		s.update(i, j)
+		solution.Iterations++
+	}
+
+	if len(s.active) < len(s.Y) {
+		s.unshrink()
+	}
+
+	solution.Alpha = s.alpha
+	solution.Rho = s.rho()
+	return solution
+}
+
+type solver struct {
+	Problem
+	cache    *KernelCache
+	alpha    []float64
+	gradient []float64
+	active   []int
+}
+
+func newSolver(problem Problem, cache *KernelCache) *solver {
+	n := len(problem.Y)
+	active := make([]int, n)
+	for t := range active {
+		active[t] = t
+	}
+
+	return &solver{
+		Problem:  problem,
+		cache:    cache,
+		alpha:    make([]float64, n),
+		gradient: append([]float64{}, problem.P...),
+		active:   active,
+	}
+}
+
+func (s *solver) q(kernelRow []float64, i, t int) float64 {
+	return s.Y[i] * s.Y[t] * kernelRow[s.Index[t]]
+}
+
+func (s *solver) qd(t int) float64 {
+	return s.cache.Diagonal(s.Index[t])
+}
+
+func (s *solver) atUpper(t int) bool {
+	return s.alpha[t] >= s.C[t]
+}
+
+func (s *solver) atLower(t int) bool {
+	return s.alpha[t] <= 0
+}
+
+// selectWorkingSet returns the pair of active variables to optimize next, or
+// reports that the active variables are optimal to within tolerance.
+func (s *solver) selectWorkingSet(tolerance float64) (int, int, bool) {
+	gMax := math.Inf(-1)
+	i := -1
+	for _, t := range s.active {
+		if s.Y[t] > 0 {
+			if !s.atUpper(t) && -s.gradient[t] >= gMax {
+				gMax = -s.gradient[t]
+				i = t
+			}
+		} else {
+			if !s.atLower(t) && s.gradient[t] >= gMax {
+				gMax = s.gradient[t]
+				i = t
+			}
+		}
+	}
+
+	if i == -1 {
+		return -1, -1, true
+	}
+
+	rowI := s.cache.Row(s.Index[i])
+	qdI := s.qd(i)
+
+	gMax2 := math.Inf(-1)
+	j := -1
+	minObjectiveDiff := math.Inf(1)
+	for _, t := range s.active {
+		var gradientDiff, quadratic float64
+		if s.Y[t] > 0 {
+			if s.atLower(t) {
+				continue
+			}
+			gradientDiff = gMax + s.gradient[t]
+			gMax2 = math.Max(gMax2, s.gradient[t])
+			quadratic = qdI + s.qd(t) - 2*s.Y[i]*s.q(rowI, i, t)
+		} else {
+			if s.atUpper(t) {
+				continue
+			}
+			gradientDiff = gMax - s.gradient[t]
+			gMax2 = math.Max(gMax2, -s.gradient[t])
+			quadratic = qdI + s.qd(t) + 2*s.Y[i]*s.q(rowI, i, t)
+		}
+
+		if gradientDiff > 0 {
+			if quadratic <= 0 {
+				quadratic = tau
+			}
+
+			objectiveDiff := -gradientDiff * gradientDiff / quadratic
+			if objectiveDiff <= minObjectiveDiff {
+				minObjectiveDiff = objectiveDiff
+				j = t
+			}
+		}
+	}
+
+	if gMax+gMax2 < tolerance || j == -1 {
+		return -1, -1, true
+	}
+
+	return i, j, false
+}
+
+// update analytically optimizes the pair (i, j), clipping to the box
+// constraints, and updates the gradients of the active variables.
+func (s *solver) update(i, j int) {
+	rowI := s.cache.Row(s.Index[i])
+	rowJ := s.cache.Row(s.Index[j])
+
+	cI, cJ := s.C[i], s.C[j]
+	oldI, oldJ := s.alpha[i], s.alpha[j]
+	qIJ := s.q(rowI, i, j)
+
+	if s.Y[i] != s.Y[j] {
+		quadratic := s.qd(i) + s.qd(j) + 2*qIJ
+		if quadratic <= 0 {
+			quadratic = tau
+		}
+		delta := (-s.gradient[i] - s.gradient[j]) / quadratic
+		diff := s.alpha[i] - s.alpha[j]
+		s.alpha[i] = s.alpha[i] + delta
+		s.alpha[j] = s.alpha[j] + delta
+
+		if diff > 0 {
+			if s.alpha[j] < 0 {
+				s.alpha[j] = 0
+				s.alpha[i] = diff
+			}
+		} else {
+			if s.alpha[i] < 0 {
+				s.alpha[i] = 0
+				s.alpha[j] = -diff
+			}
+		}
+
+		if diff > cI-cJ {
+			if s.alpha[i] > cI {
+				s.alpha[i] = cI
+				s.alpha[j] = cI - diff
+			}
+		} else {
+			if s.alpha[j] > cJ {
+				s.alpha[j] = cJ
+				s.alpha[i] = cJ + diff
+			}
+		}
+	} else {
+		quadratic := s.qd(i) + s.qd(j) - 2*qIJ
+		if quadratic <= 0 {
+			quadratic = tau
+		}
+		delta := (s.gradient[i] - s.gradient[j]) / quadratic
+		sum := s.alpha[i] + s.alpha[j]
+		s.alpha[i] = s.alpha[i] - delta
+		s.alpha[j] = s.alpha[j] + delta
+
+		if sum > cI {
+			if s.alpha[i] > cI {
+				s.alpha[i] = cI
+				s.alpha[j] = sum - cI
+			}
+		} else {
+			if s.alpha[j] < 0 {
+				s.alpha[j] = 0
+				s.alpha[i] = sum
+			}
+		}
+
+		if sum > cJ {
+			if s.alpha[j] > cJ {
+				s.alpha[j] = cJ
+				s.alpha[i] = sum - cJ
+			}
+		} else {
+			if s.alpha[i] < 0 {
+				s.alpha[i] = 0
+				s.alpha[j] = sum
+			}
+		}
+	}
+
+	deltaI := s.alpha[i] - oldI
+	deltaJ := s.alpha[j] - oldJ
+	for _, t := range s.active {
+		s.gradient[t] = s.gradient[t] + s.q(rowI, i, t)*deltaI + s.q(rowJ, j, t)*deltaJ
+	}
+}
+
+// shrink removes from the active set variables at a bound whose gradients
+// suggest they will stay there.  Once the active variables are nearly
+// optimal, the full set is restored once so that variables shrunk too
+// eagerly get another chance; the return value records whether that has
+// happened.
+func (s *solver) shrink(tolerance float64, unshrunk bool) bool {
+	gMax1, gMax2 := math.Inf(-1), math.Inf(-1)
+	for _, t := range s.active {
+		if s.Y[t] > 0 {
+			if !s.atUpper(t) {
+				gMax1 = math.Max(gMax1, -s.gradient[t])
+			}
+			if !s.atLower(t) {
+				gMax2 = math.Max(gMax2, s.gradient[t])
+			}
+		} else {
+			if !s.atUpper(t) {
+				gMax2 = math.Max(gMax2, -s.gradient[t])
+			}
+			if !s.atLower(t) {
+				gMax1 = math.Max(gMax1, s.gradient[t])
+			}
+		}
+	}
+
+	if !unshrunk && gMax1+gMax2 <= 10*tolerance {
+		unshrunk = true
+		s.unshrink()
+	}
+
+	active := s.active[:0]
+	for _, t := range s.active {
+		if !s.shouldShrink(t, gMax1, gMax2) {
+			active = append(active, t)
+		}
+	}
+	s.active = active
+
+	return unshrunk
+}
+
+func (s *solver) shouldShrink(t int, gMax1, gMax2 float64) bool {
+	if s.atUpper(t) {
+		if s.Y[t] > 0 {
+			return -s.gradient[t] > gMax1
+		}
+		return -s.gradient[t] > gMax2
+	}
+
+	if s.atLower(t) {
+		if s.Y[t] > 0 {
+			return s.gradient[t] > gMax2
+		}
+		return s.gradient[t] > gMax1
+	}
+
+	return false
+}
+
+// unshrink recomputes the gradients of inactive variables from scratch and
+// makes every variable active again.
+func (s *solver) unshrink() {
+	isActive := make([]bool, len(s.Y))
+	for _, t := range s.active {
+		isActive[t] = true
+	}
+
+	inactive := []int{}
+	for t := range isActive {
+		if !isActive[t] {
+			inactive = append(inactive, t)
+			s.gradient[t] = s.P[t]
+		}
+	}
+
+	for u, a := range s.alpha {
+		if a == 0 {
+			continue
+		}
+
+		row := s.cache.Row(s.Index[u])
+		for _, t := range inactive {
+			s.gradient[t] = s.gradient[t] + s.q(row, u, t)*a
+		}
+	}
+
+	s.active = s.active[:0]
+	for t := range isActive {
+		s.active = append(s.active, t)
+	}
+}
+
+// rho is the offset of the decision function, averaged over free variables
+// where there are any.
+func (s *solver) rho() float64 {
+	upper, lower := math.Inf(1), math.Inf(-1)
+	sumFree, numFree := 0.0, 0
+
+	for t := range s.alpha {
+		yG := s.Y[t] * s.gradient[t]
+
+		if s.atUpper(t) {
+			if s.Y[t] < 0 {
+				upper = math.Min(upper, yG)
+			} else {
+				lower = math.Max(lower, yG)
+			}
+		} else if s.atLower(t) {
+			if s.Y[t] > 0 {
+				upper = math.Min(upper, yG)
+			} else {
+				lower = math.Max(lower, yG)
+			}
+		} else {
+			numFree++
+			sumFree = sumFree + yG
+		}
+	}
+
+	if numFree > 0 {
+		return sumFree / float64(numFree)
+	}
+	return (upper + lower) / 2
+}
@@ -0,0 +1,76 @@
+package svmutilities_test
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Solve", func() {
+	classificationProblem := func(y, c []float64) svmutilities.Problem {
+		p := make([]float64, len(y))
+		index := make([]int, len(y))
+		for i := range y {
+			p[i] = -1
+			index[i] = i
+		}
+		return svmutilities.Problem{Y: y, P: p, C: c, Index: index}
+	}
+
+	It("Finds the maximum margin separator of two points", func() {
+		vectors := [][]float64{{0}, {2}}
+		cache := svmutilities.NewKernelCache(svmutilities.LinearKernel(), vectors, 2)
+
+		solution := svmutilities.Solve(classificationProblem([]float64{1, -1}, []float64{100, 100}), cache, 1e-6, 1000, true)
+		Ω(solution.Converged).Should(BeTrue())
+		Ω(solution.Alpha[0]).Should(BeNumerically("~", 0.5, 1e-6))
+		Ω(solution.Alpha[1]).Should(BeNumerically("~", 0.5, 1e-6))
+		Ω(solution.Rho).Should(BeNumerically("~", -1, 1e-6))
+	})
+
+	It("Respects the box constraints", func() {
+		vectors := [][]float64{{0}, {2}}
+		cache := svmutilities.NewKernelCache(svmutilities.LinearKernel(), vectors, 2)
+
+		solution := svmutilities.Solve(classificationProblem([]float64{1, -1}, []float64{0.1, 0.1}), cache, 1e-6, 1000, true)
+		Ω(solution.Alpha).Should(Equal([]float64{0.1, 0.1}))
+	})
+
+	It("Stops after the maximum number of iterations", func() {
+		vectors := [][]float64{{0}, {2}, {1}, {1.5}}
+		cache := svmutilities.NewKernelCache(svmutilities.LinearKernel(), vectors, 4)
+
+		solution := svmutilities.Solve(classificationProblem([]float64{1, -1, 1, -1}, []float64{1, 1, 1, 1}), cache, 1e-9, 1, false)
+		Ω(solution.Iterations).Should(Equal(1))
+		Ω(solution.Converged).Should(BeFalse())
+	})
+
+	It("Reaches the same solution with and without shrinking", func() {
+		random := rand.New(rand.NewSource(3))
+		vectors := make([][]float64, 300)
+		y := make([]float64, 300)
+		c := make([]float64, 300)
+		for i := range vectors {
+			vectors[i] = []float64{random.NormFloat64(), random.NormFloat64()}
+			y[i] = 1
+			if vectors[i][0]*vectors[i][1] < 0 {
+				y[i] = -1
+			}
+			c[i] = 10
+		}
+
+		kernel := svmutilities.RBFKernel(0.5)
+		shrunk := svmutilities.Solve(classificationProblem(y, c), svmutilities.NewKernelCache(kernel, vectors, 50), 1e-5, 1000000, true)
+		unshrunk := svmutilities.Solve(classificationProblem(y, c), svmutilities.NewKernelCache(kernel, vectors, 50), 1e-5, 1000000, false)
+
+		Ω(shrunk.Converged).Should(BeTrue())
+		Ω(unshrunk.Converged).Should(BeTrue())
+		Ω(shrunk.Rho).Should(BeNumerically("~", unshrunk.Rho, 1e-3))
+		for i := range shrunk.Alpha {
+			Ω(shrunk.Alpha[i]).Should(BeNumerically("~", unshrunk.Alpha[i], 1e-2))
+		}
+	})
+})
@@ -0,0 +1,13 @@
+package svmutilities_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSvmutilities(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Svmutilities Suite")
+}
@@ -131,7 +131,7 @@ func (dataset *inMemoryDataset) Row(i int) (row.Row, error) {
 }
 
 func NewSubset(ds Dataset, rowMap []int) Dataset {
-	return &subset{
+	s := &subset{
 		ds,
 		rowMap,
 		ds.AllFeaturesFloats(),
@@ -140,6 +140,11 @@ func NewSubset(ds Dataset, rowMap []int) Dataset {
 		ds.NumTargets(),
 		len(rowMap),
 	}
+
+	if weighted, ok := ds.(WeightedDataset); ok {
+		return &weightedSubset{s, weighted}
+	}
+	return s
 }
 
 type subset struct {
@@ -185,6 +190,70 @@ func (s *subset) Row(i int) (row.Row, error) {
 	return s.superset.Row(s.rowMap[i])
 }
 
+func NewDatasetFromRows(numFeatures, numTargets int, rows []row.Row) Dataset {
+	allFeaturesFloats := true
+	allTargetsFloats := true
+
+	for _, r := range rows {
+		if _, ok := r.Features().(slice.FloatSlice); !ok {
+			allFeaturesFloats = false
+		}
+
+		if _, ok := r.Target().(slice.FloatSlice); !ok {
+			allTargetsFloats = false
+		}
+	}
+
+	return &rowsDataset{
+		rows,
+		allFeaturesFloats,
+		allTargetsFloats,
+		numFeatures,
+		numTargets,
+	}
+}
+
+type rowsDataset struct {
+	rows              []row.Row
+	allFeaturesFloats bool
+	allTargetsFloats  bool
+	numFeatures       int
+	numTargets        int
+}
+
+func (rd *rowsDataset) AllFeaturesFloats() bool {
+	return rd.allFeaturesFloats
+}
+
+func (rd *rowsDataset) AllTargetsFloats() bool {
+	return rd.allTargetsFloats
+}
+
+func (rd *rowsDataset) NumFeatures() int {
+	return rd.numFeatures
+}
+
+func (rd *rowsDataset) NumTargets() int {
+	return rd.numTargets
+}
+
+func (rd *rowsDataset) AddRowFromStrings([]string) error {
+	return errors.New("AddRowFromStrings operation not permitted on datasets built from rows")
+}
+
+func (rd *rowsDataset) NumRows() int {
+	return len(rd.rows)
+}
+
+func (rd *rowsDataset) Row(i int) (row.Row, error) {
+	numRows := len(rd.rows)
+	if i < 0 || numRows <= i {
+		return nil, newDatasetRowIndexOutOfBoundsError(i, numRows)
+	}
+
+	return rd.rows[i], nil
+}
+
 func newRowLengthMismatchError(actual, expected int) error {
 	return errors.New(fmt.Sprintf("Row has length %d, expected %d", actual, expected))
 }
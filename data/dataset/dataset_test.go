@@ -326,4 +326,55 @@ var _ = Describe("Dataset", func() {
 			})
 		})
 	})
+
+	Describe("NewDatasetFromRows", func() {
+		var rows []row.Row
+
+		BeforeEach(func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			source := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			err = source.AddRowFromStrings([]string{"hi", "3.14"})
+			Ω(err).ShouldNot(HaveOccurred())
+			err = source.AddRowFromStrings([]string{"bye", "2.72"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			row0, err := source.Row(0)
+			Ω(err).ShouldNot(HaveOccurred())
+			row1, err := source.Row(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			rows = []row.Row{row1, row0}
+			ds = dataset.NewDatasetFromRows(1, 1, rows)
+		})
+
+		It("Infers the feature and target types from the rows", func() {
+			Ω(ds.AllFeaturesFloats()).Should(BeTrue())
+			Ω(ds.AllTargetsFloats()).Should(BeFalse())
+		})
+
+		It("Reports the given numbers of features and targets", func() {
+			Ω(ds.NumFeatures()).Should(Equal(1))
+			Ω(ds.NumTargets()).Should(Equal(1))
+		})
+
+		It("Returns the rows in order", func() {
+			Ω(ds.NumRows()).Should(Equal(2))
+
+			for i, expectedRow := range rows {
+				actualRow, err := ds.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(actualRow.Target().Equals(expectedRow.Target())).Should(BeTrue())
+			}
+
+			_, err := ds.Row(2)
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("Does not permit adding rows from strings", func() {
+			err := ds.AddRowFromStrings([]string{"hi", "3.14"})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
 })
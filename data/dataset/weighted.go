@@ -0,0 +1,84 @@
+package dataset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// WeightedDataset is a Dataset whose rows each carry a non-negative weight,
+// which learners honouring weights treat as if the row appeared that many
+// times.  Subsets of a WeightedDataset keep their rows' weights.
+type WeightedDataset interface {
+	Dataset
+	Weight(i int) (float64, error)
+}
+
+// NewWeightedDataset gives each row of the dataset the corresponding weight,
+// replacing any weights it already has.
+func NewWeightedDataset(ds Dataset, weights []float64) (WeightedDataset, error) {
+	numRows := ds.NumRows()
+	if len(weights) != numRows {
+		return nil, fmt.Errorf("Cannot weight %d rows with %d weights", numRows, len(weights))
+	}
+
+	for _, w := range weights {
+		if w < 0 || math.IsInf(w, 0) || math.IsNaN(w) {
+			return nil, fmt.Errorf("Invalid row weight %f, must be non-negative and finite", w)
+		}
+	}
+
+	if weighted, ok := ds.(*weightedDataset); ok {
+		ds = weighted.Dataset
+	}
+
+	return &weightedDataset{ds, weights}, nil
+}
+
+// Weights returns the weight of every row of the dataset: its weights if it
+// is a WeightedDataset, and 1 for every row otherwise.
+func Weights(ds Dataset) []float64 {
+	weights := make([]float64, ds.NumRows())
+
+	weighted, ok := ds.(WeightedDataset)
+	for i := range weights {
+		weights[i] = 1
+		if ok {
+			weights[i], _ = weighted.Weight(i)
+		}
+	}
+
+	return weights
+}
+
+type weightedDataset struct {
+	Dataset
+	weights []float64
+}
+
+func (wd *weightedDataset) AddRowFromStrings([]string) error {
+	return errors.New("AddRowFromStrings operation not permitted on weighted datasets")
+}
+
+func (wd *weightedDataset) Weight(i int) (float64, error) {
+	numRows := len(wd.weights)
+	if i < 0 || numRows <= i {
+		return 0, newDatasetRowIndexOutOfBoundsError(i, numRows)
+	}
+
+	return wd.weights[i], nil
+}
+
+type weightedSubset struct {
+	*subset
+	superset WeightedDataset
+}
+
+func (ws *weightedSubset) Weight(i int) (float64, error) {
+	numRows := ws.numRows
+	if i < 0 || numRows <= i {
+		return 0, newDatasetRowIndexOutOfBoundsError(i, numRows)
+	}
+
+	return ws.superset.Weight(ws.rowMap[i])
+}
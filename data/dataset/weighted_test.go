@@ -0,0 +1,79 @@
+package dataset_test
+
+import (
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WeightedDataset", func() {
+	var ds dataset.Dataset
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for _, values := range [][]string{{"1", "10"}, {"2", "20"}, {"3", "30"}} {
+			Ω(ds.AddRowFromStrings(values)).Should(Succeed())
+		}
+	})
+
+	It("Gives each row its weight, keeping the rows themselves", func() {
+		weighted, err := dataset.NewWeightedDataset(ds, []float64{0.5, 0, 2})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(weighted.NumRows()).Should(Equal(3))
+		Ω(weighted.NumFeatures()).Should(Equal(1))
+		Ω(weighted.AllFeaturesFloats()).Should(BeTrue())
+
+		r, err := weighted.Row(2)
+		Ω(err).ShouldNot(HaveOccurred())
+		expected, err := ds.Row(2)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r).Should(Equal(expected))
+
+		w, err := weighted.Weight(0)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(w).Should(Equal(0.5))
+
+		_, err = weighted.Weight(3)
+		Ω(err).Should(HaveOccurred())
+
+		Ω(weighted.AddRowFromStrings([]string{"4", "40"})).ShouldNot(Succeed())
+	})
+
+	It("Replaces the weights of an already weighted dataset", func() {
+		weighted, err := dataset.NewWeightedDataset(ds, []float64{0.5, 0, 2})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		reweighted, err := dataset.NewWeightedDataset(weighted, []float64{1, 2, 3})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(dataset.Weights(reweighted)).Should(Equal([]float64{1, 2, 3}))
+	})
+
+	It("Rejects weights which do not match the rows or are invalid", func() {
+		_, err := dataset.NewWeightedDataset(ds, []float64{1, 1})
+		Ω(err).Should(HaveOccurred())
+
+		_, err = dataset.NewWeightedDataset(ds, []float64{1, -1, 1})
+		Ω(err).Should(HaveOccurred())
+	})
+
+	Describe("Weights", func() {
+		It("Weights every row of an unweighted dataset 1", func() {
+			Ω(dataset.Weights(ds)).Should(Equal([]float64{1, 1, 1}))
+		})
+
+		It("Keeps the weights of rows in subsets", func() {
+			weighted, err := dataset.NewWeightedDataset(ds, []float64{0.5, 0, 2})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			subset := dataset.NewSubset(weighted, []int{2, 2, 0})
+			Ω(dataset.Weights(subset)).Should(Equal([]float64{2, 2, 0.5}))
+			Ω(dataset.Weights(dataset.NewSubset(ds, []int{2, 0}))).Should(Equal([]float64{1, 1}))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package slice
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/amitkgupta/goodlearn/data/columntype"
 )
@@ -18,6 +19,12 @@ type FloatSlice interface {
 	Values() []float64
 }
 
+// SparseFloatSlice is a FloatSlice which stores only its non-zero entries.
+type SparseFloatSlice interface {
+	FloatSlice
+	NonZero() (indices []int, values []float64)
+}
+
 type MixedSlice interface {
 	Slice
 	Values() []interface{}
@@ -27,6 +34,12 @@ type floatSlice struct {
 	values []float64
 }
 
+type sparseFloatSlice struct {
+	length  int
+	indices []int
+	values  []float64
+}
+
 type mixedSlice struct {
 	values []interface{}
 }
@@ -71,10 +84,48 @@ func SliceFromRawValues(
 	}
 }
 
+func NewFloatSlice(values []float64) FloatSlice {
+	return &floatSlice{values}
+}
+
+// NewSparseFloatSlice returns a slice of the given length which is zero
+// everywhere except at the given strictly increasing indices.
+func NewSparseFloatSlice(length int, indices []int, values []float64) (SparseFloatSlice, error) {
+	if len(indices) != len(values) {
+		return nil, newSparseLengthMismatchError(len(indices), len(values))
+	}
+
+	for j, i := range indices {
+		if i < 0 || i >= length || (j > 0 && i <= indices[j-1]) {
+			return nil, newInvalidSparseIndicesError(indices, length)
+		}
+	}
+
+	return &sparseFloatSlice{length, indices, values}, nil
+}
+
+// NewMixedSlice returns a slice of the given values, each of which must be a
+// float64 or a string.
+func NewMixedSlice(values []interface{}) (MixedSlice, error) {
+	for i, v := range values {
+		switch v.(type) {
+		case float64, string:
+		default:
+			return nil, newInvalidMixedEntryError(i, v)
+		}
+	}
+
+	return &mixedSlice{values}, nil
+}
+
 func (s *floatSlice) len() int {
 	return len(s.values)
 }
 
+func (s *sparseFloatSlice) len() int {
+	return s.length
+}
+
 func (s *mixedSlice) len() int {
 	return len(s.values)
 }
@@ -83,14 +134,35 @@ func (s *floatSlice) entry(i int) interface{} {
 	return s.values[i]
 }
 
+func (s *sparseFloatSlice) entry(i int) interface{} {
+	j := sort.SearchInts(s.indices, i)
+	if j < len(s.indices) && s.indices[j] == i {
+		return s.values[j]
+	}
+	return 0.0
+}
+
 func (s *mixedSlice) entry(i int) interface{} {
 	return s.values[i]
 }
 
+// Entries returns the entries of any slice, each a float64 or a string.
+func Entries(s Slice) []interface{} {
+	entries := make([]interface{}, s.len())
+	for i := range entries {
+		entries[i] = s.entry(i)
+	}
+	return entries
+}
+
 func (s *floatSlice) Equals(other Slice) bool {
 	return compare(s, other)
 }
 
+func (s *sparseFloatSlice) Equals(other Slice) bool {
+	return compare(s, other)
+}
+
 func (s *mixedSlice) Equals(other Slice) bool {
 	return compare(s, other)
 }
@@ -113,6 +185,18 @@ func (s *floatSlice) Values() []float64 {
 	return s.values
 }
 
+func (s *sparseFloatSlice) Values() []float64 {
+	values := make([]float64, s.length)
+	for j, i := range s.indices {
+		values[i] = s.values[j]
+	}
+	return values
+}
+
+func (s *sparseFloatSlice) NonZero() ([]int, []float64) {
+	return s.indices, s.values
+}
+
 func (s *mixedSlice) Values() []interface{} {
 	return s.values
 }
@@ -128,3 +212,28 @@ func newExpectedAllColumnsFloatsError(i int, columnIndices []int) error {
 		columnIndices,
 	))
 }
+
+func newSparseLengthMismatchError(numIndices, numValues int) error {
+	return errors.New(fmt.Sprintf(
+		"Got %d indices but %d values for sparse slice",
+		numIndices,
+		numValues,
+	))
+}
+
+func newInvalidSparseIndicesError(indices []int, length int) error {
+	return errors.New(fmt.Sprintf(
+		"Indices %v are not strictly increasing within a slice of length %d",
+		indices,
+		length,
+	))
+}
+
+func newInvalidMixedEntryError(i int, value interface{}) error {
+	return errors.New(fmt.Sprintf(
+		"Entry %d is %v of type %T, expected a float64 or a string",
+		i,
+		value,
+		value,
+	))
+}
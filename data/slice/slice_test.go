@@ -246,4 +246,93 @@ var _ = Describe("Slice", func() {
 			})
 		})
 	})
+
+	Describe("NewFloatSlice", func() {
+		It("Returns a float slice with the given values", func() {
+			s := slice.NewFloatSlice([]float64{1.5, -2})
+			Ω(s.Values()).Should(Equal([]float64{1.5, -2}))
+		})
+
+		It("Equals a float slice built from the same raw values", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"1.0", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			other, err := slice.SliceFromRawValues(true, []int{0, 1}, columnTypes, []float64{1.5, -2})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(slice.NewFloatSlice([]float64{1.5, -2}).Equals(other)).Should(BeTrue())
+		})
+	})
+	Describe("NewSparseFloatSlice", func() {
+		It("Expands to a dense slice with zeros off the given indices", func() {
+			s, err := slice.NewSparseFloatSlice(4, []int{1, 3}, []float64{2.5, -1})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(s.Values()).Should(Equal([]float64{0, 2.5, 0, -1}))
+
+			indices, values := s.NonZero()
+			Ω(indices).Should(Equal([]int{1, 3}))
+			Ω(values).Should(Equal([]float64{2.5, -1}))
+		})
+
+		It("Equals the equivalent dense slice", func() {
+			s, err := slice.NewSparseFloatSlice(3, []int{2}, []float64{7})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(s.Equals(slice.NewFloatSlice([]float64{0, 0, 7}))).Should(BeTrue())
+			Ω(s.Equals(slice.NewFloatSlice([]float64{0, 7, 0}))).Should(BeFalse())
+		})
+
+		It("Rejects mismatched or out of order indices", func() {
+			_, err := slice.NewSparseFloatSlice(3, []int{0, 1}, []float64{1})
+			Ω(err).Should(HaveOccurred())
+
+			_, err = slice.NewSparseFloatSlice(3, []int{1, 0}, []float64{1, 2})
+			Ω(err).Should(HaveOccurred())
+
+			_, err = slice.NewSparseFloatSlice(3, []int{3}, []float64{1})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("NewMixedSlice", func() {
+		It("Returns a mixed slice with the given values", func() {
+			s, err := slice.NewMixedSlice([]interface{}{"hi", 2.5})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(s.Values()).Should(Equal([]interface{}{"hi", 2.5}))
+		})
+
+		It("Equals a mixed slice built from the same raw values", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"hi", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			hiRaw, err := columnTypes[0].PersistRawFromString("hi")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			other, err := slice.SliceFromRawValues(false, []int{0, 1}, columnTypes, []float64{hiRaw, 2.5})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			s, err := slice.NewMixedSlice([]interface{}{"hi", 2.5})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(s.Equals(other)).Should(BeTrue())
+		})
+
+		It("Rejects values which are neither floats nor strings", func() {
+			_, err := slice.NewMixedSlice([]interface{}{"hi", 2})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Entries", func() {
+		It("Returns the entries of float, sparse and mixed slices", func() {
+			Ω(slice.Entries(slice.NewFloatSlice([]float64{1, 2}))).Should(Equal([]interface{}{1.0, 2.0}))
+
+			sparse, err := slice.NewSparseFloatSlice(3, []int{1}, []float64{4})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(slice.Entries(sparse)).Should(Equal([]interface{}{0.0, 4.0, 0.0}))
+
+			mixed, err := slice.NewMixedSlice([]interface{}{"hi", 2.5})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(slice.Entries(mixed)).Should(Equal([]interface{}{"hi", 2.5}))
+		})
+	})
 })
@@ -0,0 +1,74 @@
+package gbdt
+
+import (
+	"sort"
+)
+
+// binner maps each float feature onto at most maxBins ordinal bins, so that
+// split finding only has to scan a histogram per feature rather than sort the
+// rows at every node.  Bin b of a feature holds the values in
+// (edges[b-1], edges[b]]; the last bin is unbounded above.
+type binner struct {
+	edges [][]float64
+}
+
+func newBinner(features [][]float64, numFeatures, maxBins int) *binner {
+	edges := make([][]float64, numFeatures)
+	values := make([]float64, len(features))
+
+	for j := 0; j < numFeatures; j++ {
+		for i, x := range features {
+			values[i] = x[j]
+		}
+		sort.Float64s(values)
+
+		distinct := []float64{}
+		for i, v := range values {
+			if i == 0 || v != values[i-1] {
+				distinct = append(distinct, v)
+			}
+		}
+
+		if len(distinct) <= maxBins {
+			for i := 0; i+1 < len(distinct); i++ {
+				edges[j] = append(edges[j], (distinct[i]+distinct[i+1])/2)
+			}
+			continue
+		}
+
+		for b := 1; b < maxBins; b++ {
+			edge := values[b*len(values)/maxBins]
+			if len(edges[j]) == 0 || edge > edges[j][len(edges[j])-1] {
+				edges[j] = append(edges[j], edge)
+			}
+		}
+	}
+
+	return &binner{edges}
+}
+
+func (b *binner) numBins(feature int) int {
+	return len(b.edges[feature]) + 1
+}
+
+func (b *binner) bin(feature int, x float64) int {
+	return sort.SearchFloat64s(b.edges[feature], x)
+}
+
+func (b *binner) binRows(features [][]float64) [][]int {
+	binned := make([][]int, len(features))
+	for i, x := range features {
+		binned[i] = make([]int, len(x))
+		for j, v := range x {
+			binned[i][j] = b.bin(j, v)
+		}
+	}
+
+	return binned
+}
+
+// upperEdge is the largest value falling in the given bin, which makes it the
+// threshold for a "bin <= b" split on raw feature values.
+func (b *binner) upperEdge(feature, bin int) float64 {
+	return b.edges[feature][bin]
+}
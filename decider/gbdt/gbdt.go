@@ -0,0 +1,300 @@
+package gbdt
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/errors/decider/gbdterrors"
+)
+
+type Parameters struct {
+	NumRounds        int
+	LearningRate     float64
+	MaxDepth         int
+	MinRowsPerLeaf   int
+	L2Regularization float64
+
+	// Subsample is the fraction of rows, drawn without replacement, used to
+	// grow the trees of each round.
+	Subsample float64
+
+	// MaxBins caps the number of histogram bins per feature used when
+	// searching for splits.
+	MaxBins int
+
+	// Patience is the number of rounds without improvement in validation loss
+	// after which training stops; 0 disables early stopping.
+	Patience int
+}
+
+func DefaultParameters() Parameters {
+	return Parameters{
+		NumRounds:        100,
+		LearningRate:     0.1,
+		MaxDepth:         3,
+		MinRowsPerLeaf:   1,
+		L2Regularization: 0,
+		Subsample:        1,
+		MaxBins:          255,
+		Patience:         0,
+	}
+}
+
+func (p Parameters) Validate() error {
+	switch {
+	case p.NumRounds < 1:
+		return gbdterrors.NewInvalidParameterError("NumRounds", p.NumRounds)
+	case p.LearningRate <= 0:
+		return gbdterrors.NewInvalidParameterError("LearningRate", p.LearningRate)
+	case p.MaxDepth < 1:
+		return gbdterrors.NewInvalidParameterError("MaxDepth", p.MaxDepth)
+	case p.MinRowsPerLeaf < 1:
+		return gbdterrors.NewInvalidParameterError("MinRowsPerLeaf", p.MinRowsPerLeaf)
+	case p.L2Regularization < 0:
+		return gbdterrors.NewInvalidParameterError("L2Regularization", p.L2Regularization)
+	case p.Subsample <= 0 || p.Subsample > 1:
+		return gbdterrors.NewInvalidParameterError("Subsample", p.Subsample)
+	case p.MaxBins < 2:
+		return gbdterrors.NewInvalidParameterError("MaxBins", p.MaxBins)
+	case p.Patience < 0:
+		return gbdterrors.NewInvalidParameterError("Patience", p.Patience)
+	}
+
+	return nil
+}
+
+// Model is an additive ensemble of regression trees producing one score per
+// output of its loss.
+type Model struct {
+	numFeatures      int
+	initialScores    []float64
+	learningRate     float64
+	trees            [][]*treeNode
+	trainingLosses   []float64
+	validationLosses []float64
+}
+
+func (m *Model) NumFeatures() int {
+	return m.numFeatures
+}
+
+func (m *Model) NumRounds() int {
+	return len(m.trees)
+}
+
+// TrainingLosses returns the mean training loss after each round, including
+// any rounds discarded by early stopping.
+func (m *Model) TrainingLosses() []float64 {
+	return m.trainingLosses
+}
+
+// ValidationLosses returns the mean validation loss after each round, or nil
+// if no validation data was given.
+func (m *Model) ValidationLosses() []float64 {
+	return m.validationLosses
+}
+
+func (m *Model) Scores(x []float64) ([]float64, error) {
+	if len(x) != m.numFeatures {
+		return nil, gbdterrors.NewRowLengthMismatchError(len(x), m.numFeatures)
+	}
+
+	scores := append([]float64{}, m.initialScores...)
+	for _, round := range m.trees {
+		for k, tree := range round {
+			scores[k] = scores[k] + m.learningRate*tree.predict(x)
+		}
+	}
+
+	return scores, nil
+}
+
+// Train boosts trees on the rows x with encoded targets y.  If validationX is
+// non-empty, the validation loss is tracked each round and, with a positive
+// Patience, used for early stopping; the returned model is truncated to the
+// round with the lowest validation loss.
+func Train(
+	x [][]float64,
+	y []float64,
+	numFeatures int,
+	validationX [][]float64,
+	validationY []float64,
+	loss Loss,
+	params Parameters,
+	source rand.Source,
+) (*Model, error) {
+	return TrainWeighted(x, y, nil, numFeatures, validationX, validationY, nil, loss, params, source)
+}
+
+// TrainWeighted is Train with the loss of each training and validation row
+// weighted by the corresponding entry of weights and validationWeights, so
+// that a row of weight 2 counts as if it appeared twice; nil weights weight
+// every row 1.
+func TrainWeighted(
+	x [][]float64,
+	y []float64,
+	weights []float64,
+	numFeatures int,
+	validationX [][]float64,
+	validationY []float64,
+	validationWeights []float64,
+	loss Loss,
+	params Parameters,
+	source rand.Source,
+) (*Model, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	numRows := len(x)
+	if numRows == 0 {
+		return nil, gbdterrors.NewEmptyTrainingDataError()
+	}
+
+	if len(y) != numRows {
+		return nil, gbdterrors.NewTargetsLengthMismatchError(len(y), numRows)
+	}
+
+	if len(validationY) != len(validationX) {
+		return nil, gbdterrors.NewTargetsLengthMismatchError(len(validationY), len(validationX))
+	}
+
+	for _, rows := range [][][]float64{x, validationX} {
+		for _, r := range rows {
+			if len(r) != numFeatures {
+				return nil, gbdterrors.NewRowLengthMismatchError(len(r), numFeatures)
+			}
+		}
+	}
+
+	weights, err = rowWeightsOrOnes(weights, numRows)
+	if err != nil {
+		return nil, err
+	}
+
+	validationWeights, err = rowWeightsOrOnes(validationWeights, len(validationX))
+	if err != nil {
+		return nil, err
+	}
+
+	random := rand.New(source)
+	numScores := loss.NumScores()
+	b := newBinner(x, numFeatures, params.MaxBins)
+
+	model := &Model{
+		numFeatures:   numFeatures,
+		initialScores: loss.InitialScores(y, weights),
+		learningRate:  params.LearningRate,
+		trees:         [][]*treeNode{},
+	}
+
+	scores := initialScoreRows(model.initialScores, numRows)
+	validationScores := initialScoreRows(model.initialScores, len(validationX))
+	if len(validationX) > 0 {
+		model.validationLosses = []float64{}
+	}
+
+	builder := &treeBuilder{
+		binner:    b,
+		binned:    b.binRows(x),
+		params:    params,
+		gradients: make([]float64, numRows),
+		hessians:  make([]float64, numRows),
+	}
+
+	bestRounds, bestValidationLoss := 0, math.Inf(1)
+
+	for round := 0; round < params.NumRounds; round++ {
+		rows := sampleRows(random, numRows, params.Subsample)
+
+		trees := make([]*treeNode, numScores)
+		for k := range trees {
+			loss.Gradients(y, weights, scores, k, builder.gradients, builder.hessians)
+			builder.leafValue = func(leafRows []int) (float64, bool) {
+				return loss.LeafValue(y, weights, scores, k, leafRows)
+			}
+			trees[k] = builder.build(rows, 0)
+		}
+
+		// scores are only updated once every tree of the round is grown, so
+		// that each class's tree sees the same starting point.
+		addTreeScores(scores, x, trees, params.LearningRate)
+		addTreeScores(validationScores, validationX, trees, params.LearningRate)
+
+		model.trees = append(model.trees, trees)
+		model.trainingLosses = append(model.trainingLosses, loss.MeanLoss(y, weights, scores))
+
+		if len(validationX) == 0 {
+			continue
+		}
+
+		validationLoss := loss.MeanLoss(validationY, validationWeights, validationScores)
+		model.validationLosses = append(model.validationLosses, validationLoss)
+
+		if validationLoss < bestValidationLoss {
+			bestRounds, bestValidationLoss = len(model.trees), validationLoss
+		} else if params.Patience > 0 && len(model.trees)-bestRounds >= params.Patience {
+			break
+		}
+	}
+
+	if len(validationX) > 0 && params.Patience > 0 {
+		model.trees = model.trees[:bestRounds]
+	}
+
+	return model, nil
+}
+
+// rowWeightsOrOnes returns the weights, or a weight of 1 for each row if they
+// are nil, after checking there is one non-negative weight per row.
+func rowWeightsOrOnes(weights []float64, numRows int) ([]float64, error) {
+	if weights == nil {
+		weights = make([]float64, numRows)
+		for i := range weights {
+			weights[i] = 1
+		}
+		return weights, nil
+	}
+
+	if len(weights) != numRows {
+		return nil, gbdterrors.NewWeightsLengthMismatchError(len(weights), numRows)
+	}
+
+	for _, w := range weights {
+		if !(w >= 0) {
+			return nil, gbdterrors.NewInvalidParameterError("weight", w)
+		}
+	}
+
+	return weights, nil
+}
+
+func initialScoreRows(initialScores []float64, numRows int) [][]float64 {
+	scores := make([][]float64, numRows)
+	for i := range scores {
+		scores[i] = append([]float64{}, initialScores...)
+	}
+	return scores
+}
+
+func addTreeScores(scores [][]float64, x [][]float64, trees []*treeNode, learningRate float64) {
+	for i, r := range x {
+		for k, tree := range trees {
+			scores[i][k] = scores[i][k] + learningRate*tree.predict(r)
+		}
+	}
+}
+
+func sampleRows(random *rand.Rand, numRows int, subsample float64) []int {
+	if subsample >= 1 {
+		rows := make([]int, numRows)
+		for i := range rows {
+			rows[i] = i
+		}
+		return rows
+	}
+
+	numSampled := int(math.Max(1, math.Floor(subsample*float64(numRows))))
+	return random.Perm(numRows)[:numSampled]
+}
@@ -0,0 +1,13 @@
+package gbdt_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGbdt(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gbdt Suite")
+}
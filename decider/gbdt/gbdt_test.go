@@ -0,0 +1,182 @@
+package gbdt_test
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/decider/gbdt"
+	"github.com/amitkgupta/goodlearn/errors/decider/gbdterrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Gradient boosted decision trees", func() {
+	var params gbdt.Parameters
+
+	BeforeEach(func() {
+		params = gbdt.DefaultParameters()
+	})
+
+	Describe("Parameters", func() {
+		It("Accepts the defaults", func() {
+			Ω(params.Validate()).Should(Succeed())
+		})
+
+		It("Rejects invalid values", func() {
+			for _, mutate := range []func(*gbdt.Parameters){
+				func(p *gbdt.Parameters) { p.NumRounds = 0 },
+				func(p *gbdt.Parameters) { p.LearningRate = 0 },
+				func(p *gbdt.Parameters) { p.MaxDepth = 0 },
+				func(p *gbdt.Parameters) { p.MinRowsPerLeaf = 0 },
+				func(p *gbdt.Parameters) { p.L2Regularization = -1 },
+				func(p *gbdt.Parameters) { p.Subsample = 1.5 },
+				func(p *gbdt.Parameters) { p.MaxBins = 1 },
+				func(p *gbdt.Parameters) { p.Patience = -1 },
+			} {
+				p := gbdt.DefaultParameters()
+				mutate(&p)
+				Ω(p.Validate()).Should(BeAssignableToTypeOf(gbdterrors.InvalidParameterError{}))
+			}
+		})
+	})
+
+	Describe("Train", func() {
+		var x [][]float64
+		var y []float64
+
+		BeforeEach(func() {
+			x, y = [][]float64{}, []float64{}
+			for i := 0; i < 100; i++ {
+				x1, x2 := float64(i%10), float64(i/10)
+				x = append(x, []float64{x1, x2})
+				y = append(y, stepFunction(x1, x2))
+			}
+		})
+
+		It("Rejects empty training data", func() {
+			_, err := gbdt.Train(nil, nil, 2, nil, nil, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(gbdterrors.EmptyTrainingDataError{}))
+		})
+
+		It("Rejects mismatched targets", func() {
+			_, err := gbdt.Train(x, y[1:], 2, nil, nil, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(gbdterrors.TargetsLengthMismatchError{}))
+		})
+
+		It("Rejects rows of the wrong length", func() {
+			_, err := gbdt.Train(x, y, 3, nil, nil, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(gbdterrors.RowLengthMismatchError{}))
+		})
+
+		It("Rejects weights of the wrong length", func() {
+			_, err := gbdt.TrainWeighted(x, y, []float64{1}, 2, nil, nil, nil, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(gbdterrors.WeightsLengthMismatchError{}))
+		})
+
+		It("Weights rows as if they were repeated", func() {
+			weights := make([]float64, len(y))
+			repeatedX, repeatedY := [][]float64{}, []float64{}
+			for i := range y {
+				weights[i] = float64(1 + i%3)
+				for r := 0; r < 1+i%3; r++ {
+					repeatedX = append(repeatedX, x[i])
+					repeatedY = append(repeatedY, y[i])
+				}
+			}
+
+			for _, loss := range []gbdt.Loss{gbdt.SquaredLoss(), gbdt.BinomialDeviance()} {
+				params.NumRounds = 10
+
+				weighted, err := gbdt.TrainWeighted(x, y, weights, 2, nil, nil, nil, loss, params, rand.NewSource(1))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				repeated, err := gbdt.Train(repeatedX, repeatedY, 2, nil, nil, loss, params, rand.NewSource(1))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				for _, point := range [][]float64{{1.5, 2.5}, {7.5, 2.5}, {1.5, 7.5}, {7.5, 7.5}} {
+					weightedScores, err := weighted.Scores(point)
+					Ω(err).ShouldNot(HaveOccurred())
+					repeatedScores, err := repeated.Scores(point)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(weightedScores[0]).Should(BeNumerically("~", repeatedScores[0], 1e-9))
+				}
+
+				losses, repeatedLosses := weighted.TrainingLosses(), repeated.TrainingLosses()
+				Ω(losses[len(losses)-1]).Should(BeNumerically("~", repeatedLosses[len(repeatedLosses)-1], 1e-9))
+			}
+		})
+
+		It("Fits an interaction between features", func() {
+			model, err := gbdt.Train(x, y, 2, nil, nil, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(model.NumRounds()).Should(Equal(params.NumRounds))
+
+			for _, point := range [][]float64{{1.5, 2.5}, {7.5, 2.5}, {1.5, 7.5}, {7.5, 7.5}} {
+				scores, err := model.Scores(point)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(scores[0]).Should(BeNumerically("~", stepFunction(point[0], point[1]), 0.1))
+			}
+
+			losses := model.TrainingLosses()
+			Ω(losses[len(losses)-1]).Should(BeNumerically("<", losses[0]))
+			Ω(model.ValidationLosses()).Should(BeNil())
+		})
+
+		It("Still fits with coarse histograms and subsampling", func() {
+			params.MaxBins = 4
+			params.Subsample = 0.5
+
+			model, err := gbdt.Train(x, y, 2, nil, nil, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			scores, err := model.Scores([]float64{8, 8})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(scores[0]).Should(BeNumerically("~", stepFunction(8, 8), 0.5))
+		})
+
+		It("Stops early once validation loss stops improving", func() {
+			noisyY := make([]float64, len(y))
+			random := rand.New(rand.NewSource(3))
+			for i := range y {
+				noisyY[i] = y[i] + 3*random.NormFloat64()
+			}
+
+			params.NumRounds = 500
+			params.MaxDepth = 5
+			params.LearningRate = 0.3
+			params.Patience = 10
+
+			model, err := gbdt.Train(x, noisyY, 2, x, y, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			validationLosses := model.ValidationLosses()
+			Ω(len(validationLosses)).Should(BeNumerically("<", 500))
+			Ω(model.NumRounds()).Should(Equal(len(validationLosses) - params.Patience))
+
+			best := math.Inf(1)
+			for _, l := range validationLosses {
+				best = math.Min(best, l)
+			}
+			Ω(validationLosses[model.NumRounds()-1]).Should(Equal(best))
+		})
+
+		It("Returns an error when scoring rows of the wrong length", func() {
+			model, err := gbdt.Train(x, y, 2, nil, nil, gbdt.SquaredLoss(), params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = model.Scores([]float64{1})
+			Ω(err).Should(BeAssignableToTypeOf(gbdterrors.RowLengthMismatchError{}))
+		})
+	})
+})
+
+func stepFunction(x1, x2 float64) float64 {
+	if x1 < 5 && x2 < 5 {
+		return 1
+	}
+	if x1 >= 5 && x2 >= 5 {
+		return 4
+	}
+	return -2
+}
@@ -0,0 +1,398 @@
+package gbdt
+
+import (
+	"math"
+	"sort"
+
+	"github.com/amitkgupta/goodlearn/errors/decider/gbdterrors"
+)
+
+// Loss is a differentiable loss which gradient boosting minimises.  Targets
+// are encoded as floats: the value itself for regression losses, and the
+// target's class index for classification losses.  A loss may need several
+// scores per row (one per class for multinomial deviance), in which case one
+// tree is grown per score per round.  Each row's loss is weighted by its
+// weight in w.
+type Loss interface {
+	NumScores() int
+	InitialScores(y, w []float64) []float64
+
+	// Gradients fills g and h with the first and second derivatives of each
+	// row's weighted loss with respect to score k, at the current scores.
+	Gradients(y, w []float64, scores [][]float64, k int, g, h []float64)
+
+	// LeafValue may compute the optimal value for a leaf containing the given
+	// rows directly; if it returns false, a Newton step is used instead.
+	LeafValue(y, w []float64, scores [][]float64, k int, rows []int) (float64, bool)
+
+	// MeanLoss returns the weighted average loss over all rows.
+	MeanLoss(y, w []float64, scores [][]float64) float64
+}
+
+func SquaredLoss() Loss {
+	return squaredLoss{}
+}
+
+func AbsoluteLoss() Loss {
+	return &quantileLoss{0.5, true}
+}
+
+// HuberLoss is quadratic for residuals within the alpha-quantile of absolute
+// residuals, and linear beyond it.  alpha must be in (0, 1).
+func HuberLoss(alpha float64) (Loss, error) {
+	if !(alpha > 0 && alpha < 1) {
+		return nil, gbdterrors.NewInvalidParameterError("alpha", alpha)
+	}
+	return huberLoss{alpha}, nil
+}
+
+// QuantileLoss is the pinball loss, whose minimiser is the alpha-quantile of
+// the target.  alpha must be in (0, 1).
+func QuantileLoss(alpha float64) (Loss, error) {
+	if !(alpha > 0 && alpha < 1) {
+		return nil, gbdterrors.NewInvalidParameterError("alpha", alpha)
+	}
+	return &quantileLoss{alpha, false}, nil
+}
+
+func BinomialDeviance() Loss {
+	return binomialDeviance{}
+}
+
+func MultinomialDeviance(numClasses int) Loss {
+	return multinomialDeviance{numClasses}
+}
+
+type squaredLoss struct{}
+
+func (squaredLoss) NumScores() int {
+	return 1
+}
+
+func (squaredLoss) InitialScores(y, w []float64) []float64 {
+	return []float64{weightedMean(y, w)}
+}
+
+func (squaredLoss) Gradients(y, w []float64, scores [][]float64, k int, g, h []float64) {
+	for i := range y {
+		g[i] = w[i] * (scores[i][0] - y[i])
+		h[i] = w[i]
+	}
+}
+
+func (squaredLoss) LeafValue([]float64, []float64, [][]float64, int, []int) (float64, bool) {
+	return 0, false
+}
+
+func (squaredLoss) MeanLoss(y, w []float64, scores [][]float64) float64 {
+	losses := make([]float64, len(y))
+	for i := range y {
+		r := y[i] - scores[i][0]
+		losses[i] = 0.5 * r * r
+	}
+	return weightedMean(losses, w)
+}
+
+type quantileLoss struct {
+	alpha    float64
+	absolute bool
+}
+
+func (ql *quantileLoss) NumScores() int {
+	return 1
+}
+
+func (ql *quantileLoss) InitialScores(y, w []float64) []float64 {
+	return []float64{weightedQuantile(y, w, ql.alpha)}
+}
+
+func (ql *quantileLoss) Gradients(y, w []float64, scores [][]float64, k int, g, h []float64) {
+	for i := range y {
+		if y[i] > scores[i][0] {
+			g[i] = -w[i] * ql.alpha
+		} else {
+			g[i] = w[i] * (1 - ql.alpha)
+		}
+		h[i] = w[i]
+	}
+}
+
+func (ql *quantileLoss) LeafValue(y, w []float64, scores [][]float64, k int, rows []int) (float64, bool) {
+	return weightedQuantile(residuals(y, scores, rows), rowWeights(w, rows), ql.alpha), true
+}
+
+func (ql *quantileLoss) MeanLoss(y, w []float64, scores [][]float64) float64 {
+	losses := make([]float64, len(y))
+	for i := range y {
+		r := y[i] - scores[i][0]
+		if r > 0 {
+			losses[i] = ql.alpha * r
+		} else {
+			losses[i] = (ql.alpha - 1) * r
+		}
+	}
+
+	if ql.absolute {
+		return 2 * weightedMean(losses, w)
+	}
+	return weightedMean(losses, w)
+}
+
+// huberLoss keeps no state between calls, so that one loss may be shared by
+// models trained concurrently; delta is recomputed from the scores given.
+type huberLoss struct {
+	alpha float64
+}
+
+func (hl huberLoss) NumScores() int {
+	return 1
+}
+
+func (hl huberLoss) InitialScores(y, w []float64) []float64 {
+	return []float64{weightedQuantile(y, w, 0.5)}
+}
+
+func (hl huberLoss) Gradients(y, w []float64, scores [][]float64, k int, g, h []float64) {
+	delta := hl.delta(y, w, scores)
+
+	for i := range y {
+		r := y[i] - scores[i][0]
+		if math.Abs(r) <= delta {
+			g[i] = -w[i] * r
+		} else {
+			g[i] = -w[i] * delta * sign(r)
+		}
+		h[i] = w[i]
+	}
+}
+
+func (hl huberLoss) LeafValue(y, w []float64, scores [][]float64, k int, rows []int) (float64, bool) {
+	delta := hl.delta(y, w, scores)
+	r, weights := residuals(y, scores, rows), rowWeights(w, rows)
+	median := weightedQuantile(r, weights, 0.5)
+
+	corrections := make([]float64, len(r))
+	for j, v := range r {
+		corrections[j] = sign(v-median) * math.Min(delta, math.Abs(v-median))
+	}
+
+	return median + weightedMean(corrections, weights), true
+}
+
+func (hl huberLoss) MeanLoss(y, w []float64, scores [][]float64) float64 {
+	delta := hl.delta(y, w, scores)
+
+	losses := make([]float64, len(y))
+	for i := range y {
+		r := math.Abs(y[i] - scores[i][0])
+		if r <= delta {
+			losses[i] = 0.5 * r * r
+		} else {
+			losses[i] = delta * (r - delta/2)
+		}
+	}
+	return weightedMean(losses, w)
+}
+
+// delta is the weighted alpha-quantile of the absolute residuals over all
+// rows, the point beyond which the loss is linear.
+func (hl huberLoss) delta(y, w []float64, scores [][]float64) float64 {
+	absoluteResiduals := make([]float64, len(y))
+	for i := range y {
+		absoluteResiduals[i] = math.Abs(y[i] - scores[i][0])
+	}
+	return weightedQuantile(absoluteResiduals, w, hl.alpha)
+}
+
+type binomialDeviance struct{}
+
+func (binomialDeviance) NumScores() int {
+	return 1
+}
+
+func (binomialDeviance) InitialScores(y, w []float64) []float64 {
+	p := math.Min(math.Max(weightedMean(y, w), probabilityFloor), 1-probabilityFloor)
+	return []float64{math.Log(p / (1 - p))}
+}
+
+func (binomialDeviance) Gradients(y, w []float64, scores [][]float64, k int, g, h []float64) {
+	for i := range y {
+		p := Sigmoid(scores[i][0])
+		g[i] = w[i] * (p - y[i])
+		h[i] = w[i] * p * (1 - p)
+	}
+}
+
+func (binomialDeviance) LeafValue([]float64, []float64, [][]float64, int, []int) (float64, bool) {
+	return 0, false
+}
+
+func (binomialDeviance) MeanLoss(y, w []float64, scores [][]float64) float64 {
+	losses := make([]float64, len(y))
+	for i := range y {
+		p := math.Min(math.Max(Sigmoid(scores[i][0]), probabilityFloor), 1-probabilityFloor)
+		losses[i] = -y[i]*math.Log(p) - (1-y[i])*math.Log(1-p)
+	}
+	return weightedMean(losses, w)
+}
+
+type multinomialDeviance struct {
+	numClasses int
+}
+
+func (md multinomialDeviance) NumScores() int {
+	return md.numClasses
+}
+
+func (md multinomialDeviance) InitialScores(y, w []float64) []float64 {
+	scores := make([]float64, md.numClasses)
+	totalWeight := 0.0
+	for i, v := range y {
+		scores[int(v)] = scores[int(v)] + w[i]
+		totalWeight = totalWeight + w[i]
+	}
+	for k := range scores {
+		scores[k] = math.Log(math.Max(scores[k]/totalWeight, probabilityFloor))
+	}
+	return scores
+}
+
+// Gradients scales the hessian by K/(K-1), which reproduces Friedman's
+// (K-1)/K shrinkage of the multinomial leaf values.
+func (md multinomialDeviance) Gradients(y, w []float64, scores [][]float64, k int, g, h []float64) {
+	scale := float64(md.numClasses) / float64(md.numClasses-1)
+	for i := range y {
+		p := Softmax(scores[i])[k]
+
+		indicator := 0.0
+		if int(y[i]) == k {
+			indicator = 1
+		}
+
+		g[i] = w[i] * (p - indicator)
+		h[i] = w[i] * scale * p * (1 - p)
+	}
+}
+
+func (md multinomialDeviance) LeafValue([]float64, []float64, [][]float64, int, []int) (float64, bool) {
+	return 0, false
+}
+
+func (md multinomialDeviance) MeanLoss(y, w []float64, scores [][]float64) float64 {
+	losses := make([]float64, len(y))
+	for i := range y {
+		p := Softmax(scores[i])[int(y[i])]
+		losses[i] = -math.Log(math.Max(p, probabilityFloor))
+	}
+	return weightedMean(losses, w)
+}
+
+const probabilityFloor = 1e-15
+
+func Sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+func Softmax(scores []float64) []float64 {
+	max := math.Inf(-1)
+	for _, s := range scores {
+		max = math.Max(max, s)
+	}
+
+	probabilities := make([]float64, len(scores))
+	sum := 0.0
+	for k, s := range scores {
+		probabilities[k] = math.Exp(s - max)
+		sum = sum + probabilities[k]
+	}
+	for k := range probabilities {
+		probabilities[k] = probabilities[k] / sum
+	}
+
+	return probabilities
+}
+
+func residuals(y []float64, scores [][]float64, rows []int) []float64 {
+	r := make([]float64, len(rows))
+	for j, i := range rows {
+		r[j] = y[i] - scores[i][0]
+	}
+	return r
+}
+
+func rowWeights(w []float64, rows []int) []float64 {
+	weights := make([]float64, len(rows))
+	for j, i := range rows {
+		weights[j] = w[i]
+	}
+	return weights
+}
+
+// weightedMean returns the mean of the values weighted by w, or 0 if the
+// weights sum to zero.
+func weightedMean(values, w []float64) float64 {
+	sum, totalWeight := 0.0, 0.0
+	for i, v := range values {
+		sum = sum + w[i]*v
+		totalWeight = totalWeight + w[i]
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+	return sum / totalWeight
+}
+
+// weightedQuantile returns the alpha-quantile of the values weighted by w.
+// The sorted values are placed at the midpoints of their weights' shares of
+// the total weight, the smallest and largest standing for the 0- and
+// 1-quantiles, and the quantile is linearly interpolated between
+// neighbouring values; with equal weights, the value at position alpha*(n-1)
+// of the n sorted values is interpolated.  Values of zero weight are
+// ignored, and 0 is returned if every weight is zero.
+func weightedQuantile(values, w []float64, alpha float64) float64 {
+	order := []int{}
+	for i := range values {
+		if w[i] > 0 {
+			order = append(order, i)
+		}
+	}
+
+	if len(order) == 0 {
+		return 0
+	}
+
+	sort.Slice(order, func(a, b int) bool { return values[order[a]] < values[order[b]] })
+
+	positions := make([]float64, len(order))
+	cumulative := 0.0
+	for j, i := range order {
+		positions[j] = cumulative + w[i]/2
+		cumulative = cumulative + w[i]
+	}
+
+	first, last := positions[0], positions[len(positions)-1]
+	position := first + alpha*(last-first)
+
+	upper := sort.SearchFloat64s(positions, position)
+	if upper == 0 {
+		return values[order[0]]
+	}
+	if upper == len(positions) {
+		return values[order[len(order)-1]]
+	}
+
+	lower := upper - 1
+	fraction := (position - positions[lower]) / (positions[upper] - positions[lower])
+	return values[order[lower]] + fraction*(values[order[upper]]-values[order[lower]])
+}
+
+func sign(x float64) float64 {
+	if x < 0 {
+		return -1
+	}
+	if x > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,149 @@
+package gbdt_test
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/amitkgupta/goodlearn/decider/gbdt"
+	"github.com/amitkgupta/goodlearn/errors/decider/gbdterrors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Loss", func() {
+	var params gbdt.Parameters
+	var x [][]float64
+	var y []float64
+
+	BeforeEach(func() {
+		params = gbdt.DefaultParameters()
+		params.MaxDepth = 1
+
+		// a constant feature, so each model can only learn a single value
+		x, y = [][]float64{}, []float64{}
+		for i := 0; i < 11; i++ {
+			x = append(x, []float64{0})
+			y = append(y, float64(i))
+		}
+		y[10] = 1000
+	})
+
+	fit := func(loss gbdt.Loss) float64 {
+		model, err := gbdt.Train(x, y, 1, nil, nil, loss, params, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		scores, err := model.Scores([]float64{0})
+		Ω(err).ShouldNot(HaveOccurred())
+		return scores[0]
+	}
+
+	It("Squared loss fits the mean", func() {
+		Ω(fit(gbdt.SquaredLoss())).Should(BeNumerically("~", 1045.0/11, 1e-6))
+	})
+
+	It("Absolute loss fits the median", func() {
+		Ω(fit(gbdt.AbsoluteLoss())).Should(BeNumerically("~", 5, 1e-6))
+	})
+
+	It("Quantile loss fits the quantile", func() {
+		loss, err := gbdt.QuantileLoss(0.2)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(fit(loss)).Should(BeNumerically("~", 2, 1e-6))
+	})
+
+	It("Huber loss is robust to the outlier", func() {
+		loss, err := gbdt.HuberLoss(0.9)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(fit(loss)).Should(BeNumerically("<", 10))
+	})
+
+	It("Quantile and Huber losses require alpha strictly between 0 and 1", func() {
+		for _, alpha := range []float64{0, 1, 1.5, -0.2} {
+			_, err := gbdt.QuantileLoss(alpha)
+			Ω(err).Should(BeAssignableToTypeOf(gbdterrors.InvalidParameterError{}))
+
+			_, err = gbdt.HuberLoss(alpha)
+			Ω(err).Should(BeAssignableToTypeOf(gbdterrors.InvalidParameterError{}))
+		}
+	})
+
+	It("Huber loss can be shared by models trained concurrently", func() {
+		loss, err := gbdt.HuberLoss(0.5)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		scaled := make([]float64, len(y))
+		for i := range y {
+			scaled[i] = 100 * y[i]
+		}
+
+		expected, err := gbdt.Train(x, y, 1, nil, nil, loss, params, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var wg sync.WaitGroup
+		models := make([]*gbdt.Model, 8)
+		for m := range models {
+			wg.Add(1)
+			go func(m int) {
+				defer wg.Done()
+				targets := y
+				if m%2 == 1 {
+					targets = scaled
+				}
+				models[m], _ = gbdt.Train(x, targets, 1, nil, nil, loss, params, rand.NewSource(1))
+			}(m)
+		}
+		wg.Wait()
+
+		for m := 0; m < len(models); m = m + 2 {
+			Ω(models[m].TrainingLosses()).Should(Equal(expected.TrainingLosses()))
+		}
+	})
+
+	It("Ignores rows of zero weight", func() {
+		weights := make([]float64, len(y))
+		for i := range weights {
+			weights[i] = 1
+		}
+		weights[10] = 0
+
+		huber, err := gbdt.HuberLoss(0.9)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		for _, loss := range []gbdt.Loss{gbdt.SquaredLoss(), gbdt.AbsoluteLoss(), huber} {
+			model, err := gbdt.TrainWeighted(x, y, weights, 1, nil, nil, nil, loss, params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			scores, err := model.Scores([]float64{0})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(scores[0]).Should(BeNumerically("~", 4.5, 1e-3))
+		}
+	})
+
+	It("Binomial deviance fits the log-odds", func() {
+		for i := range y {
+			y[i] = float64(i % 2)
+		}
+
+		Ω(gbdt.Sigmoid(fit(gbdt.BinomialDeviance()))).Should(BeNumerically("~", 5.0/11, 1e-6))
+	})
+
+	It("Multinomial deviance fits the class frequencies", func() {
+		for i := range y {
+			y[i] = float64(i % 3)
+		}
+
+		model, err := gbdt.Train(x, y, 1, nil, nil, gbdt.MultinomialDeviance(3), params, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		scores, err := model.Scores([]float64{0})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		probabilities := gbdt.Softmax(scores)
+		Ω(probabilities[0]).Should(BeNumerically("~", 4.0/11, 1e-6))
+		Ω(probabilities[1]).Should(BeNumerically("~", 4.0/11, 1e-6))
+		Ω(probabilities[2]).Should(BeNumerically("~", 3.0/11, 1e-6))
+	})
+})
@@ -0,0 +1,117 @@
+package gbdt
+
+type treeNode struct {
+	feature   int
+	threshold float64
+	left      *treeNode
+	right     *treeNode
+	value     float64
+}
+
+func (n *treeNode) predict(x []float64) float64 {
+	for n.left != nil {
+		if x[n.feature] <= n.threshold {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return n.value
+}
+
+type treeBuilder struct {
+	binner    *binner
+	binned    [][]int
+	params    Parameters
+	gradients []float64
+	hessians  []float64
+	leafValue func(rows []int) (float64, bool)
+}
+
+type histogramBin struct {
+	gradient float64
+	hessian  float64
+	count    int
+}
+
+func (tb *treeBuilder) build(rows []int, depth int) *treeNode {
+	sumGradient, sumHessian := 0.0, 0.0
+	for _, i := range rows {
+		sumGradient = sumGradient + tb.gradients[i]
+		sumHessian = sumHessian + tb.hessians[i]
+	}
+
+	if depth < tb.params.MaxDepth && len(rows) >= 2*tb.params.MinRowsPerLeaf {
+		feature, bin, found := tb.bestSplit(rows, sumGradient, sumHessian)
+		if found {
+			leftRows, rightRows := []int{}, []int{}
+			for _, i := range rows {
+				if tb.binned[i][feature] <= bin {
+					leftRows = append(leftRows, i)
+				} else {
+					rightRows = append(rightRows, i)
+				}
+			}
+
+			return &treeNode{
+				feature:   feature,
+				threshold: tb.binner.upperEdge(feature, bin),
+				left:      tb.build(leftRows, depth+1),
+				right:     tb.build(rightRows, depth+1),
+			}
+		}
+	}
+
+	if value, ok := tb.leafValue(rows); ok {
+		return &treeNode{value: value}
+	}
+
+	return &treeNode{value: -sumGradient / (sumHessian + tb.params.L2Regularization + minimumHessian)}
+}
+
+const minimumHessian = 1e-12
+
+func (tb *treeBuilder) bestSplit(rows []int, sumGradient, sumHessian float64) (int, int, bool) {
+	lambda := tb.params.L2Regularization + minimumHessian
+	parentScore := sumGradient * sumGradient / (sumHessian + lambda)
+
+	bestGain, bestFeature, bestBin := minimumGain, -1, -1
+
+	for j := range tb.binner.edges {
+		histogram := make([]histogramBin, tb.binner.numBins(j))
+		for _, i := range rows {
+			h := &histogram[tb.binned[i][j]]
+			h.gradient = h.gradient + tb.gradients[i]
+			h.hessian = h.hessian + tb.hessians[i]
+			h.count++
+		}
+
+		leftGradient, leftHessian, leftCount := 0.0, 0.0, 0
+		for b := 0; b+1 < len(histogram); b++ {
+			leftGradient = leftGradient + histogram[b].gradient
+			leftHessian = leftHessian + histogram[b].hessian
+			leftCount = leftCount + histogram[b].count
+
+			rightCount := len(rows) - leftCount
+			if leftCount < tb.params.MinRowsPerLeaf || rightCount < tb.params.MinRowsPerLeaf {
+				continue
+			}
+
+			rightGradient := sumGradient - leftGradient
+			rightHessian := sumHessian - leftHessian
+
+			gain := leftGradient*leftGradient/(leftHessian+lambda) +
+				rightGradient*rightGradient/(rightHessian+lambda) -
+				parentScore
+
+			if gain > bestGain {
+				bestGain, bestFeature, bestBin = gain, j, b
+			}
+		}
+	}
+
+	return bestFeature, bestBin, bestFeature >= 0
+}
+
+const minimumGain = 1e-12
@@ -0,0 +1,121 @@
+package calibrationerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidMethodError(method int) InvalidMethodError {
+	return InvalidMethodError{method}
+}
+func NewInvalidHeldOutFractionError(fraction float64) InvalidHeldOutFractionError {
+	return InvalidHeldOutFractionError{fraction}
+}
+func NewInvalidNumberOfFoldsError(numFolds int) InvalidNumberOfFoldsError {
+	return InvalidNumberOfFoldsError{numFolds}
+}
+
+func NewScoresLengthMismatchError(numScores, numOutcomes int) ScoresLengthMismatchError {
+	return ScoresLengthMismatchError{numScores, numOutcomes}
+}
+
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewTooFewRowsError(numRows, minRows int) TooFewRowsError {
+	return TooFewRowsError{numRows, minRows}
+}
+func NewTooFewTargetsError(numTargets int) TooFewTargetsError {
+	return TooFewTargetsError{numTargets}
+}
+func NewClassifierConstructionError(err error) ClassifierConstructionError {
+	return ClassifierConstructionError{err}
+}
+func NewNonScoringClassifierError() NonScoringClassifierError {
+	return NonScoringClassifierError{}
+}
+func NewClassifierTrainingError(err error) ClassifierTrainingError {
+	return ClassifierTrainingError{err}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewClassificationError(err error) ClassificationError {
+	return ClassificationError{err}
+}
+
+type InvalidMethodError struct {
+	method int
+}
+type InvalidHeldOutFractionError struct {
+	fraction float64
+}
+type InvalidNumberOfFoldsError struct {
+	numFolds int
+}
+
+type ScoresLengthMismatchError struct {
+	numScores   int
+	numOutcomes int
+}
+
+type EmptyTrainingDatasetError struct{}
+type TooFewRowsError struct {
+	numRows int
+	minRows int
+}
+type TooFewTargetsError struct {
+	numTargets int
+}
+type ClassifierConstructionError struct {
+	err error
+}
+type NonScoringClassifierError struct{}
+type ClassifierTrainingError struct {
+	err error
+}
+
+type UntrainedClassifierError struct{}
+type ClassificationError struct {
+	err error
+}
+
+func (e InvalidMethodError) Error() string {
+	return fmt.Sprintf("invalid calibration method %d", e.method)
+}
+func (e InvalidHeldOutFractionError) Error() string {
+	return fmt.Sprintf("held out fraction %.4f must be strictly between 0 and 1", e.fraction)
+}
+func (e InvalidNumberOfFoldsError) Error() string {
+	return fmt.Sprintf("invalid number of folds %d, need at least 2", e.numFolds)
+}
+
+func (e ScoresLengthMismatchError) Error() string {
+	return fmt.Sprintf("cannot calibrate %d scores against %d outcomes", e.numScores, e.numOutcomes)
+}
+
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e TooFewRowsError) Error() string {
+	return fmt.Sprintf("cannot calibrate with %d rows, need at least %d", e.numRows, e.minRows)
+}
+func (e TooFewTargetsError) Error() string {
+	return fmt.Sprintf("cannot calibrate with %d distinct targets, need at least 2", e.numTargets)
+}
+func (e ClassifierConstructionError) Error() string {
+	return fmt.Sprintf("could not construct classifier: %s", e.err.Error())
+}
+func (e NonScoringClassifierError) Error() string {
+	return "classifier reports neither class probabilities nor decision function scores"
+}
+func (e ClassifierTrainingError) Error() string {
+	return fmt.Sprintf("could not train classifier: %s", e.err.Error())
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e ClassificationError) Error() string {
+	return fmt.Sprintf("could not score row: %s", e.err.Error())
+}
@@ -0,0 +1,123 @@
+package discriminantanalysiserrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidShrinkageError(shrinkage float64) InvalidShrinkageError {
+	return InvalidShrinkageError{shrinkage}
+}
+func NewInvalidNumberOfComponentsError(numComponents int) InvalidNumberOfComponentsError {
+	return InvalidNumberOfComponentsError{numComponents}
+}
+
+func NewNonFloatFeaturesTrainingSetError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewNoFeaturesError() NoFeaturesError {
+	return NoFeaturesError{}
+}
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewTooFewTargetsError(numTargets int) TooFewTargetsError {
+	return TooFewTargetsError{numTargets}
+}
+func NewTooFewRowsError(numRows, numTargets int) TooFewRowsError {
+	return TooFewRowsError{numRows, numTargets}
+}
+func NewTooFewRowsForTargetError(targetIndex, numRows int) TooFewRowsForTargetError {
+	return TooFewRowsForTargetError{targetIndex, numRows}
+}
+func NewSingularCovarianceError() SingularCovarianceError {
+	return SingularCovarianceError{}
+}
+func NewTooManyComponentsError(numComponents, maxComponents int) TooManyComponentsError {
+	return TooManyComponentsError{numComponents, maxComponents}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+
+type InvalidShrinkageError struct {
+	shrinkage float64
+}
+type InvalidNumberOfComponentsError struct {
+	numComponents int
+}
+
+type NonFloatFeaturesTrainingSetError struct{}
+type NoFeaturesError struct{}
+type EmptyTrainingDatasetError struct{}
+type TooFewTargetsError struct {
+	numTargets int
+}
+type TooFewRowsError struct {
+	numRows    int
+	numTargets int
+}
+type TooFewRowsForTargetError struct {
+	targetIndex int
+	numRows     int
+}
+type SingularCovarianceError struct{}
+type TooManyComponentsError struct {
+	numComponents int
+	maxComponents int
+}
+
+type UntrainedClassifierError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+
+func (e InvalidShrinkageError) Error() string {
+	return fmt.Sprintf("invalid shrinkage %.4f, must be between 0 and 1", e.shrinkage)
+}
+func (e InvalidNumberOfComponentsError) Error() string {
+	return fmt.Sprintf("invalid number of components %d", e.numComponents)
+}
+
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on a dataset with non-float features"
+}
+func (e NoFeaturesError) Error() string {
+	return "cannot train on a dataset with no features"
+}
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e TooFewTargetsError) Error() string {
+	return fmt.Sprintf("cannot train with %d distinct targets, need at least 2", e.numTargets)
+}
+func (e TooFewRowsError) Error() string {
+	return fmt.Sprintf("cannot estimate a pooled covariance from %d rows of %d targets", e.numRows, e.numTargets)
+}
+func (e TooFewRowsForTargetError) Error() string {
+	return fmt.Sprintf("cannot estimate a covariance for target %d from %d rows", e.targetIndex, e.numRows)
+}
+func (e SingularCovarianceError) Error() string {
+	return "covariance matrix is singular; try adding shrinkage"
+}
+func (e TooManyComponentsError) Error() string {
+	return fmt.Sprintf("cannot find %d components, at most %d", e.numComponents, e.maxComponents)
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot classify a row with non-float features"
+}
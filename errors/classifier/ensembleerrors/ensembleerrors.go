@@ -0,0 +1,257 @@
+package ensembleerrors
+
+import (
+	"fmt"
+)
+
+func NewNoBaseClassifiersError() NoBaseClassifiersError {
+	return NoBaseClassifiersError{}
+}
+func NewWeightsLengthMismatchError(numWeights, numClassifiers int) WeightsLengthMismatchError {
+	return WeightsLengthMismatchError{numWeights, numClassifiers}
+}
+func NewInvalidWeightError(weight float64) InvalidWeightError {
+	return InvalidWeightError{weight}
+}
+func NewInvalidNumberOfEstimatorsError(numEstimators int) InvalidNumberOfEstimatorsError {
+	return InvalidNumberOfEstimatorsError{numEstimators}
+}
+func NewInvalidNumberOfFoldsError(numFolds int) InvalidNumberOfFoldsError {
+	return InvalidNumberOfFoldsError{numFolds}
+}
+func NewInvalidNumberOfRoundsError(numRounds int) InvalidNumberOfRoundsError {
+	return InvalidNumberOfRoundsError{numRounds}
+}
+func NewInvalidPatienceError(patience int) InvalidPatienceError {
+	return InvalidPatienceError{patience}
+}
+func NewInvalidBoostingAlgorithmError(algorithm int) InvalidBoostingAlgorithmError {
+	return InvalidBoostingAlgorithmError{algorithm}
+}
+
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewTooFewRowsForFoldsError(numRows, numFolds int) TooFewRowsForFoldsError {
+	return TooFewRowsForFoldsError{numRows, numFolds}
+}
+func NewNonFloatFeaturesTrainingSetError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewSampleWeightsLengthMismatchError(numWeights, numRows int) SampleWeightsLengthMismatchError {
+	return SampleWeightsLengthMismatchError{numWeights, numRows}
+}
+func NewInvalidSampleWeightError(weight float64) InvalidSampleWeightError {
+	return InvalidSampleWeightError{weight}
+}
+func NewNonBinaryTargetsError(numTargets int) NonBinaryTargetsError {
+	return NonBinaryTargetsError{numTargets}
+}
+func NewTooFewTargetsError(numTargets int) TooFewTargetsError {
+	return TooFewTargetsError{numTargets}
+}
+func NewNonProbabilisticBaseClassifierError() NonProbabilisticBaseClassifierError {
+	return NonProbabilisticBaseClassifierError{}
+}
+func NewBaseClassifierTooWeakError(weightedError float64, numTargets int) BaseClassifierTooWeakError {
+	return BaseClassifierTooWeakError{weightedError, numTargets}
+}
+func NewBaseClassifierConstructionError(err error) BaseClassifierConstructionError {
+	return BaseClassifierConstructionError{err}
+}
+func NewBaseClassifierTrainingError(index int, err error) BaseClassifierTrainingError {
+	return BaseClassifierTrainingError{index, err}
+}
+func NewMetaClassifierConstructionError(err error) MetaClassifierConstructionError {
+	return MetaClassifierConstructionError{err}
+}
+func NewMetaClassifierTrainingError(err error) MetaClassifierTrainingError {
+	return MetaClassifierTrainingError{err}
+}
+func NewTreeBoostingError(err error) TreeBoostingError {
+	return TreeBoostingError{err}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+func NewBaseClassifierClassificationError(index int, err error) BaseClassifierClassificationError {
+	return BaseClassifierClassificationError{index, err}
+}
+func NewMetaClassifierClassificationError(err error) MetaClassifierClassificationError {
+	return MetaClassifierClassificationError{err}
+}
+
+type NoBaseClassifiersError struct{}
+type WeightsLengthMismatchError struct {
+	numWeights     int
+	numClassifiers int
+}
+type InvalidWeightError struct {
+	weight float64
+}
+type InvalidNumberOfEstimatorsError struct {
+	numEstimators int
+}
+type InvalidNumberOfFoldsError struct {
+	numFolds int
+}
+type InvalidNumberOfRoundsError struct {
+	numRounds int
+}
+type InvalidPatienceError struct {
+	patience int
+}
+type InvalidBoostingAlgorithmError struct {
+	algorithm int
+}
+
+type EmptyTrainingDatasetError struct{}
+type TooFewRowsForFoldsError struct {
+	numRows  int
+	numFolds int
+}
+type NonFloatFeaturesTrainingSetError struct{}
+type SampleWeightsLengthMismatchError struct {
+	numWeights int
+	numRows    int
+}
+type InvalidSampleWeightError struct {
+	weight float64
+}
+type NonBinaryTargetsError struct {
+	numTargets int
+}
+type TooFewTargetsError struct {
+	numTargets int
+}
+type NonProbabilisticBaseClassifierError struct{}
+type BaseClassifierTooWeakError struct {
+	weightedError float64
+	numTargets    int
+}
+type BaseClassifierConstructionError struct {
+	err error
+}
+type BaseClassifierTrainingError struct {
+	index int
+	err   error
+}
+type MetaClassifierConstructionError struct {
+	err error
+}
+type MetaClassifierTrainingError struct {
+	err error
+}
+type TreeBoostingError struct {
+	err error
+}
+
+type UntrainedClassifierError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+type BaseClassifierClassificationError struct {
+	index int
+	err   error
+}
+type MetaClassifierClassificationError struct {
+	err error
+}
+
+func (e NoBaseClassifiersError) Error() string {
+	return "ensemble must have at least one base classifier"
+}
+func (e WeightsLengthMismatchError) Error() string {
+	return fmt.Sprintf("got %d weights for %d base classifiers", e.numWeights, e.numClassifiers)
+}
+func (e InvalidWeightError) Error() string {
+	return fmt.Sprintf("invalid weight %v, weights must be non-negative", e.weight)
+}
+func (e InvalidNumberOfEstimatorsError) Error() string {
+	return fmt.Sprintf("invalid number of estimators %d", e.numEstimators)
+}
+func (e InvalidNumberOfFoldsError) Error() string {
+	return fmt.Sprintf("invalid number of folds %d, must be at least 2", e.numFolds)
+}
+
+func (e InvalidNumberOfRoundsError) Error() string {
+	return fmt.Sprintf("invalid number of boosting rounds %d", e.numRounds)
+}
+func (e InvalidPatienceError) Error() string {
+	return fmt.Sprintf("invalid early stopping patience %d, must be non-negative", e.patience)
+}
+func (e InvalidBoostingAlgorithmError) Error() string {
+	return fmt.Sprintf("invalid boosting algorithm %d", e.algorithm)
+}
+
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e TooFewRowsForFoldsError) Error() string {
+	return fmt.Sprintf("cannot split dataset with %d rows into %d folds", e.numRows, e.numFolds)
+}
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float features"
+}
+func (e SampleWeightsLengthMismatchError) Error() string {
+	return fmt.Sprintf("got %d sample weights for dataset with %d rows", e.numWeights, e.numRows)
+}
+func (e InvalidSampleWeightError) Error() string {
+	return fmt.Sprintf("invalid sample weight %v, weights must be non-negative", e.weight)
+}
+func (e NonBinaryTargetsError) Error() string {
+	return fmt.Sprintf("dataset has %d distinct targets, expected exactly 2", e.numTargets)
+}
+func (e TooFewTargetsError) Error() string {
+	return fmt.Sprintf("dataset has %d distinct targets, need at least 2", e.numTargets)
+}
+func (e NonProbabilisticBaseClassifierError) Error() string {
+	return "base classifier must report class probabilities"
+}
+func (e BaseClassifierTooWeakError) Error() string {
+	return fmt.Sprintf(
+		"base classifier has weighted error %.4f, no better than chance for %d targets",
+		e.weightedError,
+		e.numTargets,
+	)
+}
+func (e BaseClassifierConstructionError) Error() string {
+	return fmt.Sprintf("could not construct base classifier: %s", e.err.Error())
+}
+func (e BaseClassifierTrainingError) Error() string {
+	return fmt.Sprintf("could not train base classifier %d: %s", e.index, e.err.Error())
+}
+func (e MetaClassifierConstructionError) Error() string {
+	return fmt.Sprintf("could not construct meta classifier: %s", e.err.Error())
+}
+func (e MetaClassifierTrainingError) Error() string {
+	return fmt.Sprintf("could not train meta classifier: %s", e.err.Error())
+}
+func (e TreeBoostingError) Error() string {
+	return fmt.Sprintf("could not boost trees: %s", e.err.Error())
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("Test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot classify row with some non-float features"
+}
+func (e BaseClassifierClassificationError) Error() string {
+	return fmt.Sprintf("base classifier %d could not classify row: %s", e.index, e.err.Error())
+}
+func (e MetaClassifierClassificationError) Error() string {
+	return fmt.Sprintf("meta classifier could not classify row: %s", e.err.Error())
+}
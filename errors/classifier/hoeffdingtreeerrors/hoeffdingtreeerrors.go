@@ -0,0 +1,81 @@
+package hoeffdingtreeerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidHyperparameterError(name string, value float64) InvalidHyperparameterError {
+	return InvalidHyperparameterError{name, value}
+}
+func NewInvalidLeafPredictionError(leafPrediction int) InvalidLeafPredictionError {
+	return InvalidLeafPredictionError{leafPrediction}
+}
+
+func NewNonFloatFeaturesTrainingSetError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewBatchLengthMismatchError(numBatchFeatures, numTrainingSetFeatures int) BatchLengthMismatchError {
+	return BatchLengthMismatchError{numBatchFeatures, numTrainingSetFeatures}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+
+type InvalidHyperparameterError struct {
+	name  string
+	value float64
+}
+type InvalidLeafPredictionError struct {
+	leafPrediction int
+}
+
+type NonFloatFeaturesTrainingSetError struct{}
+type EmptyTrainingDatasetError struct{}
+type BatchLengthMismatchError struct {
+	numBatchFeatures       int
+	numTrainingSetFeatures int
+}
+
+type UntrainedClassifierError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+
+func (e InvalidHyperparameterError) Error() string {
+	return fmt.Sprintf("invalid value %v for %s", e.value, e.name)
+}
+func (e InvalidLeafPredictionError) Error() string {
+	return fmt.Sprintf("invalid leaf prediction %d", e.leafPrediction)
+}
+
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on a dataset with non-float features"
+}
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e BatchLengthMismatchError) Error() string {
+	return fmt.Sprintf("batch has %d features, training set has %d", e.numBatchFeatures, e.numTrainingSetFeatures)
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot classify a row with non-float features"
+}
@@ -7,6 +7,9 @@ import (
 func NewInvalidNumberOfNeighboursError(k int) InvalidNumberOfNeighboursError {
 	return InvalidNumberOfNeighboursError{k}
 }
+func NewInvalidTieBreakError(tieBreak int) InvalidTieBreakError {
+	return InvalidTieBreakError{tieBreak}
+}
 
 func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
 	return EmptyTrainingDatasetError{}
@@ -14,6 +17,9 @@ func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
 func NewNonFloatFeaturesTrainingSetError() NonFloatFeaturesTrainingSetError {
 	return NonFloatFeaturesTrainingSetError{}
 }
+func NewBatchLengthMismatchError(numBatchFeatures, numTrainingSetFeatures int) BatchLengthMismatchError {
+	return BatchLengthMismatchError{numBatchFeatures, numTrainingSetFeatures}
+}
 
 func NewUntrainedClassifierError() UntrainedClassifierError {
 	return UntrainedClassifierError{}
@@ -28,9 +34,16 @@ func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
 type InvalidNumberOfNeighboursError struct {
 	k int
 }
+type InvalidTieBreakError struct {
+	tieBreak int
+}
 
 type EmptyTrainingDatasetError struct{}
 type NonFloatFeaturesTrainingSetError struct{}
+type BatchLengthMismatchError struct {
+	numBatchFeatures       int
+	numTrainingSetFeatures int
+}
 
 type UntrainedClassifierError struct{}
 type RowLengthMismatchError struct {
@@ -42,6 +55,9 @@ type NonFloatFeaturesTestRowError struct{}
 func (e InvalidNumberOfNeighboursError) Error() string {
 	return fmt.Sprintf("invalid number of neighbours %d", e.k)
 }
+func (e InvalidTieBreakError) Error() string {
+	return fmt.Sprintf("invalid tie-breaking rule %d", e.tieBreak)
+}
 
 func (e EmptyTrainingDatasetError) Error() string {
 	return "cannot train on an empty dataset"
@@ -49,6 +65,9 @@ func (e EmptyTrainingDatasetError) Error() string {
 func (e NonFloatFeaturesTrainingSetError) Error() string {
 	return "cannot train on dataset with some non-float features"
 }
+func (e BatchLengthMismatchError) Error() string {
+	return fmt.Sprintf("batch has %d features, training set has %d", e.numBatchFeatures, e.numTrainingSetFeatures)
+}
 
 func (e UntrainedClassifierError) Error() string {
 	return "cannot classify before training"
@@ -0,0 +1,73 @@
+package multiclasserrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidNumberOfWorkersError(workers int) InvalidNumberOfWorkersError {
+	return InvalidNumberOfWorkersError{workers}
+}
+
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewTooFewTargetsError(numTargets int) TooFewTargetsError {
+	return TooFewTargetsError{numTargets}
+}
+func NewBinaryClassifierConstructionError(err error) BinaryClassifierConstructionError {
+	return BinaryClassifierConstructionError{err}
+}
+func NewBinaryClassifierTrainingError(err error) BinaryClassifierTrainingError {
+	return BinaryClassifierTrainingError{err}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewBinaryClassificationError(err error) BinaryClassificationError {
+	return BinaryClassificationError{err}
+}
+
+type InvalidNumberOfWorkersError struct {
+	workers int
+}
+
+type EmptyTrainingDatasetError struct{}
+type TooFewTargetsError struct {
+	numTargets int
+}
+type BinaryClassifierConstructionError struct {
+	err error
+}
+type BinaryClassifierTrainingError struct {
+	err error
+}
+
+type UntrainedClassifierError struct{}
+type BinaryClassificationError struct {
+	err error
+}
+
+func (e InvalidNumberOfWorkersError) Error() string {
+	return fmt.Sprintf("invalid number of workers %d", e.workers)
+}
+
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e TooFewTargetsError) Error() string {
+	return fmt.Sprintf("cannot train on dataset with %d distinct targets, need at least 2", e.numTargets)
+}
+func (e BinaryClassifierConstructionError) Error() string {
+	return fmt.Sprintf("could not construct binary classifier: %s", e.err.Error())
+}
+func (e BinaryClassifierTrainingError) Error() string {
+	return fmt.Sprintf("could not train binary classifier: %s", e.err.Error())
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e BinaryClassificationError) Error() string {
+	return fmt.Sprintf("binary classifier could not classify row: %s", e.err.Error())
+}
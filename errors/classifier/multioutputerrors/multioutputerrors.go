@@ -0,0 +1,77 @@
+package multioutputerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidNumberOfWorkersError(workers int) InvalidNumberOfWorkersError {
+	return InvalidNumberOfWorkersError{workers}
+}
+
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewInvalidChainOrderError(order []int, numTargets int) InvalidChainOrderError {
+	return InvalidChainOrderError{order, numTargets}
+}
+func NewClassifierConstructionError(target int, err error) ClassifierConstructionError {
+	return ClassifierConstructionError{target, err}
+}
+func NewClassifierTrainingError(target int, err error) ClassifierTrainingError {
+	return ClassifierTrainingError{target, err}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewClassificationError(target int, err error) ClassificationError {
+	return ClassificationError{target, err}
+}
+
+type InvalidNumberOfWorkersError struct {
+	workers int
+}
+
+type EmptyTrainingDatasetError struct{}
+type InvalidChainOrderError struct {
+	order      []int
+	numTargets int
+}
+type ClassifierConstructionError struct {
+	target int
+	err    error
+}
+type ClassifierTrainingError struct {
+	target int
+	err    error
+}
+
+type UntrainedClassifierError struct{}
+type ClassificationError struct {
+	target int
+	err    error
+}
+
+func (e InvalidNumberOfWorkersError) Error() string {
+	return fmt.Sprintf("invalid number of workers %d", e.workers)
+}
+
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e InvalidChainOrderError) Error() string {
+	return fmt.Sprintf("chain order %v is not an ordering of %d targets", e.order, e.numTargets)
+}
+func (e ClassifierConstructionError) Error() string {
+	return fmt.Sprintf("could not construct classifier for target %d: %s", e.target, e.err.Error())
+}
+func (e ClassifierTrainingError) Error() string {
+	return fmt.Sprintf("could not train classifier for target %d: %s", e.target, e.err.Error())
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e ClassificationError) Error() string {
+	return fmt.Sprintf("could not classify target %d of row: %s", e.target, e.err.Error())
+}
@@ -0,0 +1,80 @@
+package nearestcentroiderrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidShrinkThresholdError(threshold float64) InvalidShrinkThresholdError {
+	return InvalidShrinkThresholdError{threshold}
+}
+
+func NewNonFloatFeaturesTrainingSetError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewTooFewTargetsError(numTargets int) TooFewTargetsError {
+	return TooFewTargetsError{numTargets}
+}
+func NewTooFewRowsError(numRows, numTargets int) TooFewRowsError {
+	return TooFewRowsError{numRows, numTargets}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+
+type InvalidShrinkThresholdError struct {
+	threshold float64
+}
+
+type NonFloatFeaturesTrainingSetError struct{}
+type EmptyTrainingDatasetError struct{}
+type TooFewTargetsError struct {
+	numTargets int
+}
+type TooFewRowsError struct {
+	numRows    int
+	numTargets int
+}
+
+type UntrainedClassifierError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+
+func (e InvalidShrinkThresholdError) Error() string {
+	return fmt.Sprintf("invalid shrink threshold %.4f, must be non-negative", e.threshold)
+}
+
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on a dataset with non-float features"
+}
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e TooFewTargetsError) Error() string {
+	return fmt.Sprintf("cannot train with %d distinct targets, need at least 2", e.numTargets)
+}
+func (e TooFewRowsError) Error() string {
+	return fmt.Sprintf("cannot estimate within-class spread from %d rows of %d targets", e.numRows, e.numTargets)
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot classify a row with non-float features"
+}
@@ -0,0 +1,81 @@
+package svmerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidHyperparameterError(name string, value float64) InvalidHyperparameterError {
+	return InvalidHyperparameterError{name, value}
+}
+func NewClassWeightsLengthMismatchError(numTargets, numWeights int) ClassWeightsLengthMismatchError {
+	return ClassWeightsLengthMismatchError{numTargets, numWeights}
+}
+
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewNonFloatFeaturesTrainingSetError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewTooFewTargetsError(numTargets int) TooFewTargetsError {
+	return TooFewTargetsError{numTargets}
+}
+
+func NewUntrainedClassifierError() UntrainedClassifierError {
+	return UntrainedClassifierError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+
+type InvalidHyperparameterError struct {
+	name  string
+	value float64
+}
+type ClassWeightsLengthMismatchError struct {
+	numTargets int
+	numWeights int
+}
+
+type EmptyTrainingDatasetError struct{}
+type NonFloatFeaturesTrainingSetError struct{}
+type TooFewTargetsError struct {
+	numTargets int
+}
+
+type UntrainedClassifierError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+
+func (e InvalidHyperparameterError) Error() string {
+	return fmt.Sprintf("invalid value %v for %s", e.value, e.name)
+}
+func (e ClassWeightsLengthMismatchError) Error() string {
+	return fmt.Sprintf("got %d class weights for %d targets", e.numWeights, e.numTargets)
+}
+
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float features"
+}
+func (e TooFewTargetsError) Error() string {
+	return fmt.Sprintf("cannot train on dataset with %d distinct targets, need at least 2", e.numTargets)
+}
+
+func (e UntrainedClassifierError) Error() string {
+	return "cannot classify before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("Test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot classify row with some non-float features"
+}
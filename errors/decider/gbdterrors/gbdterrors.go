@@ -0,0 +1,55 @@
+package gbdterrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidParameterError(name string, value interface{}) InvalidParameterError {
+	return InvalidParameterError{name, value}
+}
+func NewEmptyTrainingDataError() EmptyTrainingDataError {
+	return EmptyTrainingDataError{}
+}
+func NewRowLengthMismatchError(numRowFeatures, numExpectedFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numRowFeatures, numExpectedFeatures}
+}
+func NewTargetsLengthMismatchError(numTargets, numRows int) TargetsLengthMismatchError {
+	return TargetsLengthMismatchError{numTargets, numRows}
+}
+func NewWeightsLengthMismatchError(numWeights, numRows int) WeightsLengthMismatchError {
+	return WeightsLengthMismatchError{numWeights, numRows}
+}
+
+type InvalidParameterError struct {
+	name  string
+	value interface{}
+}
+type EmptyTrainingDataError struct{}
+type RowLengthMismatchError struct {
+	numRowFeatures      int
+	numExpectedFeatures int
+}
+type TargetsLengthMismatchError struct {
+	numTargets int
+	numRows    int
+}
+type WeightsLengthMismatchError struct {
+	numWeights int
+	numRows    int
+}
+
+func (e InvalidParameterError) Error() string {
+	return fmt.Sprintf("invalid value %v for parameter %s", e.value, e.name)
+}
+func (e EmptyTrainingDataError) Error() string {
+	return "cannot boost on empty training data"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("row has %d features, expected %d", e.numRowFeatures, e.numExpectedFeatures)
+}
+func (e TargetsLengthMismatchError) Error() string {
+	return fmt.Sprintf("got %d targets for %d rows", e.numTargets, e.numRows)
+}
+func (e WeightsLengthMismatchError) Error() string {
+	return fmt.Sprintf("got %d weights for %d rows", e.numWeights, e.numRows)
+}
@@ -0,0 +1,64 @@
+package neuralnetworkerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidParameterError(name string, value interface{}) InvalidParameterError {
+	return InvalidParameterError{name, value}
+}
+func NewEmptyTrainingDataError() EmptyTrainingDataError {
+	return EmptyTrainingDataError{}
+}
+func NewRowLengthMismatchError(numRowFeatures, numExpectedFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numRowFeatures, numExpectedFeatures}
+}
+func NewTargetsLengthMismatchError(numTargets, numRows int) TargetsLengthMismatchError {
+	return TargetsLengthMismatchError{numTargets, numRows}
+}
+func NewTargetWidthMismatchError(numTargetValues, numOutputs int) TargetWidthMismatchError {
+	return TargetWidthMismatchError{numTargetValues, numOutputs}
+}
+func NewOptimizationError(err error) OptimizationError {
+	return OptimizationError{err}
+}
+
+type InvalidParameterError struct {
+	name  string
+	value interface{}
+}
+type EmptyTrainingDataError struct{}
+type RowLengthMismatchError struct {
+	numRowFeatures      int
+	numExpectedFeatures int
+}
+type TargetsLengthMismatchError struct {
+	numTargets int
+	numRows    int
+}
+type TargetWidthMismatchError struct {
+	numTargetValues int
+	numOutputs      int
+}
+type OptimizationError struct {
+	err error
+}
+
+func (e InvalidParameterError) Error() string {
+	return fmt.Sprintf("invalid value %v for parameter %s", e.value, e.name)
+}
+func (e EmptyTrainingDataError) Error() string {
+	return "cannot train network on empty training data"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("row has %d features, expected %d", e.numRowFeatures, e.numExpectedFeatures)
+}
+func (e TargetsLengthMismatchError) Error() string {
+	return fmt.Sprintf("got %d targets for %d rows", e.numTargets, e.numRows)
+}
+func (e TargetWidthMismatchError) Error() string {
+	return fmt.Sprintf("target has %d values, expected %d", e.numTargetValues, e.numOutputs)
+}
+func (e OptimizationError) Error() string {
+	return fmt.Sprintf("could not optimize weights: %s", e.err.Error())
+}
@@ -0,0 +1,96 @@
+package knnerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidNumberOfNeighboursError(k int) InvalidNumberOfNeighboursError {
+	return InvalidNumberOfNeighboursError{k}
+}
+func NewInvalidAggregationError(aggregation int) InvalidAggregationError {
+	return InvalidAggregationError{aggregation}
+}
+
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewNonFloatFeaturesTrainingSetError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewBatchLengthMismatchError(numBatchFeatures, numTrainingSetFeatures int) BatchLengthMismatchError {
+	return BatchLengthMismatchError{numBatchFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatTargetsTrainingSetError() NonFloatTargetsTrainingSetError {
+	return NonFloatTargetsTrainingSetError{}
+}
+func NewInvalidNumberOfTargetsError(numTargets int) InvalidNumberOfTargetsError {
+	return InvalidNumberOfTargetsError{numTargets}
+}
+
+func NewUntrainedRegressorError() UntrainedRegressorError {
+	return UntrainedRegressorError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+
+type InvalidNumberOfNeighboursError struct {
+	k int
+}
+type InvalidAggregationError struct {
+	aggregation int
+}
+
+type EmptyTrainingDatasetError struct{}
+type NonFloatFeaturesTrainingSetError struct{}
+type BatchLengthMismatchError struct {
+	numBatchFeatures       int
+	numTrainingSetFeatures int
+}
+type NonFloatTargetsTrainingSetError struct{}
+type InvalidNumberOfTargetsError struct {
+	numTargets int
+}
+
+type UntrainedRegressorError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+
+func (e InvalidNumberOfNeighboursError) Error() string {
+	return fmt.Sprintf("invalid number of neighbours %d", e.k)
+}
+func (e InvalidAggregationError) Error() string {
+	return fmt.Sprintf("invalid aggregation of neighbours' targets %d", e.aggregation)
+}
+
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train on an empty dataset"
+}
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float features"
+}
+func (e BatchLengthMismatchError) Error() string {
+	return fmt.Sprintf("batch has %d features, training set has %d", e.numBatchFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatTargetsTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float targets"
+}
+func (e InvalidNumberOfTargetsError) Error() string {
+	return fmt.Sprintf("cannot train regressor on dataset with %d targets, must have exactly 1", e.numTargets)
+}
+
+func (e UntrainedRegressorError) Error() string {
+	return "cannot predict before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("Test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot predict row with some non-float features"
+}
@@ -0,0 +1,108 @@
+package lassoerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidHyperparameterError(name string, value float64) InvalidHyperparameterError {
+	return InvalidHyperparameterError{name, value}
+}
+func NewEmptyLambdaPathError() EmptyLambdaPathError {
+	return EmptyLambdaPathError{}
+}
+func NewInvalidNumberOfFoldsError(numFolds int) InvalidNumberOfFoldsError {
+	return InvalidNumberOfFoldsError{numFolds}
+}
+
+func NewNonFloatFeaturesError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewNonFloatTargetsError() NonFloatTargetsTrainingSetError {
+	return NonFloatTargetsTrainingSetError{}
+}
+func NewInvalidNumberOfTargetsError(numTargets int) InvalidNumberOfTargetsError {
+	return InvalidNumberOfTargetsError{numTargets}
+}
+func NewNoFeaturesError() NoFeaturesError {
+	return NoFeaturesError{}
+}
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewTooFewRowsError(numRows, numFolds int) TooFewRowsError {
+	return TooFewRowsError{numRows, numFolds}
+}
+
+func NewUntrainedRegressorError() UntrainedRegressorError {
+	return UntrainedRegressorError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+
+type InvalidHyperparameterError struct {
+	name  string
+	value float64
+}
+type EmptyLambdaPathError struct{}
+type InvalidNumberOfFoldsError struct {
+	numFolds int
+}
+type NonFloatFeaturesTrainingSetError struct{}
+type NonFloatTargetsTrainingSetError struct{}
+type InvalidNumberOfTargetsError struct {
+	numTargets int
+}
+type NoFeaturesError struct{}
+type EmptyTrainingDatasetError struct{}
+type TooFewRowsError struct {
+	numRows  int
+	numFolds int
+}
+type UntrainedRegressorError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+
+func (e InvalidHyperparameterError) Error() string {
+	return fmt.Sprintf("invalid %s %g", e.name, e.value)
+}
+func (e EmptyLambdaPathError) Error() string {
+	return "lambda path must have at least one lambda"
+}
+func (e InvalidNumberOfFoldsError) Error() string {
+	return fmt.Sprintf("invalid number of folds %d, must be at least 2", e.numFolds)
+}
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float features"
+}
+func (e NonFloatTargetsTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float targets"
+}
+func (e InvalidNumberOfTargetsError) Error() string {
+	return fmt.Sprintf("cannot train regressor on dataset with %d targets, must have exactly 1", e.numTargets)
+}
+func (e NoFeaturesError) Error() string {
+	return "cannot train regressor on dataset with no features"
+}
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train regressor on dataset with no rows of positive weight"
+}
+func (e TooFewRowsError) Error() string {
+	return fmt.Sprintf("cannot cross-validate %d rows in %d folds", e.numRows, e.numFolds)
+}
+
+func (e UntrainedRegressorError) Error() string {
+	return "cannot predict before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("Test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot predict row with some non-float features"
+}
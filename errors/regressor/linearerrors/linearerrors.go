@@ -16,6 +16,30 @@ func NewInvalidNumberOfTargetsError(numTargets int) InvalidNumberOfTargetsError
 func NewNoFeaturesError() NoFeaturesError {
 	return NoFeaturesError{}
 }
+func NewInvalidHyperparameterError(name string, value float64) InvalidHyperparameterError {
+	return InvalidHyperparameterError{name, value}
+}
+func NewInvalidSolverError(solver int) InvalidSolverError {
+	return InvalidSolverError{solver}
+}
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewRankDeficientError() RankDeficientError {
+	return RankDeficientError{}
+}
+func NewInvalidConfidenceLevelError(level float64) InvalidConfidenceLevelError {
+	return InvalidConfidenceLevelError{level}
+}
+func NewTooFewDegreesOfFreedomError(numObservations float64, numParameters int) TooFewDegreesOfFreedomError {
+	return TooFewDegreesOfFreedomError{numObservations, numParameters}
+}
+func NewFeatureNamesLengthMismatchError(numFeatureNames, numFeatures int) FeatureNamesLengthMismatchError {
+	return FeatureNamesLengthMismatchError{numFeatureNames, numFeatures}
+}
+func NewInitialCoefficientsLengthMismatchError(numInitialCoefficients, numFeatures int) InitialCoefficientsLengthMismatchError {
+	return InitialCoefficientsLengthMismatchError{numInitialCoefficients, numFeatures}
+}
 func NewEstimatorConstructionError(err error) EstimatorConstructionError {
 	return EstimatorConstructionError{err}
 }
@@ -42,6 +66,30 @@ type InvalidNumberOfTargetsError struct {
 	numTargets int
 }
 type NoFeaturesError struct{}
+type InvalidHyperparameterError struct {
+	name  string
+	value float64
+}
+type InvalidSolverError struct {
+	solver int
+}
+type EmptyTrainingDatasetError struct{}
+type RankDeficientError struct{}
+type InvalidConfidenceLevelError struct {
+	level float64
+}
+type TooFewDegreesOfFreedomError struct {
+	numObservations float64
+	numParameters   int
+}
+type FeatureNamesLengthMismatchError struct {
+	numFeatureNames int
+	numFeatures     int
+}
+type InitialCoefficientsLengthMismatchError struct {
+	numInitialCoefficients int
+	numFeatures            int
+}
 type EstimatorConstructionError struct {
 	err error
 }
@@ -70,6 +118,30 @@ func (e InvalidNumberOfTargetsError) Error() string {
 func (e NoFeaturesError) Error() string {
 	return "cannot train regressor on dataset with no features"
 }
+func (e InvalidHyperparameterError) Error() string {
+	return fmt.Sprintf("invalid %s %g", e.name, e.value)
+}
+func (e InvalidSolverError) Error() string {
+	return fmt.Sprintf("invalid solver %d", e.solver)
+}
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train regressor on dataset with no rows of positive weight"
+}
+func (e RankDeficientError) Error() string {
+	return "cannot solve for coefficients of collinear features, use the SVD solver"
+}
+func (e InvalidConfidenceLevelError) Error() string {
+	return fmt.Sprintf("invalid confidence level %g, must be between 0 and 1", e.level)
+}
+func (e TooFewDegreesOfFreedomError) Error() string {
+	return fmt.Sprintf("cannot summarize regression of %d parameters on %g observations, must have more observations", e.numParameters, e.numObservations)
+}
+func (e FeatureNamesLengthMismatchError) Error() string {
+	return fmt.Sprintf("cannot train regressor with %d feature names on dataset with %d features", e.numFeatureNames, e.numFeatures)
+}
+func (e InitialCoefficientsLengthMismatchError) Error() string {
+	return fmt.Sprintf("cannot train regressor from %d initial coefficients on dataset with %d features", e.numInitialCoefficients, e.numFeatures)
+}
 func (e EstimatorConstructionError) Error() string {
 	return fmt.Sprintf("could not construct estimator: %s", e.err.Error())
 }
@@ -0,0 +1,117 @@
+package logisticerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidHyperparameterError(name string, value float64) InvalidHyperparameterError {
+	return InvalidHyperparameterError{name, value}
+}
+func NewClassWeightsLengthMismatchError(numTargets, numWeights int) ClassWeightsLengthMismatchError {
+	return ClassWeightsLengthMismatchError{numTargets, numWeights}
+}
+
+func NewNonFloatFeaturesError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewNoFeaturesError() NoFeaturesError {
+	return NoFeaturesError{}
+}
+func NewTooFewTargetsError(numTargets int) TooFewTargetsError {
+	return TooFewTargetsError{numTargets}
+}
+func NewEstimatorConstructionError(err error) EstimatorConstructionError {
+	return EstimatorConstructionError{err}
+}
+func NewEstimatorTrainingError(err error) EstimatorTrainingError {
+	return EstimatorTrainingError{err}
+}
+func NewEstimatorEstimationError(err error) EstimatorEstimationError {
+	return EstimatorEstimationError{err}
+}
+
+func NewUntrainedModelError() UntrainedModelError {
+	return UntrainedModelError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+func NewNonBinaryLogOddsError(numTargets int) NonBinaryLogOddsError {
+	return NonBinaryLogOddsError{numTargets}
+}
+
+type InvalidHyperparameterError struct {
+	name  string
+	value float64
+}
+type ClassWeightsLengthMismatchError struct {
+	numTargets int
+	numWeights int
+}
+
+type NonFloatFeaturesTrainingSetError struct{}
+type NoFeaturesError struct{}
+type TooFewTargetsError struct {
+	numTargets int
+}
+type EstimatorConstructionError struct {
+	err error
+}
+type EstimatorTrainingError struct {
+	err error
+}
+type EstimatorEstimationError struct {
+	err error
+}
+
+type UntrainedModelError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+type NonBinaryLogOddsError struct {
+	numTargets int
+}
+
+func (e InvalidHyperparameterError) Error() string {
+	return fmt.Sprintf("invalid value %v for %s", e.value, e.name)
+}
+func (e ClassWeightsLengthMismatchError) Error() string {
+	return fmt.Sprintf("got %d class weights for %d targets", e.numWeights, e.numTargets)
+}
+
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float features"
+}
+func (e NoFeaturesError) Error() string {
+	return "cannot train on dataset with no features"
+}
+func (e TooFewTargetsError) Error() string {
+	return fmt.Sprintf("cannot train on dataset with %d distinct targets, need at least 2", e.numTargets)
+}
+func (e EstimatorConstructionError) Error() string {
+	return fmt.Sprintf("could not construct estimator: %s", e.err.Error())
+}
+func (e EstimatorTrainingError) Error() string {
+	return fmt.Sprintf("could not train estimator: %s", e.err.Error())
+}
+func (e EstimatorEstimationError) Error() string {
+	return fmt.Sprintf("could not estimate coefficients: %s", e.err.Error())
+}
+
+func (e UntrainedModelError) Error() string {
+	return "cannot predict before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("Test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot predict row with some non-float features"
+}
+func (e NonBinaryLogOddsError) Error() string {
+	return fmt.Sprintf("log-odds are only defined for 2 targets, model was trained on %d", e.numTargets)
+}
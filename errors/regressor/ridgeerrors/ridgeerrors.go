@@ -0,0 +1,141 @@
+package ridgeerrors
+
+import (
+	"fmt"
+)
+
+func NewInvalidHyperparameterError(name string, value float64) InvalidHyperparameterError {
+	return InvalidHyperparameterError{name, value}
+}
+func NewInvalidSolverError(solver int) InvalidSolverError {
+	return InvalidSolverError{solver}
+}
+func NewEmptyAlphaPathError() EmptyAlphaPathError {
+	return EmptyAlphaPathError{}
+}
+func NewEstimatorConstructionError(err error) EstimatorConstructionError {
+	return EstimatorConstructionError{err}
+}
+
+func NewNonFloatFeaturesError() NonFloatFeaturesTrainingSetError {
+	return NonFloatFeaturesTrainingSetError{}
+}
+func NewNonFloatTargetsError() NonFloatTargetsTrainingSetError {
+	return NonFloatTargetsTrainingSetError{}
+}
+func NewInvalidNumberOfTargetsError(numTargets int) InvalidNumberOfTargetsError {
+	return InvalidNumberOfTargetsError{numTargets}
+}
+func NewNoFeaturesError() NoFeaturesError {
+	return NoFeaturesError{}
+}
+func NewEmptyTrainingDatasetError() EmptyTrainingDatasetError {
+	return EmptyTrainingDatasetError{}
+}
+func NewRankDeficientError() RankDeficientError {
+	return RankDeficientError{}
+}
+func NewTooFewObservationsError(numObservations float64) TooFewObservationsError {
+	return TooFewObservationsError{numObservations}
+}
+func NewEstimatorTrainingError(err error) EstimatorTrainingError {
+	return EstimatorTrainingError{err}
+}
+func NewEstimatorEstimationError(err error) EstimatorEstimationError {
+	return EstimatorEstimationError{err}
+}
+
+func NewUntrainedRegressorError() UntrainedRegressorError {
+	return UntrainedRegressorError{}
+}
+func NewRowLengthMismatchError(numTestRowFeatures, numTrainingSetFeatures int) RowLengthMismatchError {
+	return RowLengthMismatchError{numTestRowFeatures, numTrainingSetFeatures}
+}
+func NewNonFloatFeaturesTestRowError() NonFloatFeaturesTestRowError {
+	return NonFloatFeaturesTestRowError{}
+}
+
+type InvalidHyperparameterError struct {
+	name  string
+	value float64
+}
+type InvalidSolverError struct {
+	solver int
+}
+type EmptyAlphaPathError struct{}
+type EstimatorConstructionError struct {
+	err error
+}
+type NonFloatFeaturesTrainingSetError struct{}
+type NonFloatTargetsTrainingSetError struct{}
+type InvalidNumberOfTargetsError struct {
+	numTargets int
+}
+type NoFeaturesError struct{}
+type EmptyTrainingDatasetError struct{}
+type RankDeficientError struct{}
+type TooFewObservationsError struct {
+	numObservations float64
+}
+type EstimatorTrainingError struct {
+	err error
+}
+type EstimatorEstimationError struct {
+	err error
+}
+type UntrainedRegressorError struct{}
+type RowLengthMismatchError struct {
+	numTestRowFeatures     int
+	numTrainingSetFeatures int
+}
+type NonFloatFeaturesTestRowError struct{}
+
+func (e InvalidHyperparameterError) Error() string {
+	return fmt.Sprintf("invalid %s %g", e.name, e.value)
+}
+func (e InvalidSolverError) Error() string {
+	return fmt.Sprintf("invalid solver %d", e.solver)
+}
+func (e EmptyAlphaPathError) Error() string {
+	return "alpha path must have at least one alpha"
+}
+func (e EstimatorConstructionError) Error() string {
+	return fmt.Sprintf("could not construct estimator: %s", e.err.Error())
+}
+func (e NonFloatFeaturesTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float features"
+}
+func (e NonFloatTargetsTrainingSetError) Error() string {
+	return "cannot train on dataset with some non-float targets"
+}
+func (e InvalidNumberOfTargetsError) Error() string {
+	return fmt.Sprintf("cannot train regressor on dataset with %d targets, must have exactly 1", e.numTargets)
+}
+func (e NoFeaturesError) Error() string {
+	return "cannot train regressor on dataset with no features"
+}
+func (e EmptyTrainingDatasetError) Error() string {
+	return "cannot train regressor on dataset with no rows of positive weight"
+}
+func (e RankDeficientError) Error() string {
+	return "cannot solve for coefficients of collinear features without a positive alpha, use the SVD solver"
+}
+func (e TooFewObservationsError) Error() string {
+	return fmt.Sprintf("cannot choose alpha by generalized cross-validation on %g observations, every alpha fits them exactly", e.numObservations)
+}
+func (e EstimatorTrainingError) Error() string {
+	return fmt.Sprintf("could not train estimator: %s", e.err.Error())
+}
+func (e EstimatorEstimationError) Error() string {
+	return fmt.Sprintf("could not estimate coefficients: %s", e.err.Error())
+}
+
+func (e UntrainedRegressorError) Error() string {
+	return "cannot predict before training"
+}
+func (e RowLengthMismatchError) Error() string {
+	return fmt.Sprintf("Test row has %d features, training set has %d", e.numTestRowFeatures, e.numTrainingSetFeatures)
+}
+func (e NonFloatFeaturesTestRowError) Error() string {
+	return "cannot predict row with some non-float features"
+}
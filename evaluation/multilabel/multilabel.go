@@ -0,0 +1,113 @@
+package multilabel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// HammingLoss is the fraction of individual labels, across every row, which
+// were predicted wrongly.
+func HammingLoss(actual, predicted []slice.Slice) (float64, error) {
+	actualEntries, predictedEntries, err := labelEntries(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+
+	wrong, total := 0, 0
+	for i, row := range actualEntries {
+		for j, label := range row {
+			if label != predictedEntries[i][j] {
+				wrong++
+			}
+			total++
+		}
+	}
+
+	return float64(wrong) / float64(total), nil
+}
+
+// SubsetAccuracy is the fraction of rows with every label predicted
+// correctly.
+func SubsetAccuracy(actual, predicted []slice.Slice) (float64, error) {
+	actualEntries, predictedEntries, err := labelEntries(actual, predicted)
+	if err != nil {
+		return 0, err
+	}
+
+	correct := 0
+	for i, row := range actualEntries {
+		allCorrect := true
+		for j, label := range row {
+			if label != predictedEntries[i][j] {
+				allCorrect = false
+				break
+			}
+		}
+		if allCorrect {
+			correct++
+		}
+	}
+
+	return float64(correct) / float64(len(actualEntries)), nil
+}
+
+// LabelF1Scores returns the F1 score of each label, treating a label as
+// present where it takes the positive value, such as 1.0 or "yes".  A label
+// which is neither present nor predicted present in any row scores 0.
+func LabelF1Scores(actual, predicted []slice.Slice, positive interface{}) ([]float64, error) {
+	actualEntries, predictedEntries, err := labelEntries(actual, predicted)
+	if err != nil {
+		return nil, err
+	}
+
+	numLabels := len(actualEntries[0])
+	scores := make([]float64, numLabels)
+	for j := range scores {
+		truePositives, falsePositives, falseNegatives := 0, 0, 0
+		for i, row := range actualEntries {
+			isPositive := row[j] == positive
+			predictedPositive := predictedEntries[i][j] == positive
+
+			switch {
+			case isPositive && predictedPositive:
+				truePositives++
+			case predictedPositive:
+				falsePositives++
+			case isPositive:
+				falseNegatives++
+			}
+		}
+
+		if truePositives > 0 {
+			scores[j] = 2 * float64(truePositives) / float64(2*truePositives+falsePositives+falseNegatives)
+		}
+	}
+
+	return scores, nil
+}
+
+func labelEntries(actual, predicted []slice.Slice) ([][]interface{}, [][]interface{}, error) {
+	if len(actual) != len(predicted) {
+		return nil, nil, fmt.Errorf("Cannot compare %d actual targets with %d predicted targets", len(actual), len(predicted))
+	}
+
+	if len(actual) == 0 {
+		return nil, nil, errors.New("Cannot evaluate empty targets")
+	}
+
+	actualEntries := make([][]interface{}, len(actual))
+	predictedEntries := make([][]interface{}, len(predicted))
+	for i := range actual {
+		actualEntries[i] = slice.Entries(actual[i])
+		predictedEntries[i] = slice.Entries(predicted[i])
+
+		numLabels := len(actualEntries[0])
+		if len(actualEntries[i]) != numLabels || len(predictedEntries[i]) != numLabels {
+			return nil, nil, fmt.Errorf("Row %d does not have %d labels", i, numLabels)
+		}
+	}
+
+	return actualEntries, predictedEntries, nil
+}
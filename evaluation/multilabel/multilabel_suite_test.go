@@ -0,0 +1,13 @@
+package multilabel_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMultilabel(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Multilabel Suite")
+}
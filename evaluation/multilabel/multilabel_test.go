@@ -0,0 +1,94 @@
+package multilabel_test
+
+import (
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/evaluation/multilabel"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func mixed(values ...interface{}) slice.Slice {
+	s, err := slice.NewMixedSlice(values)
+	Ω(err).ShouldNot(HaveOccurred())
+	return s
+}
+
+var _ = Describe("Multilabel", func() {
+	var actual, predicted []slice.Slice
+
+	BeforeEach(func() {
+		actual = []slice.Slice{
+			slice.NewFloatSlice([]float64{1, 0, 1}),
+			slice.NewFloatSlice([]float64{0, 1, 0}),
+			slice.NewFloatSlice([]float64{1, 1, 0}),
+			slice.NewFloatSlice([]float64{0, 0, 0}),
+		}
+		predicted = []slice.Slice{
+			slice.NewFloatSlice([]float64{1, 0, 1}),
+			slice.NewFloatSlice([]float64{0, 1, 1}),
+			slice.NewFloatSlice([]float64{0, 1, 0}),
+			slice.NewFloatSlice([]float64{0, 0, 0}),
+		}
+	})
+
+	Describe("HammingLoss", func() {
+		It("is the fraction of labels predicted wrongly", func() {
+			loss, err := multilabel.HammingLoss(actual, predicted)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loss).Should(BeNumerically("~", 2.0/12))
+		})
+
+		It("compares string labels", func() {
+			loss, err := multilabel.HammingLoss(
+				[]slice.Slice{mixed("red", 1.0), mixed("blue", 0.0)},
+				[]slice.Slice{mixed("red", 0.0), mixed("blue", 0.0)},
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loss).Should(BeNumerically("~", 0.25))
+		})
+	})
+
+	Describe("SubsetAccuracy", func() {
+		It("is the fraction of rows with every label predicted correctly", func() {
+			accuracy, err := multilabel.SubsetAccuracy(actual, predicted)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(accuracy).Should(BeNumerically("~", 0.5))
+		})
+	})
+
+	Describe("LabelF1Scores", func() {
+		It("scores each label separately", func() {
+			scores, err := multilabel.LabelF1Scores(actual, predicted, 1.0)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(scores).Should(HaveLen(3))
+			Ω(scores[0]).Should(BeNumerically("~", 2.0/3))
+			Ω(scores[1]).Should(BeNumerically("~", 1))
+			Ω(scores[2]).Should(BeNumerically("~", 2.0/3))
+		})
+
+		It("scores 0 for a label never present nor predicted", func() {
+			scores, err := multilabel.LabelF1Scores(actual, predicted, "yes")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(scores).Should(Equal([]float64{0, 0, 0}))
+		})
+	})
+
+	Context("when the targets cannot be compared", func() {
+		It("errors when there are different numbers of rows", func() {
+			_, err := multilabel.HammingLoss(actual, predicted[:3])
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("errors when there are no rows", func() {
+			_, err := multilabel.SubsetAccuracy([]slice.Slice{}, []slice.Slice{})
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("errors when rows have different numbers of labels", func() {
+			predicted[2] = slice.NewFloatSlice([]float64{0, 1})
+			_, err := multilabel.LabelF1Scores(actual, predicted, 1.0)
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,288 @@
+package prequential
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/regressor"
+)
+
+// Stream yields rows one at a time, in order.  Next returns io.EOF once the
+// stream is exhausted, though a stream need never be.
+type Stream interface {
+	NumFeatures() int
+	NumTargets() int
+	Next() (row.Row, error)
+}
+
+// DatasetStream streams the rows of a dataset in order.
+func DatasetStream(ds dataset.Dataset) Stream {
+	return &datasetStream{ds: ds}
+}
+
+type datasetStream struct {
+	ds   dataset.Dataset
+	next int
+}
+
+func (s *datasetStream) NumFeatures() int {
+	return s.ds.NumFeatures()
+}
+
+func (s *datasetStream) NumTargets() int {
+	return s.ds.NumTargets()
+}
+
+func (s *datasetStream) Next() (row.Row, error) {
+	if s.next >= s.ds.NumRows() {
+		return nil, io.EOF
+	}
+
+	s.next++
+	return s.ds.Row(s.next - 1)
+}
+
+// ClassifierSnapshot summarizes a classifier's evaluation so far: the
+// accuracy over every row evaluated, and over the most recent window of
+// rows evaluated.  Metrics are NaN until a row has been evaluated.
+type ClassifierSnapshot struct {
+	RowsSeen       int
+	RowsEvaluated  int
+	Accuracy       float64
+	WindowAccuracy float64
+}
+
+// RegressorSnapshot summarizes a regressor's evaluation so far: the mean
+// absolute and root mean squared errors over every row evaluated, and over
+// the most recent window of rows evaluated.  Metrics are NaN until a row has
+// been evaluated.
+type RegressorSnapshot struct {
+	RowsSeen                   int
+	RowsEvaluated              int
+	MeanAbsoluteError          float64
+	RootMeanSquaredError       float64
+	WindowMeanAbsoluteError    float64
+	WindowRootMeanSquaredError float64
+}
+
+// EvaluateClassifier runs a prequential, or test-then-train, evaluation: it
+// reads the stream in batches of batchSize rows, classifies each row of a
+// batch and then trains the classifier on the batch with PartialFit, so that
+// every row is classified before the classifier has learned from it.  The
+// rows of the first batch are only trained on.  The window of recent rows
+// holds windowSize rows.
+//
+// After each batch, report, if not nil, is given the evaluation so far, and
+// may stop the evaluation by returning true.  The last snapshot is returned.
+func EvaluateClassifier(
+	c classifier.OnlineClassifier,
+	stream Stream,
+	batchSize, windowSize int,
+	report func(ClassifierSnapshot) bool,
+) (ClassifierSnapshot, error) {
+	err := validateSizes(batchSize, windowSize)
+	if err != nil {
+		return ClassifierSnapshot{}, err
+	}
+
+	correct := newRollingSum(windowSize)
+	snapshot := func(rowsSeen int) ClassifierSnapshot {
+		return ClassifierSnapshot{
+			RowsSeen:       rowsSeen,
+			RowsEvaluated:  correct.count,
+			Accuracy:       correct.mean(),
+			WindowAccuracy: correct.windowMean(),
+		}
+	}
+
+	var last ClassifierSnapshot
+	err = run(
+		stream,
+		batchSize,
+		func(r row.Row) error {
+			target, err := c.Classify(r)
+			if err != nil {
+				return err
+			}
+
+			if target.Equals(r.Target()) {
+				correct.add(1)
+			} else {
+				correct.add(0)
+			}
+			return nil
+		},
+		c.PartialFit,
+		func(rowsSeen int) bool {
+			last = snapshot(rowsSeen)
+			return report != nil && report(last)
+		},
+	)
+
+	return last, err
+}
+
+// EvaluateRegressor runs a prequential evaluation of a regressor, as
+// EvaluateClassifier does of a classifier.
+func EvaluateRegressor(
+	r regressor.OnlineRegressor,
+	stream Stream,
+	batchSize, windowSize int,
+	report func(RegressorSnapshot) bool,
+) (RegressorSnapshot, error) {
+	err := validateSizes(batchSize, windowSize)
+	if err != nil {
+		return RegressorSnapshot{}, err
+	}
+
+	absoluteErrors := newRollingSum(windowSize)
+	squaredErrors := newRollingSum(windowSize)
+	snapshot := func(rowsSeen int) RegressorSnapshot {
+		return RegressorSnapshot{
+			RowsSeen:                   rowsSeen,
+			RowsEvaluated:              absoluteErrors.count,
+			MeanAbsoluteError:          absoluteErrors.mean(),
+			RootMeanSquaredError:       math.Sqrt(squaredErrors.mean()),
+			WindowMeanAbsoluteError:    absoluteErrors.windowMean(),
+			WindowRootMeanSquaredError: math.Sqrt(squaredErrors.windowMean()),
+		}
+	}
+
+	var last RegressorSnapshot
+	err = run(
+		stream,
+		batchSize,
+		func(testRow row.Row) error {
+			target, ok := testRow.Target().(slice.FloatSlice)
+			if !ok || len(target.Values()) != 1 {
+				return errors.New("Rows must have a single float target")
+			}
+
+			prediction, err := r.Predict(testRow)
+			if err != nil {
+				return err
+			}
+
+			residual := prediction - target.Values()[0]
+			absoluteErrors.add(math.Abs(residual))
+			squaredErrors.add(residual * residual)
+			return nil
+		},
+		r.PartialFit,
+		func(rowsSeen int) bool {
+			last = snapshot(rowsSeen)
+			return report != nil && report(last)
+		},
+	)
+
+	return last, err
+}
+
+// run evaluates each row of each batch of the stream, except the first,
+// before training on the batch, and stops after the batch for which
+// afterBatch, given the number of rows seen, returns true.
+func run(
+	stream Stream,
+	batchSize int,
+	evaluate func(row.Row) error,
+	partialFit func(dataset.Dataset) error,
+	afterBatch func(rowsSeen int) bool,
+) error {
+	rowsSeen := 0
+	for {
+		batch := []row.Row{}
+		exhausted := false
+		for len(batch) < batchSize {
+			r, err := stream.Next()
+			if err == io.EOF {
+				exhausted = true
+				break
+			}
+			if err != nil {
+				return err
+			}
+			batch = append(batch, r)
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if rowsSeen > 0 {
+			for _, r := range batch {
+				err := evaluate(r)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err := partialFit(dataset.NewDatasetFromRows(stream.NumFeatures(), stream.NumTargets(), batch))
+		if err != nil {
+			return err
+		}
+		rowsSeen = rowsSeen + len(batch)
+
+		if afterBatch(rowsSeen) || exhausted {
+			return nil
+		}
+	}
+}
+
+func validateSizes(batchSize, windowSize int) error {
+	if batchSize < 1 {
+		return errors.New("Batch size must be positive")
+	}
+
+	if windowSize < 1 {
+		return errors.New("Window size must be positive")
+	}
+
+	return nil
+}
+
+// rollingSum keeps the total and count of every value added, and of the
+// most recent values added.
+type rollingSum struct {
+	count       int
+	total       float64
+	window      []float64
+	windowTotal float64
+}
+
+func newRollingSum(windowSize int) *rollingSum {
+	return &rollingSum{window: make([]float64, 0, windowSize)}
+}
+
+func (s *rollingSum) add(value float64) {
+	if len(s.window) < cap(s.window) {
+		s.window = append(s.window, value)
+	} else {
+		i := s.count % cap(s.window)
+		s.windowTotal = s.windowTotal - s.window[i]
+		s.window[i] = value
+	}
+
+	s.count++
+	s.total = s.total + value
+	s.windowTotal = s.windowTotal + value
+}
+
+func (s *rollingSum) mean() float64 {
+	if s.count == 0 {
+		return math.NaN()
+	}
+	return s.total / float64(s.count)
+}
+
+func (s *rollingSum) windowMean() float64 {
+	if len(s.window) == 0 {
+		return math.NaN()
+	}
+	return s.windowTotal / float64(len(s.window))
+}
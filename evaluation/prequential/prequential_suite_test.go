@@ -0,0 +1,13 @@
+package prequential_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPrequential(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Prequential Suite")
+}
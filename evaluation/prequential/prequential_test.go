@@ -0,0 +1,162 @@
+package prequential_test
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/amitkgupta/goodlearn/classifier/naivebayes"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/evaluation/prequential"
+	"github.com/amitkgupta/goodlearn/regressor/knn"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// lastTargetClassifier classifies every row as the target of the last row it
+// was trained on.
+type lastTargetClassifier struct {
+	last slice.Slice
+}
+
+func (c *lastTargetClassifier) Train(ds dataset.Dataset) error {
+	c.last = nil
+	return c.PartialFit(ds)
+}
+
+func (c *lastTargetClassifier) PartialFit(ds dataset.Dataset) error {
+	r, err := ds.Row(ds.NumRows() - 1)
+	if err != nil {
+		return err
+	}
+	c.last = r.Target()
+	return nil
+}
+
+func (c *lastTargetClassifier) Classify(row.Row) (slice.Slice, error) {
+	return c.last, nil
+}
+
+func labelledDataset(labels ...string) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "x"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+	for i, label := range labels {
+		Ω(ds.AddRowFromStrings([]string{strconv.Itoa(i), label})).Should(Succeed())
+	}
+	return ds
+}
+
+var _ = Describe("Prequential evaluation", func() {
+	Describe("EvaluateClassifier", func() {
+		It("Classifies each batch before training on it", func() {
+			ds := labelledDataset("a", "a", "a", "b", "b", "b")
+
+			snapshots := []prequential.ClassifierSnapshot{}
+			last, err := prequential.EvaluateClassifier(
+				&lastTargetClassifier{},
+				prequential.DatasetStream(ds),
+				2,
+				2,
+				func(s prequential.ClassifierSnapshot) bool {
+					snapshots = append(snapshots, s)
+					return false
+				},
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(snapshots).Should(HaveLen(3))
+			Ω(snapshots[0].RowsSeen).Should(Equal(2))
+			Ω(snapshots[0].RowsEvaluated).Should(Equal(0))
+			Ω(math.IsNaN(snapshots[0].Accuracy)).Should(BeTrue())
+
+			Ω(snapshots[1]).Should(Equal(prequential.ClassifierSnapshot{
+				RowsSeen:       4,
+				RowsEvaluated:  2,
+				Accuracy:       0.5,
+				WindowAccuracy: 0.5,
+			}))
+			Ω(last).Should(Equal(prequential.ClassifierSnapshot{
+				RowsSeen:       6,
+				RowsEvaluated:  4,
+				Accuracy:       0.75,
+				WindowAccuracy: 1,
+			}))
+		})
+
+		It("Stops when the report asks it to", func() {
+			ds := labelledDataset("a", "a", "a", "b", "b", "b")
+
+			last, err := prequential.EvaluateClassifier(
+				&lastTargetClassifier{},
+				prequential.DatasetStream(ds),
+				2,
+				10,
+				func(s prequential.ClassifierSnapshot) bool {
+					return s.RowsEvaluated > 0
+				},
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(last.RowsSeen).Should(Equal(4))
+		})
+
+		It("Evaluates a real online classifier", func() {
+			labels := []string{}
+			for i := 0; i < 200; i++ {
+				labels = append(labels, []string{"low", "high"}[(i/10)%2])
+			}
+
+			nb, err := naivebayes.NewGaussianNaiveBayes()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// Rows alternate in runs of ten between the labels, but the
+			// feature is the row's index, so no classifier can do well.
+			last, err := prequential.EvaluateClassifier(nb, prequential.DatasetStream(labelledDataset(labels...)), 5, 50, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(last.RowsSeen).Should(Equal(200))
+			Ω(last.RowsEvaluated).Should(Equal(195))
+			Ω(last.Accuracy).Should(BeNumerically("<", 0.9))
+		})
+
+		It("Requires positive batch and window sizes", func() {
+			ds := labelledDataset("a")
+
+			_, err := prequential.EvaluateClassifier(&lastTargetClassifier{}, prequential.DatasetStream(ds), 0, 1, nil)
+			Ω(err).Should(HaveOccurred())
+
+			_, err = prequential.EvaluateClassifier(&lastTargetClassifier{}, prequential.DatasetStream(ds), 1, 0, nil)
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("EvaluateRegressor", func() {
+		It("Predicts each batch before training on it", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for i := 0; i < 7; i++ {
+				Ω(ds.AddRowFromStrings([]string{strconv.Itoa(i), strconv.Itoa(i)})).Should(Succeed())
+			}
+
+			r, err := knn.NewKNNRegressor(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// Each row is predicted as the last row of the previous batch,
+			// which is 1 or 2 less, apart from the last row, which is 1 less.
+			last, err := prequential.EvaluateRegressor(r, prequential.DatasetStream(ds), 2, 3, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(last.RowsSeen).Should(Equal(7))
+			Ω(last.RowsEvaluated).Should(Equal(5))
+			Ω(last.MeanAbsoluteError).Should(BeNumerically("~", 7.0/5, 1e-12))
+			Ω(last.RootMeanSquaredError).Should(BeNumerically("~", math.Sqrt(11.0/5), 1e-12))
+			Ω(last.WindowMeanAbsoluteError).Should(BeNumerically("~", 4.0/3, 1e-12))
+			Ω(last.WindowRootMeanSquaredError).Should(BeNumerically("~", math.Sqrt(6.0/3), 1e-12))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package reliability
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Bin is one bar of a reliability diagram: the predicted probabilities in
+// [Lower, Upper), how many there were, their mean, and how often the
+// predicted event actually happened.  A well calibrated model has
+// MeanPredicted close to ObservedFrequency in every bin.
+type Bin struct {
+	Lower, Upper      float64
+	Count             int
+	MeanPredicted     float64
+	ObservedFrequency float64
+}
+
+// Diagram bins the predicted probabilities of an event into numBins equal
+// width bins over [0, 1], with a probability of exactly 1 going into the
+// last bin, and compares each bin against the outcomes, whether the event
+// happened.  Empty bins have a Count of 0 and NaN means.
+func Diagram(probabilities []float64, outcomes []bool, numBins int) ([]Bin, error) {
+	if len(probabilities) != len(outcomes) {
+		return nil, fmt.Errorf("Cannot compare %d probabilities with %d outcomes", len(probabilities), len(outcomes))
+	}
+
+	if len(probabilities) == 0 {
+		return nil, errors.New("Cannot evaluate empty probabilities")
+	}
+
+	if numBins < 1 {
+		return nil, fmt.Errorf("Invalid number of bins %d", numBins)
+	}
+
+	bins := make([]Bin, numBins)
+	predictedTotals := make([]float64, numBins)
+	observedTotals := make([]float64, numBins)
+	for b := range bins {
+		bins[b].Lower = float64(b) / float64(numBins)
+		bins[b].Upper = float64(b+1) / float64(numBins)
+	}
+
+	for i, probability := range probabilities {
+		if probability < 0 || probability > 1 || math.IsNaN(probability) {
+			return nil, fmt.Errorf("Invalid probability %f", probability)
+		}
+
+		b := int(probability * float64(numBins))
+		if b == numBins {
+			b = numBins - 1
+		}
+
+		bins[b].Count++
+		predictedTotals[b] = predictedTotals[b] + probability
+		if outcomes[i] {
+			observedTotals[b]++
+		}
+	}
+
+	for b := range bins {
+		bins[b].MeanPredicted = predictedTotals[b] / float64(bins[b].Count)
+		bins[b].ObservedFrequency = observedTotals[b] / float64(bins[b].Count)
+	}
+
+	return bins, nil
+}
+
+// ExpectedCalibrationError is the mean, over the bins of the reliability
+// diagram weighted by how many probabilities they hold, of the gap between
+// the mean predicted probability and the observed frequency.
+func ExpectedCalibrationError(probabilities []float64, outcomes []bool, numBins int) (float64, error) {
+	bins, err := Diagram(probabilities, outcomes, numBins)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, bin := range bins {
+		if bin.Count > 0 {
+			total = total + float64(bin.Count)*math.Abs(bin.MeanPredicted-bin.ObservedFrequency)
+		}
+	}
+
+	return total / float64(len(probabilities)), nil
+}
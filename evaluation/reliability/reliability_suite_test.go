@@ -0,0 +1,13 @@
+package reliability_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestReliability(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reliability Suite")
+}
@@ -0,0 +1,79 @@
+package reliability_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/evaluation/reliability"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reliability", func() {
+	var (
+		probabilities []float64
+		outcomes      []bool
+	)
+
+	BeforeEach(func() {
+		probabilities = []float64{0.1, 0.2, 0.3, 0.8, 0.9, 1}
+		outcomes = []bool{false, false, true, true, false, true}
+	})
+
+	Describe("Diagram", func() {
+		It("bins the probabilities and compares them with the outcomes", func() {
+			bins, err := reliability.Diagram(probabilities, outcomes, 2)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(bins).Should(HaveLen(2))
+
+			Ω(bins[0].Lower).Should(Equal(0.0))
+			Ω(bins[0].Upper).Should(Equal(0.5))
+			Ω(bins[0].Count).Should(Equal(3))
+			Ω(bins[0].MeanPredicted).Should(BeNumerically("~", 0.2))
+			Ω(bins[0].ObservedFrequency).Should(BeNumerically("~", 1.0/3))
+
+			Ω(bins[1].Count).Should(Equal(3))
+			Ω(bins[1].MeanPredicted).Should(BeNumerically("~", 0.9))
+			Ω(bins[1].ObservedFrequency).Should(BeNumerically("~", 2.0/3))
+		})
+
+		It("leaves empty bins with NaN means", func() {
+			bins, err := reliability.Diagram(probabilities, outcomes, 10)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(bins[5].Count).Should(Equal(0))
+			Ω(math.IsNaN(bins[5].MeanPredicted)).Should(BeTrue())
+		})
+
+		It("errors on invalid input", func() {
+			_, err := reliability.Diagram(probabilities, outcomes[:5], 10)
+			Ω(err).Should(HaveOccurred())
+
+			_, err = reliability.Diagram([]float64{}, []bool{}, 10)
+			Ω(err).Should(HaveOccurred())
+
+			_, err = reliability.Diagram(probabilities, outcomes, 0)
+			Ω(err).Should(HaveOccurred())
+
+			_, err = reliability.Diagram([]float64{1.5}, []bool{true}, 10)
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("ExpectedCalibrationError", func() {
+		It("weights each bin's gap by its count", func() {
+			ece, err := reliability.ExpectedCalibrationError(probabilities, outcomes, 2)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ece).Should(BeNumerically("~", 0.5*(1.0/3-0.2)+0.5*(0.9-2.0/3)))
+		})
+
+		It("is 0 for perfectly calibrated probabilities", func() {
+			ece, err := reliability.ExpectedCalibrationError(
+				[]float64{0.5, 0.5, 0, 1},
+				[]bool{true, false, false, true},
+				4,
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ece).Should(BeNumerically("~", 0))
+		})
+	})
+})
@@ -0,0 +1,417 @@
+package matrixutilities
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Covariance returns the column means of the rows and their sample
+// covariance matrix (dividing by n - 1, or by 1 for a single row).
+func Covariance(rows [][]float64) ([]float64, [][]float64) {
+	n := len(rows)
+	d := 0
+	if n > 0 {
+		d = len(rows[0])
+	}
+
+	means := make([]float64, d)
+	for _, r := range rows {
+		for j, v := range r {
+			means[j] = means[j] + v/float64(n)
+		}
+	}
+
+	denominator := float64(n - 1)
+	if n < 2 {
+		denominator = 1
+	}
+
+	covariance := Zeros(d, d)
+	for _, r := range rows {
+		for i := 0; i < d; i++ {
+			for j := 0; j <= i; j++ {
+				covariance[i][j] = covariance[i][j] + (r[i]-means[i])*(r[j]-means[j])/denominator
+			}
+		}
+	}
+
+	for i := 0; i < d; i++ {
+		for j := 0; j < i; j++ {
+			covariance[j][i] = covariance[i][j]
+		}
+	}
+
+	return means, covariance
+}
+
+func Zeros(numRows, numColumns int) [][]float64 {
+	m := make([][]float64, numRows)
+	for i := range m {
+		m[i] = make([]float64, numColumns)
+	}
+	return m
+}
+
+func Identity(n int) [][]float64 {
+	m := Zeros(n, n)
+	for i := range m {
+		m[i][i] = 1
+	}
+	return m
+}
+
+// Cholesky returns the lower triangular L with L L' = a, for symmetric
+// positive definite a.
+func Cholesky(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	l := Zeros(n, n)
+
+	for i := 0; i < n; i++ {
+		if len(a[i]) != n {
+			return nil, errors.New("matrix must be square")
+		}
+
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum = sum - l[i][k]*l[j][k]
+			}
+
+			if i == j {
+				if sum <= 0 || math.IsNaN(sum) {
+					return nil, errors.New("matrix is not positive definite")
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+
+	return l, nil
+}
+
+// SolveLowerTriangular solves l x = b by forward substitution.
+func SolveLowerTriangular(l [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := range x {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum = sum - l[i][k]*x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
+
+// SolveUpperTriangular solves u x = b by back substitution.
+func SolveUpperTriangular(u [][]float64, b []float64) []float64 {
+	n := len(b)
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := b[i]
+		for k := i + 1; k < n; k++ {
+			sum = sum - u[i][k]*x[k]
+		}
+		x[i] = sum / u[i][i]
+	}
+	return x
+}
+
+// CholeskySolve solves a x = b given the Cholesky factor l of a.
+func CholeskySolve(l [][]float64, b []float64) []float64 {
+	return SolveUpperTriangular(Transpose(l), SolveLowerTriangular(l, b))
+}
+
+func Transpose(a [][]float64) [][]float64 {
+	if len(a) == 0 {
+		return [][]float64{}
+	}
+
+	t := Zeros(len(a[0]), len(a))
+	for i, r := range a {
+		for j, v := range r {
+			t[j][i] = v
+		}
+	}
+	return t
+}
+
+// Inverse returns the inverse of the symmetric positive definite matrix a.
+func Inverse(a [][]float64) ([][]float64, error) {
+	l, err := Cholesky(a)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(a)
+	columns := make([][]float64, n)
+	for j, e := range Identity(n) {
+		columns[j] = CholeskySolve(l, e)
+	}
+
+	return Transpose(columns), nil
+}
+
+func MultiplyVector(a [][]float64, x []float64) []float64 {
+	result := make([]float64, len(a))
+	for i, r := range a {
+		for j, v := range r {
+			result[i] = result[i] + v*x[j]
+		}
+	}
+	return result
+}
+
+// SymmetricEigen returns the eigenvalues of the symmetric matrix a, largest
+// first, and a unit eigenvector for each, found by cyclic Jacobi rotations.
+func SymmetricEigen(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	m := Zeros(n, n)
+	for i := range m {
+		copy(m[i], a[i])
+	}
+	v := Identity(n)
+
+	for sweep := 0; sweep < 100; sweep++ {
+		offDiagonal := 0.0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				offDiagonal = offDiagonal + m[i][j]*m[i][j]
+			}
+		}
+		if offDiagonal < 1e-30 {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if m[p][q] == 0 {
+					continue
+				}
+
+				// the rotation by (c, s) zeroes m[p][q]
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					mkp, mkq := m[k][p], m[k][q]
+					m[k][p], m[k][q] = c*mkp-s*mkq, s*mkp+c*mkq
+				}
+				for k := 0; k < n; k++ {
+					mpk, mqk := m[p][k], m[q][k]
+					m[p][k], m[q][k] = c*mpk-s*mqk, s*mpk+c*mqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return m[order[i]][order[i]] > m[order[j]][order[j]]
+	})
+
+	values := make([]float64, n)
+	vectors := Zeros(n, n)
+	for k, i := range order {
+		values[k] = m[i][i]
+		for j := 0; j < n; j++ {
+			vectors[k][j] = v[j][i]
+		}
+	}
+
+	return values, vectors
+}
+
+// QR returns the thin QR decomposition of the m by n matrix a, which must
+// have at least as many rows as columns, found by Householder reflections: q
+// is m by n with orthonormal columns, r is n by n upper triangular, and
+// q r = a.
+func QR(a [][]float64) ([][]float64, [][]float64, error) {
+	m := len(a)
+	n := 0
+	if m > 0 {
+		n = len(a[0])
+	}
+	if m < n {
+		return nil, nil, errors.New("matrix must have at least as many rows as columns")
+	}
+
+	r := Zeros(m, n)
+	for i := range r {
+		if len(a[i]) != n {
+			return nil, nil, errors.New("matrix rows must have equal lengths")
+		}
+		copy(r[i], a[i])
+	}
+
+	// reflections[k] is the vector v of the reflection I - 2 v v' / v'v which
+	// zeroes column k below the diagonal, or nil if it is already zero.
+	reflections := make([][]float64, n)
+	for k := 0; k < n; k++ {
+		norm := 0.0
+		for i := k; i < m; i++ {
+			norm = norm + r[i][k]*r[i][k]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+
+		alpha := -math.Copysign(norm, r[k][k])
+		v := make([]float64, m)
+		v[k] = r[k][k] - alpha
+		for i := k + 1; i < m; i++ {
+			v[i] = r[i][k]
+		}
+
+		reflect(v, k, r, k)
+		reflections[k] = v
+	}
+
+	q := Zeros(m, n)
+	for i := 0; i < n; i++ {
+		q[i][i] = 1
+	}
+	for k := n - 1; k >= 0; k-- {
+		if reflections[k] != nil {
+			reflect(reflections[k], k, q, 0)
+		}
+	}
+
+	upper := Zeros(n, n)
+	for i := range upper {
+		copy(upper[i][i:], r[i][i:])
+	}
+
+	return q, upper, nil
+}
+
+// reflect applies the Householder reflection I - 2 v v' / v'v, where v is
+// zero before index start, to the columns of a from column first on.
+func reflect(v []float64, start int, a [][]float64, first int) {
+	vv := 0.0
+	for i := start; i < len(v); i++ {
+		vv = vv + v[i]*v[i]
+	}
+	if vv == 0 {
+		return
+	}
+
+	for j := first; j < len(a[0]); j++ {
+		dot := 0.0
+		for i := start; i < len(v); i++ {
+			dot = dot + v[i]*a[i][j]
+		}
+
+		f := 2 * dot / vv
+		for i := start; i < len(v); i++ {
+			a[i][j] = a[i][j] - f*v[i]
+		}
+	}
+}
+
+// SVD returns the thin singular value decomposition of the m by n matrix a,
+// found by one-sided Jacobi rotations: the min(m, n) singular values, largest
+// first, and matrices u and v, m by min(m, n) and n by min(m, n), whose
+// columns are the corresponding left and right singular vectors, so that
+// u diag(s) v' = a.  Columns of u for zero singular values are zero.
+func SVD(a [][]float64) ([][]float64, []float64, [][]float64) {
+	m := len(a)
+	n := 0
+	if m > 0 {
+		n = len(a[0])
+	}
+
+	if m < n {
+		u, s, v := SVD(Transpose(a))
+		return v, s, u
+	}
+
+	w := Zeros(m, n)
+	for i := range w {
+		copy(w[i], a[i])
+	}
+	v := Identity(n)
+
+	for sweep := 0; sweep < 100; sweep++ {
+		rotated := false
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for i := 0; i < m; i++ {
+					alpha = alpha + w[i][p]*w[i][p]
+					beta = beta + w[i][q]*w[i][q]
+					gamma = gamma + w[i][p]*w[i][q]
+				}
+				if math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+
+				// the rotation by (c, s) makes columns p and q orthogonal
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+
+				for i := 0; i < m; i++ {
+					wip, wiq := w[i][p], w[i][q]
+					w[i][p], w[i][q] = c*wip-s*wiq, s*wip+c*wiq
+				}
+				for i := 0; i < n; i++ {
+					vip, viq := v[i][p], v[i][q]
+					v[i][p], v[i][q] = c*vip-s*viq, s*vip+c*viq
+				}
+			}
+		}
+
+		if !rotated {
+			break
+		}
+	}
+
+	norms := make([]float64, n)
+	order := make([]int, n)
+	for j := range norms {
+		for i := 0; i < m; i++ {
+			norms[j] = norms[j] + w[i][j]*w[i][j]
+		}
+		norms[j] = math.Sqrt(norms[j])
+		order[j] = j
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return norms[order[i]] > norms[order[j]]
+	})
+
+	values := make([]float64, n)
+	leftVectors := Zeros(m, n)
+	rightVectors := Zeros(n, n)
+	for k, j := range order {
+		values[k] = norms[j]
+		for i := 0; i < m; i++ {
+			if norms[j] > 0 {
+				leftVectors[i][k] = w[i][j] / norms[j]
+			}
+		}
+		for i := 0; i < n; i++ {
+			rightVectors[i][k] = v[i][j]
+		}
+	}
+
+	return leftVectors, values, rightVectors
+}
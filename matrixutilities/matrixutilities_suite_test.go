@@ -0,0 +1,13 @@
+package matrixutilities_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMatrixutilities(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Matrixutilities Suite")
+}
@@ -0,0 +1,200 @@
+package matrixutilities_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matrix Utilities", func() {
+	spd := [][]float64{{4, 2, 0.4}, {2, 5, 1}, {0.4, 1, 3}}
+
+	Describe("Covariance", func() {
+		It("Returns the column means and sample covariance", func() {
+			means, covariance := matrixutilities.Covariance([][]float64{{1, 2}, {3, 6}, {5, 4}})
+			Ω(means).Should(Equal([]float64{3, 4}))
+			Ω(covariance).Should(Equal([][]float64{{4, 2}, {2, 4}}))
+		})
+	})
+
+	Describe("Cholesky", func() {
+		It("Factors a symmetric positive definite matrix", func() {
+			l, err := matrixutilities.Cholesky(spd)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for i := range spd {
+				for j := range spd {
+					product := 0.0
+					for k := range spd {
+						product = product + l[i][k]*l[j][k]
+					}
+					Ω(product).Should(BeNumerically("~", spd[i][j], 1e-12))
+				}
+				for j := i + 1; j < len(spd); j++ {
+					Ω(l[i][j]).Should(BeZero())
+				}
+			}
+		})
+
+		It("Rejects matrices which are not positive definite", func() {
+			_, err := matrixutilities.Cholesky([][]float64{{1, 2}, {2, 1}})
+			Ω(err).Should(HaveOccurred())
+
+			_, err = matrixutilities.Cholesky([][]float64{{1, 2}})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Triangular solves", func() {
+		It("Solves lower and upper triangular systems", func() {
+			l := [][]float64{{2, 0}, {1, 4}}
+			Ω(matrixutilities.SolveLowerTriangular(l, []float64{4, 10})).Should(Equal([]float64{2, 2}))
+			Ω(matrixutilities.SolveUpperTriangular(matrixutilities.Transpose(l), []float64{6, 8})).Should(Equal([]float64{2, 2}))
+		})
+
+		It("Solves a symmetric positive definite system from its Cholesky factor", func() {
+			l, err := matrixutilities.Cholesky(spd)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			x := matrixutilities.CholeskySolve(l, []float64{1, 2, 3})
+			b := matrixutilities.MultiplyVector(spd, x)
+			for i, v := range []float64{1, 2, 3} {
+				Ω(b[i]).Should(BeNumerically("~", v, 1e-12))
+			}
+		})
+	})
+
+	Describe("Inverse", func() {
+		It("Inverts a symmetric positive definite matrix", func() {
+			inverse, err := matrixutilities.Inverse(spd)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for i, e := range matrixutilities.Identity(3) {
+				column := matrixutilities.MultiplyVector(spd, matrixutilities.Transpose(inverse)[i])
+				for j := range e {
+					Ω(column[j]).Should(BeNumerically("~", e[j], 1e-12))
+				}
+			}
+		})
+	})
+
+	Describe("Transpose", func() {
+		It("Swaps rows and columns", func() {
+			Ω(matrixutilities.Transpose([][]float64{{1, 2, 3}, {4, 5, 6}})).Should(Equal([][]float64{{1, 4}, {2, 5}, {3, 6}}))
+			Ω(matrixutilities.Transpose([][]float64{})).Should(BeEmpty())
+		})
+	})
+
+	Describe("SymmetricEigen", func() {
+		It("Finds the eigenvalues, largest first, and unit eigenvectors", func() {
+			values, vectors := matrixutilities.SymmetricEigen(spd)
+			Ω(values).Should(HaveLen(3))
+			Ω(values[0]).Should(BeNumerically(">=", values[1]))
+			Ω(values[1]).Should(BeNumerically(">=", values[2]))
+
+			for k, vector := range vectors {
+				product := matrixutilities.MultiplyVector(spd, vector)
+				norm := 0.0
+				for j, v := range vector {
+					Ω(product[j]).Should(BeNumerically("~", values[k]*v, 1e-10))
+					norm = norm + v*v
+				}
+				Ω(norm).Should(BeNumerically("~", 1, 1e-12))
+			}
+		})
+
+		It("Returns the diagonal of a diagonal matrix", func() {
+			values, _ := matrixutilities.SymmetricEigen([][]float64{{1, 0}, {0, 3}})
+			Ω(values).Should(Equal([]float64{3, 1}))
+		})
+	})
+
+	Describe("QR", func() {
+		It("Factors a tall matrix into orthonormal columns and an upper triangle", func() {
+			a := [][]float64{{1, 2}, {3, 4}, {5, 6}, {0, -1}}
+			q, r, err := matrixutilities.QR(a)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for i := range a {
+				for j := range a[i] {
+					product := 0.0
+					for k := range r {
+						product = product + q[i][k]*r[k][j]
+					}
+					Ω(product).Should(BeNumerically("~", a[i][j], 1e-12))
+				}
+			}
+
+			for j := range r {
+				for k := range r {
+					dot := 0.0
+					for i := range q {
+						dot = dot + q[i][j]*q[i][k]
+					}
+					if j == k {
+						Ω(dot).Should(BeNumerically("~", 1, 1e-12))
+					} else {
+						Ω(dot).Should(BeNumerically("~", 0, 1e-12))
+					}
+				}
+			}
+
+			Ω(r[1][0]).Should(BeZero())
+		})
+
+		It("Rejects wide matrices", func() {
+			_, _, err := matrixutilities.QR([][]float64{{1, 2}})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("SVD", func() {
+		reconstruct := func(u [][]float64, s []float64, v [][]float64) [][]float64 {
+			a := matrixutilities.Zeros(len(u), len(v))
+			for i := range a {
+				for j := range a[i] {
+					for k, sk := range s {
+						a[i][j] = a[i][j] + u[i][k]*sk*v[j][k]
+					}
+				}
+			}
+			return a
+		}
+
+		It("Decomposes tall and wide matrices, largest singular value first", func() {
+			for _, a := range [][][]float64{
+				{{3, 2, 2}, {2, 3, -2}},
+				{{3, 2}, {2, 3}, {2, -2}},
+			} {
+				u, s, v := matrixutilities.SVD(a)
+				Ω(s).Should(HaveLen(2))
+				Ω(s[0]).Should(BeNumerically("~", 5, 1e-12))
+				Ω(s[1]).Should(BeNumerically("~", 3, 1e-12))
+
+				reconstructed := reconstruct(u, s, v)
+				for i := range a {
+					for j := range a[i] {
+						Ω(reconstructed[i][j]).Should(BeNumerically("~", a[i][j], 1e-12))
+					}
+				}
+			}
+		})
+
+		It("Finds zero singular values of rank-deficient matrices", func() {
+			a := [][]float64{{1, 2}, {2, 4}, {3, 6}}
+			u, s, v := matrixutilities.SVD(a)
+			Ω(s[0]).Should(BeNumerically("~", math.Sqrt(70), 1e-12))
+			Ω(s[1]).Should(BeNumerically("~", 0, 1e-12))
+
+			reconstructed := reconstruct(u, s, v)
+			for i := range a {
+				for j := range a[i] {
+					Ω(reconstructed[i][j]).Should(BeNumerically("~", a[i][j], 1e-12))
+				}
+			}
+		})
+	})
+})
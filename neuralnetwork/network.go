@@ -0,0 +1,232 @@
+package neuralnetwork
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/errors/neuralnetworkerrors"
+)
+
+// Network is a fully connected feed-forward network.  Its weights are held
+// in a single slice, layer by layer, each layer's weight matrix (row-major,
+// one row per output unit) followed by its biases.
+type Network struct {
+	layerSizes []int
+	activation Activation
+	output     Output
+	weights    []float64
+
+	trainingLosses   []float64
+	validationLosses []float64
+}
+
+func newNetwork(layerSizes []int, activation Activation, output Output) *Network {
+	numWeights := 0
+	for l := 0; l+1 < len(layerSizes); l++ {
+		numWeights = numWeights + (layerSizes[l]+1)*layerSizes[l+1]
+	}
+
+	return &Network{
+		layerSizes: layerSizes,
+		activation: activation,
+		output:     output,
+		weights:    make([]float64, numWeights),
+	}
+}
+
+func (n *Network) NumInputs() int {
+	return n.layerSizes[0]
+}
+
+func (n *Network) NumOutputs() int {
+	return n.layerSizes[len(n.layerSizes)-1]
+}
+
+// TrainingLosses returns the mean training loss after each epoch, including
+// any epochs discarded by early stopping.
+func (n *Network) TrainingLosses() []float64 {
+	return n.trainingLosses
+}
+
+// ValidationLosses returns the mean validation loss after each epoch, or nil
+// if no validation data was given.
+func (n *Network) ValidationLosses() []float64 {
+	return n.validationLosses
+}
+
+// Outputs returns class probabilities for a SoftmaxOutput network, and
+// predicted values for a LinearOutput network.
+func (n *Network) Outputs(x []float64) ([]float64, error) {
+	if len(x) != n.NumInputs() {
+		return nil, neuralnetworkerrors.NewRowLengthMismatchError(len(x), n.NumInputs())
+	}
+
+	activations, _ := n.forward(n.weights, x, 0, nil)
+	return activations[len(activations)-1], nil
+}
+
+// initialize draws each weight uniformly from the Glorot range for its layer
+// and zeroes the biases.
+func (n *Network) initialize(random *rand.Rand) {
+	offset := 0
+	for l := 0; l+1 < len(n.layerSizes); l++ {
+		in, out := n.layerSizes[l], n.layerSizes[l+1]
+		limit := math.Sqrt(6 / float64(in+out))
+
+		for i := 0; i < in*out; i++ {
+			n.weights[offset+i] = limit * (2*random.Float64() - 1)
+		}
+		offset = offset + (in+1)*out
+	}
+}
+
+// forward returns the output of every layer, starting with the input.  With
+// positive dropout each hidden unit is zeroed with that probability and the
+// survivors scaled up to compensate; the factor applied to each hidden unit
+// is returned alongside.
+func (n *Network) forward(weights, x []float64, dropout float64, random *rand.Rand) ([][]float64, [][]float64) {
+	numLayers := len(n.layerSizes)
+	activations := make([][]float64, numLayers)
+	scales := make([][]float64, numLayers)
+	activations[0] = x
+
+	offset := 0
+	for l := 0; l+1 < numLayers; l++ {
+		in, out := n.layerSizes[l], n.layerSizes[l+1]
+		biases := offset + in*out
+		inputs := n.droppedOut(activations[l], scales[l])
+		next := make([]float64, out)
+
+		for j := 0; j < out; j++ {
+			z := weights[biases+j]
+			row := weights[offset+j*in : offset+(j+1)*in]
+			for i, a := range inputs {
+				z = z + row[i]*a
+			}
+			next[j] = z
+		}
+		offset = biases + out
+
+		if l+2 == numLayers {
+			if n.output == SoftmaxOutput {
+				softmax(next)
+			}
+			activations[l+1] = next
+			break
+		}
+
+		scales[l+1] = make([]float64, out)
+		for j := range next {
+			next[j] = n.activation.apply(next[j])
+			scales[l+1][j] = 1
+			if dropout > 0 {
+				scales[l+1][j] = 0
+				if random.Float64() >= dropout {
+					scales[l+1][j] = 1 / (1 - dropout)
+				}
+			}
+		}
+		activations[l+1] = next
+	}
+
+	return activations, scales
+}
+
+// loss is the cross-entropy of softmax outputs, or half the squared error of
+// linear outputs, against the target y.
+func (n *Network) loss(outputs, y []float64) float64 {
+	result := 0.0
+	for k, o := range outputs {
+		if n.output == SoftmaxOutput {
+			if y[k] > 0 {
+				result = result - y[k]*math.Log(math.Max(o, 1e-15))
+			}
+		} else {
+			result = result + (o-y[k])*(o-y[k])/2
+		}
+	}
+	return result
+}
+
+// backward adds the gradient of the loss on a single example to gradient, by
+// backpropagating through the layer outputs and dropout factors recorded by
+// forward.  Both losses have output error o - y with respect to the output
+// layer's pre-activations.
+func (n *Network) backward(weights []float64, activations, scales [][]float64, y, gradient []float64) {
+	numLayers := len(n.layerSizes)
+	outputs := activations[numLayers-1]
+
+	delta := make([]float64, len(outputs))
+	for k, o := range outputs {
+		delta[k] = o - y[k]
+	}
+
+	offset := len(weights)
+	for l := numLayers - 2; l >= 0; l-- {
+		in, out := n.layerSizes[l], n.layerSizes[l+1]
+		offset = offset - (in+1)*out
+		biases := offset + in*out
+
+		inputs := n.droppedOut(activations[l], scales[l])
+		for j, d := range delta {
+			gradient[biases+j] = gradient[biases+j] + d
+			for i, a := range inputs {
+				gradient[offset+j*in+i] = gradient[offset+j*in+i] + d*a
+			}
+		}
+
+		if l == 0 {
+			break
+		}
+
+		previous := make([]float64, in)
+		for i := range previous {
+			sum := 0.0
+			for j, d := range delta {
+				sum = sum + weights[offset+j*in+i]*d
+			}
+			previous[i] = sum * scales[l][i] * n.activation.derivative(activations[l][i])
+		}
+		delta = previous
+	}
+}
+
+func (n *Network) droppedOut(activations, scales []float64) []float64 {
+	if scales == nil {
+		return activations
+	}
+
+	result := make([]float64, len(activations))
+	for i, a := range activations {
+		result[i] = a * scales[i]
+	}
+	return result
+}
+
+// meanLoss returns the mean loss over the given rows, without dropout or
+// weight decay.
+func (n *Network) meanLoss(weights []float64, x, y [][]float64) float64 {
+	total := 0.0
+	for i := range x {
+		activations, _ := n.forward(weights, x[i], 0, nil)
+		total = total + n.loss(activations[len(activations)-1], y[i])
+	}
+	return total / float64(len(x))
+}
+
+func softmax(z []float64) {
+	max := math.Inf(-1)
+	for _, v := range z {
+		max = math.Max(max, v)
+	}
+
+	sum := 0.0
+	for i, v := range z {
+		z[i] = math.Exp(v - max)
+		sum = sum + z[i]
+	}
+
+	for i := range z {
+		z[i] = z[i] / sum
+	}
+}
@@ -0,0 +1,13 @@
+package neuralnetwork_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestNeuralnetwork(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Neuralnetwork Suite")
+}
@@ -0,0 +1,119 @@
+package neuralnetwork
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/errors/neuralnetworkerrors"
+)
+
+type Activation int
+
+const (
+	ReLU Activation = iota
+	Tanh
+	Sigmoid
+)
+
+type Output int
+
+const (
+	// SoftmaxOutput produces class probabilities and is trained on the mean
+	// cross-entropy against one-hot targets.
+	SoftmaxOutput Output = iota
+	// LinearOutput produces unbounded values and is trained on half the mean
+	// squared error.
+	LinearOutput
+)
+
+type Parameters struct {
+	// HiddenLayers gives the number of units in each hidden layer.
+	HiddenLayers []int
+	Activation   Activation
+
+	LearningRate float64
+	Momentum     float64
+	BatchSize    int
+	MaxEpochs    int
+
+	// WeightDecay is the coefficient of an L2 penalty on the weights, but not
+	// the biases.
+	WeightDecay float64
+
+	// Dropout is the probability with which each hidden unit is zeroed for
+	// each training example.
+	Dropout float64
+
+	// Patience is the number of epochs without improvement in validation loss
+	// after which training stops; 0 disables early stopping.
+	Patience int
+}
+
+func DefaultParameters() Parameters {
+	return Parameters{
+		HiddenLayers: []int{100},
+		Activation:   ReLU,
+		LearningRate: 0.01,
+		Momentum:     0.9,
+		BatchSize:    32,
+		MaxEpochs:    200,
+		WeightDecay:  1e-4,
+		Dropout:      0,
+		Patience:     0,
+	}
+}
+
+func (p Parameters) Validate() error {
+	for _, size := range p.HiddenLayers {
+		if size < 1 {
+			return neuralnetworkerrors.NewInvalidParameterError("HiddenLayers", p.HiddenLayers)
+		}
+	}
+
+	switch {
+	case p.Activation < ReLU || p.Activation > Sigmoid:
+		return neuralnetworkerrors.NewInvalidParameterError("Activation", p.Activation)
+	case p.LearningRate <= 0:
+		return neuralnetworkerrors.NewInvalidParameterError("LearningRate", p.LearningRate)
+	case p.Momentum < 0 || p.Momentum >= 1:
+		return neuralnetworkerrors.NewInvalidParameterError("Momentum", p.Momentum)
+	case p.BatchSize < 1:
+		return neuralnetworkerrors.NewInvalidParameterError("BatchSize", p.BatchSize)
+	case p.MaxEpochs < 1:
+		return neuralnetworkerrors.NewInvalidParameterError("MaxEpochs", p.MaxEpochs)
+	case p.WeightDecay < 0:
+		return neuralnetworkerrors.NewInvalidParameterError("WeightDecay", p.WeightDecay)
+	case p.Dropout < 0 || p.Dropout >= 1:
+		return neuralnetworkerrors.NewInvalidParameterError("Dropout", p.Dropout)
+	case p.Patience < 0:
+		return neuralnetworkerrors.NewInvalidParameterError("Patience", p.Patience)
+	}
+
+	return nil
+}
+
+func (a Activation) apply(x float64) float64 {
+	switch a {
+	case Tanh:
+		return math.Tanh(x)
+	case Sigmoid:
+		return 1 / (1 + math.Exp(-x))
+	default:
+		return math.Max(x, 0)
+	}
+}
+
+// derivative returns the derivative of the activation in terms of its output
+// y.
+func (a Activation) derivative(y float64) float64 {
+	switch a {
+	case Tanh:
+		return 1 - y*y
+	case Sigmoid:
+		return y * (1 - y)
+	default:
+		if y > 0 {
+			return 1
+		}
+		return 0
+	}
+}
@@ -0,0 +1,39 @@
+package neuralnetwork_test
+
+import (
+	"github.com/amitkgupta/goodlearn/errors/neuralnetworkerrors"
+	"github.com/amitkgupta/goodlearn/neuralnetwork"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parameters", func() {
+	It("Accepts the defaults", func() {
+		Ω(neuralnetwork.DefaultParameters().Validate()).Should(Succeed())
+	})
+
+	It("Accepts a network with no hidden layers", func() {
+		params := neuralnetwork.DefaultParameters()
+		params.HiddenLayers = nil
+		Ω(params.Validate()).Should(Succeed())
+	})
+
+	It("Rejects invalid values", func() {
+		for _, modify := range []func(*neuralnetwork.Parameters){
+			func(p *neuralnetwork.Parameters) { p.HiddenLayers = []int{4, 0} },
+			func(p *neuralnetwork.Parameters) { p.Activation = neuralnetwork.Activation(7) },
+			func(p *neuralnetwork.Parameters) { p.LearningRate = 0 },
+			func(p *neuralnetwork.Parameters) { p.Momentum = 1 },
+			func(p *neuralnetwork.Parameters) { p.BatchSize = 0 },
+			func(p *neuralnetwork.Parameters) { p.MaxEpochs = 0 },
+			func(p *neuralnetwork.Parameters) { p.WeightDecay = -1 },
+			func(p *neuralnetwork.Parameters) { p.Dropout = 1 },
+			func(p *neuralnetwork.Parameters) { p.Patience = -1 },
+		} {
+			params := neuralnetwork.DefaultParameters()
+			modify(&params)
+			Ω(params.Validate()).Should(BeAssignableToTypeOf(neuralnetworkerrors.InvalidParameterError{}))
+		}
+	})
+})
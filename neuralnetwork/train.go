@@ -0,0 +1,140 @@
+package neuralnetwork
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/errors/neuralnetworkerrors"
+	"github.com/amitkgupta/goodlearn/optimizer/stochasticgradientdescent"
+)
+
+// Train fits a network with the given output layer to the rows x and targets
+// y, which for SoftmaxOutput are one-hot encoded, by mini-batch stochastic
+// gradient descent.  If validationX is non-empty, the validation loss is
+// tracked every epoch and, with a positive Patience, used for early stopping;
+// either way the returned network has the weights from the epoch with the
+// lowest validation loss.
+func Train(
+	x, y [][]float64,
+	validationX, validationY [][]float64,
+	output Output,
+	params Parameters,
+	source rand.Source,
+) (*Network, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if output != SoftmaxOutput && output != LinearOutput {
+		return nil, neuralnetworkerrors.NewInvalidParameterError("Output", output)
+	}
+
+	if len(x) == 0 {
+		return nil, neuralnetworkerrors.NewEmptyTrainingDataError()
+	}
+
+	if len(y) != len(x) {
+		return nil, neuralnetworkerrors.NewTargetsLengthMismatchError(len(y), len(x))
+	}
+
+	if len(validationY) != len(validationX) {
+		return nil, neuralnetworkerrors.NewTargetsLengthMismatchError(len(validationY), len(validationX))
+	}
+
+	numInputs, numOutputs := len(x[0]), len(y[0])
+	for _, rows := range [][][]float64{x, validationX} {
+		for _, r := range rows {
+			if len(r) != numInputs {
+				return nil, neuralnetworkerrors.NewRowLengthMismatchError(len(r), numInputs)
+			}
+		}
+	}
+	for _, targets := range [][][]float64{y, validationY} {
+		for _, t := range targets {
+			if len(t) != numOutputs {
+				return nil, neuralnetworkerrors.NewTargetWidthMismatchError(len(t), numOutputs)
+			}
+		}
+	}
+
+	layerSizes := append(append([]int{numInputs}, params.HiddenLayers...), numOutputs)
+	network := newNetwork(layerSizes, params.Activation, output)
+
+	random := rand.New(source)
+	network.initialize(random)
+
+	decay := network.weightDecayMask(params.WeightDecay)
+	batchGradient := func(weights []float64, batch []int) ([]float64, error) {
+		gradient := make([]float64, len(weights))
+		for _, i := range batch {
+			activations, scales := network.forward(weights, x[i], params.Dropout, random)
+			network.backward(weights, activations, scales, y[i], gradient)
+		}
+
+		batchSize := float64(len(batch))
+		for i, w := range weights {
+			gradient[i] = gradient[i]/batchSize + decay[i]*w
+		}
+		return gradient, nil
+	}
+
+	var bestWeights []float64
+	bestEpoch, bestValidationLoss := 0, math.Inf(1)
+	if len(validationX) > 0 {
+		network.validationLosses = []float64{}
+	}
+
+	endOfEpoch := func(epoch int, weights []float64) bool {
+		network.trainingLosses = append(network.trainingLosses, network.meanLoss(weights, x, y))
+		if len(validationX) == 0 {
+			return false
+		}
+
+		validationLoss := network.meanLoss(weights, validationX, validationY)
+		network.validationLosses = append(network.validationLosses, validationLoss)
+
+		if validationLoss < bestValidationLoss {
+			bestEpoch, bestValidationLoss = epoch, validationLoss
+			bestWeights = append(bestWeights[:0], weights...)
+		}
+
+		return params.Patience > 0 && epoch-bestEpoch >= params.Patience
+	}
+
+	weights, err := stochasticgradientdescent.StochasticGradientDescent(
+		network.weights,
+		params.LearningRate,
+		params.Momentum,
+		len(x),
+		params.BatchSize,
+		params.MaxEpochs,
+		batchGradient,
+		endOfEpoch,
+		source,
+	)
+	if err != nil {
+		return nil, neuralnetworkerrors.NewOptimizationError(err)
+	}
+
+	network.weights = weights
+	if bestWeights != nil {
+		network.weights = bestWeights
+	}
+
+	return network, nil
+}
+
+// weightDecayMask returns weightDecay for every weight and 0 for every bias.
+func (n *Network) weightDecayMask(weightDecay float64) []float64 {
+	mask := make([]float64, len(n.weights))
+	offset := 0
+	for l := 0; l+1 < len(n.layerSizes); l++ {
+		in, out := n.layerSizes[l], n.layerSizes[l+1]
+		for i := 0; i < in*out; i++ {
+			mask[offset+i] = weightDecay
+		}
+		offset = offset + (in+1)*out
+	}
+	return mask
+}
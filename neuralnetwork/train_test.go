@@ -0,0 +1,185 @@
+package neuralnetwork_test
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/errors/neuralnetworkerrors"
+	"github.com/amitkgupta/goodlearn/neuralnetwork"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Train", func() {
+	var xorX, xorY [][]float64
+	var params neuralnetwork.Parameters
+
+	BeforeEach(func() {
+		xorX, xorY = [][]float64{}, [][]float64{}
+		for i := 0; i < 25; i++ {
+			xorX = append(xorX, []float64{0, 0}, []float64{0, 1}, []float64{1, 0}, []float64{1, 1})
+			xorY = append(xorY, []float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0})
+		}
+
+		params = neuralnetwork.DefaultParameters()
+		params.HiddenLayers = []int{8}
+		params.Activation = neuralnetwork.Tanh
+		params.LearningRate = 0.1
+		params.BatchSize = 10
+		params.MaxEpochs = 300
+	})
+
+	Context("When given invalid inputs", func() {
+		It("Returns an error for invalid parameters", func() {
+			params.LearningRate = -1
+			_, err := neuralnetwork.Train(xorX, xorY, nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.InvalidParameterError{}))
+
+			params.LearningRate = 0.1
+			_, err = neuralnetwork.Train(xorX, xorY, nil, nil, neuralnetwork.Output(5), params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.InvalidParameterError{}))
+		})
+
+		It("Returns an error for empty training data", func() {
+			_, err := neuralnetwork.Train(nil, nil, nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.EmptyTrainingDataError{}))
+		})
+
+		It("Returns an error when targets and rows do not line up", func() {
+			_, err := neuralnetwork.Train(xorX, xorY[1:], nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.TargetsLengthMismatchError{}))
+
+			_, err = neuralnetwork.Train(xorX, xorY, xorX, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.TargetsLengthMismatchError{}))
+		})
+
+		It("Returns an error for ragged rows or targets", func() {
+			_, err := neuralnetwork.Train(xorX, xorY, [][]float64{{1}}, [][]float64{{1, 0}}, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.RowLengthMismatchError{}))
+
+			_, err = neuralnetwork.Train(xorX, xorY, [][]float64{{1, 1}}, [][]float64{{1}}, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.TargetWidthMismatchError{}))
+		})
+	})
+
+	Context("With a softmax output", func() {
+		It("Learns a function that is not linearly separable", func() {
+			network, err := neuralnetwork.Train(xorX, xorY, nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(network.NumInputs()).Should(Equal(2))
+			Ω(network.NumOutputs()).Should(Equal(2))
+
+			for i := 0; i < 4; i++ {
+				outputs, err := network.Outputs(xorX[i])
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(outputs[0] + outputs[1]).Should(BeNumerically("~", 1, 1e-9))
+				Ω(outputs[1]).Should(BeNumerically("~", xorY[i][1], 0.2))
+			}
+		})
+
+		It("Learns with ReLU and sigmoid activations and two hidden layers", func() {
+			for _, activation := range []neuralnetwork.Activation{neuralnetwork.ReLU, neuralnetwork.Sigmoid} {
+				params.Activation = activation
+				params.HiddenLayers = []int{16, 8}
+				params.MaxEpochs = 1000
+
+				network, err := neuralnetwork.Train(xorX, xorY, nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(2))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				losses := network.TrainingLosses()
+				Ω(losses).Should(HaveLen(params.MaxEpochs))
+				Ω(losses[len(losses)-1]).Should(BeNumerically("<", 0.1))
+			}
+		})
+
+		It("Still learns with dropout", func() {
+			params.Dropout = 0.1
+			params.HiddenLayers = []int{32}
+
+			network, err := neuralnetwork.Train(xorX, xorY, nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for i := 0; i < 4; i++ {
+				outputs, err := network.Outputs(xorX[i])
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(outputs[1]).Should(BeNumerically("~", xorY[i][1], 0.3))
+			}
+		})
+
+		It("Fits less tightly with heavy weight decay", func() {
+			light, err := neuralnetwork.Train(xorX, xorY, nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			params.WeightDecay = 0.1
+			heavy, err := neuralnetwork.Train(xorX, xorY, nil, nil, neuralnetwork.SoftmaxOutput, params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			lightLosses, heavyLosses := light.TrainingLosses(), heavy.TrainingLosses()
+			Ω(heavyLosses[len(heavyLosses)-1]).Should(BeNumerically(">", lightLosses[len(lightLosses)-1]))
+		})
+	})
+
+	Context("With a linear output", func() {
+		var x, y [][]float64
+
+		BeforeEach(func() {
+			x, y = [][]float64{}, [][]float64{}
+			for i := 0; i <= 100; i++ {
+				v := float64(i)/50 - 1
+				x = append(x, []float64{v})
+				y = append(y, []float64{v * v})
+			}
+
+			params.HiddenLayers = []int{16}
+			params.LearningRate = 0.05
+			params.WeightDecay = 0
+		})
+
+		It("Fits a nonlinear function", func() {
+			network, err := neuralnetwork.Train(x, y, nil, nil, neuralnetwork.LinearOutput, params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for _, v := range []float64{-0.75, 0, 0.5} {
+				outputs, err := network.Outputs([]float64{v})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(outputs[0]).Should(BeNumerically("~", v*v, 0.05))
+			}
+
+			_, err = network.Outputs([]float64{0, 1})
+			Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.RowLengthMismatchError{}))
+		})
+
+		It("Stops early once the validation loss stops improving", func() {
+			random := rand.New(rand.NewSource(3))
+			noisyY := make([][]float64, len(y))
+			for i := range y {
+				noisyY[i] = []float64{y[i][0] + 0.3*random.NormFloat64()}
+			}
+
+			params.HiddenLayers = []int{64}
+			params.MaxEpochs = 2000
+			params.Patience = 20
+
+			network, err := neuralnetwork.Train(x, noisyY, x, y, neuralnetwork.LinearOutput, params, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			validationLosses := network.ValidationLosses()
+			Ω(len(validationLosses)).Should(BeNumerically("<", params.MaxEpochs))
+			Ω(network.TrainingLosses()).Should(HaveLen(len(validationLosses)))
+
+			best := math.Inf(1)
+			for _, loss := range validationLosses {
+				best = math.Min(best, loss)
+			}
+
+			total := 0.0
+			for i := range x {
+				outputs, err := network.Outputs(x[i])
+				Ω(err).ShouldNot(HaveOccurred())
+				total = total + (outputs[0]-y[i][0])*(outputs[0]-y[i][0])/2
+			}
+			Ω(total / float64(len(x))).Should(BeNumerically("~", best, 1e-9))
+		})
+	})
+})
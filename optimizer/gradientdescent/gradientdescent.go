@@ -7,34 +7,78 @@ import (
 	"github.com/amitkgupta/goodlearn/vectorutilities"
 )
 
+// Result is the outcome of a descent: the estimated minimizer, the number of
+// steps taken, and whether a step became shorter than the precision before
+// the maximum number of iterations was reached.
+type Result struct {
+	Minimizer  []float64
+	Iterations int
+	Converged  bool
+}
+
 func GradientDescent(
 	initialGuess []float64,
 	learningRate, precision float64,
 	maxIterations int,
 	gradient func([]float64) ([]float64, error),
 ) ([]float64, error) {
+	result, err := Descend(initialGuess, learningRate, precision, maxIterations, gradient)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Minimizer, nil
+}
+
+// Descend is GradientDescent, also reporting whether it converged.
+func Descend(
+	initialGuess []float64,
+	learningRate, precision float64,
+	maxIterations int,
+	gradient func([]float64) ([]float64, error),
+) (Result, error) {
+	return ProximalDescend(initialGuess, learningRate, precision, maxIterations, gradient, nil)
+}
+
+// ProximalDescend is Descend for an objective which is the sum of a smooth
+// function with the given gradient and a non-smooth one, such as an L1
+// penalty: each gradient step is passed through proximal, with the step's
+// learning rate, which must return the point minimizing the non-smooth
+// function plus the squared distance to the stepped point over twice the
+// learning rate.  A nil proximal leaves steps unchanged.
+func ProximalDescend(
+	initialGuess []float64,
+	learningRate, precision float64,
+	maxIterations int,
+	gradient func([]float64) ([]float64, error),
+	proximal func(point []float64, learningRate float64) []float64,
+) (Result, error) {
 	if len(initialGuess) == 0 {
-		return nil, errors.New("initialGuess cannot be empty")
+		return Result{}, errors.New("initialGuess cannot be empty")
 	}
 
 	oldResult := make([]float64, len(initialGuess))
 	newResult := make([]float64, len(initialGuess))
 	copy(oldResult, initialGuess)
 
-	for i := 0; i < maxIterations; i++ {
+	iterations := 0
+	for ; iterations < maxIterations; iterations++ {
 		gradientAtOldResult, err := gradient(oldResult)
 		if err != nil {
-			return nil, err
+			return Result{}, err
 		}
 
 		newResult = vectorutilities.Add(oldResult, vectorutilities.Scale(-learningRate, gradientAtOldResult))
+		if proximal != nil {
+			newResult = proximal(newResult, learningRate)
+		}
 
 		if (knnutilities.Euclidean(newResult, oldResult, precision)) < precision*precision {
-			return newResult, nil
+			return Result{newResult, iterations + 1, true}, nil
 		} else {
 			oldResult = newResult
 		}
 	}
 
-	return newResult, nil
+	return Result{newResult, iterations, false}, nil
 }
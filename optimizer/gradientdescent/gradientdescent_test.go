@@ -92,4 +92,50 @@ var _ = Describe("GradientDescent", func() {
 			Ω(estimatedArgMin[1]).Should(BeNumerically("~", -0.4, 0.05))
 		})
 	})
+
+	Describe("Descend", func() {
+		It("Reports convergence within the maximum number of iterations", func() {
+			result, err := gradientdescent.Descend([]float64{0.3, -0.4}, 0.05, 0.0005, 100000, goodGradient)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(result.Converged).Should(BeTrue())
+			Ω(result.Iterations).Should(BeNumerically("<", 100000))
+
+			estimatedArgMin, err := gradientdescent.GradientDescent([]float64{0.3, -0.4}, 0.05, 0.0005, 100000, goodGradient)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(result.Minimizer).Should(Equal(estimatedArgMin))
+		})
+
+		It("Reports hitting the maximum number of iterations", func() {
+			result, err := gradientdescent.Descend([]float64{0.3, -0.4}, 0.0005, 0.00000005, 3, goodGradient)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(result.Converged).Should(BeFalse())
+			Ω(result.Iterations).Should(Equal(3))
+		})
+	})
+
+	Context("When given a proximal operator", func() {
+		It("Minimizes the sum of the smooth and non-smooth functions", func() {
+			// minimizes x^2 + y^2 + |x| + 0.1 |y| from (0.3, -0.4): the
+			// |x| term holds x at exactly zero, while y ends near zero.
+			softThreshold := func(point []float64, learningRate float64) []float64 {
+				result := make([]float64, len(point))
+				for i, p := range point {
+					gamma := learningRate * []float64{1, 0.1}[i]
+					switch {
+					case p > gamma:
+						result[i] = p - gamma
+					case p < -gamma:
+						result[i] = p + gamma
+					}
+				}
+				return result
+			}
+
+			result, err := gradientdescent.ProximalDescend([]float64{0.3, -0.4}, 0.05, 0.0005, 100000, goodGradient, softThreshold)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(result.Converged).Should(BeTrue())
+			Ω(result.Minimizer[0]).Should(Equal(0.0))
+			Ω(result.Minimizer[1]).Should(BeNumerically("~", 0.0, 0.005))
+		})
+	})
 })
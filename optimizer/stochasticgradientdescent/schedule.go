@@ -0,0 +1,38 @@
+package stochasticgradientdescent
+
+import (
+	"errors"
+	"math"
+)
+
+// LearningRateSchedule returns the learning rate for the t-th update, counting
+// from 0, for models which step once per example.
+type LearningRateSchedule func(t int) float64
+
+// ConstantLearningRate steps by learningRate every time.
+func ConstantLearningRate(learningRate float64) (LearningRateSchedule, error) {
+	if !(learningRate > 0) {
+		return nil, errors.New("learningRate must be positive")
+	}
+
+	return func(int) float64 {
+		return learningRate
+	}, nil
+}
+
+// InverseScalingLearningRate steps by initialLearningRate / (t+1)^power, so
+// that later examples move the parameters less; a power of 0.5 to 1 lets
+// the steps' noise average out over a long stream.
+func InverseScalingLearningRate(initialLearningRate, power float64) (LearningRateSchedule, error) {
+	if !(initialLearningRate > 0) {
+		return nil, errors.New("initialLearningRate must be positive")
+	}
+
+	if !(power >= 0) {
+		return nil, errors.New("power must be non-negative")
+	}
+
+	return func(t int) float64 {
+		return initialLearningRate / math.Pow(float64(t+1), power)
+	}, nil
+}
@@ -0,0 +1,36 @@
+package stochasticgradientdescent_test
+
+import (
+	"github.com/amitkgupta/goodlearn/optimizer/stochasticgradientdescent"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LearningRateSchedule", func() {
+	It("Keeps a constant learning rate", func() {
+		schedule, err := stochasticgradientdescent.ConstantLearningRate(0.3)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(schedule(0)).Should(Equal(0.3))
+		Ω(schedule(1000)).Should(Equal(0.3))
+	})
+
+	It("Scales the learning rate inversely with the number of updates", func() {
+		schedule, err := stochasticgradientdescent.InverseScalingLearningRate(1, 0.5)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(schedule(0)).Should(Equal(1.0))
+		Ω(schedule(3)).Should(Equal(0.5))
+		Ω(schedule(99)).Should(BeNumerically("~", 0.1, 1e-12))
+	})
+
+	It("Rejects invalid rates and powers", func() {
+		_, err := stochasticgradientdescent.ConstantLearningRate(0)
+		Ω(err).Should(HaveOccurred())
+
+		_, err = stochasticgradientdescent.InverseScalingLearningRate(-1, 0.5)
+		Ω(err).Should(HaveOccurred())
+
+		_, err = stochasticgradientdescent.InverseScalingLearningRate(1, -0.5)
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -1 +1,73 @@
 package stochasticgradientdescent
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/vectorutilities"
+)
+
+// StochasticGradientDescent minimizes an objective summed over numExamples
+// examples by stepping against the gradient of one mini-batch at a time, with
+// classical momentum.  The examples are shuffled at the start of every epoch.
+// batchGradient is given the current parameters and the indices of the
+// examples in the batch.  After every epoch endOfEpoch, if not nil, is given
+// the epoch number and current parameters, and may stop descent early by
+// returning true.
+func StochasticGradientDescent(
+	initialGuess []float64,
+	learningRate, momentum float64,
+	numExamples, batchSize, maxEpochs int,
+	batchGradient func(parameters []float64, batch []int) ([]float64, error),
+	endOfEpoch func(epoch int, parameters []float64) bool,
+	source rand.Source,
+) ([]float64, error) {
+	if len(initialGuess) == 0 {
+		return nil, errors.New("initialGuess cannot be empty")
+	}
+
+	if numExamples < 1 {
+		return nil, errors.New("numExamples must be positive")
+	}
+
+	if batchSize < 1 {
+		return nil, errors.New("batchSize must be positive")
+	}
+
+	if momentum < 0 || momentum >= 1 {
+		return nil, errors.New("momentum must be in [0, 1)")
+	}
+
+	random := rand.New(source)
+	result := make([]float64, len(initialGuess))
+	copy(result, initialGuess)
+	velocity := make([]float64, len(initialGuess))
+
+	for epoch := 0; epoch < maxEpochs; epoch++ {
+		order := random.Perm(numExamples)
+
+		for start := 0; start < numExamples; start = start + batchSize {
+			end := start + batchSize
+			if end > numExamples {
+				end = numExamples
+			}
+
+			gradient, err := batchGradient(result, order[start:end])
+			if err != nil {
+				return nil, err
+			}
+
+			velocity = vectorutilities.Add(
+				vectorutilities.Scale(momentum, velocity),
+				vectorutilities.Scale(-learningRate, gradient),
+			)
+			result = vectorutilities.Add(result, velocity)
+		}
+
+		if endOfEpoch != nil && endOfEpoch(epoch, result) {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -1 +1,100 @@
 package stochasticgradientdescent_test
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/optimizer/stochasticgradientdescent"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StochasticGradientDescent", func() {
+	var examples []float64
+	var meanGradient func([]float64, []int) ([]float64, error)
+
+	BeforeEach(func() {
+		examples = []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+		// the gradient of the mean of (w - a)^2 / 2 over the batch
+		meanGradient = func(w []float64, batch []int) ([]float64, error) {
+			g := 0.0
+			for _, i := range batch {
+				g = g + w[0] - examples[i]
+			}
+			return []float64{g / float64(len(batch))}, nil
+		}
+	})
+
+	Context("When given invalid inputs", func() {
+		It("Returns an error", func() {
+			_, err := stochasticgradientdescent.StochasticGradientDescent([]float64{}, 0.1, 0, 10, 2, 10, meanGradient, nil, rand.NewSource(1))
+			Ω(err).Should(HaveOccurred())
+
+			_, err = stochasticgradientdescent.StochasticGradientDescent([]float64{0}, 0.1, 0, 0, 2, 10, meanGradient, nil, rand.NewSource(1))
+			Ω(err).Should(HaveOccurred())
+
+			_, err = stochasticgradientdescent.StochasticGradientDescent([]float64{0}, 0.1, 0, 10, 0, 10, meanGradient, nil, rand.NewSource(1))
+			Ω(err).Should(HaveOccurred())
+
+			_, err = stochasticgradientdescent.StochasticGradientDescent([]float64{0}, 0.1, 1, 10, 2, 10, meanGradient, nil, rand.NewSource(1))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("When the given gradient function returns an error", func() {
+		It("Returns an error", func() {
+			badGradient := func([]float64, []int) ([]float64, error) {
+				return nil, errors.New("I'm bad")
+			}
+
+			_, err := stochasticgradientdescent.StochasticGradientDescent([]float64{0}, 0.1, 0, 10, 2, 10, badGradient, nil, rand.NewSource(1))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("When given reasonable inputs", func() {
+		It("Visits every example once per epoch in batches of at most the given size", func() {
+			visits := make([]int, len(examples))
+			countingGradient := func(w []float64, batch []int) ([]float64, error) {
+				Ω(len(batch)).Should(BeNumerically("<=", 3))
+				for _, i := range batch {
+					visits[i]++
+				}
+				return []float64{0}, nil
+			}
+
+			_, err := stochasticgradientdescent.StochasticGradientDescent([]float64{0}, 0.1, 0, 10, 3, 4, countingGradient, nil, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			for _, v := range visits {
+				Ω(v).Should(Equal(4))
+			}
+		})
+
+		It("Approaches the minimum of the summed objective", func() {
+			result, err := stochasticgradientdescent.StochasticGradientDescent([]float64{0}, 0.05, 0.5, 10, 2, 200, meanGradient, nil, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(result[0]).Should(BeNumerically("~", 5.5, 0.5))
+		})
+
+		It("Stops early when told to at the end of an epoch", func() {
+			epochs := 0
+			stopAfterThree := func(epoch int, w []float64) bool {
+				epochs++
+				return epoch == 2
+			}
+
+			_, err := stochasticgradientdescent.StochasticGradientDescent([]float64{0}, 0.05, 0, 10, 2, 200, meanGradient, stopAfterThree, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(epochs).Should(Equal(3))
+		})
+
+		It("Does not modify the initial guess", func() {
+			initialGuess := []float64{0}
+			_, err := stochasticgradientdescent.StochasticGradientDescent(initialGuess, 0.05, 0.5, 10, 2, 10, meanGradient, nil, rand.NewSource(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(initialGuess).Should(Equal([]float64{0}))
+		})
+	})
+})
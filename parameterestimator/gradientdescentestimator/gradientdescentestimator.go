@@ -10,12 +10,20 @@ import (
 
 type ParameterizedLossGradient func([]float64, []float64, float64) ([]float64, error)
 
+// ProximalOperator takes the parameters after a gradient step of the given
+// learning rate to the proximal point of a non-smooth penalty; see
+// gradientdescent.ProximalDescend.
+type ProximalOperator func([]float64, float64) []float64
+
 type gradientDescentParameterEstimator struct {
 	learningRate  float64
 	precision     float64
 	maxIterations int
 	plgf          ParameterizedLossGradient
+	proximal      ProximalOperator
 	trainingSet   dataset.Dataset
+	weights       []float64
+	result        gradientdescent.Result
 }
 
 func NewGradientDescentParameterEstimator(
@@ -35,6 +43,24 @@ func NewGradientDescentParameterEstimator(
 	}, nil
 }
 
+// NewProximalGradientDescentParameterEstimator returns an estimator which
+// minimizes the summed loss plus a non-smooth penalty, such as an L1 penalty,
+// by proximal gradient descent with the penalty's proximal operator.
+func NewProximalGradientDescentParameterEstimator(
+	learningRate, precision float64,
+	maxIterations int,
+	plgf ParameterizedLossGradient,
+	proximal ProximalOperator,
+) (*gradientDescentParameterEstimator, error) {
+	gdpe, err := NewGradientDescentParameterEstimator(learningRate, precision, maxIterations, plgf)
+	if err != nil {
+		return nil, err
+	}
+
+	gdpe.proximal = proximal
+	return gdpe, nil
+}
+
 func (gdpe *gradientDescentParameterEstimator) Train(ds dataset.Dataset) error {
 	if !ds.AllFeaturesFloats() {
 		return gdeErrors.NewNonFloatFeaturesError()
@@ -53,6 +79,7 @@ func (gdpe *gradientDescentParameterEstimator) Train(ds dataset.Dataset) error {
 	}
 
 	gdpe.trainingSet = ds
+	gdpe.weights = dataset.Weights(ds)
 	return nil
 }
 
@@ -69,6 +96,10 @@ func (gdpe *gradientDescentParameterEstimator) Estimate(initialParameters []floa
 		sumLossGradient := make([]float64, len(initialParameters))
 
 		for i := 0; i < gdpe.trainingSet.NumRows(); i++ {
+			if gdpe.weights[i] == 0 {
+				continue
+			}
+
 			row, _ := gdpe.trainingSet.Row(i)
 			features, _ := row.Features().(slice.FloatSlice)
 			target, _ := row.Target().(slice.FloatSlice)
@@ -79,11 +110,35 @@ func (gdpe *gradientDescentParameterEstimator) Estimate(initialParameters []floa
 			if err != nil {
 				return nil, err
 			}
-			sumLossGradient = vectorutilities.Add(sumLossGradient, lossGradient)
+			sumLossGradient = vectorutilities.Add(sumLossGradient, vectorutilities.Scale(gdpe.weights[i], lossGradient))
 		}
 
 		return sumLossGradient, nil
 	}
 
-	return gradientdescent.GradientDescent(initialParameters, gdpe.learningRate, gdpe.precision, gdpe.maxIterations, gradient)
+	result, err := gradientdescent.ProximalDescend(
+		initialParameters,
+		gdpe.learningRate,
+		gdpe.precision,
+		gdpe.maxIterations,
+		gradient,
+		gdpe.proximal,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	gdpe.result = result
+	return result.Minimizer, nil
+}
+
+// Converged reports whether the last successful Estimate converged within
+// the maximum number of iterations.
+func (gdpe *gradientDescentParameterEstimator) Converged() bool {
+	return gdpe.result.Converged
+}
+
+// Iterations returns the number of steps the last successful Estimate took.
+func (gdpe *gradientDescentParameterEstimator) Iterations() int {
+	return gdpe.result.Iterations
 }
@@ -326,6 +326,94 @@ var _ = Describe("Gradient Descent Parameter Estimation", func() {
 			return result, nil
 		}
 	})
+
+	Describe("Weighted training sets", func() {
+		It("Weights each row's loss gradient, as if it appeared that many times", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"1.0", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			rows := [][]string{{"0", "1"}, {"1", "2"}, {"2", "5"}}
+			weighted := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			duplicated := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for i, r := range rows {
+				Ω(weighted.AddRowFromStrings(r)).Should(Succeed())
+				for j := 0; j <= i; j++ {
+					Ω(duplicated.AddRowFromStrings(r)).Should(Succeed())
+				}
+			}
+
+			weightedSet, err := dataset.NewWeightedDataset(weighted, []float64{1, 2, 3})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			estimate := func(ds dataset.Dataset) []float64 {
+				estimator, err := gradientdescentestimator.NewGradientDescentParameterEstimator(
+					0.01,
+					1e-8,
+					100000,
+					gradientdescentestimator.LinearModelLeastSquaresLossGradient,
+				)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(estimator.Train(ds)).Should(Succeed())
+
+				parameters, err := estimator.Estimate([]float64{0, 0})
+				Ω(err).ShouldNot(HaveOccurred())
+				return parameters
+			}
+
+			weightedParameters, duplicatedParameters := estimate(weightedSet), estimate(duplicated)
+			for i := range weightedParameters {
+				Ω(weightedParameters[i]).Should(BeNumerically("~", duplicatedParameters[i], 1e-9))
+			}
+
+			unweightedParameters := estimate(weighted)
+			Ω(unweightedParameters[0]).ShouldNot(BeNumerically("~", weightedParameters[0], 1e-3))
+		})
+	})
+
+	Describe("Converged and Iterations", func() {
+		var trainingSet dataset.Dataset
+
+		BeforeEach(func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"1.0", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingSet = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for _, values := range [][]string{{"0", "1"}, {"1", "3"}, {"2", "5"}} {
+				Ω(trainingSet.AddRowFromStrings(values)).Should(Succeed())
+			}
+		})
+
+		It("Report whether the last estimate converged, and in how many steps", func() {
+			estimator, err := gradientdescentestimator.NewGradientDescentParameterEstimator(
+				0.05,
+				1e-6,
+				100000,
+				gradientdescentestimator.LinearModelLeastSquaresLossGradient,
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(estimator.Train(trainingSet)).Should(Succeed())
+
+			_, err = estimator.Estimate([]float64{0, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(estimator.Converged()).Should(BeTrue())
+			Ω(estimator.Iterations()).Should(BeNumerically(">", 1))
+			Ω(estimator.Iterations()).Should(BeNumerically("<", 100000))
+
+			estimator, err = gradientdescentestimator.NewGradientDescentParameterEstimator(
+				0.05,
+				1e-6,
+				10,
+				gradientdescentestimator.LinearModelLeastSquaresLossGradient,
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(estimator.Train(trainingSet)).Should(Succeed())
+
+			_, err = estimator.Estimate([]float64{0, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(estimator.Converged()).Should(BeFalse())
+			Ω(estimator.Iterations()).Should(Equal(10))
+		})
+	})
 })
 
 type testError struct {
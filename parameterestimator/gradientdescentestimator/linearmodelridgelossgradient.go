@@ -0,0 +1,23 @@
+package gradientdescentestimator
+
+// LinearModelRidgeLossGradient returns the gradient of the squared error
+// plus a share of the ridge penalty alpha * |w|^2 on the non-intercept
+// parameters, laid out as for LinearModelLeastSquaresLossGradient.  Each
+// row's share is 1 / totalWeight of the penalty, so that when totalWeight is
+// the sum of the training rows' weights, the estimator's weighted sum over
+// rows is the gradient of the weighted sum of squared errors plus the full
+// penalty.
+func LinearModelRidgeLossGradient(alpha, totalWeight float64) ParameterizedLossGradient {
+	return func(parameters, observedX []float64, observedY float64) ([]float64, error) {
+		result, err := LinearModelLeastSquaresLossGradient(parameters, observedX, observedY)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, p := range parameters[:len(parameters)-1] {
+			result[i] = result[i] + 2*alpha*p/totalWeight
+		}
+
+		return result, nil
+	}
+}
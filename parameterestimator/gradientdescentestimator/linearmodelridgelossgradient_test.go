@@ -0,0 +1,57 @@
+package gradientdescentestimator_test
+
+import (
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/parameterestimator/gradientdescentestimator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Linear Model Ridge Parameter Estimation", func() {
+	Describe("LinearModelRidgeLossGradient", func() {
+		It("Returns an error for mis-shaped parameters", func() {
+			_, err := gradientdescentestimator.LinearModelRidgeLossGradient(1, 1)([]float64{1}, []float64{1, 2}, 1)
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("Adds a share of the penalty's gradient to all but the intercept", func() {
+			gradient, err := gradientdescentestimator.LinearModelRidgeLossGradient(3, 2)([]float64{1, -1, 0.5}, []float64{2, 1}, 1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// the residual is 2 - 1 + 0.5 - 1 = 0.5
+			Ω(gradient).Should(HaveLen(3))
+			Ω(gradient[0]).Should(BeNumerically("~", 2*0.5*2+2*3*1.0/2, 1e-12))
+			Ω(gradient[1]).Should(BeNumerically("~", 2*0.5*1+2*3*-1.0/2, 1e-12))
+			Ω(gradient[2]).Should(BeNumerically("~", 2*0.5, 1e-12))
+		})
+
+		It("Estimates the ridge parameters", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"1.0", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingSet := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for _, line := range [][]string{{"-1", "0"}, {"0", "1"}, {"1", "4"}} {
+				Ω(trainingSet.AddRowFromStrings(line)).Should(Succeed())
+			}
+
+			estimator, err := gradientdescentestimator.NewGradientDescentParameterEstimator(
+				0.05,
+				1e-10,
+				100000,
+				gradientdescentestimator.LinearModelRidgeLossGradient(2, 3),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(estimator.Train(trainingSet)).Should(Succeed())
+
+			// the centred features have sum of squares 2 and cross product 4
+			// with the target, so the slope is 4 / (2 + 2) and the intercept
+			// the mean target
+			parameters, err := estimator.Estimate([]float64{0, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(parameters[0]).Should(BeNumerically("~", 1, 1e-6))
+			Ω(parameters[1]).Should(BeNumerically("~", 5.0/3, 1e-6))
+		})
+	})
+})
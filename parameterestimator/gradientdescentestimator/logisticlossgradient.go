@@ -0,0 +1,93 @@
+package gradientdescentestimator
+
+import (
+	"errors"
+	"math"
+)
+
+// LogisticLossGradient is the gradient of the negative log-likelihood of a
+// binary logistic model, for an observed target of 0 or 1.  As with
+// LinearModelLeastSquaresLossGradient, the last parameter is the intercept.
+func LogisticLossGradient(parameters, observedX []float64, observedY float64) ([]float64, error) {
+	if len(parameters) != len(observedX)+1 {
+		return nil, errors.New("need exactly one more parameter than observed Xs for the constant term")
+	}
+
+	z := parameters[len(parameters)-1]
+	for i, x := range observedX {
+		z = z + parameters[i]*x
+	}
+	residual := 1/(1+math.Exp(-z)) - observedY
+
+	result := make([]float64, len(parameters))
+	result[len(parameters)-1] = residual
+	for i := range parameters[:len(parameters)-1] {
+		result[i] = observedX[i] * residual
+	}
+
+	return result, nil
+}
+
+// MultinomialLogisticLossGradient returns the gradient of the negative
+// log-likelihood of a softmax model over numClasses classes, for an observed
+// target holding the class index.  The parameters are numClasses consecutive
+// blocks, one per class, each laid out as for LogisticLossGradient.
+func MultinomialLogisticLossGradient(numClasses int) ParameterizedLossGradient {
+	return func(parameters, observedX []float64, observedY float64) ([]float64, error) {
+		blockSize := len(observedX) + 1
+		if len(parameters) != numClasses*blockSize {
+			return nil, errors.New("need one block of parameters, including the constant term, per class")
+		}
+
+		observedClass := int(observedY)
+		if observedClass < 0 || observedClass >= numClasses || float64(observedClass) != observedY {
+			return nil, errors.New("observed Y must be a class index")
+		}
+
+		probabilities := MultinomialProbabilities(parameters, observedX, numClasses)
+
+		result := make([]float64, len(parameters))
+		for k, p := range probabilities {
+			residual := p
+			if k == observedClass {
+				residual = residual - 1
+			}
+
+			offset := k * blockSize
+			for i, x := range observedX {
+				result[offset+i] = x * residual
+			}
+			result[offset+blockSize-1] = residual
+		}
+
+		return result, nil
+	}
+}
+
+// MultinomialProbabilities returns the softmax class probabilities of a
+// multinomial logistic model with the given parameters.
+func MultinomialProbabilities(parameters, x []float64, numClasses int) []float64 {
+	blockSize := len(x) + 1
+	scores := make([]float64, numClasses)
+	max := math.Inf(-1)
+
+	for k := range scores {
+		offset := k * blockSize
+		scores[k] = parameters[offset+blockSize-1]
+		for i, v := range x {
+			scores[k] = scores[k] + parameters[offset+i]*v
+		}
+		max = math.Max(max, scores[k])
+	}
+
+	sum := 0.0
+	for k := range scores {
+		scores[k] = math.Exp(scores[k] - max)
+		sum = sum + scores[k]
+	}
+	for k := range scores {
+		scores[k] = scores[k] / sum
+	}
+
+	return scores
+}
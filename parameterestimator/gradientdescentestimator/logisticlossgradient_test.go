@@ -0,0 +1,132 @@
+package gradientdescentestimator_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/parameterestimator"
+	"github.com/amitkgupta/goodlearn/parameterestimator/gradientdescentestimator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logistic Loss Parameter Estimation", func() {
+	Describe("LogisticLossGradient", func() {
+		It("Returns an error for mis-shaped parameters", func() {
+			_, err := gradientdescentestimator.LogisticLossGradient([]float64{1}, []float64{1, 2}, 1)
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("Computes the gradient of the negative log-likelihood", func() {
+			gradient, err := gradientdescentestimator.LogisticLossGradient([]float64{1, -1, 0.5}, []float64{2, 1}, 1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			residual := 1/(1+math.Exp(-1.5)) - 1
+			Ω(gradient[0]).Should(BeNumerically("~", 2*residual, 1e-12))
+			Ω(gradient[1]).Should(BeNumerically("~", residual, 1e-12))
+			Ω(gradient[2]).Should(BeNumerically("~", residual, 1e-12))
+		})
+
+		It("Estimates the true parameters of a logistic model", func() {
+			trueParameters := []float64{2, -1}
+
+			estimator, err := gradientdescentestimator.NewGradientDescentParameterEstimator(
+				0.005,
+				0.00001,
+				20000,
+				gradientdescentestimator.LogisticLossGradient,
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"1.0", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			random := rand.New(rand.NewSource(1))
+			trainingSet := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for i := 0; i < 2000; i++ {
+				x := 4*random.Float64() - 2
+				y := 0.0
+				if random.Float64() < 1/(1+math.Exp(-(trueParameters[0]*x+trueParameters[1]))) {
+					y = 1
+				}
+
+				err = trainingSet.AddRowFromStrings([]string{fmt.Sprintf("%.10f", x), fmt.Sprintf("%.0f", y)})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+			Ω(estimator.Train(trainingSet)).Should(Succeed())
+
+			estimatedParameters, err := estimator.Estimate([]float64{0, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for i := range trueParameters {
+				Ω(estimatedParameters[i]).Should(BeNumerically("~", trueParameters[i], 0.3))
+			}
+		})
+	})
+
+	Describe("MultinomialLogisticLossGradient", func() {
+		var lossGradient gradientdescentestimator.ParameterizedLossGradient
+
+		BeforeEach(func() {
+			lossGradient = gradientdescentestimator.MultinomialLogisticLossGradient(3)
+		})
+
+		It("Returns an error for mis-shaped parameters", func() {
+			_, err := lossGradient([]float64{1, 2, 3, 4}, []float64{1}, 0)
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("Returns an error when the target is not a class index", func() {
+			_, err := lossGradient(make([]float64, 6), []float64{1}, 3)
+			Ω(err).Should(HaveOccurred())
+
+			_, err = lossGradient(make([]float64, 6), []float64{1}, 0.5)
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("Computes the gradient of the negative log-likelihood", func() {
+			gradient, err := lossGradient(make([]float64, 6), []float64{2}, 1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			third := 1.0 / 3
+			expected := []float64{2 * third, third, 2 * (third - 1), third - 1, 2 * third, third}
+			for i := range expected {
+				Ω(gradient[i]).Should(BeNumerically("~", expected[i], 1e-12))
+			}
+		})
+
+		It("Can be used for estimation", func() {
+			var estimator parameterestimator.ParameterEstimator
+			estimator, err := gradientdescentestimator.NewGradientDescentParameterEstimator(0.01, 0.0001, 5000, lossGradient)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"1.0", "1.0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingSet := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for i := 0; i < 30; i++ {
+				class := i % 3
+				x := fmt.Sprintf("%.2f", float64(class)*3+0.1*float64(i%5))
+				err = trainingSet.AddRowFromStrings([]string{x, fmt.Sprintf("%d", class)})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+			Ω(estimator.Train(trainingSet)).Should(Succeed())
+
+			estimatedParameters, err := estimator.Estimate(make([]float64, 6))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for class := 0; class < 3; class++ {
+				probabilities := gradientdescentestimator.MultinomialProbabilities(
+					estimatedParameters,
+					[]float64{float64(class) * 3},
+					3,
+				)
+				Ω(probabilities[class]).Should(BeNumerically(">", 0.5))
+			}
+		})
+	})
+})
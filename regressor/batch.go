@@ -0,0 +1,34 @@
+package regressor
+
+import (
+	"context"
+
+	"github.com/amitkgupta/goodlearn/batchutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+)
+
+// PredictBatch predicts the target of every row of the test data from the
+// given number of concurrent workers, or from one worker per CPU if workers
+// is 0, so the regressor must be safe to call Predict on concurrently; with 1
+// worker any trained regressor can be used.  The predictions and per-row
+// errors are returned in the order of the rows, and a row which cannot be
+// predicted does not stop the others.  Once ctx is done no more rows are
+// predicted, those left get ctx's error, and ctx's error is also returned.
+func PredictBatch(ctx context.Context, regressor Regressor, testData dataset.Dataset, workers int) ([]float64, []error, error) {
+	predictions := make([]float64, testData.NumRows())
+
+	errs, err := batchutilities.Run(ctx, len(predictions), workers, func(i int) error {
+		testRow, err := testData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		predictions[i], err = regressor.Predict(testRow)
+		return err
+	})
+	if errs == nil {
+		return nil, nil, err
+	}
+
+	return predictions, errs, err
+}
@@ -0,0 +1,67 @@
+package regressor_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/regressor"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// squareRegressor predicts the square of the first feature, and refuses
+// negative features.
+type squareRegressor struct{}
+
+func (squareRegressor) Train(dataset.Dataset) error {
+	return nil
+}
+
+func (squareRegressor) Predict(testRow row.Row) (float64, error) {
+	x := testRow.Features().(slice.FloatSlice).Values()[0]
+	if x < 0 {
+		return 0, errors.New("cannot predict negative rows")
+	}
+	return x * x, nil
+}
+
+var _ = Describe("PredictBatch", func() {
+	var testData dataset.Dataset
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		testData = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for i := -1; i < 99; i++ {
+			Ω(testData.AddRowFromStrings([]string{strconv.Itoa(i), "0"})).Should(Succeed())
+		}
+	})
+
+	It("Predicts every row in order, returning per-row errors", func() {
+		predictions, errs, err := regressor.PredictBatch(context.Background(), squareRegressor{}, testData, 0)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(predictions).Should(HaveLen(100))
+
+		Ω(errs[0]).Should(HaveOccurred())
+		for i := 1; i < 100; i++ {
+			Ω(errs[i]).ShouldNot(HaveOccurred())
+			Ω(predictions[i]).Should(Equal(float64((i - 1) * (i - 1))))
+		}
+	})
+
+	It("Returns the context's error when cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, errs, err := regressor.PredictBatch(ctx, squareRegressor{}, testData, 3)
+		Ω(err).Should(Equal(context.Canceled))
+		Ω(errs[99]).Should(Equal(context.Canceled))
+	})
+})
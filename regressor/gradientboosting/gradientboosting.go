@@ -0,0 +1,141 @@
+package gradientboosting
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/decider/gbdt"
+	"github.com/amitkgupta/goodlearn/errors/regressor/gradientboostingerrors"
+)
+
+// NewGradientBoostingRegressor boosts regression trees to minimise the given
+// loss (gbdt.SquaredLoss, gbdt.AbsoluteLoss, gbdt.HuberLoss or
+// gbdt.QuantileLoss).  If validationData is not nil its loss is tracked every
+// round, and used for early stopping when params.Patience is positive.  Each
+// row's loss is weighted by its weight if the training or validation data is
+// a dataset.WeightedDataset.
+func NewGradientBoostingRegressor(
+	loss gbdt.Loss,
+	params gbdt.Parameters,
+	validationData dataset.Dataset,
+	source rand.Source,
+) (*gradientBoostingRegressor, error) {
+	if loss.NumScores() != 1 {
+		return nil, gradientboostingerrors.NewMultipleScoreLossError(loss.NumScores())
+	}
+
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &gradientBoostingRegressor{
+		loss:           loss,
+		params:         params,
+		validationData: validationData,
+		source:         source,
+	}, nil
+}
+
+type gradientBoostingRegressor struct {
+	loss           gbdt.Loss
+	params         gbdt.Parameters
+	validationData dataset.Dataset
+	source         rand.Source
+	model          *gbdt.Model
+}
+
+// Model returns the trained tree ensemble, which reports the per-round
+// training and validation losses.
+func (regressor *gradientBoostingRegressor) Model() *gbdt.Model {
+	return regressor.model
+}
+
+func (regressor *gradientBoostingRegressor) Train(trainingData dataset.Dataset) error {
+	x, y, err := featuresAndTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	var validationX [][]float64
+	var validationY, validationWeights []float64
+	if regressor.validationData != nil {
+		validationX, validationY, err = featuresAndTargets(regressor.validationData)
+		if err != nil {
+			return err
+		}
+		validationWeights = dataset.Weights(regressor.validationData)
+	}
+
+	model, err := gbdt.TrainWeighted(
+		x,
+		y,
+		dataset.Weights(trainingData),
+		trainingData.NumFeatures(),
+		validationX,
+		validationY,
+		validationWeights,
+		regressor.loss,
+		regressor.params,
+		regressor.source,
+	)
+	if err != nil {
+		return gradientboostingerrors.NewBoostingError(err)
+	}
+
+	regressor.model = model
+	return nil
+}
+
+func (regressor *gradientBoostingRegressor) Predict(testRow row.Row) (float64, error) {
+	model := regressor.model
+	if model == nil {
+		return 0, gradientboostingerrors.NewUntrainedRegressorError()
+	}
+
+	if testRow.NumFeatures() != model.NumFeatures() {
+		return 0, gradientboostingerrors.NewRowLengthMismatchError(testRow.NumFeatures(), model.NumFeatures())
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return 0, gradientboostingerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	scores, err := model.Scores(testFeatures.Values())
+	if err != nil {
+		return 0, err
+	}
+
+	return scores[0], nil
+}
+
+func featuresAndTargets(ds dataset.Dataset) ([][]float64, []float64, error) {
+	if !ds.AllFeaturesFloats() {
+		return nil, nil, gradientboostingerrors.NewNonFloatFeaturesError()
+	}
+
+	if !ds.AllTargetsFloats() {
+		return nil, nil, gradientboostingerrors.NewNonFloatTargetsError()
+	}
+
+	if ds.NumTargets() != 1 {
+		return nil, nil, gradientboostingerrors.NewInvalidNumberOfTargetsError(ds.NumTargets())
+	}
+
+	x := make([][]float64, ds.NumRows())
+	y := make([]float64, ds.NumRows())
+	for i := range x {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		x[i] = r.Features().(slice.FloatSlice).Values()
+		y[i] = r.Target().(slice.FloatSlice).Values()[0]
+	}
+
+	return x, y, nil
+}
@@ -0,0 +1,13 @@
+package gradientboosting_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGradientboosting(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gradientboosting Suite")
+}
@@ -0,0 +1,176 @@
+package gradientboosting_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/decider/gbdt"
+	"github.com/amitkgupta/goodlearn/errors/regressor/gradientboostingerrors"
+	"github.com/amitkgupta/goodlearn/regressor"
+	"github.com/amitkgupta/goodlearn/regressor/gradientboosting"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GradientBoostingRegressor", func() {
+	var gbr regressor.Regressor
+
+	newDataset := func(f func(x float64) float64) dataset.Dataset {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for i := 0; i < 50; i++ {
+			x := float64(i) / 5
+			err = ds.AddRowFromStrings([]string{fmt.Sprintf("%.4f", x), fmt.Sprintf("%.4f", f(x))})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		return ds
+	}
+
+	square := func(x float64) float64 { return x * x }
+
+	Describe("NewGradientBoostingRegressor", func() {
+		It("Rejects losses with several scores", func() {
+			_, err := gradientboosting.NewGradientBoostingRegressor(
+				gbdt.MultinomialDeviance(3),
+				gbdt.DefaultParameters(),
+				nil,
+				rand.NewSource(1),
+			)
+			Ω(err).Should(BeAssignableToTypeOf(gradientboostingerrors.MultipleScoreLossError{}))
+		})
+
+		It("Rejects invalid parameters", func() {
+			params := gbdt.DefaultParameters()
+			params.LearningRate = -1
+
+			_, err := gradientboosting.NewGradientBoostingRegressor(gbdt.SquaredLoss(), params, nil, rand.NewSource(1))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Train", func() {
+		BeforeEach(func() {
+			var err error
+			gbr, err = gradientboosting.NewGradientBoostingRegressor(
+				gbdt.SquaredLoss(),
+				gbdt.DefaultParameters(),
+				nil,
+				rand.NewSource(1),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("Rejects non-float targets", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = gbr.Train(dataset.NewDataset([]int{0}, []int{1}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(gradientboostingerrors.NonFloatTargetsTrainingSetError{}))
+		})
+
+		It("Rejects multiple targets", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = gbr.Train(dataset.NewDataset([]int{0}, []int{1, 2}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(gradientboostingerrors.InvalidNumberOfTargetsError{}))
+		})
+
+		It("Wraps errors from boosting", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = gbr.Train(dataset.NewDataset([]int{0}, []int{1}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(gradientboostingerrors.BoostingError{}))
+		})
+	})
+
+	Describe("Predict", func() {
+		BeforeEach(func() {
+			var err error
+			gbr, err = gradientboosting.NewGradientBoostingRegressor(
+				gbdt.SquaredLoss(),
+				gbdt.DefaultParameters(),
+				nil,
+				rand.NewSource(1),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("Returns an error before training", func() {
+			_, err := gbr.Predict(row.NewRow(slice.NewFloatSlice([]float64{1}), nil, 1))
+			Ω(err).Should(BeAssignableToTypeOf(gradientboostingerrors.UntrainedRegressorError{}))
+		})
+
+		It("Returns an error for rows of the wrong length", func() {
+			Ω(gbr.Train(newDataset(square))).Should(Succeed())
+
+			_, err := gbr.Predict(row.NewRow(slice.NewFloatSlice([]float64{1, 2}), nil, 2))
+			Ω(err).Should(BeAssignableToTypeOf(gradientboostingerrors.RowLengthMismatchError{}))
+		})
+
+		It("Fits a nonlinear function", func() {
+			Ω(gbr.Train(newDataset(square))).Should(Succeed())
+
+			for _, x := range []float64{1, 4.5, 8} {
+				prediction, err := gbr.Predict(row.NewRow(slice.NewFloatSlice([]float64{x}), nil, 1))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(prediction).Should(BeNumerically("~", x*x, 2))
+			}
+		})
+
+		It("Weights each row's loss by its weight", func() {
+			// every other row is corrupted, but has no weight
+			corrupted := newDataset(func(x float64) float64 {
+				if int(x*5+0.5)%2 == 1 {
+					return 100
+				}
+				return x * x
+			})
+
+			weights := make([]float64, corrupted.NumRows())
+			for i := range weights {
+				weights[i] = float64(1 - i%2)
+			}
+			weighted, err := dataset.NewWeightedDataset(corrupted, weights)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(gbr.Train(weighted)).Should(Succeed())
+
+			for _, x := range []float64{2, 4.4, 8} {
+				prediction, err := gbr.Predict(row.NewRow(slice.NewFloatSlice([]float64{x}), nil, 1))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(prediction).Should(BeNumerically("~", x*x, 2))
+			}
+		})
+
+		It("Stops early against validation data", func() {
+			params := gbdt.DefaultParameters()
+			params.NumRounds = 1000
+			params.Patience = 5
+
+			loss, err := gbdt.HuberLoss(0.9)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			gbr, err = gradientboosting.NewGradientBoostingRegressor(
+				loss,
+				params,
+				newDataset(square),
+				rand.NewSource(1),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(gbr.Train(newDataset(func(x float64) float64 { return x*x + 3*rand.NormFloat64() }))).Should(Succeed())
+
+			model := gbr.(interface{ Model() *gbdt.Model }).Model()
+			Ω(model.NumRounds()).Should(BeNumerically("<", 1000))
+		})
+	})
+})
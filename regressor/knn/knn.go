@@ -1 +1,267 @@
 package knn
+
+import (
+	"math"
+	"sort"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/knnerrors"
+)
+
+// Aggregation is how the targets of the nearest neighbours are combined into
+// a prediction.
+type Aggregation int
+
+const (
+	Mean Aggregation = iota
+	Median
+	// WeightedMean weights each neighbour's target by its distance, with
+	// knnutilities.InverseDistanceWeights unless set by Weighting.
+	WeightedMean
+)
+
+type Option func(*kNNRegressor)
+
+// Metric sets the distance between rows; knnutilities.EuclideanDistance by
+// default.
+func Metric(distance knnutilities.Distance) Option {
+	return func(regressor *kNNRegressor) {
+		regressor.distance = distance
+	}
+}
+
+// SearchIndex sets how the nearest training rows are found, as for the kNN
+// classifier; by default each query is compared against every training row.
+func SearchIndex(builder knnutilities.IndexBuilder) Option {
+	return func(regressor *kNNRegressor) {
+		regressor.indexBuilder = builder
+	}
+}
+
+// Aggregating sets how the neighbours' targets are combined; Mean by default.
+func Aggregating(aggregation Aggregation) Option {
+	return func(regressor *kNNRegressor) {
+		regressor.aggregation = aggregation
+	}
+}
+
+// Weighting sets how each neighbour is weighted by its distance with the
+// WeightedMean aggregation.
+func Weighting(weight knnutilities.WeightFunction) Option {
+	return func(regressor *kNNRegressor) {
+		regressor.weight = weight
+	}
+}
+
+func NewKNNRegressor(k int, options ...Option) (*kNNRegressor, error) {
+	if k < 1 {
+		return nil, knnerrors.NewInvalidNumberOfNeighboursError(k)
+	}
+
+	regressor := &kNNRegressor{
+		k:           k,
+		aggregation: Mean,
+		weight:      knnutilities.InverseDistanceWeights(),
+	}
+
+	for _, option := range options {
+		option(regressor)
+	}
+
+	if regressor.weight == nil {
+		regressor.weight = knnutilities.InverseDistanceWeights()
+	}
+
+	if regressor.aggregation != Mean && regressor.aggregation != Median && regressor.aggregation != WeightedMean {
+		return nil, knnerrors.NewInvalidAggregationError(int(regressor.aggregation))
+	}
+
+	return regressor, nil
+}
+
+type kNNRegressor struct {
+	k            int
+	distance     knnutilities.Distance
+	indexBuilder knnutilities.IndexBuilder
+	search       *knnutilities.NeighbourSearch
+	aggregation  Aggregation
+	weight       knnutilities.WeightFunction
+	numFeatures  int
+	rowWeights   []float64
+}
+
+func (regressor *kNNRegressor) Train(trainingData dataset.Dataset) error {
+	search := knnutilities.NewNeighbourSearch(regressor.k, regressor.distance, regressor.indexBuilder)
+
+	if search.FloatsOnly() && !trainingData.AllFeaturesFloats() {
+		return knnerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if !trainingData.AllTargetsFloats() {
+		return knnerrors.NewNonFloatTargetsTrainingSetError()
+	}
+
+	if trainingData.NumTargets() != 1 {
+		return knnerrors.NewInvalidNumberOfTargetsError(trainingData.NumTargets())
+	}
+
+	if trainingData.NumRows() == 0 {
+		return knnerrors.NewEmptyTrainingDatasetError()
+	}
+
+	err := search.Train(trainingData)
+	if err != nil {
+		return err
+	}
+
+	regressor.search = search
+	regressor.numFeatures = trainingData.NumFeatures()
+	regressor.rowWeights = dataset.Weights(trainingData)
+	return nil
+}
+
+// PartialFit appends the batch's rows to the training data, trains the
+// regressor on the batch if it hasn't been trained, and otherwise indexes
+// them without refitting the distance.  It must not be called concurrently
+// with prediction.
+func (regressor *kNNRegressor) PartialFit(batch dataset.Dataset) error {
+	if regressor.search == nil {
+		return regressor.Train(batch)
+	}
+
+	if batch.NumRows() == 0 {
+		return nil
+	}
+
+	if regressor.search.FloatsOnly() && !batch.AllFeaturesFloats() {
+		return knnerrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if !batch.AllTargetsFloats() {
+		return knnerrors.NewNonFloatTargetsTrainingSetError()
+	}
+
+	if batch.NumTargets() != 1 {
+		return knnerrors.NewInvalidNumberOfTargetsError(batch.NumTargets())
+	}
+
+	if batch.NumFeatures() != regressor.numFeatures {
+		return knnerrors.NewBatchLengthMismatchError(batch.NumFeatures(), regressor.numFeatures)
+	}
+
+	err := regressor.search.Add(batch)
+	if err != nil {
+		return err
+	}
+
+	regressor.rowWeights = append(regressor.rowWeights, dataset.Weights(batch)...)
+	return nil
+}
+
+func (regressor *kNNRegressor) Predict(testRow row.Row) (float64, error) {
+	prediction, _, err := regressor.PredictWithSpread(testRow)
+	return prediction, err
+}
+
+// PredictWithSpread also returns the standard deviation of the neighbours'
+// targets, weighted as for the prediction with WeightedMean, as a crude
+// measure of the prediction's uncertainty.
+func (regressor *kNNRegressor) PredictWithSpread(testRow row.Row) (float64, float64, error) {
+	neighbours, err := regressor.Neighbours(testRow)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	targets := make([]float64, len(neighbours))
+	for i, n := range neighbours {
+		targets[i] = n.Target.(slice.FloatSlice).Values()[0]
+	}
+
+	weights := make([]float64, len(neighbours))
+	if regressor.aggregation == WeightedMean {
+		weights = knnutilities.Weights(neighbours, regressor.weight)
+	} else {
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	weights = knnutilities.SampleWeighted(neighbours, weights, regressor.rowWeights)
+
+	mean := weightedMean(targets, weights)
+
+	squaredDeviations := make([]float64, len(targets))
+	for i, t := range targets {
+		squaredDeviations[i] = (t - mean) * (t - mean)
+	}
+	spread := math.Sqrt(weightedMean(squaredDeviations, weights))
+
+	if regressor.aggregation == Median {
+		return weightedMedian(targets, weights), spread, nil
+	}
+	return mean, spread, nil
+}
+
+// Neighbours returns the k nearest training rows, nearest first, with their
+// indices in the training data and distances from the test row.  The rows of
+// batches given to PartialFit follow those of the training data, in order.
+// Rows at equal distances are ordered by index.
+func (regressor *kNNRegressor) Neighbours(testRow row.Row) ([]knnutilities.Neighbour, error) {
+	if regressor.search == nil {
+		return nil, knnerrors.NewUntrainedRegressorError()
+	}
+
+	numTestRowFeatures := testRow.NumFeatures()
+	if numTestRowFeatures != regressor.numFeatures {
+		return nil, knnerrors.NewRowLengthMismatchError(numTestRowFeatures, regressor.numFeatures)
+	}
+
+	testFeatures := testRow.Features()
+	if _, ok := testFeatures.(slice.FloatSlice); regressor.search.FloatsOnly() && !ok {
+		return nil, knnerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return regressor.search.Nearest(testFeatures), nil
+}
+
+func weightedMean(values, weights []float64) float64 {
+	sum, total := 0.0, 0.0
+	for i, v := range values {
+		sum = sum + weights[i]*v
+		total = total + weights[i]
+	}
+	return sum / total
+}
+
+// weightedMedian returns the smallest value at which the cumulative weight
+// reaches half the total, averaged with the next value if it is exactly half.
+func weightedMedian(values, weights []float64) float64 {
+	order := make([]int, len(values))
+	total := 0.0
+	for i := range order {
+		order[i] = i
+		total = total + weights[i]
+	}
+	sort.SliceStable(order, func(a, b int) bool { return values[order[a]] < values[order[b]] })
+
+	cumulative := 0.0
+	for p, i := range order {
+		cumulative = cumulative + weights[i]
+		if cumulative < total/2 {
+			continue
+		}
+
+		if cumulative == total/2 {
+			for _, j := range order[p+1:] {
+				if weights[j] > 0 {
+					return (values[i] + values[j]) / 2
+				}
+			}
+		}
+		return values[i]
+	}
+
+	return values[order[len(order)-1]]
+}
@@ -1 +1,216 @@
 package knn_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/knn/knnutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/knnerrors"
+	"github.com/amitkgupta/goodlearn/regressor"
+	"github.com/amitkgupta/goodlearn/regressor/knn"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KNNRegressor", func() {
+	var trainingData dataset.Dataset
+
+	testRowAt := func(x float64) row.Row {
+		return row.NewRow(slice.NewFloatSlice([]float64{x}), nil, 1)
+	}
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		trainingData = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for _, r := range [][]string{{"0", "1"}, {"1", "2"}, {"2", "9"}, {"10", "100"}} {
+			Ω(trainingData.AddRowFromStrings(r)).Should(Succeed())
+		}
+	})
+
+	Describe("NewKNNRegressor", func() {
+		It("Rejects a non-positive k", func() {
+			_, err := knn.NewKNNRegressor(0)
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.InvalidNumberOfNeighboursError{}))
+		})
+
+		It("Rejects an unknown aggregation", func() {
+			_, err := knn.NewKNNRegressor(2, knn.Aggregating(knn.Aggregation(7)))
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.InvalidAggregationError{}))
+		})
+
+		It("Is a Regressor", func() {
+			var r regressor.Regressor
+			r, err := knn.NewKNNRegressor(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r).ShouldNot(BeNil())
+		})
+	})
+
+	Describe("Train", func() {
+		It("Rejects an empty dataset", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, err := knn.NewKNNRegressor(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = r.Train(dataset.NewDataset([]int{0}, []int{1}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.EmptyTrainingDatasetError{}))
+		})
+
+		It("Rejects non-float targets and features", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "x"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, err := knn.NewKNNRegressor(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = r.Train(dataset.NewDataset([]int{0}, []int{1}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.NonFloatTargetsTrainingSetError{}))
+
+			err = r.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.NonFloatFeaturesTrainingSetError{}))
+		})
+
+		It("Rejects multiple targets", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, err := knn.NewKNNRegressor(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = r.Train(dataset.NewDataset([]int{0}, []int{1, 2}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.InvalidNumberOfTargetsError{}))
+		})
+	})
+
+	Describe("Predict", func() {
+		It("Refuses to predict before training", func() {
+			r, err := knn.NewKNNRegressor(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = r.Predict(testRowAt(0))
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.UntrainedRegressorError{}))
+		})
+
+		It("Refuses rows of the wrong length", func() {
+			r, err := knn.NewKNNRegressor(1)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			_, err = r.Predict(row.NewRow(slice.NewFloatSlice([]float64{0, 1}), nil, 2))
+			Ω(err).Should(BeAssignableToTypeOf(knnerrors.RowLengthMismatchError{}))
+		})
+
+		It("Predicts the mean of the nearest targets by default, with their spread", func() {
+			r, err := knn.NewKNNRegressor(3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			prediction, spread, err := r.PredictWithSpread(testRowAt(0.2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 4, 1e-12))
+			Ω(spread).Should(BeNumerically("~", math.Sqrt((9+4+25)/3.0), 1e-12))
+		})
+
+		It("Predicts the median of the nearest targets", func() {
+			r, err := knn.NewKNNRegressor(3, knn.Aggregating(knn.Median))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			prediction, err := r.Predict(testRowAt(0.2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(Equal(2.0))
+
+			r, err = knn.NewKNNRegressor(4, knn.Aggregating(knn.Median))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			prediction, err = r.Predict(testRowAt(0.2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(Equal(5.5))
+		})
+
+		It("Predicts the distance-weighted mean of the nearest targets", func() {
+			r, err := knn.NewKNNRegressor(2, knn.Aggregating(knn.WeightedMean))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			prediction, err := r.Predict(testRowAt(0.25))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", (4*1+(4.0/3)*2)/(4+4.0/3), 1e-12))
+
+			prediction, spread, err := r.PredictWithSpread(testRowAt(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(Equal(2.0))
+			Ω(spread).Should(Equal(0.0))
+		})
+
+		It("Weights the nearest targets by the weights of a weighted dataset's rows", func() {
+			weighted, err := dataset.NewWeightedDataset(trainingData, []float64{1, 1, 2, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, err := knn.NewKNNRegressor(3)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(weighted)).Should(Succeed())
+
+			prediction, err := r.Predict(testRowAt(0.2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 5.25, 1e-12))
+
+			r, err = knn.NewKNNRegressor(3, knn.Aggregating(knn.Median))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(weighted)).Should(Succeed())
+
+			prediction, err = r.Predict(testRowAt(0.2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(Equal(5.5))
+
+			weighted, err = dataset.NewWeightedDataset(trainingData, []float64{3, 1, 1, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(weighted)).Should(Succeed())
+
+			prediction, err = r.Predict(testRowAt(0.2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(Equal(1.0))
+		})
+
+		It("Trains on the first batch and appends later ones", func() {
+			r, err := knn.NewKNNRegressor(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var online regressor.OnlineRegressor = r
+			Ω(online.PartialFit(dataset.NewSubset(trainingData, []int{0, 1}))).Should(Succeed())
+
+			prediction, err := r.Predict(testRowAt(9))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(Equal(2.0))
+
+			Ω(online.PartialFit(dataset.NewSubset(trainingData, []int{2, 3}))).Should(Succeed())
+
+			prediction, err = r.Predict(testRowAt(9))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(Equal(100.0))
+		})
+
+		It("Shares the classifier's metrics and indexes", func() {
+			kdTree, err := knnutilities.KDTreeIndex(1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, err := knn.NewKNNRegressor(1, knn.Metric(knnutilities.ChebyshevDistance()), knn.SearchIndex(kdTree))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			neighbours, err := r.Neighbours(testRowAt(7))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(neighbours[0].Index).Should(Equal(3))
+			Ω(neighbours[0].Distance).Should(Equal(3.0))
+		})
+	})
+})
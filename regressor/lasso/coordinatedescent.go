@@ -0,0 +1,163 @@
+package lasso
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+)
+
+// problem is the elastic net objective on a centred design X, y of total
+// weight W,
+//
+//	|y - X b|^2 / 2W + lambda (l1Ratio |b|_1 + (1 - l1Ratio) |b|^2 / 2),
+//
+// laid out for coordinate descent.
+type problem struct {
+	design  regressorutilities.Design
+	columns [][]float64
+	// squares is |X_j|^2 / W for each feature j.
+	squares []float64
+	l1Ratio float64
+	// threshold is how little a sweep must change the fitted values, in
+	// root mean square, for the descent to have converged.
+	threshold float64
+}
+
+func newProblem(d regressorutilities.Design, l1Ratio, tolerance float64) problem {
+	columns := matrixutilities.Transpose(d.X)
+	if len(d.X) == 0 {
+		columns = matrixutilities.Zeros(len(d.FeatureMeans), 0)
+	}
+
+	squares := make([]float64, len(columns))
+	for j, column := range columns {
+		squares[j] = dot(column, column) / d.TotalWeight
+	}
+
+	targetSquares := dot(d.Y, d.Y) / d.TotalWeight
+
+	return problem{d, columns, squares, l1Ratio, tolerance * math.Sqrt(targetSquares)}
+}
+
+// lambdaMax is the smallest lambda at which every coefficient is zero.
+func (p problem) lambdaMax() float64 {
+	largest := 0.0
+	for _, column := range p.columns {
+		largest = math.Max(largest, math.Abs(dot(column, p.design.Y)))
+	}
+	return largest / (p.design.TotalWeight * p.l1Ratio)
+}
+
+// descend minimizes the objective for lambda by cyclic coordinate descent
+// from the given coefficients, which it updates in place, and returns the
+// number of sweeps over the coefficients and whether it converged within
+// maxIterations of them.  After each sweep over all the coefficients it
+// sweeps only over the nonzero ones until they settle, as most coefficients
+// of a sparse fit stay at zero.
+func (p problem) descend(coefficients []float64, lambda float64, maxIterations int) (int, bool) {
+	residuals := append([]float64{}, p.design.Y...)
+	for j, c := range coefficients {
+		if c != 0 {
+			axpy(-c, p.columns[j], residuals)
+		}
+	}
+
+	all := make([]int, len(coefficients))
+	for j := range all {
+		all[j] = j
+	}
+
+	iterations := 0
+	for iterations < maxIterations {
+		change := p.sweep(coefficients, residuals, lambda, all)
+		iterations++
+		if change <= p.threshold {
+			return iterations, true
+		}
+
+		active := []int{}
+		for j, c := range coefficients {
+			if c != 0 {
+				active = append(active, j)
+			}
+		}
+
+		for iterations < maxIterations {
+			change = p.sweep(coefficients, residuals, lambda, active)
+			iterations++
+			if change <= p.threshold {
+				break
+			}
+		}
+	}
+
+	return iterations, false
+}
+
+// sweep updates each of the given coefficients in turn to minimize the
+// objective with the others held fixed, keeping the residuals y - X b up to
+// date, and returns the largest change in the fitted values, in root mean
+// square, that any update made.
+func (p problem) sweep(coefficients, residuals []float64, lambda float64, features []int) float64 {
+	largestChange := 0.0
+
+	for _, j := range features {
+		if p.squares[j] == 0 {
+			continue
+		}
+
+		old := coefficients[j]
+		rho := dot(p.columns[j], residuals)/p.design.TotalWeight + p.squares[j]*old
+		updated := softThreshold(rho, lambda*p.l1Ratio) / (p.squares[j] + lambda*(1-p.l1Ratio))
+
+		if updated != old {
+			axpy(old-updated, p.columns[j], residuals)
+			coefficients[j] = updated
+			largestChange = math.Max(largestChange, math.Sqrt(p.squares[j])*math.Abs(updated-old))
+		}
+	}
+
+	return largestChange
+}
+
+// softThreshold shrinks x towards zero by gamma, to exactly zero if |x| is
+// at most gamma.
+func softThreshold(x, gamma float64) float64 {
+	if x > gamma {
+		return x - gamma
+	}
+	if x < -gamma {
+		return x + gamma
+	}
+	return 0
+}
+
+// lambdaGrid returns numLambdas lambdas evenly spaced on a log scale from
+// largest down to minRatio times it.
+func lambdaGrid(largest float64, numLambdas int, minRatio float64) []float64 {
+	lambdas := make([]float64, numLambdas)
+	for k := range lambdas {
+		if numLambdas == 1 {
+			lambdas[k] = largest
+			continue
+		}
+		lambdas[k] = largest * math.Pow(minRatio, float64(k)/float64(numLambdas-1))
+	}
+	return lambdas
+}
+
+func dot(x, y []float64) float64 {
+	result := 0.0
+	for i, v := range x {
+		result = result + v*y[i]
+	}
+	return result
+}
+
+// axpy adds a times x to y.
+func axpy(a float64, x, y []float64) {
+	for i, v := range x {
+		y[i] = y[i] + a*v
+	}
+}
@@ -1 +1,364 @@
 package lasso
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/lassoerrors"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+)
+
+const (
+	defaultLambda        = 1.0
+	defaultTolerance     = 1e-6
+	defaultMaxIterations = 1000
+	defaultNumFolds      = 5
+)
+
+type Option func(*lassoRegressor)
+
+// Lambda sets the weight of the penalty; 1 by default.  It is overridden by
+// LambdaPath or AutomaticLambdaPath.
+func Lambda(lambda float64) Option {
+	return func(regressor *lassoRegressor) {
+		regressor.lambda = lambda
+	}
+}
+
+// LambdaPath has Train fit every given lambda, from the largest down, each
+// starting from the coefficients of the last, and keep the fit whose lambda
+// has the least cross-validated error.  Path returns all the fits.
+func LambdaPath(lambdas ...float64) Option {
+	return func(regressor *lassoRegressor) {
+		regressor.lambdaPath = append([]float64{}, lambdas...)
+		regressor.automaticLambdaPath = false
+	}
+}
+
+// AutomaticLambdaPath is LambdaPath with numLambdas lambdas evenly spaced on
+// a log scale from the smallest lambda at which every coefficient is zero
+// down to minRatio times it.
+func AutomaticLambdaPath(numLambdas int, minRatio float64) Option {
+	return func(regressor *lassoRegressor) {
+		regressor.automaticLambdaPath = true
+		regressor.numLambdas = numLambdas
+		regressor.minLambdaRatio = minRatio
+		regressor.lambdaPath = nil
+	}
+}
+
+// CrossValidated sets the number of random folds over which a lambda path's
+// fits are cross-validated; 5 by default.
+func CrossValidated(numFolds int) Option {
+	return func(regressor *lassoRegressor) {
+		regressor.numFolds = numFolds
+	}
+}
+
+// OneStandardErrorRule has Train keep the fit of the largest lambda whose
+// cross-validated error is within one standard error of the least, rather
+// than the fit of least error: a sparser fit whose error is not detectably
+// worse.
+func OneStandardErrorRule() Option {
+	return func(regressor *lassoRegressor) {
+		regressor.oneStandardErrorRule = true
+	}
+}
+
+// Tolerance sets how little a sweep of coordinate descent must change the
+// fitted values, in root mean square and relative to the targets' standard
+// deviation, for it to have converged; 1e-6 by default.
+func Tolerance(tolerance float64) Option {
+	return func(regressor *lassoRegressor) {
+		regressor.tolerance = tolerance
+	}
+}
+
+// MaxIterations caps the number of sweeps of coordinate descent for each
+// lambda; 1000 by default.
+func MaxIterations(maxIterations int) Option {
+	return func(regressor *lassoRegressor) {
+		regressor.maxIterations = maxIterations
+	}
+}
+
+// RandomSource sets the source used to split the training data into folds.
+func RandomSource(source rand.Source) Option {
+	return func(regressor *lassoRegressor) {
+		regressor.source = source
+	}
+}
+
+// NewLassoRegressor returns a least squares regressor with an intercept
+// which minimizes half the mean squared error plus lambda times the sum of
+// the absolute values of the coefficients, by cyclic coordinate descent,
+// setting the coefficients of the least useful features to exactly zero.
+// The intercept is not penalized, and each row's squared error is weighted
+// by its weight if the training data is a dataset.WeightedDataset.
+// Features are not standardized, so the penalty falls hardest on features of
+// small scale.
+func NewLassoRegressor(options ...Option) (*lassoRegressor, error) {
+	return NewElasticNetRegressor(1, options...)
+}
+
+// NewElasticNetRegressor returns a regressor like NewLassoRegressor's whose
+// penalty is lambda times a mix of the sum of the absolute values of the
+// coefficients, in proportion l1Ratio, and half the sum of their squares,
+// in proportion 1 - l1Ratio, which shares the effect of correlated features
+// among them rather than picking one.  l1Ratio must be in (0, 1]; for no
+// absolute penalty, use a ridge regressor.
+func NewElasticNetRegressor(l1Ratio float64, options ...Option) (*lassoRegressor, error) {
+	regressor := &lassoRegressor{
+		l1Ratio:       l1Ratio,
+		lambda:        defaultLambda,
+		numFolds:      defaultNumFolds,
+		tolerance:     defaultTolerance,
+		maxIterations: defaultMaxIterations,
+	}
+
+	for _, option := range options {
+		option(regressor)
+	}
+
+	if !(regressor.l1Ratio > 0 && regressor.l1Ratio <= 1) {
+		return nil, lassoerrors.NewInvalidHyperparameterError("L1 ratio", regressor.l1Ratio)
+	}
+
+	if !(regressor.lambda >= 0) {
+		return nil, lassoerrors.NewInvalidHyperparameterError("lambda", regressor.lambda)
+	}
+
+	if regressor.lambdaPath != nil && len(regressor.lambdaPath) == 0 {
+		return nil, lassoerrors.NewEmptyLambdaPathError()
+	}
+
+	for _, lambda := range regressor.lambdaPath {
+		if !(lambda >= 0) {
+			return nil, lassoerrors.NewInvalidHyperparameterError("lambda", lambda)
+		}
+	}
+
+	if regressor.automaticLambdaPath {
+		if regressor.numLambdas < 1 {
+			return nil, lassoerrors.NewInvalidHyperparameterError("number of lambdas", float64(regressor.numLambdas))
+		}
+
+		if !(regressor.minLambdaRatio > 0 && regressor.minLambdaRatio <= 1) {
+			return nil, lassoerrors.NewInvalidHyperparameterError("minimum lambda ratio", regressor.minLambdaRatio)
+		}
+	}
+
+	if regressor.numFolds < 2 {
+		return nil, lassoerrors.NewInvalidNumberOfFoldsError(regressor.numFolds)
+	}
+
+	if !(regressor.tolerance > 0) {
+		return nil, lassoerrors.NewInvalidHyperparameterError("tolerance", regressor.tolerance)
+	}
+
+	if regressor.maxIterations < 1 {
+		return nil, lassoerrors.NewInvalidHyperparameterError("maximum iterations", float64(regressor.maxIterations))
+	}
+
+	if regressor.source == nil {
+		regressor.source = rand.NewSource(1)
+	}
+	regressor.random = rand.New(regressor.source)
+
+	return regressor, nil
+}
+
+type lassoRegressor struct {
+	l1Ratio              float64
+	lambda               float64
+	lambdaPath           []float64
+	automaticLambdaPath  bool
+	numLambdas           int
+	minLambdaRatio       float64
+	numFolds             int
+	oneStandardErrorRule bool
+	tolerance            float64
+	maxIterations        int
+	source               rand.Source
+	random               *rand.Rand
+
+	coefficients []float64
+	chosenLambda float64
+	path         []PathPoint
+	iterations   int
+	converged    bool
+}
+
+func (regressor *lassoRegressor) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return lassoerrors.NewNonFloatFeaturesError()
+	}
+
+	if !trainingData.AllTargetsFloats() {
+		return lassoerrors.NewNonFloatTargetsError()
+	}
+
+	if trainingData.NumTargets() != 1 {
+		return lassoerrors.NewInvalidNumberOfTargetsError(trainingData.NumTargets())
+	}
+
+	if trainingData.NumFeatures() == 0 {
+		return lassoerrors.NewNoFeaturesError()
+	}
+
+	d, err := regressorutilities.CentredDesign(trainingData)
+	if err != nil {
+		return err
+	}
+
+	if d.TotalWeight == 0 {
+		return lassoerrors.NewEmptyTrainingDatasetError()
+	}
+
+	p := newProblem(d, regressor.l1Ratio, regressor.tolerance)
+
+	if regressor.lambdaPath == nil && !regressor.automaticLambdaPath {
+		coefficients := make([]float64, trainingData.NumFeatures())
+		iterations, converged := p.descend(coefficients, regressor.lambda, regressor.maxIterations)
+
+		regressor.coefficients = append(coefficients, d.Intercept(coefficients))
+		regressor.chosenLambda = regressor.lambda
+		regressor.path = nil
+		regressor.iterations = iterations
+		regressor.converged = converged
+		return nil
+	}
+
+	lambdas := append([]float64{}, regressor.lambdaPath...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(lambdas)))
+	if regressor.automaticLambdaPath {
+		lambdas = lambdaGrid(p.lambdaMax(), regressor.numLambdas, regressor.minLambdaRatio)
+	}
+
+	path := regressor.fitPath(p, lambdas)
+
+	err = regressor.crossValidate(trainingData, path)
+	if err != nil {
+		return err
+	}
+
+	best := 0
+	for k, point := range path {
+		if point.CVError < path[best].CVError {
+			best = k
+		}
+	}
+
+	if regressor.oneStandardErrorRule {
+		threshold := path[best].CVError + path[best].CVStandardError
+		for k, point := range path {
+			if point.CVError <= threshold {
+				best = k
+				break
+			}
+		}
+	}
+
+	regressor.coefficients = append(append([]float64{}, path[best].Coefficients...), path[best].Intercept)
+	regressor.chosenLambda = path[best].Lambda
+	regressor.path = path
+	regressor.iterations = path[best].Iterations
+	regressor.converged = path[best].Converged
+	return nil
+}
+
+func (regressor *lassoRegressor) Predict(testRow row.Row) (float64, error) {
+	coefficients := regressor.coefficients
+	if coefficients == nil {
+		return 0, lassoerrors.NewUntrainedRegressorError()
+	}
+
+	numTestRowFeatures := testRow.NumFeatures()
+	numCoefficients := len(coefficients)
+	if numCoefficients != numTestRowFeatures+1 {
+		return 0, lassoerrors.NewRowLengthMismatchError(numTestRowFeatures, numCoefficients-1)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return 0, lassoerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return regressorutilities.Prediction(
+		coefficients[:numCoefficients-1],
+		coefficients[numCoefficients-1],
+		testFeatures.Values(),
+	), nil
+}
+
+// Coefficients returns the fitted coefficient of each feature, in order, or
+// nil before training.
+func (regressor *lassoRegressor) Coefficients() []float64 {
+	if regressor.coefficients == nil {
+		return nil
+	}
+
+	return append([]float64(nil), regressor.coefficients[:len(regressor.coefficients)-1]...)
+}
+
+// Intercept returns the fitted intercept, or 0 before training.
+func (regressor *lassoRegressor) Intercept() float64 {
+	if regressor.coefficients == nil {
+		return 0
+	}
+
+	return regressor.coefficients[len(regressor.coefficients)-1]
+}
+
+// SelectedFeatures returns the indices, in order, of the features whose
+// fitted coefficients are not zero, or nil before training.
+func (regressor *lassoRegressor) SelectedFeatures() []int {
+	return featuresWhere(regressor.Coefficients(), func(c float64) bool { return c != 0 })
+}
+
+// ZeroCoefficients returns the indices, in order, of the features whose
+// fitted coefficients are exactly zero, or nil before training.
+func (regressor *lassoRegressor) ZeroCoefficients() []int {
+	return featuresWhere(regressor.Coefficients(), func(c float64) bool { return c == 0 })
+}
+
+func featuresWhere(coefficients []float64, predicate func(float64) bool) []int {
+	if coefficients == nil {
+		return nil
+	}
+
+	features := []int{}
+	for j, c := range coefficients {
+		if predicate(c) {
+			features = append(features, j)
+		}
+	}
+	return features
+}
+
+// Lambda returns the lambda of the fitted coefficients: the one chosen by
+// cross-validation if a lambda path was given.
+func (regressor *lassoRegressor) Lambda() float64 {
+	return regressor.chosenLambda
+}
+
+// Path returns the fit for each lambda of the lambda path, from the largest
+// lambda down, or nil if there was no path or before training.
+func (regressor *lassoRegressor) Path() []PathPoint {
+	return regressor.path
+}
+
+// Converged reports whether coordinate descent converged within the maximum
+// number of iterations for the fitted lambda in the last training.
+func (regressor *lassoRegressor) Converged() bool {
+	return regressor.converged
+}
+
+// Iterations returns the number of sweeps coordinate descent took for the
+// fitted lambda in the last training.
+func (regressor *lassoRegressor) Iterations() int {
+	return regressor.iterations
+}
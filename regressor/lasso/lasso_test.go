@@ -1 +1,187 @@
 package lasso_test
+
+import (
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/lassoerrors"
+	"github.com/amitkgupta/goodlearn/regressor"
+	"github.com/amitkgupta/goodlearn/regressor/lasso"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// orthogonalDataset has targets 3 x0 + 0.5 x1 + 2 on the corners of a
+// square, each repeated copies times, so that the features are orthogonal
+// with mean 0 and mean square 1, and each coefficient is its least squares
+// value shrunk independently of the other.
+func orthogonalDataset(copies int) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	ds := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+	for c := 0; c < copies; c++ {
+		for _, line := range [][]string{{"1", "1", "5.5"}, {"-1", "1", "-0.5"}, {"1", "-1", "4.5"}, {"-1", "-1", "-1.5"}} {
+			Ω(ds.AddRowFromStrings(line)).Should(Succeed())
+		}
+	}
+	return ds
+}
+
+var _ = Describe("Lasso Regressor", func() {
+	Describe("NewLassoRegressor and NewElasticNetRegressor", func() {
+		It("Return regressors", func() {
+			r, err := lasso.NewLassoRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+			var _ regressor.Regressor = r
+
+			_, err = lasso.NewElasticNetRegressor(0.5)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("Reject invalid hyperparameters", func() {
+			for _, l1Ratio := range []float64{0, 1.5} {
+				_, err := lasso.NewElasticNetRegressor(l1Ratio)
+				Ω(err).Should(BeAssignableToTypeOf(lassoerrors.InvalidHyperparameterError{}))
+			}
+
+			for _, option := range []lasso.Option{
+				lasso.Lambda(-1),
+				lasso.LambdaPath(1, -1),
+				lasso.AutomaticLambdaPath(0, 0.01),
+				lasso.AutomaticLambdaPath(10, 0),
+				lasso.Tolerance(0),
+				lasso.MaxIterations(0),
+			} {
+				_, err := lasso.NewLassoRegressor(option)
+				Ω(err).Should(BeAssignableToTypeOf(lassoerrors.InvalidHyperparameterError{}))
+			}
+
+			_, err := lasso.NewLassoRegressor(lasso.LambdaPath())
+			Ω(err).Should(BeAssignableToTypeOf(lassoerrors.EmptyLambdaPathError{}))
+
+			_, err = lasso.NewLassoRegressor(lasso.CrossValidated(1))
+			Ω(err).Should(BeAssignableToTypeOf(lassoerrors.InvalidNumberOfFoldsError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Soft-thresholds the coefficients, to exactly zero if need be", func() {
+			r, err := lasso.NewLassoRegressor(lasso.Lambda(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(orthogonalDataset(1))).Should(Succeed())
+
+			coefficients := r.Coefficients()
+			Ω(coefficients[0]).Should(BeNumerically("~", 2, 1e-9))
+			Ω(coefficients[1]).Should(Equal(0.0))
+			Ω(r.Intercept()).Should(BeNumerically("~", 2, 1e-9))
+
+			Ω(r.SelectedFeatures()).Should(Equal([]int{0}))
+			Ω(r.ZeroCoefficients()).Should(Equal([]int{1}))
+			Ω(r.Lambda()).Should(Equal(1.0))
+			Ω(r.Converged()).Should(BeTrue())
+			Ω(r.Path()).Should(BeNil())
+
+			r, err = lasso.NewLassoRegressor(lasso.Lambda(0.25))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(orthogonalDataset(1))).Should(Succeed())
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 2.75, 1e-9))
+			Ω(r.Coefficients()[1]).Should(BeNumerically("~", 0.25, 1e-9))
+			Ω(r.ZeroCoefficients()).Should(BeEmpty())
+		})
+
+		It("Also shrinks the coefficients proportionally for an elastic net", func() {
+			r, err := lasso.NewElasticNetRegressor(0.5, lasso.Lambda(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(orthogonalDataset(1))).Should(Succeed())
+
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 2.5/1.5, 1e-9))
+			Ω(r.Coefficients()[1]).Should(Equal(0.0))
+		})
+
+		It("Recovers the least squares fit without a penalty", func() {
+			r, err := lasso.NewLassoRegressor(lasso.Lambda(0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(orthogonalDataset(1))).Should(Succeed())
+
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 3, 1e-9))
+			Ω(r.Coefficients()[1]).Should(BeNumerically("~", 0.5, 1e-9))
+		})
+
+		It("Weights each row's squared error by its weight", func() {
+			weighted, err := dataset.NewWeightedDataset(orthogonalDataset(1), []float64{2, 1, 0, 1})
+			Ω(err).ShouldNot(HaveOccurred())
+			duplicated := dataset.NewSubset(orthogonalDataset(1), []int{0, 0, 1, 3})
+
+			weightedRegressor, err := lasso.NewLassoRegressor(lasso.Lambda(0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(weightedRegressor.Train(weighted)).Should(Succeed())
+
+			duplicatedRegressor, err := lasso.NewLassoRegressor(lasso.Lambda(0.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(duplicatedRegressor.Train(duplicated)).Should(Succeed())
+
+			for j, c := range duplicatedRegressor.Coefficients() {
+				Ω(weightedRegressor.Coefficients()[j]).Should(BeNumerically("~", c, 1e-9))
+			}
+			Ω(weightedRegressor.Intercept()).Should(BeNumerically("~", duplicatedRegressor.Intercept(), 1e-9))
+		})
+
+		It("Reports when coordinate descent hits the iteration cap", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			correlated := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			for _, line := range [][]string{{"1", "1.1", "2"}, {"2", "1.9", "4"}, {"3", "3.2", "5"}, {"4", "3.9", "9"}} {
+				Ω(correlated.AddRowFromStrings(line)).Should(Succeed())
+			}
+
+			r, err := lasso.NewLassoRegressor(lasso.Lambda(0.001), lasso.MaxIterations(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(correlated)).Should(Succeed())
+			Ω(r.Converged()).Should(BeFalse())
+			Ω(r.Iterations()).Should(Equal(1))
+
+			r, err = lasso.NewLassoRegressor(lasso.Lambda(0.001))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(correlated)).Should(Succeed())
+			Ω(r.Converged()).Should(BeTrue())
+			Ω(r.Iterations()).Should(BeNumerically(">", 1))
+		})
+
+		It("Rejects datasets it cannot fit", func() {
+			r, err := lasso.NewLassoRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+			nonFloat := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			Ω(r.Train(nonFloat)).Should(BeAssignableToTypeOf(lassoerrors.NonFloatFeaturesTrainingSetError{}))
+
+			unweighted, err := dataset.NewWeightedDataset(orthogonalDataset(1), []float64{0, 0, 0, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(unweighted)).Should(BeAssignableToTypeOf(lassoerrors.EmptyTrainingDatasetError{}))
+		})
+	})
+
+	Describe("Predict", func() {
+		It("Predicts from the fitted coefficients and intercept", func() {
+			r, err := lasso.NewLassoRegressor(lasso.Lambda(1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = r.Predict(row.NewRow(slice.NewFloatSlice([]float64{1, 1}), nil, 2))
+			Ω(err).Should(BeAssignableToTypeOf(lassoerrors.UntrainedRegressorError{}))
+
+			Ω(r.Train(orthogonalDataset(1))).Should(Succeed())
+
+			prediction, err := r.Predict(row.NewRow(slice.NewFloatSlice([]float64{2, 7}), nil, 2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 6, 1e-9))
+
+			_, err = r.Predict(row.NewRow(slice.NewFloatSlice([]float64{2}), nil, 1))
+			Ω(err).Should(BeAssignableToTypeOf(lassoerrors.RowLengthMismatchError{}))
+		})
+	})
+})
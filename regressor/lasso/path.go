@@ -0,0 +1,164 @@
+package lasso
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/lassoerrors"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+)
+
+// PathPoint is the fit for one lambda of a lambda path.
+type PathPoint struct {
+	Lambda       float64
+	Coefficients []float64
+	Intercept    float64
+	// NumNonZero is the number of coefficients which are not zero.
+	NumNonZero int
+	Iterations int
+	Converged  bool
+	// CVError is the weighted mean squared error of the held-out rows of
+	// every fold, predicted by the fit for this lambda to the other folds.
+	CVError float64
+	// CVStandardError is the standard error of the mean of the folds' mean
+	// squared errors.
+	CVStandardError float64
+}
+
+// fitPath fits each lambda in turn, starting coordinate descent from the
+// coefficients of the previous one, which for decreasing lambdas are close
+// and mostly zero.
+func (regressor *lassoRegressor) fitPath(p problem, lambdas []float64) []PathPoint {
+	coefficients := make([]float64, len(p.squares))
+	path := make([]PathPoint, len(lambdas))
+
+	for k, lambda := range lambdas {
+		iterations, converged := p.descend(coefficients, lambda, regressor.maxIterations)
+
+		numNonZero := 0
+		for _, c := range coefficients {
+			if c != 0 {
+				numNonZero++
+			}
+		}
+
+		path[k] = PathPoint{
+			Lambda:       lambda,
+			Coefficients: append([]float64{}, coefficients...),
+			Intercept:    p.design.Intercept(coefficients),
+			NumNonZero:   numNonZero,
+			Iterations:   iterations,
+			Converged:    converged,
+		}
+	}
+
+	return path
+}
+
+// crossValidate sets the cross-validated errors of the path's fits to the
+// training data by fitting the path's lambdas to all but one of numFolds
+// random folds of the rows at a time.
+func (regressor *lassoRegressor) crossValidate(trainingData dataset.Dataset, path []PathPoint) error {
+	numRows, numFolds := trainingData.NumRows(), regressor.numFolds
+	if numRows < numFolds {
+		return lassoerrors.NewTooFewRowsError(numRows, numFolds)
+	}
+
+	lambdas := make([]float64, len(path))
+	for k, point := range path {
+		lambdas[k] = point.Lambda
+	}
+
+	folds := make([][]int, numFolds)
+	for i, rowIndex := range regressor.random.Perm(numRows) {
+		folds[i%numFolds] = append(folds[i%numFolds], rowIndex)
+	}
+
+	weights := dataset.Weights(trainingData)
+	squaredErrors := make([]float64, len(path))
+	totalWeight := 0.0
+	foldErrors := [][]float64{}
+
+	for f, heldOut := range folds {
+		trainingRowMap := []int{}
+		for g, fold := range folds {
+			if g != f {
+				trainingRowMap = append(trainingRowMap, fold...)
+			}
+		}
+
+		d, err := regressorutilities.CentredDesign(dataset.NewSubset(trainingData, trainingRowMap))
+		if err != nil {
+			return err
+		}
+
+		heldOutWeight := 0.0
+		for _, i := range heldOut {
+			heldOutWeight = heldOutWeight + weights[i]
+		}
+
+		if d.TotalWeight == 0 || heldOutWeight == 0 {
+			continue
+		}
+
+		foldPath := regressor.fitPath(newProblem(d, regressor.l1Ratio, regressor.tolerance), lambdas)
+
+		foldSquaredErrors := make([]float64, len(path))
+		for _, i := range heldOut {
+			if weights[i] == 0 {
+				continue
+			}
+
+			r, err := trainingData.Row(i)
+			if err != nil {
+				return err
+			}
+
+			features := r.Features().(slice.FloatSlice).Values()
+			target := r.Target().(slice.FloatSlice).Values()[0]
+
+			for k, point := range foldPath {
+				residual := target - regressorutilities.Prediction(point.Coefficients, point.Intercept, features)
+				foldSquaredErrors[k] = foldSquaredErrors[k] + weights[i]*residual*residual
+			}
+		}
+
+		for k, e := range foldSquaredErrors {
+			squaredErrors[k] = squaredErrors[k] + e
+			foldSquaredErrors[k] = e / heldOutWeight
+		}
+		totalWeight = totalWeight + heldOutWeight
+		foldErrors = append(foldErrors, foldSquaredErrors)
+	}
+
+	if len(foldErrors) == 0 {
+		return lassoerrors.NewEmptyTrainingDatasetError()
+	}
+
+	for k := range path {
+		path[k].CVError = squaredErrors[k] / totalWeight
+		path[k].CVStandardError = standardErrorOfMean(foldErrors, k)
+	}
+
+	return nil
+}
+
+func standardErrorOfMean(foldErrors [][]float64, k int) float64 {
+	n := float64(len(foldErrors))
+	if n < 2 {
+		return 0
+	}
+
+	mean := 0.0
+	for _, e := range foldErrors {
+		mean = mean + e[k]/n
+	}
+
+	sumOfSquares := 0.0
+	for _, e := range foldErrors {
+		sumOfSquares = sumOfSquares + (e[k]-mean)*(e[k]-mean)
+	}
+
+	return math.Sqrt(sumOfSquares / (n - 1) / n)
+}
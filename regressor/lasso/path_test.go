@@ -0,0 +1,121 @@
+package lasso_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/regressor/lassoerrors"
+	"github.com/amitkgupta/goodlearn/regressor/lasso"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lambda paths", func() {
+	It("Generates a grid down from the smallest lambda zeroing every coefficient", func() {
+		r, err := lasso.NewLassoRegressor(lasso.AutomaticLambdaPath(3, 0.1), lasso.CrossValidated(3))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(orthogonalDataset(3))).Should(Succeed())
+
+		path := r.Path()
+		Ω(path).Should(HaveLen(3))
+		for k, lambda := range []float64{3, 3 * math.Sqrt(0.1), 0.3} {
+			Ω(path[k].Lambda).Should(BeNumerically("~", lambda, 1e-12))
+			Ω(path[k].Converged).Should(BeTrue())
+		}
+
+		Ω(path[0].NumNonZero).Should(Equal(0))
+		Ω(path[0].Intercept).Should(BeNumerically("~", 2, 1e-12))
+		Ω(path[1].NumNonZero).Should(Equal(1))
+		Ω(path[1].Coefficients[0]).Should(BeNumerically("~", 3-3*math.Sqrt(0.1), 1e-9))
+		Ω(path[2].NumNonZero).Should(Equal(2))
+		Ω(path[2].Coefficients[1]).Should(BeNumerically("~", 0.2, 1e-9))
+	})
+
+	It("Fits an explicit path from the largest lambda down, as separate regressors would", func() {
+		r, err := lasso.NewElasticNetRegressor(0.5, lasso.LambdaPath(0.1, 2, 0.5), lasso.CrossValidated(2))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(orthogonalDataset(2))).Should(Succeed())
+
+		path := r.Path()
+		Ω(path).Should(HaveLen(3))
+		for k, lambda := range []float64{2, 0.5, 0.1} {
+			Ω(path[k].Lambda).Should(Equal(lambda))
+
+			single, err := lasso.NewElasticNetRegressor(0.5, lasso.Lambda(lambda))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(single.Train(orthogonalDataset(2))).Should(Succeed())
+
+			for j, c := range single.Coefficients() {
+				Ω(path[k].Coefficients[j]).Should(BeNumerically("~", c, 1e-9))
+			}
+		}
+	})
+
+	It("Chooses the lambda of least cross-validated error and selects the relevant features", func() {
+		numFeatures := 10
+		featureColumns := []int{}
+		columnTypeStrings := []string{"0"}
+		for j := 0; j < numFeatures; j++ {
+			featureColumns = append(featureColumns, j)
+			columnTypeStrings = append(columnTypeStrings, "0")
+		}
+
+		columnTypes, err := columntype.StringsToColumnTypes(columnTypeStrings)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		random := rand.New(rand.NewSource(1))
+		ds := dataset.NewDataset(featureColumns, []int{numFeatures}, columnTypes)
+		for i := 0; i < 100; i++ {
+			values := make([]string, numFeatures+1)
+			x := make([]float64, numFeatures)
+			for j := range x {
+				x[j] = random.NormFloat64()
+				values[j] = fmt.Sprintf("%g", x[j])
+			}
+			values[numFeatures] = fmt.Sprintf("%g", 3*x[0]-2*x[1]+1+0.5*random.NormFloat64())
+			Ω(ds.AddRowFromStrings(values)).Should(Succeed())
+		}
+
+		r, err := lasso.NewLassoRegressor(lasso.AutomaticLambdaPath(50, 0.001))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(ds)).Should(Succeed())
+
+		path := r.Path()
+		Ω(path).Should(HaveLen(50))
+		for _, point := range path {
+			Ω(point.CVError).Should(BeNumerically(">=", 0))
+			Ω(point.CVStandardError).Should(BeNumerically(">", 0))
+			if point.Lambda == r.Lambda() {
+				for _, other := range path {
+					Ω(point.CVError).Should(BeNumerically("<=", other.CVError))
+				}
+			}
+		}
+
+		// the error of the best fits is about that of the noise
+		Ω(r.Lambda()).Should(BeNumerically("<", path[0].Lambda))
+		Ω(r.Coefficients()[0]).Should(BeNumerically("~", 3, 0.2))
+		Ω(r.Coefficients()[1]).Should(BeNumerically("~", -2, 0.2))
+		Ω(r.Intercept()).Should(BeNumerically("~", 1, 0.2))
+		Ω(r.SelectedFeatures()).Should(ContainElement(0))
+		Ω(r.SelectedFeatures()).Should(ContainElement(1))
+
+		sparser, err := lasso.NewLassoRegressor(lasso.AutomaticLambdaPath(50, 0.001), lasso.OneStandardErrorRule())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(sparser.Train(ds)).Should(Succeed())
+
+		Ω(sparser.Lambda()).Should(BeNumerically(">", r.Lambda()))
+		Ω(sparser.SelectedFeatures()).Should(Equal([]int{0, 1}))
+		Ω(sparser.ZeroCoefficients()).Should(Equal([]int{2, 3, 4, 5, 6, 7, 8, 9}))
+	})
+
+	It("Needs at least as many rows as folds", func() {
+		r, err := lasso.NewLassoRegressor(lasso.AutomaticLambdaPath(10, 0.01))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(orthogonalDataset(1))).Should(BeAssignableToTypeOf(lassoerrors.TooFewRowsError{}))
+	})
+})
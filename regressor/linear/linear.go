@@ -1,27 +1,162 @@
 package linear
 
 import (
+	"fmt"
+
 	"github.com/amitkgupta/goodlearn/data/dataset"
 	"github.com/amitkgupta/goodlearn/data/row"
 	"github.com/amitkgupta/goodlearn/data/slice"
 	"github.com/amitkgupta/goodlearn/errors/regressor/linearerrors"
 	"github.com/amitkgupta/goodlearn/parameterestimator/gradientdescentestimator"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
 )
 
-func NewLinearRegressor() *linearRegressor {
-	return &linearRegressor{}
-}
+// Solver is how the least squares coefficients are found.
+type Solver int
 
-type linearRegressor struct {
-	coefficients []float64
-}
+const (
+	// Auto solves by QR decomposition, falling back to SVD if the features
+	// are collinear, unless the dataset is too large to hold as a matrix,
+	// when it uses gradient descent.
+	Auto Solver = iota
+	// NormalEquations solves X'X b = X'y by Cholesky decomposition: the
+	// fastest exact solver, but the least accurate for ill-conditioned
+	// features.
+	NormalEquations
+	// QR solves by Householder QR decomposition of X.
+	QR
+	// SVD finds the minimum norm least squares solution from the singular
+	// value decomposition of X, treating singular values negligible beside
+	// the largest as zero, so that it also solves for collinear features.
+	SVD
+	// GradientDescent minimizes the sum of squared errors by gradient
+	// descent.
+	GradientDescent
+)
 
 const (
 	defaultLearningRate  = 0.004
 	defaultPrecision     = 1e-8
 	defaultMaxIterations = 1e8
+
+	defaultConfidenceLevel = 0.95
 )
 
+type Option func(*linearRegressor)
+
+// Solving sets how the coefficients are found; Auto by default.
+func Solving(solver Solver) Option {
+	return func(regressor *linearRegressor) {
+		regressor.solver = solver
+	}
+}
+
+// LearningRate sets the step size of the GradientDescent solver; 0.004 by
+// default.
+func LearningRate(learningRate float64) Option {
+	return func(regressor *linearRegressor) {
+		regressor.learningRate = learningRate
+	}
+}
+
+// Precision sets how short a step of the GradientDescent solver must be for
+// it to have converged; 1e-8 by default.
+func Precision(precision float64) Option {
+	return func(regressor *linearRegressor) {
+		regressor.precision = precision
+	}
+}
+
+// MaxIterations caps the number of steps of the GradientDescent solver;
+// 1e8 by default.
+func MaxIterations(maxIterations int) Option {
+	return func(regressor *linearRegressor) {
+		regressor.maxIterations = maxIterations
+	}
+}
+
+// InitialCoefficients starts the GradientDescent solver from the given
+// coefficients, one per feature, and intercept, such as those of an earlier
+// fit, rather than from zero.  The exact solvers ignore them.
+func InitialCoefficients(coefficients []float64, intercept float64) Option {
+	return func(regressor *linearRegressor) {
+		regressor.initialCoefficients = append(append([]float64(nil), coefficients...), intercept)
+	}
+}
+
+// FeatureNames names the features, in order, for NamedCoefficients and
+// Summary; by default they are named x0, x1 and so on.
+func FeatureNames(names ...string) Option {
+	return func(regressor *linearRegressor) {
+		regressor.featureNames = names
+	}
+}
+
+// ConfidenceLevel sets the level of the confidence intervals in Summary and
+// of PredictionInterval; 0.95 by default.
+func ConfidenceLevel(level float64) Option {
+	return func(regressor *linearRegressor) {
+		regressor.confidenceLevel = level
+	}
+}
+
+// NewLinearRegressor returns an ordinary least squares regressor with an
+// intercept, with each row's squared error weighted by its weight if the
+// training data is a dataset.WeightedDataset.  The exact solvers centre the
+// features and target on their weighted means and solve for the intercept
+// from them, rather than adding a column of ones to the features.
+func NewLinearRegressor(options ...Option) (*linearRegressor, error) {
+	regressor := &linearRegressor{
+		solver:          Auto,
+		learningRate:    defaultLearningRate,
+		precision:       defaultPrecision,
+		maxIterations:   defaultMaxIterations,
+		confidenceLevel: defaultConfidenceLevel,
+	}
+
+	for _, option := range options {
+		option(regressor)
+	}
+
+	if regressor.solver < Auto || regressor.solver > GradientDescent {
+		return nil, linearerrors.NewInvalidSolverError(int(regressor.solver))
+	}
+
+	if !(regressor.confidenceLevel > 0 && regressor.confidenceLevel < 1) {
+		return nil, linearerrors.NewInvalidConfidenceLevelError(regressor.confidenceLevel)
+	}
+
+	if !(regressor.learningRate > 0) {
+		return nil, linearerrors.NewInvalidHyperparameterError("learning rate", regressor.learningRate)
+	}
+
+	if !(regressor.precision > 0) {
+		return nil, linearerrors.NewInvalidHyperparameterError("precision", regressor.precision)
+	}
+
+	if regressor.maxIterations < 1 {
+		return nil, linearerrors.NewInvalidHyperparameterError("maximum iterations", float64(regressor.maxIterations))
+	}
+
+	return regressor, nil
+}
+
+type linearRegressor struct {
+	solver              Solver
+	learningRate        float64
+	precision           float64
+	maxIterations       int
+	initialCoefficients []float64
+	featureNames        []string
+	confidenceLevel     float64
+
+	coefficients []float64
+	iterations   int
+	converged    bool
+	trainingData dataset.Dataset
+	inference    *inference
+}
+
 func (regressor *linearRegressor) Train(trainingData dataset.Dataset) error {
 	if !trainingData.AllFeaturesFloats() {
 		return linearerrors.NewNonFloatFeaturesError()
@@ -35,14 +170,47 @@ func (regressor *linearRegressor) Train(trainingData dataset.Dataset) error {
 		return linearerrors.NewInvalidNumberOfTargetsError(trainingData.NumTargets())
 	}
 
-	if trainingData.NumFeatures() == 0 {
+	numFeatures := trainingData.NumFeatures()
+	if numFeatures == 0 {
 		return linearerrors.NewNoFeaturesError()
 	}
 
+	if regressor.featureNames != nil && len(regressor.featureNames) != numFeatures {
+		return linearerrors.NewFeatureNamesLengthMismatchError(len(regressor.featureNames), numFeatures)
+	}
+
+	if regressor.initialCoefficients != nil && len(regressor.initialCoefficients) != numFeatures+1 {
+		return linearerrors.NewInitialCoefficientsLengthMismatchError(len(regressor.initialCoefficients)-1, numFeatures)
+	}
+
+	regressor.trainingData = trainingData
+	regressor.inference = nil
+
+	solver := regressor.solver
+	if solver == Auto && regressorutilities.TooLargeForMatrix(trainingData) {
+		solver = GradientDescent
+	}
+
+	if solver == GradientDescent {
+		return regressor.estimateByGradientDescent(trainingData)
+	}
+
+	coefficients, err := solveExactly(trainingData, solver)
+	if err != nil {
+		return err
+	}
+
+	regressor.coefficients = coefficients
+	regressor.iterations = 0
+	regressor.converged = true
+	return nil
+}
+
+func (regressor *linearRegressor) estimateByGradientDescent(trainingData dataset.Dataset) error {
 	estimator, err := gradientdescentestimator.NewGradientDescentParameterEstimator(
-		defaultLearningRate,
-		defaultPrecision,
-		defaultMaxIterations,
+		regressor.learningRate,
+		regressor.precision,
+		regressor.maxIterations,
 		gradientdescentestimator.LinearModelLeastSquaresLossGradient,
 	)
 	if err != nil {
@@ -54,12 +222,19 @@ func (regressor *linearRegressor) Train(trainingData dataset.Dataset) error {
 		return linearerrors.NewEstimatorTrainingError(err)
 	}
 
-	coefficients, err := estimator.Estimate(defaultInitialCoefficientEstimate(trainingData.NumFeatures()))
+	initialCoefficients := regressor.initialCoefficients
+	if initialCoefficients == nil {
+		initialCoefficients = defaultInitialCoefficientEstimate(trainingData.NumFeatures())
+	}
+
+	coefficients, err := estimator.Estimate(initialCoefficients)
 	if err != nil {
 		return linearerrors.NewEstimatorEstimationError(err)
 	}
 
 	regressor.coefficients = coefficients
+	regressor.iterations = estimator.Iterations()
+	regressor.converged = estimator.Converged()
 	return nil
 }
 
@@ -79,14 +254,65 @@ func (regressor *linearRegressor) Predict(testRow row.Row) (float64, error) {
 	if !ok {
 		return 0, linearerrors.NewNonFloatFeaturesTestRowError()
 	}
-	testFeatureValues := testFeatures.Values()
+	return regressorutilities.Prediction(
+		coefficients[:numCoefficients-1],
+		coefficients[numCoefficients-1],
+		testFeatures.Values(),
+	), nil
+}
 
-	result := coefficients[numCoefficients-1]
-	for i, c := range coefficients[:numCoefficients-1] {
-		result = result + c*testFeatureValues[i]
+// Coefficients returns the fitted coefficient of each feature, in order, or
+// nil before training.
+func (regressor *linearRegressor) Coefficients() []float64 {
+	if regressor.coefficients == nil {
+		return nil
 	}
 
-	return result, nil
+	return append([]float64(nil), regressor.coefficients[:len(regressor.coefficients)-1]...)
+}
+
+// Intercept returns the fitted intercept, or 0 before training.
+func (regressor *linearRegressor) Intercept() float64 {
+	if regressor.coefficients == nil {
+		return 0
+	}
+
+	return regressor.coefficients[len(regressor.coefficients)-1]
+}
+
+// NamedCoefficients returns the fitted coefficient of each feature, keyed by
+// its name, and the intercept, or nil and 0 before training.
+func (regressor *linearRegressor) NamedCoefficients() (map[string]float64, float64) {
+	if regressor.coefficients == nil {
+		return nil, 0
+	}
+
+	named := map[string]float64{}
+	for j, c := range regressor.Coefficients() {
+		named[regressor.featureName(j)] = c
+	}
+
+	return named, regressor.Intercept()
+}
+
+func (regressor *linearRegressor) featureName(j int) string {
+	if regressor.featureNames != nil {
+		return regressor.featureNames[j]
+	}
+	return fmt.Sprintf("x%d", j)
+}
+
+// Converged reports whether the last training converged: always for the
+// exact solvers, and for GradientDescent, whether a step became shorter than
+// the precision before the maximum number of iterations was reached.
+func (regressor *linearRegressor) Converged() bool {
+	return regressor.converged
+}
+
+// Iterations returns the number of steps the GradientDescent solver took in
+// the last training, or 0 for the exact solvers.
+func (regressor *linearRegressor) Iterations() int {
+	return regressor.iterations
 }
 
 func defaultInitialCoefficientEstimate(numFeatures int) []float64 {
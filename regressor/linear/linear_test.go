@@ -20,7 +20,9 @@ var _ = Describe("LinearRegressor", func() {
 		var trainingData dataset.Dataset
 
 		BeforeEach(func() {
-			linearRegressor = linear.NewLinearRegressor()
+			var err error
+			linearRegressor, err = linear.NewLinearRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
 		})
 
 		Context("When the dataset's features are not all floats", func() {
@@ -123,7 +125,8 @@ var _ = Describe("LinearRegressor", func() {
 		var err error
 
 		BeforeEach(func() {
-			linearRegressor = linear.NewLinearRegressor()
+			linearRegressor, err = linear.NewLinearRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
 
 			columnTypes, err = columntype.StringsToColumnTypes([]string{"0", "0", "0"})
 			Ω(err).ShouldNot(HaveOccurred())
@@ -211,4 +214,166 @@ var _ = Describe("LinearRegressor", func() {
 			})
 		})
 	})
+
+	Describe("NewLinearRegressor", func() {
+		It("Rejects an unknown solver", func() {
+			_, err := linear.NewLinearRegressor(linear.Solving(linear.Solver(9)))
+			Ω(err).Should(BeAssignableToTypeOf(linearerrors.InvalidSolverError{}))
+		})
+
+		It("Rejects invalid gradient descent settings", func() {
+			_, err := linear.NewLinearRegressor(linear.LearningRate(0))
+			Ω(err).Should(BeAssignableToTypeOf(linearerrors.InvalidHyperparameterError{}))
+
+			_, err = linear.NewLinearRegressor(linear.Precision(-1))
+			Ω(err).Should(BeAssignableToTypeOf(linearerrors.InvalidHyperparameterError{}))
+
+			_, err = linear.NewLinearRegressor(linear.MaxIterations(0))
+			Ω(err).Should(BeAssignableToTypeOf(linearerrors.InvalidHyperparameterError{}))
+		})
+	})
+
+	Describe("Coefficients and Intercept", func() {
+		It("Return the fitted model, or nothing before training", func() {
+			r, err := linear.NewLinearRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Coefficients()).Should(BeNil())
+			Ω(r.Intercept()).Should(BeZero())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingData := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for _, values := range [][]string{{"0", "1"}, {"1", "3"}, {"2", "5"}} {
+				Ω(trainingData.AddRowFromStrings(values)).Should(Succeed())
+			}
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			coefficients := r.Coefficients()
+			Ω(coefficients).Should(HaveLen(1))
+			Ω(coefficients[0]).Should(BeNumerically("~", 2, 1e-12))
+			Ω(r.Intercept()).Should(BeNumerically("~", 1, 1e-12))
+
+			coefficients[0] = 7
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 2, 1e-12))
+		})
+	})
+
+	Describe("NamedCoefficients", func() {
+		var trainingData dataset.Dataset
+
+		BeforeEach(func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingData = dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+			for _, values := range [][]string{{"0", "0", "1"}, {"1", "0", "3"}, {"0", "1", "0"}, {"1", "1", "2"}} {
+				Ω(trainingData.AddRowFromStrings(values)).Should(Succeed())
+			}
+		})
+
+		It("Keys the coefficients by feature name", func() {
+			r, err := linear.NewLinearRegressor(linear.FeatureNames("tv", "radio"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			named, intercept := r.NamedCoefficients()
+			Ω(named).Should(BeNil())
+			Ω(intercept).Should(BeZero())
+
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			named, intercept = r.NamedCoefficients()
+			Ω(named).Should(HaveLen(2))
+			Ω(named["tv"]).Should(BeNumerically("~", 2, 1e-12))
+			Ω(named["radio"]).Should(BeNumerically("~", -1, 1e-12))
+			Ω(intercept).Should(BeNumerically("~", 1, 1e-12))
+		})
+
+		It("Names the features by position by default", func() {
+			r, err := linear.NewLinearRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			named, _ := r.NamedCoefficients()
+			Ω(named).Should(HaveKey("x0"))
+			Ω(named).Should(HaveKey("x1"))
+		})
+
+		It("Requires a name for each feature", func() {
+			r, err := linear.NewLinearRegressor(linear.FeatureNames("tv"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = r.Train(trainingData)
+			Ω(err).Should(BeAssignableToTypeOf(linearerrors.FeatureNamesLengthMismatchError{}))
+		})
+	})
+
+	Describe("Converged and Iterations", func() {
+		var trainingData dataset.Dataset
+
+		BeforeEach(func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			trainingData = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for _, values := range [][]string{{"0", "1"}, {"1", "3"}, {"2", "5"}} {
+				Ω(trainingData.AddRowFromStrings(values)).Should(Succeed())
+			}
+		})
+
+		It("Report that the exact solvers converge immediately", func() {
+			r, err := linear.NewLinearRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			Ω(r.Converged()).Should(BeTrue())
+			Ω(r.Iterations()).Should(BeZero())
+		})
+
+		It("Distinguish gradient descent hitting its iteration cap", func() {
+			r, err := linear.NewLinearRegressor(
+				linear.Solving(linear.GradientDescent),
+				linear.LearningRate(0.05),
+				linear.MaxIterations(10),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			Ω(r.Converged()).Should(BeFalse())
+			Ω(r.Iterations()).Should(Equal(10))
+
+			r, err = linear.NewLinearRegressor(
+				linear.Solving(linear.GradientDescent),
+				linear.LearningRate(0.05),
+				linear.MaxIterations(100000),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			Ω(r.Converged()).Should(BeTrue())
+			Ω(r.Iterations()).Should(BeNumerically("<", 100000))
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 2, 1e-3))
+		})
+
+		It("Warm-start gradient descent from given coefficients", func() {
+			r, err := linear.NewLinearRegressor(
+				linear.Solving(linear.GradientDescent),
+				linear.InitialCoefficients([]float64{2}, 1),
+				linear.MaxIterations(10),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			Ω(r.Converged()).Should(BeTrue())
+			Ω(r.Iterations()).Should(Equal(1))
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 2, 1e-12))
+			Ω(r.Intercept()).Should(BeNumerically("~", 1, 1e-12))
+
+			r, err = linear.NewLinearRegressor(linear.InitialCoefficients([]float64{2, 0}, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = r.Train(trainingData)
+			Ω(err).Should(BeAssignableToTypeOf(linearerrors.InitialCoefficientsLengthMismatchError{}))
+		})
+	})
 })
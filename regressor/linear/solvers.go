@@ -0,0 +1,115 @@
+package linear
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/regressor/linearerrors"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+)
+
+// solveExactly returns the weighted least squares coefficients, followed by
+// the intercept, found by the given exact solver, or by Auto's choice of
+// them.
+func solveExactly(trainingData dataset.Dataset, solver Solver) ([]float64, error) {
+	d, err := centredDesign(trainingData)
+	if err != nil {
+		return nil, err
+	}
+	x, y := d.X, d.Y
+
+	var beta []float64
+	switch solver {
+	case NormalEquations:
+		beta, err = solveNormalEquations(x, y)
+	case QR:
+		beta, err = solveQR(x, y)
+	case SVD:
+		beta = solveSVD(x, y)
+	default:
+		beta, err = solveQR(x, y)
+		if _, ok := err.(linearerrors.RankDeficientError); ok {
+			beta, err = solveSVD(x, y), nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(beta, d.Intercept(beta)), nil
+}
+
+// centredDesign is the regressorutilities.Design of the training data, which
+// must have a row of positive weight.
+func centredDesign(trainingData dataset.Dataset) (regressorutilities.Design, error) {
+	d, err := regressorutilities.CentredDesign(trainingData)
+	if err != nil {
+		return regressorutilities.Design{}, err
+	}
+
+	if d.TotalWeight == 0 {
+		return regressorutilities.Design{}, linearerrors.NewEmptyTrainingDatasetError()
+	}
+
+	return d, nil
+}
+
+func solveNormalEquations(x [][]float64, y []float64) ([]float64, error) {
+	xtx, xty := regressorutilities.NormalEquations(x, y, len(x[0]))
+
+	l, err := matrixutilities.Cholesky(xtx)
+	if err != nil {
+		return nil, linearerrors.NewRankDeficientError()
+	}
+
+	return matrixutilities.CholeskySolve(l, xty), nil
+}
+
+func solveQR(x [][]float64, y []float64) ([]float64, error) {
+	q, r, err := matrixutilities.QR(x)
+	if err != nil {
+		return nil, linearerrors.NewRankDeficientError()
+	}
+
+	largest := 0.0
+	for i := range r {
+		largest = math.Max(largest, math.Abs(r[i][i]))
+	}
+	for i := range r {
+		if math.Abs(r[i][i]) <= regressorutilities.Negligible(len(x), len(r))*largest {
+			return nil, linearerrors.NewRankDeficientError()
+		}
+	}
+
+	qty := make([]float64, len(r))
+	for i, v := range y {
+		for j := range qty {
+			qty[j] = qty[j] + q[i][j]*v
+		}
+	}
+
+	return matrixutilities.SolveUpperTriangular(r, qty), nil
+}
+
+func solveSVD(x [][]float64, y []float64) []float64 {
+	u, s, v := matrixutilities.SVD(x)
+
+	beta := make([]float64, len(x[0]))
+	for k, sk := range s {
+		if sk <= regressorutilities.Negligible(len(x), len(beta))*s[0] {
+			break
+		}
+
+		uty := 0.0
+		for i, value := range y {
+			uty = uty + u[i][k]*value
+		}
+
+		for j := range beta {
+			beta[j] = beta[j] + v[j][k]*uty/sk
+		}
+	}
+
+	return beta
+}
@@ -0,0 +1,125 @@
+package linear_test
+
+import (
+	"fmt"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/regressor/linearerrors"
+	"github.com/amitkgupta/goodlearn/regressor/linear"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Solvers", func() {
+	exactSolvers := []linear.Solver{linear.Auto, linear.NormalEquations, linear.QR, linear.SVD}
+
+	// planeDataset has targets 2 x0 - 3 x1 + 5, and, if collinear, a third
+	// feature equal to 2 x0.
+	planeDataset := func(collinear bool) dataset.Dataset {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		featureColumns := []int{0, 1}
+		if collinear {
+			featureColumns = append(featureColumns, 2)
+		}
+
+		ds := dataset.NewDataset(featureColumns, []int{3}, columnTypes)
+		for i := 0; i < 20; i++ {
+			x0, x1 := float64(i), float64((i*i)%7)
+			err = ds.AddRowFromStrings([]string{
+				fmt.Sprintf("%g", x0),
+				fmt.Sprintf("%g", x1),
+				fmt.Sprintf("%g", 2*x0),
+				fmt.Sprintf("%g", 2*x0-3*x1+5),
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		return ds
+	}
+
+	It("Recover exact coefficients", func() {
+		for _, solver := range exactSolvers {
+			r, err := linear.NewLinearRegressor(linear.Solving(solver))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(planeDataset(false))).Should(Succeed())
+
+			coefficients := r.Coefficients()
+			Ω(coefficients[0]).Should(BeNumerically("~", 2, 1e-9))
+			Ω(coefficients[1]).Should(BeNumerically("~", -3, 1e-9))
+			Ω(r.Intercept()).Should(BeNumerically("~", 5, 1e-9))
+		}
+	})
+
+	It("Find the minimum norm solution for collinear features by SVD", func() {
+		for _, solver := range []linear.Solver{linear.Auto, linear.SVD} {
+			r, err := linear.NewLinearRegressor(linear.Solving(solver))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(planeDataset(true))).Should(Succeed())
+
+			coefficients := r.Coefficients()
+			Ω(coefficients[0]).Should(BeNumerically("~", 0.4, 1e-9))
+			Ω(coefficients[1]).Should(BeNumerically("~", -3, 1e-9))
+			Ω(coefficients[2]).Should(BeNumerically("~", 0.8, 1e-9))
+			Ω(r.Intercept()).Should(BeNumerically("~", 5, 1e-9))
+		}
+	})
+
+	It("Refuse collinear features with normal equations or QR", func() {
+		for _, solver := range []linear.Solver{linear.NormalEquations, linear.QR} {
+			r, err := linear.NewLinearRegressor(linear.Solving(solver))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = r.Train(planeDataset(true))
+			Ω(err).Should(BeAssignableToTypeOf(linearerrors.RankDeficientError{}))
+		}
+	})
+
+	It("Weight each row's squared error by its weight", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for _, values := range [][]string{{"0", "0"}, {"1", "1"}, {"2", "0"}, {"3", "9"}} {
+			Ω(ds.AddRowFromStrings(values)).Should(Succeed())
+		}
+
+		weighted, err := dataset.NewWeightedDataset(ds, []float64{1, 1, 0, 0})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		for _, solver := range exactSolvers {
+			r, err := linear.NewLinearRegressor(linear.Solving(solver))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(weighted)).Should(Succeed())
+
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 1, 1e-12))
+			Ω(r.Intercept()).Should(BeNumerically("~", 0, 1e-12))
+		}
+
+		weighted, err = dataset.NewWeightedDataset(ds, []float64{0, 0, 0, 0})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		r, err := linear.NewLinearRegressor()
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(weighted)).Should(BeAssignableToTypeOf(linearerrors.EmptyTrainingDatasetError{}))
+	})
+
+	It("Still offer gradient descent", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for _, values := range [][]string{{"0", "1"}, {"1", "3"}, {"2", "5"}} {
+			Ω(ds.AddRowFromStrings(values)).Should(Succeed())
+		}
+
+		r, err := linear.NewLinearRegressor(linear.Solving(linear.GradientDescent))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(ds)).Should(Succeed())
+
+		Ω(r.Coefficients()[0]).Should(BeNumerically("~", 2, 1e-3))
+		Ω(r.Intercept()).Should(BeNumerically("~", 1, 1e-3))
+	})
+})
@@ -0,0 +1,248 @@
+package linear
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"text/tabwriter"
+
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/linearerrors"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+	"github.com/amitkgupta/goodlearn/statisticsutilities"
+)
+
+const interceptName = "(intercept)"
+
+// CoefficientSummary is a coefficient's estimate, its standard error, the
+// t statistic and two-sided p-value for the hypothesis that it is zero, and
+// its confidence interval.
+type CoefficientSummary struct {
+	Name          string
+	Estimate      float64
+	StandardError float64
+	TStatistic    float64
+	PValue        float64
+	Lower         float64
+	Upper         float64
+}
+
+// Summary is the classical inference for a least squares fit, assuming
+// independent, normally distributed errors of equal variance.  Coefficients
+// lists the features in order, then the intercept.
+type Summary struct {
+	Coefficients          []CoefficientSummary
+	ConfidenceLevel       float64
+	NumObservations       float64
+	DegreesOfFreedom      float64
+	ResidualStandardError float64
+	RSquared              float64
+	AdjustedRSquared      float64
+	FStatistic            float64
+	FPValue               float64
+	LogLikelihood         float64
+	AIC                   float64
+	BIC                   float64
+}
+
+// inference is what the summary and prediction intervals are computed from.
+type inference struct {
+	numObservations  float64
+	degreesOfFreedom float64
+	featureMeans     []float64
+	inverseGram      [][]float64
+	residualVariance float64
+	residualSquares  float64
+	totalSquares     float64
+	criticalT        float64
+}
+
+// Summary returns the inference for the fitted coefficients, computed from
+// the training dataset.  Rows' weights are treated as frequencies, so a row
+// of weight 2 counts as two observations.  The features must not be
+// collinear, and there must be more observations than coefficients.
+func (regressor *linearRegressor) Summary() (Summary, error) {
+	inf, err := regressor.infer()
+	if err != nil {
+		return Summary{}, err
+	}
+
+	numFeatures := len(inf.featureMeans)
+	coefficients := make([]CoefficientSummary, numFeatures+1)
+	for j := range coefficients {
+		name, variance := interceptName, inf.interceptVariance()
+		if j < numFeatures {
+			name, variance = regressor.featureName(j), inf.residualVariance*inf.inverseGram[j][j]
+		}
+
+		estimate := regressor.coefficients[j]
+		standardError := math.Sqrt(variance)
+		t := estimate / standardError
+		coefficients[j] = CoefficientSummary{
+			Name:          name,
+			Estimate:      estimate,
+			StandardError: standardError,
+			TStatistic:    t,
+			PValue:        2 * statisticsutilities.StudentTCDF(-math.Abs(t), inf.degreesOfFreedom),
+			Lower:         estimate - inf.criticalT*standardError,
+			Upper:         estimate + inf.criticalT*standardError,
+		}
+	}
+
+	n := inf.numObservations
+	numParameters := float64(numFeatures + 1)
+	rSquared := 1 - inf.residualSquares/inf.totalSquares
+	fStatistic := (inf.totalSquares - inf.residualSquares) / float64(numFeatures) / inf.residualVariance
+	fPValue := 0.0
+	if !math.IsInf(fStatistic, 1) {
+		fPValue = 1 - statisticsutilities.FCDF(fStatistic, float64(numFeatures), inf.degreesOfFreedom)
+	}
+	logLikelihood := -n / 2 * (math.Log(2*math.Pi) + math.Log(inf.residualSquares/n) + 1)
+
+	return Summary{
+		Coefficients:          coefficients,
+		ConfidenceLevel:       regressor.confidenceLevel,
+		NumObservations:       n,
+		DegreesOfFreedom:      inf.degreesOfFreedom,
+		ResidualStandardError: math.Sqrt(inf.residualVariance),
+		RSquared:              rSquared,
+		AdjustedRSquared:      1 - (1-rSquared)*(n-1)/inf.degreesOfFreedom,
+		FStatistic:            fStatistic,
+		FPValue:               fPValue,
+		LogLikelihood:         logLikelihood,
+		AIC:                   2*numParameters - 2*logLikelihood,
+		BIC:                   numParameters*math.Log(n) - 2*logLikelihood,
+	}, nil
+}
+
+// PredictionInterval returns the prediction for the row and the interval
+// which, at the confidence level, contains the target of a new observation
+// with the row's features, under the assumptions of Summary.
+func (regressor *linearRegressor) PredictionInterval(testRow row.Row) (float64, float64, float64, error) {
+	prediction, err := regressor.Predict(testRow)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	inf, err := regressor.infer()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	x := testRow.Features().(slice.FloatSlice).Values()
+	deviations := make([]float64, len(x))
+	for j, v := range x {
+		deviations[j] = v - inf.featureMeans[j]
+	}
+
+	variance := inf.residualVariance * (1 + 1/inf.numObservations + quadraticForm(inf.inverseGram, deviations))
+	halfWidth := inf.criticalT * math.Sqrt(variance)
+
+	return prediction, prediction - halfWidth, prediction + halfWidth, nil
+}
+
+func (regressor *linearRegressor) infer() (*inference, error) {
+	if regressor.coefficients == nil {
+		return nil, linearerrors.NewUntrainedRegressorError()
+	}
+
+	if regressor.inference != nil {
+		return regressor.inference, nil
+	}
+
+	d, err := centredDesign(regressor.trainingData)
+	if err != nil {
+		return nil, err
+	}
+
+	numFeatures := len(d.FeatureMeans)
+	degreesOfFreedom := d.TotalWeight - float64(numFeatures+1)
+	if degreesOfFreedom <= 0 {
+		return nil, linearerrors.NewTooFewDegreesOfFreedomError(d.TotalWeight, numFeatures+1)
+	}
+
+	gram := matrixutilities.Zeros(numFeatures, numFeatures)
+	residualSquares, totalSquares := 0.0, 0.0
+	for i, features := range d.X {
+		residual := d.Y[i]
+		for j, v := range features {
+			residual = residual - regressor.coefficients[j]*v
+			for k := range features {
+				gram[j][k] = gram[j][k] + v*features[k]
+			}
+		}
+
+		residualSquares = residualSquares + residual*residual
+		totalSquares = totalSquares + d.Y[i]*d.Y[i]
+	}
+
+	inverseGram, err := matrixutilities.Inverse(gram)
+	if err != nil {
+		return nil, linearerrors.NewRankDeficientError()
+	}
+
+	regressor.inference = &inference{
+		numObservations:  d.TotalWeight,
+		degreesOfFreedom: degreesOfFreedom,
+		featureMeans:     d.FeatureMeans,
+		inverseGram:      inverseGram,
+		residualVariance: residualSquares / degreesOfFreedom,
+		residualSquares:  residualSquares,
+		totalSquares:     totalSquares,
+		criticalT:        statisticsutilities.StudentTQuantile(1-(1-regressor.confidenceLevel)/2, degreesOfFreedom),
+	}
+	return regressor.inference, nil
+}
+
+// interceptVariance is the variance of the intercept, the mean target less
+// the slopes' contribution at the mean features, which are uncorrelated.
+func (inf *inference) interceptVariance() float64 {
+	return inf.residualVariance * (1/inf.numObservations + quadraticForm(inf.inverseGram, inf.featureMeans))
+}
+
+func quadraticForm(a [][]float64, x []float64) float64 {
+	result := 0.0
+	for i, ax := range matrixutilities.MultiplyVector(a, x) {
+		result = result + x[i]*ax
+	}
+	return result
+}
+
+// String formats the summary as a table.
+func (s Summary) String() string {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "Observations: %g, residual degrees of freedom: %g\n", s.NumObservations, s.DegreesOfFreedom)
+	fmt.Fprintf(&buffer, "Residual standard error: %.6g\n", s.ResidualStandardError)
+	fmt.Fprintf(&buffer, "R-squared: %.6g, adjusted R-squared: %.6g\n", s.RSquared, s.AdjustedRSquared)
+	fmt.Fprintf(
+		&buffer,
+		"F statistic: %.6g on %d and %g degrees of freedom, p-value: %.4g\n",
+		s.FStatistic,
+		len(s.Coefficients)-1,
+		s.DegreesOfFreedom,
+		s.FPValue,
+	)
+	fmt.Fprintf(&buffer, "Log-likelihood: %.6g, AIC: %.6g, BIC: %.6g\n\n", s.LogLikelihood, s.AIC, s.BIC)
+
+	tail := 100 * (1 - s.ConfidenceLevel) / 2
+	table := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(table, "\testimate\tstd. error\tt\tp-value\t%g%%\t%g%%\t\n", tail, 100-tail)
+	for _, c := range s.Coefficients {
+		fmt.Fprintf(
+			table,
+			"%s\t%.6g\t%.6g\t%.4g\t%.4g\t%.6g\t%.6g\t\n",
+			c.Name,
+			c.Estimate,
+			c.StandardError,
+			c.TStatistic,
+			c.PValue,
+			c.Lower,
+			c.Upper,
+		)
+	}
+	table.Flush()
+
+	return buffer.String()
+}
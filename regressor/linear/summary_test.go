@@ -0,0 +1,158 @@
+package linear_test
+
+import (
+	"math"
+	"strings"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/linearerrors"
+	"github.com/amitkgupta/goodlearn/regressor/linear"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Summary", func() {
+	var trainingData dataset.Dataset
+
+	// the targets 2, 4, 5, 4, 5 at 1, 2, 3, 4, 5 are fit by 2.2 + 0.6 x, with
+	// residual sum of squares 2.4 and total sum of squares 6
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		trainingData = dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for _, values := range [][]string{{"1", "2"}, {"2", "4"}, {"3", "5"}, {"4", "4"}, {"5", "5"}} {
+			Ω(trainingData.AddRowFromStrings(values)).Should(Succeed())
+		}
+	})
+
+	It("Summarizes a simple regression", func() {
+		r, err := linear.NewLinearRegressor(linear.FeatureNames("x"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(trainingData)).Should(Succeed())
+
+		summary, err := r.Summary()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(summary.NumObservations).Should(Equal(5.0))
+		Ω(summary.DegreesOfFreedom).Should(Equal(3.0))
+		Ω(summary.ResidualStandardError).Should(BeNumerically("~", math.Sqrt(0.8), 1e-12))
+		Ω(summary.RSquared).Should(BeNumerically("~", 0.6, 1e-12))
+		Ω(summary.AdjustedRSquared).Should(BeNumerically("~", 1-0.4*4/3, 1e-12))
+		Ω(summary.FStatistic).Should(BeNumerically("~", 4.5, 1e-9))
+
+		slope, intercept := summary.Coefficients[0], summary.Coefficients[1]
+		Ω(slope.Name).Should(Equal("x"))
+		Ω(slope.Estimate).Should(BeNumerically("~", 0.6, 1e-12))
+		Ω(slope.StandardError).Should(BeNumerically("~", math.Sqrt(0.08), 1e-12))
+		Ω(slope.TStatistic).Should(BeNumerically("~", 0.6/math.Sqrt(0.08), 1e-9))
+		Ω(slope.Lower).Should(BeNumerically("~", 0.6-3.182446305284*math.Sqrt(0.08), 1e-9))
+		Ω(slope.Upper).Should(BeNumerically("~", 0.6+3.182446305284*math.Sqrt(0.08), 1e-9))
+
+		// with one feature, the F test is the slope's t test
+		Ω(slope.PValue).Should(BeNumerically("~", summary.FPValue, 1e-9))
+		Ω(slope.PValue).Should(BeNumerically("~", 0.1240, 1e-4))
+
+		Ω(intercept.Name).Should(Equal("(intercept)"))
+		Ω(intercept.Estimate).Should(BeNumerically("~", 2.2, 1e-12))
+		Ω(intercept.StandardError).Should(BeNumerically("~", math.Sqrt(0.88), 1e-12))
+
+		logLikelihood := -2.5 * (math.Log(2*math.Pi) + math.Log(0.48) + 1)
+		Ω(summary.LogLikelihood).Should(BeNumerically("~", logLikelihood, 1e-9))
+		Ω(summary.AIC).Should(BeNumerically("~", 4-2*logLikelihood, 1e-9))
+		Ω(summary.BIC).Should(BeNumerically("~", 2*math.Log(5)-2*logLikelihood, 1e-9))
+	})
+
+	It("Formats the summary as a table", func() {
+		r, err := linear.NewLinearRegressor(linear.FeatureNames("x"), linear.ConfidenceLevel(0.9))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(trainingData)).Should(Succeed())
+
+		summary, err := r.Summary()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		table := summary.String()
+		Ω(table).Should(ContainSubstring("R-squared: 0.6"))
+		Ω(table).Should(ContainSubstring("5%"))
+		Ω(table).Should(ContainSubstring("95%"))
+
+		lines := strings.Split(strings.TrimSpace(table), "\n")
+		Ω(strings.TrimSpace(lines[len(lines)-2])).Should(HavePrefix("x"))
+		Ω(strings.TrimSpace(lines[len(lines)-1])).Should(HavePrefix("(intercept)"))
+	})
+
+	It("Treats rows' weights as frequencies", func() {
+		weighted, err := dataset.NewWeightedDataset(trainingData, []float64{2, 1, 1, 1, 1})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		duplicated := dataset.NewSubset(trainingData, []int{0, 0, 1, 2, 3, 4})
+
+		r, err := linear.NewLinearRegressor()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(r.Train(weighted)).Should(Succeed())
+		weightedSummary, err := r.Summary()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(r.Train(duplicated)).Should(Succeed())
+		duplicatedSummary, err := r.Summary()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(weightedSummary.NumObservations).Should(Equal(6.0))
+		Ω(weightedSummary.RSquared).Should(BeNumerically("~", duplicatedSummary.RSquared, 1e-12))
+		for j, c := range weightedSummary.Coefficients {
+			Ω(c.StandardError).Should(BeNumerically("~", duplicatedSummary.Coefficients[j].StandardError, 1e-12))
+		}
+	})
+
+	It("Needs a trained regressor, more observations than coefficients and independent features", func() {
+		r, err := linear.NewLinearRegressor()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = r.Summary()
+		Ω(err).Should(BeAssignableToTypeOf(linearerrors.UntrainedRegressorError{}))
+
+		Ω(r.Train(dataset.NewSubset(trainingData, []int{0, 1}))).Should(Succeed())
+		_, err = r.Summary()
+		Ω(err).Should(BeAssignableToTypeOf(linearerrors.TooFewDegreesOfFreedomError{}))
+
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		collinear := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+		for _, values := range [][]string{{"1", "2", "1"}, {"2", "4", "3"}, {"3", "6", "2"}, {"4", "8", "5"}} {
+			Ω(collinear.AddRowFromStrings(values)).Should(Succeed())
+		}
+
+		Ω(r.Train(collinear)).Should(Succeed())
+		_, err = r.Summary()
+		Ω(err).Should(BeAssignableToTypeOf(linearerrors.RankDeficientError{}))
+	})
+
+	It("Rejects confidence levels outside (0, 1)", func() {
+		_, err := linear.NewLinearRegressor(linear.ConfidenceLevel(1))
+		Ω(err).Should(BeAssignableToTypeOf(linearerrors.InvalidConfidenceLevelError{}))
+	})
+
+	Describe("PredictionInterval", func() {
+		It("Widens the prediction's confidence interval by the residual variance", func() {
+			r, err := linear.NewLinearRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(trainingData)).Should(Succeed())
+
+			prediction, lower, upper, err := r.PredictionInterval(row.NewRow(slice.NewFloatSlice([]float64{3}), nil, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 4, 1e-12))
+			Ω(upper - prediction).Should(BeNumerically("~", 3.182446305284*math.Sqrt(0.96), 1e-9))
+			Ω(prediction - lower).Should(BeNumerically("~", upper-prediction, 1e-12))
+
+			_, lowerFarther, upperFarther, err := r.PredictionInterval(row.NewRow(slice.NewFloatSlice([]float64{10}), nil, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(upperFarther - lowerFarther).Should(BeNumerically(">", upper-lower))
+		})
+	})
+})
@@ -1 +1,380 @@
 package logistic
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/classifierutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/logisticerrors"
+	"github.com/amitkgupta/goodlearn/parameterestimator/gradientdescentestimator"
+)
+
+const (
+	defaultLearningRate  = 0.5
+	defaultPrecision     = 1e-6
+	defaultMaxIterations = 100000
+)
+
+type Option func(*logisticRegression)
+
+func LearningRate(learningRate float64) Option {
+	return func(lr *logisticRegression) {
+		lr.learningRate = learningRate
+	}
+}
+
+func Precision(precision float64) Option {
+	return func(lr *logisticRegression) {
+		lr.precision = precision
+	}
+}
+
+func MaxIterations(maxIterations int) Option {
+	return func(lr *logisticRegression) {
+		lr.maxIterations = maxIterations
+	}
+}
+
+// L1Penalty adds penalty * |w| to the objective for each non-intercept
+// coefficient w.  It is applied by soft-thresholding after each gradient
+// step, so coefficients of features which do not pay for their penalty are
+// set to exactly zero.
+func L1Penalty(penalty float64) Option {
+	return func(lr *logisticRegression) {
+		lr.l1Penalty = penalty
+	}
+}
+
+// L2Penalty adds penalty * w * w / 2 to the objective for each non-intercept
+// coefficient w.
+func L2Penalty(penalty float64) Option {
+	return func(lr *logisticRegression) {
+		lr.l2Penalty = penalty
+	}
+}
+
+// ClassWeights scales the loss of each training row by the weight given for
+// its target; targets not listed have weight 1.
+func ClassWeights(targets []slice.Slice, weights []float64) Option {
+	return func(lr *logisticRegression) {
+		lr.classWeightTargets = targets
+		lr.classWeights = weights
+	}
+}
+
+// BalancedClassWeights weights each target inversely to its frequency in the
+// training data, so that every target contributes equally to the loss.
+func BalancedClassWeights() Option {
+	return func(lr *logisticRegression) {
+		lr.balancedClassWeights = true
+	}
+}
+
+// NewLogisticRegression returns a model which is both a classifier.Classifier
+// with class probabilities and, for two targets, a regressor.Regressor
+// predicting the log-odds of the second target seen in training against the
+// first.  With more than two targets a multinomial (softmax) model is fit.
+//
+// Coefficients are fit by proximal gradient descent on the mean
+// (class-weighted) negative log-likelihood plus any penalties, with each row's
+// likelihood also weighted by its weight if the training data is a
+// dataset.WeightedDataset.
+func NewLogisticRegression(options ...Option) (*logisticRegression, error) {
+	lr := &logisticRegression{
+		learningRate:  defaultLearningRate,
+		precision:     defaultPrecision,
+		maxIterations: defaultMaxIterations,
+	}
+
+	for _, option := range options {
+		option(lr)
+	}
+
+	if lr.l1Penalty < 0 {
+		return nil, logisticerrors.NewInvalidHyperparameterError("L1 penalty", lr.l1Penalty)
+	}
+
+	if lr.l2Penalty < 0 {
+		return nil, logisticerrors.NewInvalidHyperparameterError("L2 penalty", lr.l2Penalty)
+	}
+
+	if len(lr.classWeightTargets) != len(lr.classWeights) {
+		return nil, logisticerrors.NewClassWeightsLengthMismatchError(len(lr.classWeightTargets), len(lr.classWeights))
+	}
+
+	for _, w := range lr.classWeights {
+		if w < 0 {
+			return nil, logisticerrors.NewInvalidHyperparameterError("class weight", w)
+		}
+	}
+
+	if !(lr.learningRate > 0) {
+		return nil, logisticerrors.NewInvalidHyperparameterError("learning rate", lr.learningRate)
+	}
+
+	if !(lr.precision > 0) {
+		return nil, logisticerrors.NewInvalidHyperparameterError("precision", lr.precision)
+	}
+
+	if lr.maxIterations < 1 {
+		return nil, logisticerrors.NewInvalidHyperparameterError("maximum iterations", float64(lr.maxIterations))
+	}
+
+	return lr, nil
+}
+
+type logisticRegression struct {
+	learningRate         float64
+	precision            float64
+	maxIterations        int
+	l1Penalty            float64
+	l2Penalty            float64
+	classWeightTargets   []slice.Slice
+	classWeights         []float64
+	balancedClassWeights bool
+
+	targets      []slice.Slice
+	numFeatures  int
+	coefficients []float64
+}
+
+func (lr *logisticRegression) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return logisticerrors.NewNonFloatFeaturesError()
+	}
+
+	numFeatures := trainingData.NumFeatures()
+	if numFeatures == 0 {
+		return logisticerrors.NewNoFeaturesError()
+	}
+
+	targets, err := classifierutilities.DistinctTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	numTargets := len(targets)
+	if numTargets < 2 {
+		return logisticerrors.NewTooFewTargetsError(numTargets)
+	}
+
+	numRows := trainingData.NumRows()
+	encodedRows := make([]row.Row, numRows)
+	counts := make([]float64, numTargets)
+	rowWeights := dataset.Weights(trainingData)
+
+	for i := range encodedRows {
+		r, err := trainingData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		k := classifierutilities.TargetIndex(targets, r.Target())
+		counts[k] = counts[k] + rowWeights[i]
+		encodedRows[i] = row.NewRow(r.Features(), slice.NewFloatSlice([]float64{float64(k)}), numFeatures)
+	}
+
+	lossGradient := gradientdescentestimator.LogisticLossGradient
+	numParameters := numFeatures + 1
+	if numTargets > 2 {
+		lossGradient = gradientdescentestimator.MultinomialLogisticLossGradient(numTargets)
+		numParameters = numTargets * (numFeatures + 1)
+	}
+
+	totalWeight := 0.0
+	for _, c := range counts {
+		totalWeight = totalWeight + c
+	}
+
+	estimator, err := gradientdescentestimator.NewProximalGradientDescentParameterEstimator(
+		lr.learningRate,
+		lr.precision,
+		lr.maxIterations,
+		lr.penalizedLossGradient(lossGradient, lr.rowWeights(targets, counts), numFeatures, numRows),
+		lr.l1Proximal(numFeatures, totalWeight/float64(numRows)),
+	)
+	if err != nil {
+		return logisticerrors.NewEstimatorConstructionError(err)
+	}
+
+	encoded, err := dataset.NewWeightedDataset(
+		dataset.NewDatasetFromRows(numFeatures, 1, encodedRows),
+		rowWeights,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = estimator.Train(encoded)
+	if err != nil {
+		return logisticerrors.NewEstimatorTrainingError(err)
+	}
+
+	coefficients, err := estimator.Estimate(make([]float64, numParameters))
+	if err != nil {
+		return logisticerrors.NewEstimatorEstimationError(err)
+	}
+
+	lr.targets = targets
+	lr.numFeatures = numFeatures
+	lr.coefficients = coefficients
+	return nil
+}
+
+func (lr *logisticRegression) Classify(testRow row.Row) (slice.Slice, error) {
+	targets, probabilities, err := lr.ClassProbabilities(testRow)
+	if err != nil {
+		return nil, err
+	}
+
+	best := 0
+	for k, p := range probabilities {
+		if p > probabilities[best] {
+			best = k
+		}
+	}
+
+	return targets[best], nil
+}
+
+func (lr *logisticRegression) ClassProbabilities(testRow row.Row) ([]slice.Slice, []float64, error) {
+	x, err := lr.testFeatureValues(testRow)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(lr.targets) == 2 {
+		p := 1 / (1 + math.Exp(-lr.logOdds(x)))
+		return lr.targets, []float64{1 - p, p}, nil
+	}
+
+	return lr.targets, gradientdescentestimator.MultinomialProbabilities(lr.coefficients, x, len(lr.targets)), nil
+}
+
+// Predict returns the log-odds of the second training target against the
+// first; it is only defined for models trained on exactly two targets.
+func (lr *logisticRegression) Predict(testRow row.Row) (float64, error) {
+	x, err := lr.testFeatureValues(testRow)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(lr.targets) != 2 {
+		return 0, logisticerrors.NewNonBinaryLogOddsError(len(lr.targets))
+	}
+
+	return lr.logOdds(x), nil
+}
+
+func (lr *logisticRegression) logOdds(x []float64) float64 {
+	z := lr.coefficients[len(lr.coefficients)-1]
+	for i, v := range x {
+		z = z + lr.coefficients[i]*v
+	}
+	return z
+}
+
+func (lr *logisticRegression) testFeatureValues(testRow row.Row) ([]float64, error) {
+	if lr.coefficients == nil {
+		return nil, logisticerrors.NewUntrainedModelError()
+	}
+
+	if testRow.NumFeatures() != lr.numFeatures {
+		return nil, logisticerrors.NewRowLengthMismatchError(testRow.NumFeatures(), lr.numFeatures)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return nil, logisticerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return testFeatures.Values(), nil
+}
+
+func (lr *logisticRegression) rowWeights(targets []slice.Slice, counts []float64) []float64 {
+	weights := make([]float64, len(targets))
+	total := 0.0
+	for _, c := range counts {
+		total = total + c
+	}
+
+	for k, target := range targets {
+		weights[k] = 1
+		if lr.balancedClassWeights {
+			weights[k] = total / (float64(len(targets)) * counts[k])
+		}
+
+		if j := classifierutilities.TargetIndex(lr.classWeightTargets, target); j >= 0 {
+			weights[k] = weights[k] * lr.classWeights[j]
+		}
+	}
+
+	return weights
+}
+
+// penalizedLossGradient scales each row's loss gradient by its class weight
+// over the number of rows, and adds a numRows-th share of the L2 penalty
+// gradient, so that the estimator's sum over rows is the gradient of the mean
+// weighted loss plus the full L2 penalty.  Intercepts are not penalized.
+func (lr *logisticRegression) penalizedLossGradient(
+	lossGradient gradientdescentestimator.ParameterizedLossGradient,
+	classWeights []float64,
+	numFeatures, numRows int,
+) gradientdescentestimator.ParameterizedLossGradient {
+	n := float64(numRows)
+	blockSize := numFeatures + 1
+
+	return func(parameters, x []float64, y float64) ([]float64, error) {
+		gradient, err := lossGradient(parameters, x, y)
+		if err != nil {
+			return nil, err
+		}
+
+		w := classWeights[int(y)]
+		for i, p := range parameters {
+			gradient[i] = gradient[i] * w / n
+
+			if i%blockSize != blockSize-1 {
+				gradient[i] = gradient[i] + lr.l2Penalty*p/n
+			}
+		}
+
+		return gradient, nil
+	}
+}
+
+// l1Proximal soft-thresholds the non-intercept coefficients after each
+// gradient step, the proximal operator of the L1 penalty.  The penalty is
+// scaled as the L2 penalty's row shares are by the estimator's row weights,
+// by the mean row weight.  It returns nil if there is no L1 penalty.
+func (lr *logisticRegression) l1Proximal(numFeatures int, meanRowWeight float64) gradientdescentestimator.ProximalOperator {
+	if lr.l1Penalty == 0 {
+		return nil
+	}
+
+	blockSize := numFeatures + 1
+
+	return func(parameters []float64, learningRate float64) []float64 {
+		gamma := learningRate * lr.l1Penalty * meanRowWeight
+		for i, p := range parameters {
+			if i%blockSize != blockSize-1 {
+				parameters[i] = softThreshold(p, gamma)
+			}
+		}
+		return parameters
+	}
+}
+
+// softThreshold shrinks x towards zero by gamma, to exactly zero if |x| is
+// at most gamma.
+func softThreshold(x, gamma float64) float64 {
+	if x > gamma {
+		return x - gamma
+	}
+	if x < -gamma {
+		return x + gamma
+	}
+	return 0
+}
@@ -1 +1,276 @@
 package logistic_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/classifier"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/logisticerrors"
+	"github.com/amitkgupta/goodlearn/regressor"
+	"github.com/amitkgupta/goodlearn/regressor/logistic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogisticRegression", func() {
+	var binaryData, multiclassData dataset.Dataset
+
+	testRowAt := func(x ...float64) row.Row {
+		return row.NewRow(slice.NewFloatSlice(x), nil, len(x))
+	}
+
+	targetOfRow := func(ds dataset.Dataset, i int) slice.Slice {
+		r, err := ds.Row(i)
+		Ω(err).ShouldNot(HaveOccurred())
+		return r.Target()
+	}
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		// P(churn | x) = sigmoid(3x - 1)
+		random := rand.New(rand.NewSource(1))
+		binaryData = dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		for i := 0; i < 1000; i++ {
+			x := 4*random.Float64() - 2
+			label := "stay"
+			if i == 0 || (i > 1 && random.Float64() < 1/(1+math.Exp(-(3*x-1)))) {
+				label = "churn"
+			}
+
+			err = binaryData.AddRowFromStrings([]string{label, fmt.Sprintf("%.6f", x)})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+
+		columnTypes, err = columntype.StringsToColumnTypes([]string{"x", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		multiclassData = dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+		centres := map[string][]float64{"red": {0, 0}, "green": {3, 0}, "blue": {0, 3}}
+		for i := 0; i < 90; i++ {
+			for label, centre := range centres {
+				err = multiclassData.AddRowFromStrings([]string{
+					label,
+					fmt.Sprintf("%.6f", centre[0]+0.5*random.NormFloat64()),
+					fmt.Sprintf("%.6f", centre[1]+0.5*random.NormFloat64()),
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+		}
+	})
+
+	Describe("NewLogisticRegression", func() {
+		It("Rejects negative penalties", func() {
+			_, err := logistic.NewLogisticRegression(logistic.L1Penalty(-1))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.InvalidHyperparameterError{}))
+
+			_, err = logistic.NewLogisticRegression(logistic.L2Penalty(-1))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.InvalidHyperparameterError{}))
+		})
+
+		It("Rejects invalid gradient descent settings", func() {
+			_, err := logistic.NewLogisticRegression(logistic.LearningRate(0))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.InvalidHyperparameterError{}))
+
+			_, err = logistic.NewLogisticRegression(logistic.Precision(-1))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.InvalidHyperparameterError{}))
+
+			_, err = logistic.NewLogisticRegression(logistic.MaxIterations(0))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.InvalidHyperparameterError{}))
+		})
+
+		It("Rejects mismatched class weights", func() {
+			_, err := logistic.NewLogisticRegression(logistic.ClassWeights([]slice.Slice{nil}, []float64{1, 2}))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.ClassWeightsLengthMismatchError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Requires at least two targets", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			err = ds.AddRowFromStrings([]string{"only", "1"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			lr, err := logistic.NewLogisticRegression()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(lr.Train(ds)).Should(BeAssignableToTypeOf(logisticerrors.TooFewTargetsError{}))
+		})
+
+		It("Requires float features", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "y"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			lr, err := logistic.NewLogisticRegression()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(lr.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))).Should(
+				BeAssignableToTypeOf(logisticerrors.NonFloatFeaturesTrainingSetError{}),
+			)
+		})
+	})
+
+	Describe("Binary classification", func() {
+		var lr interface {
+			classifier.ProbabilisticClassifier
+			regressor.Regressor
+		}
+
+		BeforeEach(func() {
+			model, err := logistic.NewLogisticRegression()
+			Ω(err).ShouldNot(HaveOccurred())
+			lr = model
+		})
+
+		It("Returns errors before training", func() {
+			_, err := lr.Classify(testRowAt(0))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.UntrainedModelError{}))
+
+			_, err = lr.Predict(testRowAt(0))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.UntrainedModelError{}))
+		})
+
+		It("Predicts the log-odds of the second target", func() {
+			Ω(lr.Train(binaryData)).Should(Succeed())
+
+			for _, x := range []float64{-1, 0, 1} {
+				logOdds, err := lr.Predict(testRowAt(x))
+				Ω(err).ShouldNot(HaveOccurred())
+				// the second target seen is "stay", whose log-odds are 1 - 3x
+				Ω(logOdds).Should(BeNumerically("~", 1-3*x, 0.6))
+			}
+		})
+
+		It("Returns calibrated probabilities and classifies by the most probable target", func() {
+			Ω(lr.Train(binaryData)).Should(Succeed())
+
+			targets, probabilities, err := lr.ClassProbabilities(testRowAt(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(targets[0].Equals(targetOfRow(binaryData, 0))).Should(BeTrue())
+			Ω(probabilities[0]).Should(BeNumerically("~", 1/(1+math.Exp(-2)), 0.07))
+
+			target, err := lr.Classify(testRowAt(1.5))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(target.Equals(targetOfRow(binaryData, 0))).Should(BeTrue())
+		})
+
+		It("Rejects rows of the wrong length", func() {
+			Ω(lr.Train(binaryData)).Should(Succeed())
+
+			_, err := lr.Classify(testRowAt(1, 2))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.RowLengthMismatchError{}))
+		})
+
+		It("Shrinks coefficients under an L2 penalty", func() {
+			Ω(lr.Train(binaryData)).Should(Succeed())
+			unpenalized, err := lr.Predict(testRowAt(2))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			penalized, err := logistic.NewLogisticRegression(logistic.L2Penalty(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(penalized.Train(binaryData)).Should(Succeed())
+
+			shrunk, err := penalized.Predict(testRowAt(2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(math.Abs(shrunk)).Should(BeNumerically("<", math.Abs(unpenalized)))
+		})
+
+		It("Sets the coefficient of an irrelevant feature to exactly zero under a strong L1 penalty", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// the second feature is noise, independent of the target
+			random := rand.New(rand.NewSource(2))
+			ds := dataset.NewDataset([]int{1, 2}, []int{0}, columnTypes)
+			for i := 0; i < 400; i++ {
+				x := 4*random.Float64() - 2
+				label := "stay"
+				if random.Float64() < 1/(1+math.Exp(-(3*x-1))) {
+					label = "churn"
+				}
+
+				err = ds.AddRowFromStrings([]string{
+					label,
+					fmt.Sprintf("%.6f", x),
+					fmt.Sprintf("%.6f", random.NormFloat64()),
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			penalized, err := logistic.NewLogisticRegression(logistic.L1Penalty(0.05))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(penalized.Train(ds)).Should(Succeed())
+
+			atOrigin, err := penalized.Predict(testRowAt(0, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			for _, noise := range []float64{-2, 1, 3} {
+				logOdds, err := penalized.Predict(testRowAt(0, noise))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(logOdds).Should(Equal(atOrigin))
+			}
+
+			relevant, err := penalized.Predict(testRowAt(1, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(relevant).ShouldNot(Equal(atOrigin))
+		})
+
+		It("Shifts probabilities towards up-weighted targets", func() {
+			Ω(lr.Train(binaryData)).Should(Succeed())
+			_, unweighted, err := lr.ClassProbabilities(testRowAt(0))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			weighted, err := logistic.NewLogisticRegression(
+				logistic.ClassWeights([]slice.Slice{targetOfRow(binaryData, 0)}, []float64{5}),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(weighted.Train(binaryData)).Should(Succeed())
+
+			_, reweighted, err := weighted.ClassProbabilities(testRowAt(0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reweighted[0]).Should(BeNumerically(">", unweighted[0]+0.1))
+		})
+	})
+
+	Describe("Multinomial classification", func() {
+		It("Classifies amongst several targets", func() {
+			lr, err := logistic.NewLogisticRegression(logistic.BalancedClassWeights())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(lr.Train(multiclassData)).Should(Succeed())
+
+			correct := 0
+			for i := 0; i < multiclassData.NumRows(); i++ {
+				r, err := multiclassData.Row(i)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				target, err := lr.Classify(r)
+				Ω(err).ShouldNot(HaveOccurred())
+				if target.Equals(r.Target()) {
+					correct++
+				}
+			}
+			Ω(correct).Should(BeNumerically(">", 0.95*float64(multiclassData.NumRows())))
+
+			_, probabilities, err := lr.ClassProbabilities(testRowAt(0, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(probabilities).Should(HaveLen(3))
+		})
+
+		It("Does not define log-odds", func() {
+			lr, err := logistic.NewLogisticRegression()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(lr.Train(multiclassData)).Should(Succeed())
+
+			_, err = lr.Predict(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(logisticerrors.NonBinaryLogOddsError{}))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+package mlp
+
+import (
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/mlperrors"
+	"github.com/amitkgupta/goodlearn/neuralnetwork"
+)
+
+// NewMLPRegressor trains a feed-forward network with a single linear output
+// unit on the squared error.  If validationData is not nil its loss is
+// tracked every epoch, and used for early stopping when params.Patience is
+// positive.
+func NewMLPRegressor(
+	params neuralnetwork.Parameters,
+	validationData dataset.Dataset,
+	source rand.Source,
+) (*mlpRegressor, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &mlpRegressor{
+		params:         params,
+		validationData: validationData,
+		source:         source,
+	}, nil
+}
+
+type mlpRegressor struct {
+	params         neuralnetwork.Parameters
+	validationData dataset.Dataset
+	source         rand.Source
+	network        *neuralnetwork.Network
+}
+
+// Network returns the trained network, which reports the per-epoch training
+// and validation losses.
+func (regressor *mlpRegressor) Network() *neuralnetwork.Network {
+	return regressor.network
+}
+
+func (regressor *mlpRegressor) Train(trainingData dataset.Dataset) error {
+	x, y, err := featuresAndTargets(trainingData)
+	if err != nil {
+		return err
+	}
+
+	var validationX, validationY [][]float64
+	if regressor.validationData != nil {
+		validationX, validationY, err = featuresAndTargets(regressor.validationData)
+		if err != nil {
+			return err
+		}
+	}
+
+	network, err := neuralnetwork.Train(
+		x,
+		y,
+		validationX,
+		validationY,
+		neuralnetwork.LinearOutput,
+		regressor.params,
+		regressor.source,
+	)
+	if err != nil {
+		return mlperrors.NewNetworkTrainingError(err)
+	}
+
+	regressor.network = network
+	return nil
+}
+
+func (regressor *mlpRegressor) Predict(testRow row.Row) (float64, error) {
+	network := regressor.network
+	if network == nil {
+		return 0, mlperrors.NewUntrainedRegressorError()
+	}
+
+	if testRow.NumFeatures() != network.NumInputs() {
+		return 0, mlperrors.NewRowLengthMismatchError(testRow.NumFeatures(), network.NumInputs())
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return 0, mlperrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	outputs, err := network.Outputs(testFeatures.Values())
+	if err != nil {
+		return 0, err
+	}
+
+	return outputs[0], nil
+}
+
+func featuresAndTargets(ds dataset.Dataset) ([][]float64, [][]float64, error) {
+	if !ds.AllFeaturesFloats() {
+		return nil, nil, mlperrors.NewNonFloatFeaturesError()
+	}
+
+	if !ds.AllTargetsFloats() {
+		return nil, nil, mlperrors.NewNonFloatTargetsError()
+	}
+
+	if ds.NumTargets() != 1 {
+		return nil, nil, mlperrors.NewInvalidNumberOfTargetsError(ds.NumTargets())
+	}
+
+	x := make([][]float64, ds.NumRows())
+	y := make([][]float64, ds.NumRows())
+	for i := range x {
+		r, err := ds.Row(i)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		x[i] = r.Features().(slice.FloatSlice).Values()
+		y[i] = r.Target().(slice.FloatSlice).Values()
+	}
+
+	return x, y, nil
+}
@@ -0,0 +1,13 @@
+package mlp_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMlp(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mlp Suite")
+}
@@ -0,0 +1,112 @@
+package mlp_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/neuralnetworkerrors"
+	"github.com/amitkgupta/goodlearn/errors/regressor/mlperrors"
+	"github.com/amitkgupta/goodlearn/neuralnetwork"
+	"github.com/amitkgupta/goodlearn/regressor/mlp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MLPRegressor", func() {
+	var trainingData dataset.Dataset
+	var params neuralnetwork.Parameters
+
+	testRowAt := func(x float64) row.Row {
+		return row.NewRow(slice.NewFloatSlice([]float64{x}), nil, 1)
+	}
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		trainingData = dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		for i := 0; i <= 100; i++ {
+			x := float64(i)/50 - 1
+			err = trainingData.AddRowFromStrings([]string{fmt.Sprintf("%.6f", math.Sin(3*x)), fmt.Sprintf("%.6f", x)})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+
+		params = neuralnetwork.DefaultParameters()
+		params.HiddenLayers = []int{32}
+		params.Activation = neuralnetwork.Tanh
+		params.LearningRate = 0.05
+		params.BatchSize = 8
+		params.MaxEpochs = 500
+		params.WeightDecay = 0
+	})
+
+	It("Rejects invalid parameters", func() {
+		params.Dropout = -0.5
+		_, err := mlp.NewMLPRegressor(params, nil, rand.NewSource(1))
+		Ω(err).Should(BeAssignableToTypeOf(neuralnetworkerrors.InvalidParameterError{}))
+	})
+
+	It("Returns an error before training", func() {
+		regressor, err := mlp.NewMLPRegressor(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		_, err = regressor.Predict(testRowAt(0))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.UntrainedRegressorError{}))
+	})
+
+	It("Requires float targets", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		regressor, err := mlp.NewMLPRegressor(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = regressor.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.NonFloatTargetsTrainingSetError{}))
+	})
+
+	It("Requires a single target", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		regressor, err := mlp.NewMLPRegressor(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = regressor.Train(dataset.NewDataset([]int{2}, []int{0, 1}, columnTypes))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.InvalidNumberOfTargetsError{}))
+	})
+
+	It("Wraps network training errors", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		regressor, err := mlp.NewMLPRegressor(params, nil, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = regressor.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.NetworkTrainingError{}))
+	})
+
+	It("Fits a nonlinear function", func() {
+		regressor, err := mlp.NewMLPRegressor(params, trainingData, rand.NewSource(1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(regressor.Train(trainingData)).Should(Succeed())
+
+		for _, x := range []float64{-0.7, 0.1, 0.6} {
+			prediction, err := regressor.Predict(testRowAt(x))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", math.Sin(3*x), 0.1))
+		}
+
+		Ω(regressor.Network().ValidationLosses()).Should(HaveLen(params.MaxEpochs))
+
+		_, err = regressor.Predict(row.NewRow(slice.NewFloatSlice([]float64{0, 1}), nil, 2))
+		Ω(err).Should(BeAssignableToTypeOf(mlperrors.RowLengthMismatchError{}))
+	})
+})
@@ -9,3 +9,12 @@ type Regressor interface {
 	Train(dataset.Dataset) error
 	Predict(row.Row) (float64, error)
 }
+
+// OnlineRegressor is a Regressor which can also learn incrementally:
+// PartialFit updates the regressor with a batch of rows without forgetting
+// those it has already seen, and may be called before Train.  Train starts
+// afresh.
+type OnlineRegressor interface {
+	Regressor
+	PartialFit(dataset.Dataset) error
+}
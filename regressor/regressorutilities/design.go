@@ -0,0 +1,88 @@
+package regressorutilities
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/slice"
+)
+
+// Design is the features and targets of a dataset's rows of positive
+// weight, centred on their weighted means and scaled by the square roots of
+// the rows' weights, so that least squares without an intercept on them is
+// weighted least squares with an intercept.
+type Design struct {
+	X            [][]float64
+	Y            []float64
+	FeatureMeans []float64
+	TargetMean   float64
+	TotalWeight  float64
+}
+
+// CentredDesign returns the Design of a dataset with float features and a
+// single float target.  Its TotalWeight is 0, and it has no rows, if no row
+// has positive weight.
+func CentredDesign(ds dataset.Dataset) (Design, error) {
+	numFeatures := ds.NumFeatures()
+	rowWeights := dataset.Weights(ds)
+
+	x := [][]float64{}
+	y := []float64{}
+	weights := []float64{}
+	featureMeans := make([]float64, numFeatures)
+	targetMean := 0.0
+	totalWeight := 0.0
+
+	for i, weight := range rowWeights {
+		if weight == 0 {
+			continue
+		}
+
+		r, err := ds.Row(i)
+		if err != nil {
+			return Design{}, err
+		}
+
+		features := append([]float64(nil), r.Features().(slice.FloatSlice).Values()...)
+		target := r.Target().(slice.FloatSlice).Values()[0]
+
+		x = append(x, features)
+		y = append(y, target)
+		weights = append(weights, weight)
+
+		totalWeight = totalWeight + weight
+		for j, v := range features {
+			featureMeans[j] = featureMeans[j] + weight*v
+		}
+		targetMean = targetMean + weight*target
+	}
+
+	if totalWeight == 0 {
+		return Design{X: x, Y: y, FeatureMeans: featureMeans}, nil
+	}
+
+	for j := range featureMeans {
+		featureMeans[j] = featureMeans[j] / totalWeight
+	}
+	targetMean = targetMean / totalWeight
+
+	for i, features := range x {
+		scale := math.Sqrt(weights[i])
+		for j := range features {
+			features[j] = scale * (features[j] - featureMeans[j])
+		}
+		y[i] = scale * (y[i] - targetMean)
+	}
+
+	return Design{x, y, featureMeans, targetMean, totalWeight}, nil
+}
+
+// Intercept returns the intercept which, with the given coefficients fitted
+// to the centred design, predicts the mean target at the mean features.
+func (d Design) Intercept(coefficients []float64) float64 {
+	intercept := d.TargetMean
+	for j, c := range coefficients {
+		intercept = intercept - c*d.FeatureMeans[j]
+	}
+	return intercept
+}
@@ -0,0 +1,73 @@
+package regressorutilities_test
+
+import (
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Design", func() {
+	var ds dataset.Dataset
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds = dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+		for _, line := range [][]string{{"1", "10", "3"}, {"3", "20", "5"}, {"5", "60", "1"}} {
+			err = ds.AddRowFromStrings(line)
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	})
+
+	Describe("CentredDesign", func() {
+		It("Centres the features and target on their means", func() {
+			d, err := regressorutilities.CentredDesign(ds)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(d.TotalWeight).Should(Equal(3.0))
+			Ω(d.FeatureMeans).Should(Equal([]float64{3, 30}))
+			Ω(d.TargetMean).Should(Equal(3.0))
+			Ω(d.X).Should(Equal([][]float64{{-2, -20}, {0, -10}, {2, 30}}))
+			Ω(d.Y).Should(Equal([]float64{0, 2, -2}))
+		})
+
+		It("Weights the means, scales rows by the square roots of their weights and drops rows of zero weight", func() {
+			weighted, err := dataset.NewWeightedDataset(ds, []float64{4, 0, 1})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			d, err := regressorutilities.CentredDesign(weighted)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(d.TotalWeight).Should(Equal(5.0))
+			Ω(d.FeatureMeans).Should(Equal([]float64{1.8, 20}))
+			Ω(d.TargetMean).Should(BeNumerically("~", 2.6, 1e-12))
+			Ω(d.X).Should(HaveLen(2))
+			Ω(d.X[0][0]).Should(BeNumerically("~", 2*-0.8, 1e-12))
+			Ω(d.Y[1]).Should(BeNumerically("~", -1.6, 1e-12))
+			Ω(d.X[1][1]).Should(BeNumerically("~", 40, 1e-12))
+		})
+
+		It("Has no weight when no row has positive weight", func() {
+			weighted, err := dataset.NewWeightedDataset(ds, []float64{0, 0, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			d, err := regressorutilities.CentredDesign(weighted)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(d.TotalWeight).Should(BeZero())
+			Ω(d.X).Should(BeEmpty())
+		})
+	})
+
+	Describe("Intercept", func() {
+		It("Predicts the mean target at the mean features", func() {
+			d, err := regressorutilities.CentredDesign(ds)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(d.Intercept([]float64{0.5, 0.1})).Should(BeNumerically("~", 3-1.5-3, 1e-12))
+		})
+	})
+})
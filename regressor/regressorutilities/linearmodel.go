@@ -0,0 +1,56 @@
+package regressorutilities
+
+import (
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+)
+
+const maxMatrixEntries = 1 << 24
+
+// TooLargeForMatrix reports whether a dataset has too many feature values to
+// hold as a matrix for an exact solver, so that its Auto solver should fall
+// back to gradient descent.
+func TooLargeForMatrix(ds dataset.Dataset) bool {
+	return ds.NumRows()*ds.NumFeatures() > maxMatrixEntries
+}
+
+// NormalEquations returns X'X and X'y for the numFeatures columns of x.
+func NormalEquations(x [][]float64, y []float64, numFeatures int) ([][]float64, []float64) {
+	xtx := matrixutilities.Zeros(numFeatures, numFeatures)
+	xty := make([]float64, numFeatures)
+	for i, features := range x {
+		for j, v := range features {
+			xty[j] = xty[j] + v*y[i]
+			for k := 0; k <= j; k++ {
+				xtx[j][k] = xtx[j][k] + v*features[k]
+			}
+		}
+	}
+	for j := range xtx {
+		for k := 0; k < j; k++ {
+			xtx[k][j] = xtx[j][k]
+		}
+	}
+
+	return xtx, xty
+}
+
+// Negligible is the fraction of the largest singular value, or diagonal
+// entry of a triangular factor, below which one of an m by n matrix is
+// treated as zero in deciding its rank.
+func Negligible(m, n int) float64 {
+	if m < n {
+		m = n
+	}
+	return float64(m) * 2.220446049250313e-16
+}
+
+// Prediction is the intercept plus the dot product of the coefficients and
+// features of a linear model.
+func Prediction(coefficients []float64, intercept float64, features []float64) float64 {
+	result := intercept
+	for j, c := range coefficients {
+		result = result + c*features[j]
+	}
+	return result
+}
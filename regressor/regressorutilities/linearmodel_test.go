@@ -0,0 +1,57 @@
+package regressorutilities_test
+
+import (
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Linear model", func() {
+	Describe("TooLargeForMatrix", func() {
+		It("Is false for small datasets", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			Ω(ds.AddRowFromStrings([]string{"1", "2"})).Should(Succeed())
+			Ω(regressorutilities.TooLargeForMatrix(ds)).Should(BeFalse())
+		})
+	})
+
+	Describe("NormalEquations", func() {
+		It("Returns X'X and X'y", func() {
+			xtx, xty := regressorutilities.NormalEquations(
+				[][]float64{{1, 2}, {3, 4}, {5, 6}},
+				[]float64{1, 0, -1},
+				2,
+			)
+
+			Ω(xtx).Should(Equal([][]float64{{35, 44}, {44, 56}}))
+			Ω(xty).Should(Equal([]float64{-4, -4}))
+		})
+
+		It("Returns zeros for no rows", func() {
+			xtx, xty := regressorutilities.NormalEquations(nil, nil, 2)
+
+			Ω(xtx).Should(Equal([][]float64{{0, 0}, {0, 0}}))
+			Ω(xty).Should(Equal([]float64{0, 0}))
+		})
+	})
+
+	Describe("Negligible", func() {
+		It("Grows with the larger dimension of the matrix", func() {
+			Ω(regressorutilities.Negligible(10, 3)).Should(Equal(regressorutilities.Negligible(3, 10)))
+			Ω(regressorutilities.Negligible(20, 3)).Should(BeNumerically(">", regressorutilities.Negligible(10, 3)))
+			Ω(regressorutilities.Negligible(10, 3)).Should(BeNumerically("<", 1e-13))
+		})
+	})
+
+	Describe("Prediction", func() {
+		It("Adds the intercept to the dot product of coefficients and features", func() {
+			Ω(regressorutilities.Prediction([]float64{2, -1}, 0.5, []float64{3, 4})).Should(Equal(2.5))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package regressorutilities_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRegressorutilities(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Regressorutilities Suite")
+}
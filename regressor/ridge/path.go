@@ -0,0 +1,133 @@
+package ridge
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/errors/regressor/ridgeerrors"
+	"github.com/amitkgupta/goodlearn/matrixutilities"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+)
+
+var infinity = math.Inf(1)
+
+// PathPoint is the fit for one alpha of an AlphaPath.
+type PathPoint struct {
+	Alpha        float64
+	Coefficients []float64
+	Intercept    float64
+	// DegreesOfFreedom is the effective number of parameters, the trace of
+	// the matrix taking the targets to the fitted values, counting the
+	// intercept as one.
+	DegreesOfFreedom float64
+	// GCV is the generalized cross-validation error, the mean squared error
+	// over (1 - DegreesOfFreedom / n)^2 for n observations, an estimate of
+	// the leave-one-out error; +Inf if the fit has no residual degrees of
+	// freedom.
+	GCV float64
+}
+
+// spectrum is the singular value decomposition X = U S V' of a centred
+// design, from which the ridge fit for any alpha is cheap.
+type spectrum struct {
+	design         regressorutilities.Design
+	singularValues []float64
+	v              [][]float64
+	// uty is U'y, the targets in the basis of the left singular vectors.
+	uty []float64
+	// unexplained is the sum of squares of the targets outside the span of
+	// the left singular vectors, which no fit can reduce.
+	unexplained float64
+}
+
+// newSpectrum decomposes the centred design, keeping only the singular
+// values not negligible beside the largest, so that an alpha of 0 gives the
+// minimum norm least squares fit.
+func newSpectrum(d regressorutilities.Design) spectrum {
+	u, s, v := matrixutilities.SVD(d.X)
+
+	rank := 0
+	for rank < len(s) && s[rank] > regressorutilities.Negligible(len(d.X), len(d.FeatureMeans))*s[0] {
+		rank++
+	}
+
+	uty := make([]float64, rank)
+	for i, value := range d.Y {
+		for k := range uty {
+			uty[k] = uty[k] + u[i][k]*value
+		}
+	}
+
+	unexplained := 0.0
+	for _, value := range d.Y {
+		unexplained = unexplained + value*value
+	}
+	for _, c := range uty {
+		unexplained = unexplained - c*c
+	}
+
+	return spectrum{d, s[:rank], v, uty, math.Max(unexplained, 0)}
+}
+
+// fit returns the ridge fit for the given alpha: the coefficients are
+// V diag(s / (s^2 + alpha)) U'y and the residual sum of squares the
+// unexplained sum of squares plus, for each singular value, the share
+// alpha / (s^2 + alpha) of its component of the targets left unfitted.
+func (s spectrum) fit(alpha float64) PathPoint {
+	coefficients := make([]float64, len(s.design.FeatureMeans))
+	degreesOfFreedom := 1.0
+	residualSquares := s.unexplained
+
+	for k, sk := range s.singularValues {
+		shrinkage := sk * sk / (sk*sk + alpha)
+		degreesOfFreedom = degreesOfFreedom + shrinkage
+
+		unfitted := (1 - shrinkage) * s.uty[k]
+		residualSquares = residualSquares + unfitted*unfitted
+
+		for j := range coefficients {
+			coefficients[j] = coefficients[j] + s.v[j][k]*s.uty[k]*sk/(sk*sk+alpha)
+		}
+	}
+
+	n := s.design.TotalWeight
+	gcv := infinity
+	if degreesOfFreedom < n {
+		gcv = residualSquares / n / math.Pow(1-degreesOfFreedom/n, 2)
+	}
+
+	return PathPoint{
+		Alpha:            alpha,
+		Coefficients:     coefficients,
+		Intercept:        s.design.Intercept(coefficients),
+		DegreesOfFreedom: degreesOfFreedom,
+		GCV:              gcv,
+	}
+}
+
+// solveCholesky solves the penalized normal equations (X'X + alpha I) b = X'y,
+// which have a unique solution for any positive alpha, but are reported rank
+// deficient if alpha is too small beside X'X to make them well conditioned.
+func solveCholesky(x [][]float64, y []float64, numFeatures int, alpha float64) ([]float64, error) {
+	a, xty := regressorutilities.NormalEquations(x, y, numFeatures)
+	for j := range a {
+		a[j][j] = a[j][j] + alpha
+	}
+
+	largest := 0.0
+	for j := range a {
+		largest = math.Max(largest, a[j][j])
+	}
+
+	l, err := matrixutilities.Cholesky(a)
+	if err != nil {
+		return nil, ridgeerrors.NewRankDeficientError()
+	}
+
+	for j := range l {
+		if l[j][j]*l[j][j] <= regressorutilities.Negligible(len(x), numFeatures)*largest {
+			return nil, ridgeerrors.NewRankDeficientError()
+		}
+	}
+
+	return matrixutilities.CholeskySolve(l, xty), nil
+}
@@ -0,0 +1,113 @@
+package ridge_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/errors/regressor/ridgeerrors"
+	"github.com/amitkgupta/goodlearn/regressor/ridge"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AlphaPath", func() {
+	It("Chooses the alpha of least generalized cross-validation error", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for _, line := range [][]string{{"-1", "0"}, {"0", "1"}, {"1", "4"}} {
+			Ω(ds.AddRowFromStrings(line)).Should(Succeed())
+		}
+
+		r, err := ridge.NewRidgeRegressor(ridge.AlphaPath(2, 0, 100))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(ds)).Should(Succeed())
+
+		// the residual sums of squares are 2/3 + 8 (alpha / (2 + alpha))^2
+		// and the degrees of freedom 1 + 2 / (2 + alpha)
+		path := r.Path()
+		Ω(path).Should(HaveLen(3))
+		Ω(path[0].Alpha).Should(Equal(2.0))
+		Ω(path[0].DegreesOfFreedom).Should(BeNumerically("~", 1.5, 1e-12))
+		Ω(path[0].GCV).Should(BeNumerically("~", 32.0/9, 1e-9))
+		Ω(path[1].GCV).Should(BeNumerically("~", 2, 1e-9))
+		Ω(path[2].Coefficients[0]).Should(BeNumerically("~", 4.0/102, 1e-12))
+
+		Ω(r.Alpha()).Should(Equal(0.0))
+		Ω(r.Coefficients()[0]).Should(BeNumerically("~", 2, 1e-9))
+		Ω(r.Intercept()).Should(BeNumerically("~", 5.0/3, 1e-9))
+	})
+
+	It("Fits each alpha as a separate regressor would", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		random := rand.New(rand.NewSource(1))
+		ds := dataset.NewDataset([]int{0, 1, 2}, []int{3}, columnTypes)
+		for i := 0; i < 50; i++ {
+			x0 := random.NormFloat64()
+			x1 := x0 + 0.01*random.NormFloat64()
+			x2 := random.NormFloat64()
+			y := x0 + x1 - x2 + 0.5*random.NormFloat64()
+			Ω(ds.AddRowFromStrings([]string{
+				fmt.Sprintf("%g", x0),
+				fmt.Sprintf("%g", x1),
+				fmt.Sprintf("%g", x2),
+				fmt.Sprintf("%g", y),
+			})).Should(Succeed())
+		}
+
+		alphas := []float64{0.01, 0.1, 1, 10, 100}
+		r, err := ridge.NewRidgeRegressor(ridge.AlphaPath(alphas...))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(ds)).Should(Succeed())
+
+		path := r.Path()
+		bestGCV := math.Inf(1)
+		for i, alpha := range alphas {
+			single, err := ridge.NewRidgeRegressor(ridge.Alpha(alpha), ridge.Solving(ridge.Cholesky))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(single.Train(ds)).Should(Succeed())
+
+			for j, c := range single.Coefficients() {
+				Ω(path[i].Coefficients[j]).Should(BeNumerically("~", c, 1e-9))
+			}
+			Ω(path[i].Intercept).Should(BeNumerically("~", single.Intercept(), 1e-9))
+
+			if i > 0 {
+				Ω(path[i].DegreesOfFreedom).Should(BeNumerically("<", path[i-1].DegreesOfFreedom))
+			}
+			bestGCV = math.Min(bestGCV, path[i].GCV)
+		}
+
+		for _, point := range path {
+			if point.Alpha == r.Alpha() {
+				Ω(point.GCV).Should(Equal(bestGCV))
+			}
+		}
+	})
+
+	It("Needs more observations than every alpha's degrees of freedom", func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		ds := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+		for _, line := range [][]string{{"-1", "0"}, {"1", "4"}} {
+			Ω(ds.AddRowFromStrings(line)).Should(Succeed())
+		}
+
+		r, err := ridge.NewRidgeRegressor(ridge.AlphaPath(0))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(ds)).Should(BeAssignableToTypeOf(ridgeerrors.TooFewObservationsError{}))
+
+		r, err = ridge.NewRidgeRegressor(ridge.AlphaPath(0, 1))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(r.Train(ds)).Should(Succeed())
+		Ω(r.Alpha()).Should(Equal(1.0))
+	})
+})
@@ -0,0 +1,347 @@
+package ridge
+
+import (
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/ridgeerrors"
+	"github.com/amitkgupta/goodlearn/parameterestimator/gradientdescentestimator"
+	"github.com/amitkgupta/goodlearn/regressor/regressorutilities"
+)
+
+// Solver is how the ridge coefficients are found for a single alpha.
+type Solver int
+
+const (
+	// Auto uses Cholesky for a positive alpha, whose penalized normal
+	// equations are never singular, and SVD for an alpha of 0, which is
+	// ordinary least squares on possibly collinear features.  Datasets too
+	// large to hold as a matrix are fitted by GradientDescent instead.
+	Auto Solver = iota
+	// Cholesky solves (X'X + alpha I) b = X'y by Cholesky decomposition.
+	Cholesky
+	// SVD solves from the singular value decomposition of X, as for an
+	// AlphaPath of one alpha.
+	SVD
+	// GradientDescent minimizes the penalized sum of squared errors by
+	// gradient descent, with gradientdescentestimator's
+	// LinearModelRidgeLossGradient.
+	GradientDescent
+)
+
+const (
+	defaultAlpha = 1.0
+
+	defaultLearningRate  = 0.004
+	defaultPrecision     = 1e-8
+	defaultMaxIterations = 1e8
+)
+
+type Option func(*ridgeRegressor)
+
+// Alpha sets the weight of the penalty on the squared coefficients; 1 by
+// default.  An alpha of 0 is ordinary least squares.
+func Alpha(alpha float64) Option {
+	return func(regressor *ridgeRegressor) {
+		regressor.alpha = alpha
+	}
+}
+
+// AlphaPath has Train fit every given alpha, from a single singular value
+// decomposition of the features whatever the Solver, and keep the fit whose
+// alpha minimizes the generalized cross-validation error.  Path returns all
+// the fits.
+func AlphaPath(alphas ...float64) Option {
+	return func(regressor *ridgeRegressor) {
+		regressor.alphaPath = append([]float64{}, alphas...)
+	}
+}
+
+// Solving sets how the coefficients are found for a single alpha; Auto by
+// default.
+func Solving(solver Solver) Option {
+	return func(regressor *ridgeRegressor) {
+		regressor.solver = solver
+	}
+}
+
+// LearningRate sets the step size of gradient descent on the penalized loss,
+// whether it was asked for with Solving or chosen by Auto for a large
+// dataset; 0.004 by default.
+func LearningRate(learningRate float64) Option {
+	return func(regressor *ridgeRegressor) {
+		regressor.learningRate = learningRate
+	}
+}
+
+// Precision sets the step length below which gradient descent on the
+// penalized loss stops; 1e-8 by default.
+func Precision(precision float64) Option {
+	return func(regressor *ridgeRegressor) {
+		regressor.precision = precision
+	}
+}
+
+// MaxIterations bounds the number of gradient descent steps on the penalized
+// loss, after which Converged reports false; 1e8 by default.
+func MaxIterations(maxIterations int) Option {
+	return func(regressor *ridgeRegressor) {
+		regressor.maxIterations = maxIterations
+	}
+}
+
+// NewRidgeRegressor returns a least squares regressor with an intercept
+// which minimizes the sum of squared errors plus alpha times the sum of the
+// squared coefficients, shrinking the coefficients of collinear features
+// towards each other and zero.  The intercept is not penalized.  Each row's
+// squared error is weighted by its weight if the training data is a
+// dataset.WeightedDataset.  Features are not standardized, so the penalty
+// falls hardest on features of small scale.
+func NewRidgeRegressor(options ...Option) (*ridgeRegressor, error) {
+	regressor := &ridgeRegressor{
+		alpha:         defaultAlpha,
+		solver:        Auto,
+		learningRate:  defaultLearningRate,
+		precision:     defaultPrecision,
+		maxIterations: defaultMaxIterations,
+	}
+
+	for _, option := range options {
+		option(regressor)
+	}
+
+	if !(regressor.alpha >= 0) {
+		return nil, ridgeerrors.NewInvalidHyperparameterError("alpha", regressor.alpha)
+	}
+
+	if regressor.alphaPath != nil && len(regressor.alphaPath) == 0 {
+		return nil, ridgeerrors.NewEmptyAlphaPathError()
+	}
+
+	for _, alpha := range regressor.alphaPath {
+		if !(alpha >= 0) {
+			return nil, ridgeerrors.NewInvalidHyperparameterError("alpha", alpha)
+		}
+	}
+
+	if regressor.solver < Auto || regressor.solver > GradientDescent {
+		return nil, ridgeerrors.NewInvalidSolverError(int(regressor.solver))
+	}
+
+	if !(regressor.learningRate > 0) {
+		return nil, ridgeerrors.NewInvalidHyperparameterError("learning rate", regressor.learningRate)
+	}
+
+	if !(regressor.precision > 0) {
+		return nil, ridgeerrors.NewInvalidHyperparameterError("precision", regressor.precision)
+	}
+
+	if regressor.maxIterations < 1 {
+		return nil, ridgeerrors.NewInvalidHyperparameterError("maximum iterations", float64(regressor.maxIterations))
+	}
+
+	return regressor, nil
+}
+
+type ridgeRegressor struct {
+	alpha         float64
+	alphaPath     []float64
+	solver        Solver
+	learningRate  float64
+	precision     float64
+	maxIterations int
+
+	coefficients []float64
+	chosenAlpha  float64
+	path         []PathPoint
+	iterations   int
+	converged    bool
+}
+
+func (regressor *ridgeRegressor) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return ridgeerrors.NewNonFloatFeaturesError()
+	}
+
+	if !trainingData.AllTargetsFloats() {
+		return ridgeerrors.NewNonFloatTargetsError()
+	}
+
+	if trainingData.NumTargets() != 1 {
+		return ridgeerrors.NewInvalidNumberOfTargetsError(trainingData.NumTargets())
+	}
+
+	numFeatures := trainingData.NumFeatures()
+	if numFeatures == 0 {
+		return ridgeerrors.NewNoFeaturesError()
+	}
+
+	solver := regressor.solver
+	if solver == Auto && regressorutilities.TooLargeForMatrix(trainingData) {
+		solver = GradientDescent
+	}
+
+	if regressor.alphaPath == nil && solver == GradientDescent {
+		return regressor.estimateByGradientDescent(trainingData)
+	}
+
+	d, err := regressorutilities.CentredDesign(trainingData)
+	if err != nil {
+		return err
+	}
+
+	if d.TotalWeight == 0 {
+		return ridgeerrors.NewEmptyTrainingDatasetError()
+	}
+
+	if regressor.alphaPath != nil {
+		return regressor.fitAlphaPath(d)
+	}
+
+	var beta []float64
+	if solver == SVD || (solver == Auto && regressor.alpha == 0) {
+		beta = newSpectrum(d).fit(regressor.alpha).Coefficients
+	} else {
+		beta, err = solveCholesky(d.X, d.Y, numFeatures, regressor.alpha)
+		if err != nil {
+			return err
+		}
+	}
+
+	regressor.coefficients = append(beta, d.Intercept(beta))
+	regressor.chosenAlpha = regressor.alpha
+	regressor.path = nil
+	regressor.iterations = 0
+	regressor.converged = true
+	return nil
+}
+
+func (regressor *ridgeRegressor) fitAlphaPath(d regressorutilities.Design) error {
+	s := newSpectrum(d)
+
+	path := make([]PathPoint, len(regressor.alphaPath))
+	best := -1
+	for i, alpha := range regressor.alphaPath {
+		path[i] = s.fit(alpha)
+		if path[i].GCV < infinity && (best < 0 || path[i].GCV < path[best].GCV) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return ridgeerrors.NewTooFewObservationsError(d.TotalWeight)
+	}
+
+	regressor.coefficients = append(append([]float64{}, path[best].Coefficients...), path[best].Intercept)
+	regressor.chosenAlpha = path[best].Alpha
+	regressor.path = path
+	regressor.iterations = 0
+	regressor.converged = true
+	return nil
+}
+
+func (regressor *ridgeRegressor) estimateByGradientDescent(trainingData dataset.Dataset) error {
+	totalWeight := 0.0
+	for _, weight := range dataset.Weights(trainingData) {
+		totalWeight = totalWeight + weight
+	}
+
+	if totalWeight == 0 {
+		return ridgeerrors.NewEmptyTrainingDatasetError()
+	}
+
+	estimator, err := gradientdescentestimator.NewGradientDescentParameterEstimator(
+		regressor.learningRate,
+		regressor.precision,
+		regressor.maxIterations,
+		gradientdescentestimator.LinearModelRidgeLossGradient(regressor.alpha, totalWeight),
+	)
+	if err != nil {
+		return ridgeerrors.NewEstimatorConstructionError(err)
+	}
+
+	err = estimator.Train(trainingData)
+	if err != nil {
+		return ridgeerrors.NewEstimatorTrainingError(err)
+	}
+
+	coefficients, err := estimator.Estimate(make([]float64, trainingData.NumFeatures()+1))
+	if err != nil {
+		return ridgeerrors.NewEstimatorEstimationError(err)
+	}
+
+	regressor.coefficients = coefficients
+	regressor.chosenAlpha = regressor.alpha
+	regressor.path = nil
+	regressor.iterations = estimator.Iterations()
+	regressor.converged = estimator.Converged()
+	return nil
+}
+
+func (regressor *ridgeRegressor) Predict(testRow row.Row) (float64, error) {
+	coefficients := regressor.coefficients
+	if coefficients == nil {
+		return 0, ridgeerrors.NewUntrainedRegressorError()
+	}
+
+	numTestRowFeatures := testRow.NumFeatures()
+	numCoefficients := len(coefficients)
+	if numCoefficients != numTestRowFeatures+1 {
+		return 0, ridgeerrors.NewRowLengthMismatchError(numTestRowFeatures, numCoefficients-1)
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return 0, ridgeerrors.NewNonFloatFeaturesTestRowError()
+	}
+	return regressorutilities.Prediction(
+		coefficients[:numCoefficients-1],
+		coefficients[numCoefficients-1],
+		testFeatures.Values(),
+	), nil
+}
+
+// Coefficients returns the shrunken coefficient of each feature, in order,
+// for the alpha Alpha reports, or nil before training.
+func (regressor *ridgeRegressor) Coefficients() []float64 {
+	if regressor.coefficients == nil {
+		return nil
+	}
+
+	return append([]float64(nil), regressor.coefficients[:len(regressor.coefficients)-1]...)
+}
+
+// Intercept returns the intercept, which the penalty leaves unshrunk, or 0
+// before training.
+func (regressor *ridgeRegressor) Intercept() float64 {
+	if regressor.coefficients == nil {
+		return 0
+	}
+
+	return regressor.coefficients[len(regressor.coefficients)-1]
+}
+
+// Alpha returns the alpha of the fitted coefficients: the one chosen by
+// generalized cross-validation if an AlphaPath was given.
+func (regressor *ridgeRegressor) Alpha() float64 {
+	return regressor.chosenAlpha
+}
+
+// Path returns the fit for each alpha of the AlphaPath, in the order given,
+// or nil if none was given or before training.
+func (regressor *ridgeRegressor) Path() []PathPoint {
+	return regressor.path
+}
+
+// Converged reports whether gradient descent on the penalized loss reached
+// the precision within MaxIterations steps in the last training.  Fits by
+// Cholesky, SVD or an AlphaPath are exact, and always report true.
+func (regressor *ridgeRegressor) Converged() bool {
+	return regressor.converged
+}
+
+// Iterations returns the number of gradient descent steps the last training
+// took, which is 0 if the fit was exact.
+func (regressor *ridgeRegressor) Iterations() int {
+	return regressor.iterations
+}
@@ -0,0 +1,13 @@
+package ridge_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRidge(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ridge Suite")
+}
@@ -0,0 +1,175 @@
+package ridge_test
+
+import (
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/ridgeerrors"
+	"github.com/amitkgupta/goodlearn/regressor"
+	"github.com/amitkgupta/goodlearn/regressor/ridge"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ridge Regressor", func() {
+	// lineDataset has targets 0, 1, 4 at -1, 0, 1, and, if duplicated, a
+	// second feature equal to the first; the centred features have sum of
+	// squares 2 and cross product 4 with the target, whose mean is 5/3.
+	lineDataset := func(duplicated bool) dataset.Dataset {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		featureColumns := []int{0}
+		if duplicated {
+			featureColumns = append(featureColumns, 1)
+		}
+
+		ds := dataset.NewDataset(featureColumns, []int{2}, columnTypes)
+		for _, line := range [][]string{{"-1", "-1", "0"}, {"0", "0", "1"}, {"1", "1", "4"}} {
+			Ω(ds.AddRowFromStrings(line)).Should(Succeed())
+		}
+		return ds
+	}
+
+	Describe("NewRidgeRegressor", func() {
+		It("Returns a regressor", func() {
+			r, err := ridge.NewRidgeRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var _ regressor.Regressor = r
+		})
+
+		It("Rejects negative alphas", func() {
+			_, err := ridge.NewRidgeRegressor(ridge.Alpha(-1))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.InvalidHyperparameterError{}))
+
+			_, err = ridge.NewRidgeRegressor(ridge.AlphaPath(1, -1))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.InvalidHyperparameterError{}))
+		})
+
+		It("Rejects an empty alpha path", func() {
+			_, err := ridge.NewRidgeRegressor(ridge.AlphaPath())
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.EmptyAlphaPathError{}))
+		})
+
+		It("Rejects unknown solvers and invalid gradient descent settings", func() {
+			_, err := ridge.NewRidgeRegressor(ridge.Solving(ridge.Solver(9)))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.InvalidSolverError{}))
+
+			_, err = ridge.NewRidgeRegressor(ridge.LearningRate(0))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.InvalidHyperparameterError{}))
+
+			_, err = ridge.NewRidgeRegressor(ridge.Precision(-1))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.InvalidHyperparameterError{}))
+
+			_, err = ridge.NewRidgeRegressor(ridge.MaxIterations(0))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.InvalidHyperparameterError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Shrinks the slope but not the intercept, with every solver", func() {
+			for _, solver := range []ridge.Solver{ridge.Auto, ridge.Cholesky, ridge.SVD} {
+				r, err := ridge.NewRidgeRegressor(ridge.Alpha(2), ridge.Solving(solver))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(r.Train(lineDataset(false))).Should(Succeed())
+
+				Ω(r.Coefficients()[0]).Should(BeNumerically("~", 1, 1e-9))
+				Ω(r.Intercept()).Should(BeNumerically("~", 5.0/3, 1e-9))
+				Ω(r.Alpha()).Should(Equal(2.0))
+				Ω(r.Converged()).Should(BeTrue())
+			}
+		})
+
+		It("Agrees with the closed form by gradient descent", func() {
+			r, err := ridge.NewRidgeRegressor(
+				ridge.Alpha(2),
+				ridge.Solving(ridge.GradientDescent),
+				ridge.LearningRate(0.05),
+				ridge.Precision(1e-10),
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(lineDataset(false))).Should(Succeed())
+
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 1, 1e-6))
+			Ω(r.Intercept()).Should(BeNumerically("~", 5.0/3, 1e-6))
+			Ω(r.Converged()).Should(BeTrue())
+			Ω(r.Iterations()).Should(BeNumerically(">", 0))
+		})
+
+		It("Splits the effect of duplicated features evenly", func() {
+			r, err := ridge.NewRidgeRegressor(ridge.Alpha(2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(lineDataset(true))).Should(Succeed())
+
+			coefficients := r.Coefficients()
+			Ω(coefficients[0]).Should(BeNumerically("~", 2.0/3, 1e-9))
+			Ω(coefficients[1]).Should(BeNumerically("~", 2.0/3, 1e-9))
+		})
+
+		It("Falls back to the minimum norm least squares fit for collinear features without a penalty", func() {
+			r, err := ridge.NewRidgeRegressor(ridge.Alpha(0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(lineDataset(true))).Should(Succeed())
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 1, 1e-9))
+			Ω(r.Coefficients()[1]).Should(BeNumerically("~", 1, 1e-9))
+
+			r, err = ridge.NewRidgeRegressor(ridge.Alpha(0), ridge.Solving(ridge.Cholesky))
+			Ω(err).ShouldNot(HaveOccurred())
+			err = r.Train(lineDataset(true))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.RankDeficientError{}))
+		})
+
+		It("Weights each row's squared error by its weight", func() {
+			weighted, err := dataset.NewWeightedDataset(lineDataset(false), []float64{1, 0, 3})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, err := ridge.NewRidgeRegressor(ridge.Alpha(1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(weighted)).Should(Succeed())
+
+			// the weighted means are 0.5 and 3, the centred features have
+			// weighted sum of squares 3 and cross product 6 with the target
+			Ω(r.Coefficients()[0]).Should(BeNumerically("~", 1.5, 1e-9))
+			Ω(r.Intercept()).Should(BeNumerically("~", 3-0.75, 1e-9))
+		})
+
+		It("Rejects datasets it cannot fit", func() {
+			r, err := ridge.NewRidgeRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+			nonFloat := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			Ω(r.Train(nonFloat)).Should(BeAssignableToTypeOf(ridgeerrors.NonFloatFeaturesTrainingSetError{}))
+
+			noFeatures := dataset.NewDataset([]int{}, []int{0, 1}, []columntype.ColumnType{columnTypes[1], columnTypes[1]})
+			Ω(r.Train(noFeatures)).Should(BeAssignableToTypeOf(ridgeerrors.InvalidNumberOfTargetsError{}))
+
+			unweighted, err := dataset.NewWeightedDataset(lineDataset(false), []float64{0, 0, 0})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(r.Train(unweighted)).Should(BeAssignableToTypeOf(ridgeerrors.EmptyTrainingDatasetError{}))
+		})
+	})
+
+	Describe("Predict", func() {
+		It("Predicts from the fitted coefficients and intercept", func() {
+			r, err := ridge.NewRidgeRegressor(ridge.Alpha(2))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = r.Predict(row.NewRow(slice.NewFloatSlice([]float64{3}), nil, 1))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.UntrainedRegressorError{}))
+
+			Ω(r.Train(lineDataset(false))).Should(Succeed())
+
+			prediction, err := r.Predict(row.NewRow(slice.NewFloatSlice([]float64{3}), nil, 1))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 3+5.0/3, 1e-9))
+
+			_, err = r.Predict(row.NewRow(slice.NewFloatSlice([]float64{3, 4}), nil, 2))
+			Ω(err).Should(BeAssignableToTypeOf(ridgeerrors.RowLengthMismatchError{}))
+		})
+	})
+})
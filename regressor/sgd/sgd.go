@@ -0,0 +1,247 @@
+package sgd
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/sgderrors"
+	"github.com/amitkgupta/goodlearn/optimizer/stochasticgradientdescent"
+)
+
+const (
+	defaultL2Penalty           = 1e-4
+	defaultInitialLearningRate = 0.01
+	defaultPower               = 0.25
+	defaultEpochs              = 5
+)
+
+type Option func(*sgdRegressor)
+
+// HuberLoss fits the Huber loss rather than the squared loss: residuals
+// larger than epsilon are penalized linearly, so that outliers pull the fit
+// less.
+func HuberLoss(epsilon float64) Option {
+	return func(r *sgdRegressor) {
+		r.huber = true
+		r.epsilon = epsilon
+	}
+}
+
+// L2Penalty adds penalty * w * w / 2 to each example's loss for each
+// non-intercept coefficient w; 1e-4 by default.
+func L2Penalty(penalty float64) Option {
+	return func(r *sgdRegressor) {
+		r.l2Penalty = penalty
+	}
+}
+
+// LearningRate sets the step size of each update; by default it decays from
+// 0.01 as the inverse fourth root of the number of updates.
+func LearningRate(schedule stochasticgradientdescent.LearningRateSchedule) Option {
+	return func(r *sgdRegressor) {
+		r.schedule = schedule
+	}
+}
+
+// Epochs sets how many passes Train makes over the training rows; 5 by
+// default.  PartialFit always makes one pass over its batch.
+func Epochs(epochs int) Option {
+	return func(r *sgdRegressor) {
+		r.epochs = epochs
+	}
+}
+
+// RandomSource sets the source used to shuffle the order in which Train
+// visits the training rows.
+func RandomSource(source rand.Source) Option {
+	return func(r *sgdRegressor) {
+		r.source = source
+	}
+}
+
+// NewSGDRegressor returns a linear regressor fit by stochastic gradient
+// descent on the squared loss by default, stepping once per row, each row's
+// step scaled by its weight if the training data is a
+// dataset.WeightedDataset.  Features should be on similar scales.
+//
+// PartialFit continues descent from the current coefficients, and the
+// learning rate continues to decay across batches.
+func NewSGDRegressor(options ...Option) (*sgdRegressor, error) {
+	r := &sgdRegressor{
+		l2Penalty: defaultL2Penalty,
+		epochs:    defaultEpochs,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	if r.huber && !(r.epsilon > 0) {
+		return nil, sgderrors.NewInvalidHyperparameterError("Huber epsilon", r.epsilon)
+	}
+
+	if r.l2Penalty < 0 || math.IsNaN(r.l2Penalty) {
+		return nil, sgderrors.NewInvalidHyperparameterError("L2 penalty", r.l2Penalty)
+	}
+
+	if r.epochs < 1 {
+		return nil, sgderrors.NewInvalidHyperparameterError("epochs", float64(r.epochs))
+	}
+
+	if r.schedule == nil {
+		r.schedule, _ = stochasticgradientdescent.InverseScalingLearningRate(defaultInitialLearningRate, defaultPower)
+	}
+
+	if r.source == nil {
+		r.source = rand.NewSource(1)
+	}
+	r.random = rand.New(r.source)
+
+	return r, nil
+}
+
+type sgdRegressor struct {
+	huber     bool
+	epsilon   float64
+	l2Penalty float64
+	schedule  stochasticgradientdescent.LearningRateSchedule
+	epochs    int
+	source    rand.Source
+	random    *rand.Rand
+
+	coefficients []float64
+	intercept    float64
+	updates      int
+}
+
+func (r *sgdRegressor) Train(trainingData dataset.Dataset) error {
+	err := validateTrainingData(trainingData)
+	if err != nil {
+		return err
+	}
+
+	if trainingData.NumRows() == 0 {
+		return sgderrors.NewEmptyTrainingDatasetError()
+	}
+
+	r.coefficients = nil
+	r.intercept = 0
+	r.updates = 0
+
+	for epoch := 0; epoch < r.epochs; epoch++ {
+		err := r.fit(trainingData, r.random.Perm(trainingData.NumRows()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PartialFit makes one pass over the batch's rows in order.
+func (r *sgdRegressor) PartialFit(batch dataset.Dataset) error {
+	err := validateTrainingData(batch)
+	if err != nil {
+		return err
+	}
+
+	order := make([]int, batch.NumRows())
+	for i := range order {
+		order[i] = i
+	}
+
+	return r.fit(batch, order)
+}
+
+func validateTrainingData(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return sgderrors.NewNonFloatFeaturesTrainingSetError()
+	}
+
+	if !trainingData.AllTargetsFloats() {
+		return sgderrors.NewNonFloatTargetsTrainingSetError()
+	}
+
+	if trainingData.NumTargets() != 1 {
+		return sgderrors.NewInvalidNumberOfTargetsError(trainingData.NumTargets())
+	}
+
+	return nil
+}
+
+// fit steps once for each of the given rows of the batch, in order.
+func (r *sgdRegressor) fit(batch dataset.Dataset, order []int) error {
+	if len(order) == 0 {
+		return nil
+	}
+
+	numFeatures := batch.NumFeatures()
+	if r.coefficients == nil {
+		r.coefficients = make([]float64, numFeatures)
+	} else if numFeatures != len(r.coefficients) {
+		return sgderrors.NewBatchLengthMismatchError(numFeatures, len(r.coefficients))
+	}
+
+	rowWeights := dataset.Weights(batch)
+	for _, i := range order {
+		trainingRow, err := batch.Row(i)
+		if err != nil {
+			return err
+		}
+
+		x := trainingRow.Features().(slice.FloatSlice).Values()
+		y := trainingRow.Target().(slice.FloatSlice).Values()[0]
+		r.step(x, y, rowWeights[i])
+	}
+
+	return nil
+}
+
+// step moves the coefficients against the gradient of the loss on one row.
+func (r *sgdRegressor) step(x []float64, y, weight float64) {
+	learningRate := r.schedule(r.updates)
+	r.updates++
+
+	g := weight * r.lossDerivative(r.predict(x)-y)
+	for j, v := range x {
+		r.coefficients[j] = r.coefficients[j] - learningRate*(g*v+r.l2Penalty*r.coefficients[j])
+	}
+	r.intercept = r.intercept - learningRate*g
+}
+
+// lossDerivative is the derivative of half the squared residual, or of the
+// residual's Huber loss, with respect to the prediction.
+func (r *sgdRegressor) lossDerivative(residual float64) float64 {
+	if r.huber && math.Abs(residual) > r.epsilon {
+		return math.Copysign(r.epsilon, residual)
+	}
+	return residual
+}
+
+func (r *sgdRegressor) predict(x []float64) float64 {
+	prediction := r.intercept
+	for j, v := range x {
+		prediction = prediction + r.coefficients[j]*v
+	}
+	return prediction
+}
+
+func (r *sgdRegressor) Predict(testRow row.Row) (float64, error) {
+	if r.coefficients == nil {
+		return 0, sgderrors.NewUntrainedRegressorError()
+	}
+
+	if testRow.NumFeatures() != len(r.coefficients) {
+		return 0, sgderrors.NewRowLengthMismatchError(testRow.NumFeatures(), len(r.coefficients))
+	}
+
+	testFeatures, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return 0, sgderrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return r.predict(testFeatures.Values()), nil
+}
@@ -0,0 +1,203 @@
+package sgd_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/sgderrors"
+	"github.com/amitkgupta/goodlearn/optimizer/stochasticgradientdescent"
+	"github.com/amitkgupta/goodlearn/regressor"
+	"github.com/amitkgupta/goodlearn/regressor/sgd"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// lineDataset has numRows rows with target 2x - y + 1 plus a little noise,
+// or 30 more for the rows listed as outliers.
+func lineDataset(numRows int, outliers ...int) dataset.Dataset {
+	columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	random := rand.New(rand.NewSource(3))
+	ds := dataset.NewDataset([]int{0, 1}, []int{2}, columnTypes)
+	for i := 0; i < numRows; i++ {
+		x, y := random.NormFloat64(), random.NormFloat64()
+		target := 2*x - y + 1 + 0.01*random.NormFloat64()
+		for _, o := range outliers {
+			if o == i {
+				target = target + 30
+			}
+		}
+
+		err = ds.AddRowFromStrings([]string{
+			fmt.Sprintf("%.6f", x),
+			fmt.Sprintf("%.6f", y),
+			fmt.Sprintf("%.6f", target),
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+	return ds
+}
+
+func rowRange(ds dataset.Dataset, start, end int) dataset.Dataset {
+	rowMap := []int{}
+	for i := start; i < end; i++ {
+		rowMap = append(rowMap, i)
+	}
+	return dataset.NewSubset(ds, rowMap)
+}
+
+func testRowAt(x ...float64) row.Row {
+	return row.NewRow(slice.NewFloatSlice(x), nil, len(x))
+}
+
+var _ = Describe("SGDRegressor", func() {
+	var ds dataset.Dataset
+
+	BeforeEach(func() {
+		ds = lineDataset(500)
+	})
+
+	Describe("NewSGDRegressor", func() {
+		It("Rejects invalid hyperparameters", func() {
+			_, err := sgd.NewSGDRegressor(sgd.HuberLoss(0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.InvalidHyperparameterError{}))
+
+			_, err = sgd.NewSGDRegressor(sgd.L2Penalty(-1))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.InvalidHyperparameterError{}))
+
+			_, err = sgd.NewSGDRegressor(sgd.Epochs(0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.InvalidHyperparameterError{}))
+		})
+
+		It("Is an online regressor", func() {
+			r, err := sgd.NewSGDRegressor()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var _ regressor.OnlineRegressor = r
+		})
+	})
+
+	Describe("Train", func() {
+		It("Requires float features and a single float target", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"a", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			nonFloatFeatures := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			Ω(nonFloatFeatures.AddRowFromStrings([]string{"a", "1", "2"})).Should(Succeed())
+			nonFloatTargets := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			Ω(nonFloatTargets.AddRowFromStrings([]string{"a", "1", "2"})).Should(Succeed())
+			twoTargets := dataset.NewDataset([]int{}, []int{1, 2}, columnTypes)
+			Ω(twoTargets.AddRowFromStrings([]string{"a", "1", "2"})).Should(Succeed())
+
+			r, _ := sgd.NewSGDRegressor()
+			Ω(r.Train(nonFloatFeatures)).Should(BeAssignableToTypeOf(sgderrors.NonFloatFeaturesTrainingSetError{}))
+			Ω(r.Train(nonFloatTargets)).Should(BeAssignableToTypeOf(sgderrors.NonFloatTargetsTrainingSetError{}))
+			Ω(r.PartialFit(twoTargets)).Should(BeAssignableToTypeOf(sgderrors.InvalidNumberOfTargetsError{}))
+		})
+
+		It("Requires a non-empty dataset", func() {
+			r, _ := sgd.NewSGDRegressor()
+			Ω(r.Train(rowRange(ds, 0, 0))).Should(BeAssignableToTypeOf(sgderrors.EmptyTrainingDatasetError{}))
+		})
+
+		It("Fits a line with the squared loss", func() {
+			r, _ := sgd.NewSGDRegressor()
+			Ω(r.Train(ds)).Should(Succeed())
+
+			prediction, err := r.Predict(testRowAt(1, 2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 1, 0.05))
+		})
+
+		It("Is pulled less by outliers with the Huber loss", func() {
+			withOutliers := lineDataset(500, 3, 50, 97, 120, 200, 333, 404, 450)
+
+			squared, _ := sgd.NewSGDRegressor(sgd.Epochs(20))
+			Ω(squared.Train(withOutliers)).Should(Succeed())
+			huber, _ := sgd.NewSGDRegressor(sgd.HuberLoss(0.5), sgd.Epochs(20))
+			Ω(huber.Train(withOutliers)).Should(Succeed())
+
+			squaredPrediction, err := squared.Predict(testRowAt(0, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+			huberPrediction, err := huber.Predict(testRowAt(0, 0))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(huberPrediction - 1).Should(BeNumerically("<", squaredPrediction-1))
+			Ω(huberPrediction).Should(BeNumerically("~", 1, 0.1))
+		})
+	})
+
+	Describe("Predict", func() {
+		It("Returns an error before training", func() {
+			r, _ := sgd.NewSGDRegressor()
+			_, err := r.Predict(testRowAt(0, 0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.UntrainedRegressorError{}))
+		})
+
+		It("Returns an error for rows of the wrong length", func() {
+			r, _ := sgd.NewSGDRegressor()
+			Ω(r.Train(ds)).Should(Succeed())
+
+			_, err := r.Predict(testRowAt(0))
+			Ω(err).Should(BeAssignableToTypeOf(sgderrors.RowLengthMismatchError{}))
+		})
+	})
+
+	Describe("PartialFit", func() {
+		It("Learns from a stream of small batches", func() {
+			schedule, err := stochasticgradientdescent.InverseScalingLearningRate(0.05, 0.25)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, _ := sgd.NewSGDRegressor(sgd.LearningRate(schedule))
+			for start := 0; start < ds.NumRows(); start = start + 10 {
+				Ω(r.PartialFit(rowRange(ds, start, start+10))).Should(Succeed())
+			}
+
+			prediction, err := r.Predict(testRowAt(1, 2))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 1, 0.1))
+		})
+
+		It("Continues the learning rate's decay across batches", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			// With a constant feature of 0 and a learning rate of 1/(t+1),
+			// the intercept is the running mean of the targets.
+			constant := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			for _, target := range []string{"2", "4", "9"} {
+				Ω(constant.AddRowFromStrings([]string{"0", target})).Should(Succeed())
+			}
+
+			schedule, err := stochasticgradientdescent.InverseScalingLearningRate(1, 1)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			r, _ := sgd.NewSGDRegressor(sgd.LearningRate(schedule))
+			for i := 0; i < constant.NumRows(); i++ {
+				Ω(r.PartialFit(rowRange(constant, i, i+1))).Should(Succeed())
+			}
+
+			prediction, err := r.Predict(testRowAt(0))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 5, 1e-12))
+		})
+
+		It("Requires batches with as many features as earlier ones", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			narrow := dataset.NewDataset([]int{0}, []int{1}, columnTypes)
+			Ω(narrow.AddRowFromStrings([]string{"1", "2"})).Should(Succeed())
+
+			r, _ := sgd.NewSGDRegressor()
+			Ω(r.PartialFit(ds)).Should(Succeed())
+			Ω(r.PartialFit(narrow)).Should(BeAssignableToTypeOf(sgderrors.BatchLengthMismatchError{}))
+		})
+	})
+})
@@ -0,0 +1,213 @@
+package svr
+
+import (
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/svrerrors"
+)
+
+const (
+	defaultCost          = 1.0
+	defaultEpsilon       = 0.1
+	defaultTolerance     = 1e-3
+	defaultMaxIterations = 10000000
+	defaultCacheRows     = 1000
+)
+
+type Option func(*svr)
+
+// Cost sets C, the penalty on errors larger than epsilon.
+func Cost(cost float64) Option {
+	return func(r *svr) {
+		r.cost = cost
+	}
+}
+
+// Epsilon sets the half-width of the tube within which errors are not
+// penalized.
+func Epsilon(epsilon float64) Option {
+	return func(r *svr) {
+		r.epsilon = epsilon
+	}
+}
+
+func Tolerance(tolerance float64) Option {
+	return func(r *svr) {
+		r.tolerance = tolerance
+	}
+}
+
+func MaxIterations(maxIterations int) Option {
+	return func(r *svr) {
+		r.maxIterations = maxIterations
+	}
+}
+
+// CacheSize sets how many rows of the kernel matrix are kept in memory.
+func CacheSize(rows int) Option {
+	return func(r *svr) {
+		r.cacheRows = rows
+	}
+}
+
+func NoShrinking() Option {
+	return func(r *svr) {
+		r.shrinking = false
+	}
+}
+
+// NewSVR returns an epsilon-support vector regressor trained by sequential
+// minimal optimization in the feature space of the given kernel.  A nil
+// kernel means an RBF kernel with gamma of one over the number of features.
+func NewSVR(kernel svmutilities.Kernel, options ...Option) (*svr, error) {
+	r := &svr{
+		kernel:        kernel,
+		cost:          defaultCost,
+		epsilon:       defaultEpsilon,
+		tolerance:     defaultTolerance,
+		maxIterations: defaultMaxIterations,
+		cacheRows:     defaultCacheRows,
+		shrinking:     true,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	if r.cost <= 0 {
+		return nil, svrerrors.NewInvalidHyperparameterError("cost", r.cost)
+	}
+
+	if r.epsilon < 0 {
+		return nil, svrerrors.NewInvalidHyperparameterError("epsilon", r.epsilon)
+	}
+
+	if r.tolerance <= 0 {
+		return nil, svrerrors.NewInvalidHyperparameterError("tolerance", r.tolerance)
+	}
+
+	if r.maxIterations < 1 {
+		return nil, svrerrors.NewInvalidHyperparameterError("max iterations", float64(r.maxIterations))
+	}
+
+	if r.cacheRows < 1 {
+		return nil, svrerrors.NewInvalidHyperparameterError("cache size", float64(r.cacheRows))
+	}
+
+	return r, nil
+}
+
+type svr struct {
+	kernel        svmutilities.Kernel
+	cost          float64
+	epsilon       float64
+	tolerance     float64
+	maxIterations int
+	cacheRows     int
+	shrinking     bool
+
+	numFeatures int
+	model       *svmutilities.Model
+}
+
+// Model returns the support vectors and dual coefficients of the trained
+// regressor.
+func (r *svr) Model() *svmutilities.Model {
+	return r.model
+}
+
+func (r *svr) Train(trainingData dataset.Dataset) error {
+	if !trainingData.AllFeaturesFloats() {
+		return svrerrors.NewNonFloatFeaturesError()
+	}
+
+	if !trainingData.AllTargetsFloats() {
+		return svrerrors.NewNonFloatTargetsError()
+	}
+
+	if trainingData.NumTargets() != 1 {
+		return svrerrors.NewInvalidNumberOfTargetsError(trainingData.NumTargets())
+	}
+
+	n := trainingData.NumRows()
+	if n == 0 {
+		return svrerrors.NewEmptyTrainingDatasetError()
+	}
+
+	numFeatures := trainingData.NumFeatures()
+	kernel := r.kernel
+	if kernel == nil {
+		kernel = svmutilities.RBFKernel(1 / float64(numFeatures))
+	}
+
+	// Variable i < n is the dual variable for the upper edge of the tube
+	// around row i, and variable n + i that for the lower edge.
+	vectors := make([][]float64, n)
+	problem := svmutilities.Problem{
+		Y:     make([]float64, 2*n),
+		P:     make([]float64, 2*n),
+		C:     make([]float64, 2*n),
+		Index: make([]int, 2*n),
+	}
+
+	for i := 0; i < n; i++ {
+		rr, err := trainingData.Row(i)
+		if err != nil {
+			return err
+		}
+
+		features, ok := rr.Features().(slice.FloatSlice)
+		if !ok {
+			return svrerrors.NewNonFloatFeaturesError()
+		}
+
+		target, ok := rr.Target().(slice.FloatSlice)
+		if !ok {
+			return svrerrors.NewNonFloatTargetsError()
+		}
+
+		vectors[i] = features.Values()
+		z := target.Values()[0]
+
+		problem.Y[i], problem.Y[n+i] = 1, -1
+		problem.P[i], problem.P[n+i] = r.epsilon-z, r.epsilon+z
+		problem.C[i], problem.C[n+i] = r.cost, r.cost
+		problem.Index[i], problem.Index[n+i] = i, i
+	}
+
+	solution := svmutilities.Solve(
+		problem,
+		svmutilities.NewKernelCache(kernel, vectors, r.cacheRows),
+		r.tolerance,
+		r.maxIterations,
+		r.shrinking,
+	)
+
+	coefficients := make([]float64, n)
+	for i := range coefficients {
+		coefficients[i] = solution.Alpha[i] - solution.Alpha[n+i]
+	}
+
+	r.numFeatures = numFeatures
+	r.model = svmutilities.NewModel(kernel, vectors, coefficients, solution.Rho)
+	return nil
+}
+
+func (r *svr) Predict(testRow row.Row) (float64, error) {
+	if r.model == nil {
+		return 0, svrerrors.NewUntrainedRegressorError()
+	}
+
+	if testRow.NumFeatures() != r.numFeatures {
+		return 0, svrerrors.NewRowLengthMismatchError(testRow.NumFeatures(), r.numFeatures)
+	}
+
+	features, ok := testRow.Features().(slice.FloatSlice)
+	if !ok {
+		return 0, svrerrors.NewNonFloatFeaturesTestRowError()
+	}
+
+	return r.model.Decision(features.Values()), nil
+}
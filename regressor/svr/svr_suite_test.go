@@ -0,0 +1,13 @@
+package svr_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSvr(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Svr Suite")
+}
@@ -0,0 +1,151 @@
+package svr_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/amitkgupta/goodlearn/classifier/svm/svmutilities"
+	"github.com/amitkgupta/goodlearn/data/columntype"
+	"github.com/amitkgupta/goodlearn/data/dataset"
+	"github.com/amitkgupta/goodlearn/data/row"
+	"github.com/amitkgupta/goodlearn/data/slice"
+	"github.com/amitkgupta/goodlearn/errors/regressor/svrerrors"
+	"github.com/amitkgupta/goodlearn/regressor/svr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SVR", func() {
+	var sineData dataset.Dataset
+
+	testRowAt := func(x float64) row.Row {
+		return row.NewRow(slice.NewFloatSlice([]float64{x}), nil, 1)
+	}
+
+	BeforeEach(func() {
+		columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		sineData = dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+		for i := 0; i <= 60; i++ {
+			x := float64(i) / 10
+			err = sineData.AddRowFromStrings([]string{fmt.Sprintf("%.6f", math.Sin(x)), fmt.Sprintf("%.6f", x)})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+	})
+
+	Describe("NewSVR", func() {
+		It("Rejects invalid hyperparameters", func() {
+			_, err := svr.NewSVR(nil, svr.Cost(0))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.InvalidHyperparameterError{}))
+
+			_, err = svr.NewSVR(nil, svr.Epsilon(-0.1))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.InvalidHyperparameterError{}))
+
+			_, err = svr.NewSVR(nil, svr.Tolerance(0))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.InvalidHyperparameterError{}))
+
+			_, err = svr.NewSVR(nil, svr.MaxIterations(0))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.InvalidHyperparameterError{}))
+
+			_, err = svr.NewSVR(nil, svr.CacheSize(0))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.InvalidHyperparameterError{}))
+		})
+	})
+
+	Describe("Train", func() {
+		It("Requires float targets", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"x", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			regressor, err := svr.NewSVR(nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = regressor.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.NonFloatTargetsTrainingSetError{}))
+		})
+
+		It("Requires a single target", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			regressor, err := svr.NewSVR(nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = regressor.Train(dataset.NewDataset([]int{2}, []int{0, 1}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.InvalidNumberOfTargetsError{}))
+		})
+
+		It("Requires a non-empty dataset", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			regressor, err := svr.NewSVR(nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = regressor.Train(dataset.NewDataset([]int{1}, []int{0}, columnTypes))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.EmptyTrainingDatasetError{}))
+		})
+	})
+
+	Describe("Predict", func() {
+		It("Returns errors before training and for bad rows", func() {
+			regressor, err := svr.NewSVR(nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = regressor.Predict(testRowAt(0))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.UntrainedRegressorError{}))
+
+			Ω(regressor.Train(sineData)).Should(Succeed())
+
+			_, err = regressor.Predict(row.NewRow(slice.NewFloatSlice([]float64{0, 1}), nil, 2))
+			Ω(err).Should(BeAssignableToTypeOf(svrerrors.RowLengthMismatchError{}))
+		})
+
+		It("Fits a nonlinear function to within roughly epsilon", func() {
+			regressor, err := svr.NewSVR(svmutilities.RBFKernel(1), svr.Cost(10), svr.Epsilon(0.05))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(regressor.Train(sineData)).Should(Succeed())
+
+			for _, x := range []float64{0.55, 1.5, 3.05, 4.7} {
+				prediction, err := regressor.Predict(testRowAt(x))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(prediction).Should(BeNumerically("~", math.Sin(x), 0.1))
+			}
+		})
+
+		It("Uses fewer support vectors with a wider tube", func() {
+			narrow, err := svr.NewSVR(nil, svr.Epsilon(0.01))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(narrow.Train(sineData)).Should(Succeed())
+
+			wide, err := svr.NewSVR(nil, svr.Epsilon(0.3))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(wide.Train(sineData)).Should(Succeed())
+
+			Ω(len(wide.Model().SupportVectors)).Should(BeNumerically("<", len(narrow.Model().SupportVectors)))
+			Ω(wide.Model().SupportIndices).Should(HaveLen(len(wide.Model().DualCoefficients)))
+		})
+
+		It("Recovers a linear function with the linear kernel", func() {
+			columnTypes, err := columntype.StringsToColumnTypes([]string{"0", "0"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			ds := dataset.NewDataset([]int{1}, []int{0}, columnTypes)
+			for i := 0; i < 20; i++ {
+				x := float64(i) / 4
+				err = ds.AddRowFromStrings([]string{fmt.Sprintf("%.6f", 2*x-1), fmt.Sprintf("%.6f", x)})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			regressor, err := svr.NewSVR(svmutilities.LinearKernel(), svr.Cost(100), svr.Epsilon(0.01), svr.NoShrinking())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(regressor.Train(ds)).Should(Succeed())
+
+			prediction, err := regressor.Predict(testRowAt(10))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(prediction).Should(BeNumerically("~", 19, 0.1))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package statisticsutilities
+
+import (
+	"math"
+)
+
+// RegularizedIncompleteBeta returns I_x(a, b), the probability that a
+// Beta(a, b) variable is at most x, evaluated by its continued fraction.
+func RegularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	// the continued fraction converges quickly only below (a + 1) / (a + b + 2)
+	if x > (a+1)/(a+b+2) {
+		return 1 - RegularizedIncompleteBeta(1-x, b, a)
+	}
+
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	lgammaAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+
+	return front * betaContinuedFraction(x, a, b) / a
+}
+
+// betaContinuedFraction evaluates the continued fraction for the incomplete
+// beta function by the modified Lentz method.
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		tiny      = 1e-300
+		tolerance = 1e-15
+	)
+
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	result := d
+
+	for m := 1; m <= 300; m++ {
+		fm := float64(m)
+
+		for _, numerator := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + numerator*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + numerator/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			result = result * d * c
+		}
+
+		if math.Abs(d*c-1) < tolerance {
+			break
+		}
+	}
+
+	return result
+}
+
+// StudentTCDF returns the probability that a Student's t variable with the
+// given degrees of freedom is at most t.
+func StudentTCDF(t, degreesOfFreedom float64) float64 {
+	tail := 0.5 * RegularizedIncompleteBeta(degreesOfFreedom/(degreesOfFreedom+t*t), degreesOfFreedom/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// StudentTQuantile returns the t at which StudentTCDF is p, for p strictly
+// between 0 and 1, found by bisection.
+func StudentTQuantile(p, degreesOfFreedom float64) float64 {
+	low, high := -1.0, 1.0
+	for StudentTCDF(low, degreesOfFreedom) > p {
+		low = 2 * low
+	}
+	for StudentTCDF(high, degreesOfFreedom) < p {
+		high = 2 * high
+	}
+
+	for i := 0; i < 200 && high-low > 1e-12*math.Max(1, math.Abs(low)); i++ {
+		middle := (low + high) / 2
+		if StudentTCDF(middle, degreesOfFreedom) < p {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+
+	return (low + high) / 2
+}
+
+// FCDF returns the probability that an F variable with the given numerator
+// and denominator degrees of freedom is at most f.
+func FCDF(f, numeratorDegreesOfFreedom, denominatorDegreesOfFreedom float64) float64 {
+	if f <= 0 {
+		return 0
+	}
+
+	x := numeratorDegreesOfFreedom * f / (numeratorDegreesOfFreedom*f + denominatorDegreesOfFreedom)
+	return RegularizedIncompleteBeta(x, numeratorDegreesOfFreedom/2, denominatorDegreesOfFreedom/2)
+}
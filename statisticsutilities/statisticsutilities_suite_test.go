@@ -0,0 +1,13 @@
+package statisticsutilities_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestStatisticsutilities(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Statisticsutilities Suite")
+}
@@ -0,0 +1,48 @@
+package statisticsutilities_test
+
+import (
+	"math"
+
+	"github.com/amitkgupta/goodlearn/statisticsutilities"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Statistics Utilities", func() {
+	Describe("RegularizedIncompleteBeta", func() {
+		It("Matches the binomial sum for integer parameters", func() {
+			// I_0.4(2, 3) = P(at least 2 of 4 trials succeed), each with probability 0.4
+			Ω(statisticsutilities.RegularizedIncompleteBeta(0.4, 2, 3)).Should(BeNumerically("~", 0.5248, 1e-12))
+			Ω(statisticsutilities.RegularizedIncompleteBeta(0.9, 2, 3)).Should(BeNumerically("~", 0.9963, 1e-12))
+		})
+
+		It("Is 0 and 1 at the ends of the unit interval", func() {
+			Ω(statisticsutilities.RegularizedIncompleteBeta(0, 2, 3)).Should(Equal(0.0))
+			Ω(statisticsutilities.RegularizedIncompleteBeta(1, 2, 3)).Should(Equal(1.0))
+		})
+	})
+
+	Describe("StudentTCDF", func() {
+		It("Is the Cauchy distribution with one degree of freedom", func() {
+			Ω(statisticsutilities.StudentTCDF(1, 1)).Should(BeNumerically("~", 0.75, 1e-12))
+			Ω(statisticsutilities.StudentTCDF(-2, 1)).Should(BeNumerically("~", 0.5-math.Atan(2)/math.Pi, 1e-12))
+			Ω(statisticsutilities.StudentTCDF(0, 7)).Should(BeNumerically("~", 0.5, 1e-12))
+		})
+	})
+
+	Describe("StudentTQuantile", func() {
+		It("Inverts the CDF", func() {
+			Ω(statisticsutilities.StudentTQuantile(0.975, 10)).Should(BeNumerically("~", 2.228138851986, 1e-9))
+			Ω(statisticsutilities.StudentTQuantile(0.025, 10)).Should(BeNumerically("~", -2.228138851986, 1e-9))
+			Ω(statisticsutilities.StudentTQuantile(0.995, 1)).Should(BeNumerically("~", math.Tan(math.Pi*0.495), 1e-6))
+		})
+	})
+
+	Describe("FCDF", func() {
+		It("Has a closed form with two numerator degrees of freedom", func() {
+			Ω(1 - statisticsutilities.FCDF(3, 2, 10)).Should(BeNumerically("~", math.Pow(1.6, -5), 1e-12))
+			Ω(statisticsutilities.FCDF(0, 2, 10)).Should(Equal(0.0))
+		})
+	})
+})